@@ -5,16 +5,25 @@ import (
 	"embed"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/gi8lino/motus/internal/auth"
 	"github.com/gi8lino/motus/internal/bootstrap"
 	"github.com/gi8lino/motus/internal/db"
 	"github.com/gi8lino/motus/internal/flag"
 	"github.com/gi8lino/motus/internal/handler"
+	"github.com/gi8lino/motus/internal/jose"
 	"github.com/gi8lino/motus/internal/logging"
+	"github.com/gi8lino/motus/internal/mailer"
+	"github.com/gi8lino/motus/internal/middleware"
+	"github.com/gi8lino/motus/internal/oidc"
 	"github.com/gi8lino/motus/internal/routes"
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/trainings"
 
 	"github.com/containeroo/httpgrace/server"
 	"github.com/containeroo/tinyflags"
@@ -109,6 +118,74 @@ func Run(
 		}
 	}
 
+	// Restrict the proxy auth header to trusted peers and signed assertions.
+	var proxyTrust *auth.ProxyTrust
+	if len(opts.TrustedProxies) > 0 || opts.AssertionHeader != "" {
+		proxyTrust = &auth.ProxyTrust{
+			TrustedProxies:    opts.TrustedProxies,
+			AssertionHeader:   opts.AssertionHeader,
+			AssertionIssuer:   opts.AssertionIssuer,
+			AssertionAudience: opts.AssertionAudience,
+		}
+		if opts.AssertionJWKSFile != "" {
+			keys, err := jose.LoadKeySet(opts.AssertionJWKSFile)
+			if err != nil {
+				sysLogger.Error("application failed",
+					"event", "app_failed",
+					"stage", "load_assertion_jwks",
+					"err", err,
+				)
+				return fmt.Errorf("load assertion jwks: %w", err)
+			}
+			proxyTrust.AssertionKeys = keys
+		}
+	}
+
+	// Act as an OpenID Connect relying party when an issuer is configured.
+	var oidcProvider *oidc.Provider
+	if opts.OIDCIssuer != "" {
+		oidcProvider = oidc.New(oidc.Config{
+			Issuer:       opts.OIDCIssuer,
+			ClientID:     opts.OIDCClientID,
+			ClientSecret: opts.OIDCClientSecret,
+			RedirectURL:  opts.SiteRoot + "/api/auth/oidc/callback",
+			Scopes:       opts.OIDCScopes,
+			GroupsClaim:  opts.OIDCGroupsClaim,
+			AdminGroup:   opts.OIDCAdminGroup,
+		}, nil)
+		sysLogger.Info("oidc login enabled",
+			"event", "oidc_enabled",
+			"issuer", opts.OIDCIssuer,
+		)
+	}
+
+	// Deliver account mails through SMTP, or log them when no relay is configured.
+	var mail mailer.Mailer
+	switch {
+	case opts.SMTPHost != "":
+		mail = &mailer.SMTP{
+			Host:     opts.SMTPHost,
+			Port:     opts.SMTPPort,
+			Username: opts.SMTPUsername,
+			Password: opts.SMTPPassword,
+			From:     opts.MailFrom,
+		}
+	case opts.MailLogFile != "":
+		f, err := os.OpenFile(opts.MailLogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			sysLogger.Error("application failed",
+				"event", "app_failed",
+				"stage", "open_mail_log",
+				"err", err,
+			)
+			return fmt.Errorf("open mail log: %w", err)
+		}
+		defer f.Close() // nolint:errcheck
+		mail = mailer.NewLog(logging.SetupLogger(opts.LogFormat, false, f))
+	default:
+		mail = mailer.NewLog(sysLogger)
+	}
+
 	// Build the API handler with runtime configuration.
 	api := handler.NewAPI(
 		store,
@@ -119,10 +196,31 @@ func Run(
 		commit,
 		opts.AllowRegistration,
 		opts.AutoCreateUsers,
+		opts.RequireAdmin2FA,
+		opts.SessionTTL,
+		oidcProvider,
+		proxyTrust,
+		mail,
 	)
 
+	// Drop audit events past the retention period in the background.
+	if opts.AuditRetention > 0 {
+		go pruneAuditLog(ctx, api.Audit, opts.AuditRetention, sysLogger)
+	}
+
+	// Fan training changes from all replicas out to the event streams of this one.
+	go listenTrainingEvents(ctx, store, api.Trainings, sysLogger)
+
 	// Configure the HTTP router and SPA asset handler.
-	router, err := routes.NewRouter(assets, opts.RoutePrefix, sysLogger, api, opts.Debug)
+	limits := routes.Limits{
+		Login: middleware.LoginLimits{
+			IP:      middleware.NewLimiter(opts.LoginIPLimit, time.Minute),
+			Account: middleware.NewLimiter(opts.LoginAccountLimit, time.Minute),
+			Lockout: middleware.NewLockout(opts.LockoutThreshold, opts.LockoutDuration, opts.LockoutMax),
+		},
+		Write: middleware.NewLimiter(opts.WriteLimit, time.Minute),
+	}
+	router, err := routes.NewRouter(assets, opts.RoutePrefix, sysLogger, api, limits, opts.Debug)
 	if err != nil {
 		sysLogger.Error("application failed",
 			"event", "app_failed",
@@ -144,3 +242,51 @@ func Run(
 
 	return nil
 }
+
+// pruneAuditLog deletes expired audit events at startup and then hourly until ctx is done.
+func pruneAuditLog(ctx context.Context, svc *audit.Service, retention time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := svc.Prune(ctx, retention)
+		if err != nil {
+			logger.Error("prune audit log failed",
+				"event", "audit_prune_failed",
+				"err", err,
+			)
+		} else if deleted > 0 {
+			logger.Info("pruned audit log",
+				"event", "audit_pruned",
+				"count", deleted,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// listenTrainingEvents forwards training notifications to svc and reconnects after failures until ctx is done.
+func listenTrainingEvents(ctx context.Context, store *db.Store, svc *trainings.Service, logger *slog.Logger) {
+	for {
+		err := store.ListenTrainingEvents(ctx, func(trainingID string) {
+			svc.Publish(ctx, trainingID)
+		})
+		if err != nil {
+			logger.Error("listen for training events failed",
+				"event", "training_events_listen_failed",
+				"err", err,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
@@ -5,15 +5,20 @@ import (
 	"errors"
 	"net/http"
 	"strings"
-
-	"github.com/jackc/pgx/v5"
+	"time"
 
 	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/tokens"
 	"github.com/gi8lino/motus/internal/utils"
 )
 
-// localAuthHeader is the fallback header for local auth.
-const localAuthHeader = "X-User-ID"
+// SessionCookieName is the cookie carrying the local-auth session token.
+const SessionCookieName = "motus_session"
+
+// errorScope is the service error scope for authentication.
+const errorScope = "auth"
 
 // Store defines the persistence methods needed by auth helpers.
 type Store interface {
@@ -21,52 +26,157 @@ type Store interface {
 	GetUser(ctx context.Context, email string) (*db.User, error)
 	// CreateUser inserts a new user for auto-provisioning.
 	CreateUser(ctx context.Context, email, avatarURL, passwordHash string) (*db.User, error)
+	// GetSession returns a session by its hashed token.
+	GetSession(ctx context.Context, id string) (*db.Session, error)
+	// GetAPITokenByHash returns a personal access token by its hashed secret.
+	GetAPITokenByHash(ctx context.Context, hash string) (*db.APIToken, error)
+	// TouchAPIToken records when a personal access token was last used.
+	TouchAPIToken(ctx context.Context, id string, at time.Time) error
+}
+
+// touchInterval throttles last-used updates for API tokens.
+const touchInterval = time.Minute
+
+// ResolveUserID selects the user id from a bearer token, the proxy auth header or the session cookie.
+// A non-nil proxy restricts which requests may use the auth header.
+func ResolveUserID(r *http.Request, store Store, authHeader string, proxy *ProxyTrust, autoCreateUsers bool) (string, error) {
+	userID, _, err := resolve(r, store, authHeader, proxy, autoCreateUsers)
+	return userID, err
+}
+
+// ResolveActor resolves the authenticated user and its role for policy checks.
+func ResolveActor(r *http.Request, store Store, authHeader string, proxy *ProxyTrust, autoCreateUsers bool) (policy.Actor, error) {
+	userID, token, err := resolve(r, store, authHeader, proxy, autoCreateUsers)
+	if err != nil {
+		return policy.Actor{}, err
+	}
+	user, err := store.GetUser(r.Context(), userID)
+	if err != nil && !errors.Is(err, db.ErrUserNotFound) {
+		return policy.Actor{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	// Proxy users without a row yet are treated as regular members.
+	role := db.RoleMember
+	if user != nil {
+		role = user.Role
+	}
+	// Tokens only carry elevated roles when explicitly granted admin scope.
+	if token != nil && !tokens.Allows(token.Scopes, tokens.ScopeAdmin) {
+		role = db.RoleMember
+	}
+	return policy.NewActor(userID, role), nil
 }
 
-// ResolveUserID selects the user id from auth header or request payload.
-func ResolveUserID(r *http.Request, store Store, authHeader string, autoCreateUsers bool, fallback string) (string, error) {
+// BearerToken returns the bearer token from the Authorization header, if any.
+func BearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(r.Header.Get("Authorization")), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// resolve authenticates the request and returns the API token when one was used.
+func resolve(r *http.Request, store Store, authHeader string, proxy *ProxyTrust, autoCreateUsers bool) (string, *db.APIToken, error) {
+	// Personal access tokens work in every auth mode.
+	if bearer := BearerToken(r); bearer != "" {
+		token, err := resolveAPIToken(r, store, bearer)
+		if err != nil {
+			return "", nil, err
+		}
+		return token.UserID, token, nil
+	}
+	userID, err := resolveUser(r, store, authHeader, proxy, autoCreateUsers)
+	return userID, nil, err
+}
+
+// resolveUser selects the user id from the proxy auth header or the session cookie.
+func resolveUser(r *http.Request, store Store, authHeader string, proxy *ProxyTrust, autoCreateUsers bool) (string, error) {
 	// Prefer proxy auth header when configured.
 	if authHeader != "" {
 		id := strings.TrimSpace(r.Header.Get(authHeader))
 		if id == "" {
-			return "", errors.New("auth header is required")
+			return "", errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "auth header is required", errorScope)
 		}
-
 		email, err := utils.NormalizeEmail(id)
 		if err != nil {
+			return "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+		}
+		// Only honour the header from trusted proxies with a valid assertion, when configured.
+		if err := proxy.verify(r, email); err != nil {
 			return "", err
 		}
-
 		// Optionally auto-provision users for new headers.
 		if autoCreateUsers {
 			if err := ensureUser(r.Context(), store, email); err != nil {
-				return "", err
+				return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 			}
 		}
 		return email, nil
 	}
 
-	if fallback != "" {
-		// Accept a fallback id when provided by handlers.
-		email, err := utils.NormalizeEmail(fallback)
-		if err != nil {
-			return "", err
-		}
-		return email, nil
+	// Local auth resolves the user from the server-side session.
+	return resolveSession(r, store)
+}
+
+// SessionToken returns the raw session token sent by the client, if any.
+func SessionToken(r *http.Request) string {
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return ""
 	}
+	return strings.TrimSpace(cookie.Value)
+}
 
-	// Require the local auth header when no proxy header is configured.
-	id := strings.TrimSpace(r.Header.Get(localAuthHeader))
-	if id == "" {
-		return "", errors.New("userId is required")
+// resolveSession looks up the session cookie and returns its user id.
+func resolveSession(r *http.Request, store Store) (string, error) {
+	token := SessionToken(r)
+	if token == "" {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "session is required", errorScope)
+	}
+	session, err := store.GetSession(r.Context(), utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, db.ErrSessionNotFound) {
+			return "", errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "session is invalid", errorScope)
+		}
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
+	if session == nil || !session.IsActive(time.Now()) {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "session is invalid", errorScope)
+	}
+	return session.UserID, nil
+}
 
-	email, err := utils.NormalizeEmail(id)
+// resolveAPIToken validates a bearer token and its scope for the request method.
+func resolveAPIToken(r *http.Request, store Store, bearer string) (*db.APIToken, error) {
+	token, err := store.GetAPITokenByHash(r.Context(), utils.HashToken(bearer))
 	if err != nil {
-		return "", err
+		if errors.Is(err, db.ErrAPITokenNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "api token is invalid", errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	now := time.Now()
+	if token == nil || !token.IsActive(now) {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "api token is invalid", errorScope)
+	}
+	if !tokens.Allows(token.Scopes, requiredScope(r)) {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "api token scope does not allow this request", errorScope)
+	}
+	// Best effort: a failed bookkeeping write must not reject an authenticated request.
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= touchInterval {
+		_ = store.TouchAPIToken(r.Context(), token.ID, now.UTC())
 	}
+	return token, nil
+}
 
-	return email, nil
+// requiredScope maps the request method to the token scope it needs.
+func requiredScope(r *http.Request) string {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return tokens.ScopeRead
+	default:
+		return tokens.ScopeWrite
+	}
 }
 
 // ensureUser creates a user if it does not already exist.
@@ -76,12 +186,10 @@ func ensureUser(ctx context.Context, store Store, email string) error {
 	if err == nil && user != nil {
 		return nil
 	}
-
 	// Bubble up unexpected lookup errors.
-	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+	if err != nil && !errors.Is(err, db.ErrUserNotFound) {
 		return err
 	}
-
 	// Create a placeholder user; retry lookup to handle races.
 	if _, err := store.CreateUser(ctx, email, "", ""); err != nil {
 		// Guard against race conditions if another request created the user.
@@ -90,6 +198,5 @@ func ensureUser(ctx context.Context, store Store, email string) error {
 		}
 		return err
 	}
-
 	return nil
 }
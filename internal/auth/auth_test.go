@@ -6,17 +6,22 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/utils"
 )
 
 type fakeStore struct {
 	getUserFn    func(context.Context, string) (*db.User, error)
 	createUserFn func(context.Context, string, string, string) (*db.User, error)
+	getSessionFn func(context.Context, string) (*db.Session, error)
+	getTokenFn   func(context.Context, string) (*db.APIToken, error)
+	touchTokenFn func(context.Context, string, time.Time) error
 }
 
 func (f *fakeStore) GetUser(ctx context.Context, email string) (*db.User, error) {
@@ -33,6 +38,42 @@ func (f *fakeStore) CreateUser(ctx context.Context, email, avatarURL, passwordHa
 	return f.createUserFn(ctx, email, avatarURL, passwordHash)
 }
 
+func (f *fakeStore) GetSession(ctx context.Context, id string) (*db.Session, error) {
+	if f.getSessionFn == nil {
+		return nil, db.ErrSessionNotFound
+	}
+	return f.getSessionFn(ctx, id)
+}
+
+func (f *fakeStore) GetAPITokenByHash(ctx context.Context, hash string) (*db.APIToken, error) {
+	if f.getTokenFn == nil {
+		return nil, db.ErrAPITokenNotFound
+	}
+	return f.getTokenFn(ctx, hash)
+}
+
+func (f *fakeStore) TouchAPIToken(ctx context.Context, id string, at time.Time) error {
+	if f.touchTokenFn == nil {
+		return nil
+	}
+	return f.touchTokenFn(ctx, id, at)
+}
+
+// tokenStore returns a store resolving the secret "pat" to a token with the given scopes.
+func tokenStore(scopes ...string) *fakeStore {
+	return &fakeStore{
+		getUserFn: func(_ context.Context, id string) (*db.User, error) {
+			return &db.User{ID: id, Role: db.RoleAdmin}, nil
+		},
+		getTokenFn: func(_ context.Context, hash string) (*db.APIToken, error) {
+			if hash != utils.HashToken("pat") {
+				return nil, db.ErrAPITokenNotFound
+			}
+			return &db.APIToken{ID: "t1", UserID: "user@example.com", Scopes: scopes}, nil
+		},
+	}
+}
+
 func TestResolveUserID(t *testing.T) {
 	t.Parallel()
 
@@ -42,35 +83,73 @@ func TestResolveUserID(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		req.Header.Set("X-User-Email", "User@Example.com")
 
-		id, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", false, "")
+		id, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", nil, false)
 		require.NoError(t, err)
 		assert.Equal(t, "user@example.com", id)
 	})
 
-	t.Run("Uses fallback when no auth header", func(t *testing.T) {
+	t.Run("Uses session cookie in local mode", func(t *testing.T) {
 		t.Parallel()
 
+		store := &fakeStore{
+			getSessionFn: func(_ context.Context, id string) (*db.Session, error) {
+				require.Equal(t, utils.HashToken("token"), id)
+				return &db.Session{ID: id, UserID: "user@example.com", ExpiresAt: time.Now().Add(time.Hour)}, nil
+			},
+		}
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
-		id, err := ResolveUserID(req, &fakeStore{}, "", false, "User@Example.com")
+		req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "token"})
+		id, err := ResolveUserID(req, store, "", nil, false)
 		require.NoError(t, err)
 		assert.Equal(t, "user@example.com", id)
 	})
 
-	t.Run("Uses local header when fallback missing", func(t *testing.T) {
+	t.Run("Ignores user header in local mode", func(t *testing.T) {
 		t.Parallel()
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
-		req.Header.Set(localAuthHeader, "User@Example.com")
-		id, err := ResolveUserID(req, &fakeStore{}, "", false, "")
-		require.NoError(t, err)
-		assert.Equal(t, "user@example.com", id)
+		req.Header.Set("X-User-ID", "user@example.com")
+		_, err := ResolveUserID(req, &fakeStore{}, "", nil, false)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+	})
+
+	t.Run("Rejects expired session", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeStore{
+			getSessionFn: func(_ context.Context, id string) (*db.Session, error) {
+				return &db.Session{ID: id, UserID: "user@example.com", ExpiresAt: time.Now().Add(-time.Minute)}, nil
+			},
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "token"})
+		_, err := ResolveUserID(req, store, "", nil, false)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+	})
+
+	t.Run("Rejects revoked session", func(t *testing.T) {
+		t.Parallel()
+
+		revokedAt := time.Now()
+		store := &fakeStore{
+			getSessionFn: func(_ context.Context, id string) (*db.Session, error) {
+				return &db.Session{ID: id, UserID: "user@example.com", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil
+			},
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "token"})
+		_, err := ResolveUserID(req, store, "", nil, false)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
 	})
 
 	t.Run("Requires auth header when configured", func(t *testing.T) {
 		t.Parallel()
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
-		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", false, "")
+		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", nil, false)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "auth header")
 	})
@@ -81,7 +160,7 @@ func TestResolveUserID(t *testing.T) {
 		created := false
 		store := &fakeStore{
 			getUserFn: func(context.Context, string) (*db.User, error) {
-				return nil, pgx.ErrNoRows
+				return nil, db.ErrUserNotFound
 			},
 			createUserFn: func(context.Context, string, string, string) (*db.User, error) {
 				created = true
@@ -91,7 +170,7 @@ func TestResolveUserID(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		req.Header.Set("X-User-Email", "user@example.com")
 
-		id, err := ResolveUserID(req, store, "X-User-Email", true, "")
+		id, err := ResolveUserID(req, store, "X-User-Email", nil, true)
 		require.NoError(t, err)
 		assert.Equal(t, "user@example.com", id)
 		assert.True(t, created, "expected user to be created")
@@ -111,8 +190,146 @@ func TestResolveUserID(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		req.Header.Set("X-User-Email", "user@example.com")
 
-		id, err := ResolveUserID(req, store, "X-User-Email", true, "")
+		id, err := ResolveUserID(req, store, "X-User-Email", nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", id)
+	})
+}
+
+func TestResolveActor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Carries admin flag", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeStore{
+			getUserFn: func(_ context.Context, id string) (*db.User, error) {
+				return &db.User{ID: id, Role: db.RoleAdmin}, nil
+			},
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User-Email", "admin@example.com")
+
+		actor, err := ResolveActor(req, store, "X-User-Email", nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, "admin@example.com", actor.UserID)
+		assert.True(t, actor.IsAdmin)
+	})
+
+	t.Run("Missing user is a member", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeStore{
+			getUserFn: func(context.Context, string) (*db.User, error) {
+				return nil, db.ErrUserNotFound
+			},
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User-Email", "user@example.com")
+
+		actor, err := ResolveActor(req, store, "X-User-Email", nil, false)
+		require.NoError(t, err)
+		assert.False(t, actor.IsAdmin)
+	})
+
+	t.Run("Surfaces lookup errors", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeStore{
+			getUserFn: func(context.Context, string) (*db.User, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User-Email", "user@example.com")
+
+		_, err := ResolveActor(req, store, "X-User-Email", nil, false)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorInternal))
+	})
+}
+
+func TestResolveBearerToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Works alongside auth header mode", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer pat")
+
+		id, err := ResolveUserID(req, tokenStore("read"), "X-User-Email", nil, false)
 		require.NoError(t, err)
 		assert.Equal(t, "user@example.com", id)
 	})
+
+	t.Run("Works in local mode and records usage", func(t *testing.T) {
+		t.Parallel()
+
+		store := tokenStore("read")
+		touched := false
+		store.touchTokenFn = func(_ context.Context, id string, _ time.Time) error {
+			touched = id == "t1"
+			return nil
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "bearer pat")
+
+		id, err := ResolveUserID(req, store, "", nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", id)
+		assert.True(t, touched)
+	})
+
+	t.Run("Rejects unknown token", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer nope")
+
+		_, err := ResolveUserID(req, tokenStore("read"), "", nil, false)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+	})
+
+	t.Run("Rejects expired token", func(t *testing.T) {
+		t.Parallel()
+
+		expired := time.Now().Add(-time.Minute)
+		store := &fakeStore{getTokenFn: func(context.Context, string) (*db.APIToken, error) {
+			return &db.APIToken{ID: "t1", UserID: "user@example.com", Scopes: []string{"write"}, ExpiresAt: &expired}, nil
+		}}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer pat")
+
+		_, err := ResolveUserID(req, store, "", nil, false)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+	})
+
+	t.Run("Read scope cannot write", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("Authorization", "Bearer pat")
+
+		_, err := ResolveUserID(req, tokenStore("read"), "", nil, false)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Admin flag requires admin scope", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer pat")
+
+		actor, err := ResolveActor(req, tokenStore("write"), "", nil, false)
+		require.NoError(t, err)
+		assert.False(t, actor.IsAdmin)
+
+		actor, err = ResolveActor(req, tokenStore("admin"), "", nil, false)
+		require.NoError(t, err)
+		assert.True(t, actor.IsAdmin)
+	})
 }
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/jose"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// ProxyTrust restricts who may assert identities through the proxy auth header.
+type ProxyTrust struct {
+	TrustedProxies    []netip.Prefix // TrustedProxies lists peers allowed to set the auth header; empty trusts any peer.
+	AssertionHeader   string         // AssertionHeader carries a signed JWT vouching for the user.
+	AssertionKeys     *jose.KeySet   // AssertionKeys verify the assertion signature.
+	AssertionIssuer   string         // AssertionIssuer is the required iss claim, if set.
+	AssertionAudience string         // AssertionAudience is the required aud claim, if set.
+	AssertionClaim    string         // AssertionClaim names the claim that must match the auth header; defaults to email.
+}
+
+// verify checks that the request came through a trusted proxy and carries a valid assertion for email.
+func (p *ProxyTrust) verify(r *http.Request, email string) error {
+	if p == nil {
+		return nil
+	}
+	if len(p.TrustedProxies) > 0 && !p.trustedPeer(r.RemoteAddr) {
+		return errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "auth header from untrusted peer", errorScope)
+	}
+	if p.AssertionHeader == "" {
+		return nil
+	}
+	return p.verifyAssertion(strings.TrimSpace(r.Header.Get(p.AssertionHeader)), email)
+}
+
+// trustedPeer reports whether the direct peer address is in a trusted range.
+func (p *ProxyTrust) trustedPeer(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyAssertion validates the signed JWT and matches its identity claim against email.
+func (p *ProxyTrust) verifyAssertion(raw, email string) error {
+	if raw == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "auth assertion is required", errorScope)
+	}
+	claims, err := jose.Verify(raw, p.AssertionKeys, time.Now())
+	if err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "auth assertion is invalid", errorScope)
+	}
+	if p.AssertionIssuer != "" && strings.TrimRight(claims.String("iss"), "/") != strings.TrimRight(p.AssertionIssuer, "/") {
+		return errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "auth assertion issuer mismatch", errorScope)
+	}
+	if p.AssertionAudience != "" && !claims.HasAudience(p.AssertionAudience) {
+		return errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "auth assertion audience mismatch", errorScope)
+	}
+	claim := utils.DefaultIfZero(p.AssertionClaim, "email")
+	subject, err := utils.NormalizeEmail(claims.String(claim))
+	if err != nil || subject != email {
+		return errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "auth assertion does not match auth header", errorScope)
+	}
+	return nil
+}
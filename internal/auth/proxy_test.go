@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/jose"
+	"github.com/gi8lino/motus/internal/oidc/oidctest"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+func TestProxyTrust(t *testing.T) {
+	t.Parallel()
+
+	issuer := oidctest.NewIssuer(t, "motus", "")
+	keys, err := jose.ParseKeySet(issuer.JWKS())
+	require.NoError(t, err)
+
+	assertion := func(claims map[string]any) string {
+		base := map[string]any{
+			"iss":   "https://team.cloudflareaccess.com",
+			"aud":   "motus-app",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"email": "user@example.com",
+		}
+		for k, v := range claims {
+			base[k] = v
+		}
+		return issuer.Sign(base)
+	}
+
+	trust := &ProxyTrust{
+		TrustedProxies:    []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+		AssertionHeader:   "Cf-Access-Jwt-Assertion",
+		AssertionKeys:     keys,
+		AssertionIssuer:   "https://team.cloudflareaccess.com",
+		AssertionAudience: "motus-app",
+	}
+
+	newRequest := func(remoteAddr, token string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-User-Email", "user@example.com")
+		if token != "" {
+			req.Header.Set("Cf-Access-Jwt-Assertion", token)
+		}
+		return req
+	}
+
+	t.Run("Trusted peer with valid assertion", func(t *testing.T) {
+		t.Parallel()
+		req := newRequest("10.1.2.3:4567", assertion(nil))
+
+		id, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", trust, false)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", id)
+	})
+
+	t.Run("Untrusted peer", func(t *testing.T) {
+		t.Parallel()
+		req := newRequest("192.0.2.1:4567", assertion(nil))
+		req.Header.Set("X-Forwarded-For", "10.1.2.3")
+
+		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", trust, false)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+		assert.EqualError(t, err, "auth header from untrusted peer")
+	})
+
+	t.Run("IPv4-mapped peer", func(t *testing.T) {
+		t.Parallel()
+		req := newRequest("[::ffff:10.1.2.3]:4567", assertion(nil))
+
+		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", trust, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("Missing assertion", func(t *testing.T) {
+		t.Parallel()
+		req := newRequest("10.1.2.3:4567", "")
+
+		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", trust, false)
+		assert.EqualError(t, err, "auth assertion is required")
+	})
+
+	t.Run("Assertion for another user", func(t *testing.T) {
+		t.Parallel()
+		req := newRequest("10.1.2.3:4567", assertion(map[string]any{"email": "other@example.com"}))
+
+		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", trust, false)
+		assert.EqualError(t, err, "auth assertion does not match auth header")
+	})
+
+	t.Run("Assertion with wrong audience", func(t *testing.T) {
+		t.Parallel()
+		req := newRequest("10.1.2.3:4567", assertion(map[string]any{"aud": "other-app"}))
+
+		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", trust, false)
+		assert.EqualError(t, err, "auth assertion audience mismatch")
+	})
+
+	t.Run("Assertion with wrong issuer", func(t *testing.T) {
+		t.Parallel()
+		req := newRequest("10.1.2.3:4567", assertion(map[string]any{"iss": "https://evil.example.com"}))
+
+		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", trust, false)
+		assert.EqualError(t, err, "auth assertion issuer mismatch")
+	})
+
+	t.Run("Expired assertion", func(t *testing.T) {
+		t.Parallel()
+		req := newRequest("10.1.2.3:4567", assertion(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()}))
+
+		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", trust, false)
+		assert.EqualError(t, err, "auth assertion is invalid")
+	})
+
+	t.Run("Trusted proxies without assertion", func(t *testing.T) {
+		t.Parallel()
+		peerOnly := &ProxyTrust{TrustedProxies: trust.TrustedProxies}
+		req := newRequest("10.1.2.3:4567", "")
+
+		_, err := ResolveUserID(req, &fakeStore{}, "X-User-Email", peerOnly, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("Bearer tokens bypass proxy checks", func(t *testing.T) {
+		t.Parallel()
+		req := newRequest("192.0.2.1:4567", "")
+		req.Header.Set("Authorization", "Bearer pat")
+
+		id, err := ResolveUserID(req, tokenStore("read"), "X-User-Email", trust, false)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", id)
+	})
+}
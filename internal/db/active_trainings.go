@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateActiveTraining stores a new training in progress, replacing any other active training of the user.
+// Watchers of a replaced training are notified that it ended.
+func (s *Store) CreateActiveTraining(ctx context.Context, training ActiveTraining) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	userID := strings.TrimSpace(training.UserID)
+	if _, err := tx.Exec(ctx, `
+		WITH deleted AS (
+			DELETE FROM active_trainings WHERE user_id=$1 RETURNING id
+		)
+		SELECT pg_notify($2, id) FROM deleted
+	`, userID, TrainingEventsChannel); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO active_trainings(id, user_id, workout_id, state, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, training.ID, userID, training.WorkoutID, training.State, training.CreatedAt, training.UpdatedAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetActiveTraining fetches a training in progress by id.
+func (s *Store) GetActiveTraining(ctx context.Context, id string) (*ActiveTraining, error) {
+	return scanActiveTraining(s.pool.QueryRow(ctx, `
+		SELECT id, user_id, workout_id, state, created_at, updated_at
+		FROM active_trainings
+		WHERE id=$1
+	`, strings.TrimSpace(id)))
+}
+
+// ActiveTrainingForUser returns the training the user has in progress.
+func (s *Store) ActiveTrainingForUser(ctx context.Context, userID string) (*ActiveTraining, error) {
+	return scanActiveTraining(s.pool.QueryRow(ctx, `
+		SELECT id, user_id, workout_id, state, created_at, updated_at
+		FROM active_trainings
+		WHERE user_id=$1
+	`, strings.TrimSpace(userID)))
+}
+
+// UpdateActiveTraining replaces the stored state of a training in progress and notifies its watchers.
+// The update only applies while the training was last changed at prev; otherwise ErrActiveTrainingConflict is returned.
+func (s *Store) UpdateActiveTraining(ctx context.Context, training ActiveTraining, prev time.Time) error {
+	id := strings.TrimSpace(training.ID)
+	tag, err := s.pool.Exec(ctx, `
+		WITH updated AS (
+			UPDATE active_trainings
+			SET state=$2, updated_at=$3
+			WHERE id=$1 AND updated_at=$5
+			RETURNING id
+		)
+		SELECT pg_notify($4, id) FROM updated
+	`, id, training.State, training.UpdatedAt, TrainingEventsChannel, prev)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := s.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM active_trainings WHERE id=$1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return ErrActiveTrainingConflict
+	}
+	return ErrActiveTrainingNotFound
+}
+
+// DeleteActiveTraining discards a training in progress and notifies its watchers.
+func (s *Store) DeleteActiveTraining(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx, `
+		WITH deleted AS (
+			DELETE FROM active_trainings WHERE id=$1 RETURNING id
+		)
+		SELECT pg_notify($2, id) FROM deleted
+	`, strings.TrimSpace(id), TrainingEventsChannel)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrActiveTrainingNotFound
+	}
+	return nil
+}
+
+// scanActiveTraining maps a single active training row.
+func scanActiveTraining(row pgx.Row) (*ActiveTraining, error) {
+	var training ActiveTraining
+	if err := row.Scan(&training.ID, &training.UserID, &training.WorkoutID, &training.State, &training.CreatedAt, &training.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrActiveTrainingNotFound
+		}
+		return nil, err
+	}
+	return &training, nil
+}
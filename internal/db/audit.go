@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CreateAuditEvent appends an event to the audit log.
+func (s *Store) CreateAuditEvent(ctx context.Context, event AuditEvent) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO audit_events(
+			created_at,
+			actor_id,
+			action,
+			resource,
+			resource_id,
+			before,
+			after,
+			request_id,
+			ip
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		event.CreatedAt,
+		strings.TrimSpace(event.ActorID),
+		event.Action,
+		event.Resource,
+		event.ResourceID,
+		event.Before,
+		event.After,
+		event.RequestID,
+		event.IP,
+	)
+	return err
+}
+
+// ListAuditEvents returns events matching filter, newest first.
+func (s *Store) ListAuditEvents(ctx context.Context, filter AuditFilter) ([]AuditEvent, error) {
+	var (
+		where []string
+		args  []any
+	)
+	add := func(clause string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+	if filter.ActorID != "" {
+		add("actor_id = $%d", filter.ActorID)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.Resource != "" {
+		add("resource = $%d", filter.Resource)
+	}
+	if filter.ResourceID != "" {
+		add("resource_id = $%d", filter.ResourceID)
+	}
+	if filter.Since != nil {
+		add("created_at >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		add("created_at < $%d", *filter.Until)
+	}
+	if filter.BeforeID > 0 {
+		add("id < $%d", filter.BeforeID)
+	}
+
+	query := `
+		SELECT id, created_at, actor_id, action, resource, resource_id, before, after, request_id, ip
+		FROM audit_events`
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf("\n\t\tORDER BY id DESC\n\t\tLIMIT $%d", len(args))
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []AuditEvent
+	for rows.Next() {
+		var event AuditEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.CreatedAt,
+			&event.ActorID,
+			&event.Action,
+			&event.Resource,
+			&event.ResourceID,
+			&event.Before,
+			&event.After,
+			&event.RequestID,
+			&event.IP,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// DeleteAuditEventsBefore removes events older than cutoff and returns how many were deleted.
+func (s *Store) DeleteAuditEventsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM audit_events WHERE created_at < $1`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateClass stores a new open class.
+func (s *Store) CreateClass(ctx context.Context, class Class) error {
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO classes(id, coach_id, workout_id, workout_name, training_id, join_code, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (join_code) WHERE ended_at IS NULL DO NOTHING
+	`, class.ID, strings.TrimSpace(class.CoachID), class.WorkoutID, class.WorkoutName, class.TrainingID, class.JoinCode, class.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJoinCodeTaken
+	}
+	return nil
+}
+
+// GetClass fetches a class by id.
+func (s *Store) GetClass(ctx context.Context, id string) (*Class, error) {
+	return scanClass(s.pool.QueryRow(ctx, `
+		SELECT id, coach_id, workout_id, workout_name, training_id, join_code, created_at, ended_at
+		FROM classes
+		WHERE id=$1
+	`, strings.TrimSpace(id)))
+}
+
+// OpenClassByCode fetches the open class that uses a join code.
+func (s *Store) OpenClassByCode(ctx context.Context, code string) (*Class, error) {
+	return scanClass(s.pool.QueryRow(ctx, `
+		SELECT id, coach_id, workout_id, workout_name, training_id, join_code, created_at, ended_at
+		FROM classes
+		WHERE join_code=$1 AND ended_at IS NULL
+	`, strings.ToUpper(strings.TrimSpace(code))))
+}
+
+// EndClass closes a class so its join code can be reused.
+func (s *Store) EndClass(ctx context.Context, id string, at time.Time) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE classes
+		SET ended_at=$2
+		WHERE id=$1 AND ended_at IS NULL
+	`, strings.TrimSpace(id), at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrClassNotFound
+	}
+	return nil
+}
+
+// AddClassParticipant adds a user to a class; joining twice keeps the first entry.
+func (s *Store) AddClassParticipant(ctx context.Context, participant ClassParticipant) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO class_participants(class_id, user_id, joined_at, seen_at, seen_index)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (class_id, user_id) DO NOTHING
+	`, participant.ClassID, strings.TrimSpace(participant.UserID), participant.JoinedAt, participant.SeenAt, participant.SeenIndex)
+	return err
+}
+
+// GetClassParticipant fetches a participant of a class.
+func (s *Store) GetClassParticipant(ctx context.Context, classID, userID string) (*ClassParticipant, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT p.class_id, p.user_id, u.name, p.joined_at, p.left_at, p.training_id, p.seen_at, p.seen_index, p.results
+		FROM class_participants p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.class_id=$1 AND p.user_id=$2
+	`, strings.TrimSpace(classID), strings.TrimSpace(userID))
+	var p ClassParticipant
+	if err := row.Scan(&p.ClassID, &p.UserID, &p.Name, &p.JoinedAt, &p.LeftAt, &p.TrainingID, &p.SeenAt, &p.SeenIndex, &p.Results); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrClassParticipantNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ListClassParticipants returns the participants of a class in join order.
+func (s *Store) ListClassParticipants(ctx context.Context, classID string) ([]ClassParticipant, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT p.class_id, p.user_id, u.name, p.joined_at, p.left_at, p.training_id, p.seen_at, p.seen_index, p.results
+		FROM class_participants p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.class_id=$1
+		ORDER BY p.joined_at ASC, p.user_id ASC
+	`, strings.TrimSpace(classID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []ClassParticipant
+	for rows.Next() {
+		var p ClassParticipant
+		if err := rows.Scan(&p.ClassID, &p.UserID, &p.Name, &p.JoinedAt, &p.LeftAt, &p.TrainingID, &p.SeenAt, &p.SeenIndex, &p.Results); err != nil {
+			return nil, err
+		}
+		participants = append(participants, p)
+	}
+	return participants, rows.Err()
+}
+
+// LeaveClass marks a participant as gone and links the training log written for them, if any.
+func (s *Store) LeaveClass(ctx context.Context, classID, userID, trainingID string, at time.Time) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE class_participants
+		SET left_at=$3, training_id=$4
+		WHERE class_id=$1 AND user_id=$2 AND left_at IS NULL
+	`, strings.TrimSpace(classID), strings.TrimSpace(userID), at, trainingID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrClassParticipantNotFound
+	}
+	return nil
+}
+
+// SeeClassParticipant records that a participant is still following a class at the given step.
+func (s *Store) SeeClassParticipant(ctx context.Context, classID, userID string, at time.Time, index int) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE class_participants
+		SET seen_at=$3, seen_index=$4
+		WHERE class_id=$1 AND user_id=$2 AND left_at IS NULL
+	`, strings.TrimSpace(classID), strings.TrimSpace(userID), at, index)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrClassParticipantNotFound
+	}
+	return nil
+}
+
+// SaveClassResults replaces the exercise results a participant submitted and marks them as seen.
+func (s *Store) SaveClassResults(ctx context.Context, classID, userID string, results json.RawMessage, at time.Time, index int) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE class_participants
+		SET results=$3, seen_at=$4, seen_index=$5
+		WHERE class_id=$1 AND user_id=$2 AND left_at IS NULL
+	`, strings.TrimSpace(classID), strings.TrimSpace(userID), results, at, index)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrClassParticipantNotFound
+	}
+	return nil
+}
+
+// scanClass maps a single class row.
+func scanClass(row pgx.Row) (*Class, error) {
+	var c Class
+	if err := row.Scan(&c.ID, &c.CoachID, &c.WorkoutID, &c.WorkoutName, &c.TrainingID, &c.JoinCode, &c.CreatedAt, &c.EndedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrClassNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
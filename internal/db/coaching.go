@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateCoachLink stores a pending invitation from a coach to an athlete.
+func (s *Store) CreateCoachLink(ctx context.Context, link CoachLink) error {
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO coach_links(id, coach_id, athlete_id, status, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (coach_id, athlete_id) DO NOTHING
+	`, link.ID, strings.TrimSpace(link.CoachID), strings.TrimSpace(link.AthleteID), link.Status, link.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCoachLinkExists
+	}
+	return nil
+}
+
+// ListCoachLinks returns all links where the user is coach or athlete, newest first.
+func (s *Store) ListCoachLinks(ctx context.Context, userID string) ([]CoachLink, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, coach_id, athlete_id, status, created_at, accepted_at
+		FROM coach_links
+		WHERE coach_id=$1 OR athlete_id=$1
+		ORDER BY created_at DESC
+	`, strings.TrimSpace(userID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []CoachLink
+	for rows.Next() {
+		var link CoachLink
+		if err := rows.Scan(&link.ID, &link.CoachID, &link.AthleteID, &link.Status, &link.CreatedAt, &link.AcceptedAt); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// GetCoachLink fetches a single coach link by id.
+func (s *Store) GetCoachLink(ctx context.Context, id string) (*CoachLink, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT id, coach_id, athlete_id, status, created_at, accepted_at
+		FROM coach_links
+		WHERE id=$1
+	`, strings.TrimSpace(id))
+	var link CoachLink
+	if err := row.Scan(&link.ID, &link.CoachID, &link.AthleteID, &link.Status, &link.CreatedAt, &link.AcceptedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCoachLinkNotFound
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+// AcceptCoachLink activates a pending coach link.
+func (s *Store) AcceptCoachLink(ctx context.Context, id string, at time.Time) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE coach_links
+		SET status=$2, accepted_at=$3
+		WHERE id=$1 AND status=$4
+	`, strings.TrimSpace(id), CoachLinkActive, at, CoachLinkPending)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCoachLinkNotFound
+	}
+	return nil
+}
+
+// DeleteCoachLink removes a pending or active coach link.
+func (s *Store) DeleteCoachLink(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM coach_links WHERE id=$1`, strings.TrimSpace(id))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCoachLinkNotFound
+	}
+	return nil
+}
+
+// IsCoachOf reports whether coachID has an active link to athleteID.
+func (s *Store) IsCoachOf(ctx context.Context, coachID, athleteID string) (bool, error) {
+	var active bool
+	err := s.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM coach_links
+			WHERE coach_id=$1 AND athlete_id=$2 AND status=$3
+		)
+	`, strings.TrimSpace(coachID), strings.TrimSpace(athleteID), CoachLinkActive).Scan(&active)
+	return active, err
+}
+
+// AssignWorkout copies a workout into the athlete's library and records who assigned it.
+func (s *Store) AssignWorkout(ctx context.Context, workoutID, athleteID, assignedBy, name string) (*Workout, error) {
+	src, err := s.WorkoutWithSteps(ctx, workoutID)
+	if err != nil {
+		return nil, err
+	}
+	workout := &Workout{
+		UserID:     strings.TrimSpace(athleteID),
+		Name:       strings.TrimSpace(name),
+		AssignedBy: strings.TrimSpace(assignedBy),
+		Steps:      cloneSteps(src.Steps),
+	}
+	if workout.Name == "" {
+		workout.Name = src.Name
+	}
+	return s.insertWorkout(ctx, workout, false)
+}
@@ -2,5 +2,68 @@ package db
 
 import "errors"
 
+// ErrUserNotFound indicates that the referenced user does not exist.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrLastAdmin indicates that a role change would leave no admin.
+var ErrLastAdmin = errors.New("at least one admin is required")
+
 // ErrWorkoutNotFound indicates that the referenced workout does not exist.
 var ErrWorkoutNotFound = errors.New("workout not found")
+
+// ErrSessionNotFound indicates that the referenced session does not exist.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrAPITokenNotFound indicates that the referenced API token does not exist.
+var ErrAPITokenNotFound = errors.New("api token not found")
+
+// ErrOneTimeTokenNotFound indicates that a reset or verification token is unknown, used or expired.
+var ErrOneTimeTokenNotFound = errors.New("token is invalid or expired")
+
+// ErrTOTPNotEnrolled indicates that a user has no pending or active TOTP secret.
+var ErrTOTPNotEnrolled = errors.New("two-factor authentication is not set up")
+
+// ErrInvitationNotFound indicates that the referenced invitation does not exist.
+var ErrInvitationNotFound = errors.New("invitation not found")
+
+// ErrInvitationInvalid indicates that an invite token is unknown, revoked, expired or used up.
+var ErrInvitationInvalid = errors.New("invitation is invalid or expired")
+
+// ErrCoachLinkNotFound indicates that the referenced coach link does not exist.
+var ErrCoachLinkNotFound = errors.New("coach link not found")
+
+// ErrCoachLinkExists indicates that the coach already invited or coaches the athlete.
+var ErrCoachLinkExists = errors.New("coach link already exists")
+
+// ErrTrainingNotFound indicates that the referenced training does not exist.
+var ErrTrainingNotFound = errors.New("training not found")
+
+// ErrTrainingStepNotFound indicates that the referenced step is not part of the training.
+var ErrTrainingStepNotFound = errors.New("training step not found")
+
+// ErrActiveTrainingNotFound indicates that no training is in progress for the given id or user.
+var ErrActiveTrainingNotFound = errors.New("no active training")
+
+// ErrActiveTrainingConflict indicates that a training in progress changed since it was read.
+var ErrActiveTrainingConflict = errors.New("training changed concurrently")
+
+// ErrOrganizationNotFound indicates that the referenced organization does not exist.
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+// ErrOrgMemberNotFound indicates that the user is not a member of the organization.
+var ErrOrgMemberNotFound = errors.New("organization member not found")
+
+// ErrOrgMemberExists indicates that the user already belongs to the organization.
+var ErrOrgMemberExists = errors.New("user is already a member of the organization")
+
+// ErrClassNotFound indicates that the referenced class does not exist or no open class uses the join code.
+var ErrClassNotFound = errors.New("class not found")
+
+// ErrJoinCodeTaken indicates that another open class already uses the join code.
+var ErrJoinCodeTaken = errors.New("join code is already in use")
+
+// ErrClassParticipantNotFound indicates that the user did not join the class.
+var ErrClassParticipantNotFound = errors.New("class participant not found")
+
+// ErrSummaryTemplateNotFound indicates that the referenced summary template does not exist.
+var ErrSummaryTemplateNotFound = errors.New("summary template not found")
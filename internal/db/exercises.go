@@ -121,14 +121,15 @@ func (s *Store) insertMissingCoreExercises(ctx context.Context, tx pgx.Tx, names
 
 // BackfillCoreExercises creates core exercises from existing workout data and links them.
 
-// ListExercises returns core exercises plus user-owned exercises.
-func (s *Store) ListExercises(ctx context.Context, userID string) ([]Exercise, error) {
+// ListExercises returns global core exercises, the shared exercises of orgID if set, and user-owned exercises.
+func (s *Store) ListExercises(ctx context.Context, userID, orgID string) ([]Exercise, error) {
 	// Return core exercises plus user-owned entries.
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, name, owner_user_id, (is_core OR owner_user_id IS NULL OR owner_user_id = '') AS is_core, created_at
+		SELECT id, name, owner_user_id, (is_core OR owner_user_id IS NULL OR owner_user_id = '') AS is_core, org_id, created_at
 		FROM exercises
-		WHERE is_core = TRUE OR owner_user_id = $1 OR owner_user_id IS NULL OR owner_user_id = ''
-		ORDER BY is_core DESC, name ASC`, strings.TrimSpace(userID))
+		WHERE (org_id = '' OR org_id = $2)
+		AND (is_core = TRUE OR owner_user_id = $1 OR owner_user_id IS NULL OR owner_user_id = '')
+		ORDER BY is_core DESC, name ASC`, strings.TrimSpace(userID), strings.TrimSpace(orgID))
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +140,7 @@ func (s *Store) ListExercises(ctx context.Context, userID string) ([]Exercise, e
 	for rows.Next() {
 		var ex Exercise
 		var ownerID *string
-		if err := rows.Scan(&ex.ID, &ex.Name, &ownerID, &ex.IsCore, &ex.CreatedAt); err != nil {
+		if err := rows.Scan(&ex.ID, &ex.Name, &ownerID, &ex.IsCore, &ex.OrgID, &ex.CreatedAt); err != nil {
 			return nil, err
 		}
 		if ownerID != nil {
@@ -154,12 +155,12 @@ func (s *Store) ListExercises(ctx context.Context, userID string) ([]Exercise, e
 func (s *Store) GetExercise(ctx context.Context, id string) (*Exercise, error) {
 	// Fetch a single exercise row by id.
 	row := s.pool.QueryRow(ctx, `
-		SELECT id, name, owner_user_id, (is_core OR owner_user_id IS NULL OR owner_user_id = '') AS is_core, created_at
+		SELECT id, name, owner_user_id, (is_core OR owner_user_id IS NULL OR owner_user_id = '') AS is_core, org_id, created_at
 		FROM exercises
 		WHERE id=$1`, strings.TrimSpace(id))
 	var ex Exercise
 	var ownerID *string
-	if err := row.Scan(&ex.ID, &ex.Name, &ownerID, &ex.IsCore, &ex.CreatedAt); err != nil {
+	if err := row.Scan(&ex.ID, &ex.Name, &ownerID, &ex.IsCore, &ex.OrgID, &ex.CreatedAt); err != nil {
 		return nil, err
 	}
 	if ownerID != nil {
@@ -195,6 +196,29 @@ func (s *Store) CreateExercise(ctx context.Context, name, ownerUserID string, is
 	return ex, nil
 }
 
+// CreateOrgExercise inserts an exercise shared by all members of an organization.
+func (s *Store) CreateOrgExercise(ctx context.Context, name, orgID string) (*Exercise, error) {
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" {
+		return nil, errors.New("exercise name required")
+	}
+	ex := &Exercise{
+		ID:        utils.NewID(),
+		Name:      trimmed,
+		IsCore:    true,
+		OrgID:     strings.TrimSpace(orgID),
+		CreatedAt: time.Now().UTC(),
+	}
+	if _, err := s.pool.Exec(ctx, `
+		INSERT INTO exercises(id, name, owner_user_id, is_core, org_id, created_at)
+		VALUES ($1, $2, '', TRUE, $3, $4)
+	`,
+		ex.ID, ex.Name, ex.OrgID, ex.CreatedAt); err != nil {
+		return nil, err
+	}
+	return ex, nil
+}
+
 // RenameExercise updates the catalog name and linked workout exercise names.
 func (s *Store) RenameExercise(ctx context.Context, id, name string) (*Exercise, error) {
 	// Update exercise name and propagate to workout references.
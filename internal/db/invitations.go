@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// CreateInvitation stores a new invitation.
+func (s *Store) CreateInvitation(ctx context.Context, invitation Invitation) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO invitations(
+			id,
+			token_hash,
+			created_by,
+			role,
+			max_uses,
+			uses,
+			created_at,
+			expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		invitation.ID,
+		invitation.TokenHash,
+		strings.TrimSpace(invitation.CreatedBy),
+		invitation.Role,
+		invitation.MaxUses,
+		invitation.Uses,
+		invitation.CreatedAt,
+		invitation.ExpiresAt,
+	)
+	return err
+}
+
+// ListInvitations returns all non-revoked invitations, newest first.
+func (s *Store) ListInvitations(ctx context.Context) ([]Invitation, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, token_hash, created_by, role, max_uses, uses, created_at, expires_at, revoked_at
+		FROM invitations
+		WHERE revoked_at IS NULL
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []Invitation
+	for rows.Next() {
+		var invitation Invitation
+		if err := rows.Scan(
+			&invitation.ID,
+			&invitation.TokenHash,
+			&invitation.CreatedBy,
+			&invitation.Role,
+			&invitation.MaxUses,
+			&invitation.Uses,
+			&invitation.CreatedAt,
+			&invitation.ExpiresAt,
+			&invitation.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations, rows.Err()
+}
+
+// RevokeInvitation marks an invitation as revoked.
+func (s *Store) RevokeInvitation(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE invitations
+		SET revoked_at=NOW()
+		WHERE id=$1 AND revoked_at IS NULL
+	`, strings.TrimSpace(id))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// CreateInvitedUser redeems one use of an invitation and inserts a verified user with its role.
+// The use is only counted when the user could be created.
+func (s *Store) CreateInvitedUser(ctx context.Context, tokenHash, email, avatarURL, passwordHash string, at time.Time) (*User, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	var role string
+	err = tx.QueryRow(ctx, `
+		UPDATE invitations
+		SET uses=uses+1
+		WHERE token_hash=$1 AND revoked_at IS NULL AND expires_at > $2 AND uses < max_uses
+		RETURNING role
+	`, tokenHash, at).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvitationInvalid
+		}
+		return nil, err
+	}
+
+	normalized := utils.NormalizeToken(email)
+	user := &User{
+		ID:              normalized,
+		Name:            normalized,
+		AvatarURL:       strings.TrimSpace(avatarURL),
+		CreatedAt:       at,
+		EmailVerifiedAt: &at,
+	}
+	user.SetRole(role)
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO users(
+			id,
+			name,
+			role,
+			avatar_url,
+			password_hash,
+			created_at,
+			email_verified_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		user.ID, user.Name, user.Role, user.AvatarURL, strings.TrimSpace(passwordHash), user.CreatedAt, user.EmailVerifiedAt); err != nil {
+		return nil, err
+	}
+	return user, tx.Commit(ctx)
+}
@@ -1,24 +1,46 @@
 package db
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+// User roles; the permissions granted to each role live in the policy package.
+const (
+	RoleAdmin         = "admin"
+	RoleCoach         = "coach"
+	RoleCatalogEditor = "catalog-editor"
+	RoleMember        = "member"
+)
 
 // User represents an account owner.
 type User struct {
-	ID        string    `json:"id"`        // ID is the unique user identifier.
-	Name      string    `json:"name"`      // Name is the display name.
-	IsAdmin   bool      `json:"isAdmin"`   // IsAdmin marks admin privileges.
-	AvatarURL string    `json:"avatarUrl"` // AvatarURL is the optional avatar image.
-	CreatedAt time.Time `json:"createdAt"` // CreatedAt records when the user was created.
+	ID              string     `json:"id"`                        // ID is the unique user identifier.
+	Name            string     `json:"name"`                      // Name is the display name.
+	Role            string     `json:"role"`                      // Role names the permission set of the user.
+	IsAdmin         bool       `json:"isAdmin"`                   // IsAdmin is derived from Role and marks admin privileges.
+	AvatarURL       string     `json:"avatarUrl"`                 // AvatarURL is the optional avatar image.
+	CreatedAt       time.Time  `json:"createdAt"`                 // CreatedAt records when the user was created.
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"` // EmailVerifiedAt is nil until a self-registered user confirms their email.
+	TOTPEnabled     bool       `json:"totpEnabled"`               // TOTPEnabled marks users with two-factor authentication.
+}
+
+// SetRole assigns role and derives the admin flag from it.
+func (u *User) SetRole(role string) {
+	u.Role = role
+	u.IsAdmin = role == RoleAdmin
 }
 
 // Workout groups stopwatch steps.
 type Workout struct {
-	ID         string        `json:"id"`         // ID is the unique workout identifier.
-	UserID     string        `json:"userId"`     // UserID owns the workout.
-	Name       string        `json:"name"`       // Name is the workout title.
-	IsTemplate bool          `json:"isTemplate"` // IsTemplate marks shared templates.
-	CreatedAt  time.Time     `json:"createdAt"`  // CreatedAt records when the workout was created.
-	Steps      []WorkoutStep `json:"steps"`      // Steps defines the workout flow.
+	ID         string        `json:"id"`                   // ID is the unique workout identifier.
+	UserID     string        `json:"userId"`               // UserID owns the workout.
+	Name       string        `json:"name"`                 // Name is the workout title.
+	IsTemplate bool          `json:"isTemplate"`           // IsTemplate marks shared templates.
+	AssignedBy string        `json:"assignedBy,omitempty"` // AssignedBy is the coach who assigned this copy, if any.
+	OrgID      string        `json:"orgId,omitempty"`      // OrgID scopes a template to an organization; empty means global.
+	CreatedAt  time.Time     `json:"createdAt"`            // CreatedAt records when the workout was created.
+	Steps      []WorkoutStep `json:"steps"`                // Steps defines the workout flow.
 }
 
 // PauseOptions captures optional behaviour for pause steps.
@@ -91,6 +113,7 @@ type Exercise struct {
 	Name        string    `json:"name"`                  // Name is the exercise label.
 	OwnerUserID string    `json:"ownerUserId,omitempty"` // OwnerUserID is set for user-owned entries.
 	IsCore      bool      `json:"isCore"`                // IsCore marks built-in exercises.
+	OrgID       string    `json:"orgId,omitempty"`       // OrgID scopes a shared exercise to an organization; empty means global.
 	CreatedAt   time.Time `json:"createdAt"`             // CreatedAt records when the entry was created.
 }
 
@@ -102,15 +125,304 @@ type TrainingLog struct {
 	UserID      string    `json:"userId"`      // UserID owns the training.
 	StartedAt   time.Time `json:"startedAt"`   // StartedAt is when the training began.
 	CompletedAt time.Time `json:"completedAt"` // CompletedAt is when the training finished.
+
+	Notes          string  `json:"notes,omitempty"`          // Notes is free text about how the session went.
+	RPE            int     `json:"rpe,omitempty"`            // RPE is the session rating of perceived exertion from 1 to 10.
+	Mood           int     `json:"mood,omitempty"`           // Mood is rated from 1 to 5.
+	Energy         int     `json:"energy,omitempty"`         // Energy is rated from 1 to 5.
+	Bodyweight     float64 `json:"bodyweight,omitempty"`     // Bodyweight is the weight of the user on that day.
+	BodyweightUnit string  `json:"bodyweightUnit,omitempty"` // BodyweightUnit is kg or lb when a bodyweight was logged.
 }
 
 // TrainingStepLog captures actual timing for a completed step.
 type TrainingStepLog struct {
-	ID               string `json:"id"`               // ID is the unique log row identifier.
-	TrainingID       string `json:"trainingId"`       // TrainingID links to the training.
-	StepOrder        int    `json:"stepOrder"`        // StepOrder preserves training ordering.
-	Type             string `json:"type"`             // Type is the step kind.
-	Name             string `json:"name"`             // Name is the step label.
-	EstimatedSeconds int    `json:"estimatedSeconds"` // EstimatedSeconds is the target duration.
-	ElapsedMillis    int64  `json:"elapsedMillis"`    // ElapsedMillis is the observed duration.
+	ID               string                 `json:"id"`                  // ID is the unique log row identifier.
+	TrainingID       string                 `json:"trainingId"`          // TrainingID links to the training.
+	StepOrder        int                    `json:"stepOrder"`           // StepOrder preserves training ordering.
+	Type             string                 `json:"type"`                // Type is the step kind.
+	Name             string                 `json:"name"`                // Name is the step label.
+	EstimatedSeconds int                    `json:"estimatedSeconds"`    // EstimatedSeconds is the target duration.
+	ElapsedMillis    int64                  `json:"elapsedMillis"`       // ElapsedMillis is the observed duration.
+	RPE              int                    `json:"rpe,omitempty"`       // RPE is the rating of perceived exertion for the step.
+	Exercises        []TrainingStepExercise `json:"exercises,omitempty"` // Exercises holds what was actually performed.
+}
+
+// Exercise result states.
+const (
+	ExerciseCompleted = "completed"
+	ExerciseSkipped   = "skipped"
+	ExerciseFailed    = "failed"
+)
+
+// Weight units for logged exercises.
+const (
+	WeightUnitKg = "kg"
+	WeightUnitLb = "lb"
+)
+
+// TrainingStepExercise captures the actual result of one exercise within a logged step.
+type TrainingStepExercise struct {
+	ID              string  `json:"id"`                   // ID is the unique result row identifier.
+	StepID          string  `json:"stepId"`               // StepID links to the logged step.
+	ExerciseOrder   int     `json:"exerciseOrder"`        // ExerciseOrder preserves the order within the step.
+	ExerciseID      string  `json:"exerciseId,omitempty"` // ExerciseID links to the catalog entry when known.
+	Type            string  `json:"type,omitempty"`       // Type is rep, stopwatch, or countdown when known.
+	Name            string  `json:"name"`                 // Name is the exercise label.
+	Reps            int     `json:"reps"`                 // Reps is the number of repetitions done.
+	Weight          float64 `json:"weight"`               // Weight is the load used.
+	WeightUnit      string  `json:"weightUnit,omitempty"` // WeightUnit is kg or lb when a weight was used.
+	DurationSeconds int     `json:"durationSeconds"`      // DurationSeconds is the time spent on timed exercises.
+	Status          string  `json:"status"`               // Status is completed, skipped or failed.
+}
+
+// SummaryTemplate is a user-defined text/template for training summaries.
+type SummaryTemplate struct {
+	ID        string    `json:"id"`        // ID is the unique template identifier.
+	UserID    string    `json:"userId"`    // UserID owns the template.
+	Name      string    `json:"name"`      // Name is the user-provided label.
+	Body      string    `json:"body"`      // Body is the template source.
+	CreatedAt time.Time `json:"createdAt"` // CreatedAt records when the template was created.
+	UpdatedAt time.Time `json:"updatedAt"` // UpdatedAt records the last change.
+}
+
+// StatsFilter selects the trainings of a user that statistics aggregate.
+type StatsFilter struct {
+	UserID   string    // UserID owns the trainings.
+	Period   string    // Period is the bucket size understood by date_trunc, such as week or month.
+	TimeZone string    // TimeZone is the IANA zone the buckets follow.
+	From     time.Time // From includes trainings started at or after this time.
+	To       time.Time // To includes trainings started before this time.
+}
+
+// StatsBucket aggregates the trainings of one period.
+type StatsBucket struct {
+	Start        time.Time `json:"start"`        // Start is the first day of the period in the requested time zone.
+	Sessions     int       `json:"sessions"`     // Sessions counts the trainings.
+	TotalSeconds int64     `json:"totalSeconds"` // TotalSeconds is the time from start to completion.
+	WorkSeconds  int64     `json:"workSeconds"`  // WorkSeconds is the time spent in set steps.
+	PauseSeconds int64     `json:"pauseSeconds"` // PauseSeconds is the time spent in pause steps.
+	Volume       float64   `json:"volume"`       // Volume is reps times weight of completed exercises, in kg.
+	Load         int       `json:"load"`         // Load is session RPE times duration in minutes.
+}
+
+// ExerciseStats aggregates the logged results of one exercise.
+type ExerciseStats struct {
+	ExerciseID string  `json:"exerciseId,omitempty"` // ExerciseID links to the catalog entry when known.
+	Name       string  `json:"name"`                 // Name is the exercise label.
+	Sessions   int     `json:"sessions"`             // Sessions counts the trainings with the exercise.
+	Sets       int     `json:"sets"`                 // Sets counts the completed results.
+	Reps       int     `json:"reps"`                 // Reps sums the repetitions.
+	Volume     float64 `json:"volume"`               // Volume is reps times weight, in kg.
+}
+
+// Personal record kinds.
+const (
+	RecordMaxWeight    = "max_weight"    // RecordMaxWeight is the heaviest weight lifted.
+	RecordMaxReps      = "max_reps"      // RecordMaxReps is the most reps at one weight.
+	RecordEstimated1RM = "estimated_1rm" // RecordEstimated1RM is the best estimated one-rep max.
+	RecordFastestTime  = "fastest_time"  // RecordFastestTime is the shortest time of a timed exercise.
+)
+
+// PersonalRecord is a best performance of a user on a catalog exercise, set during a training.
+type PersonalRecord struct {
+	ID              string    `json:"id"`                   // ID is the unique record identifier.
+	UserID          string    `json:"userId"`               // UserID owns the record.
+	ExerciseID      string    `json:"exerciseId"`           // ExerciseID links to the catalog entry.
+	ExerciseName    string    `json:"exerciseName"`         // ExerciseName is the exercise label when the record was set.
+	Kind            string    `json:"kind"`                 // Kind is max_weight, max_reps, estimated_1rm or fastest_time.
+	Value           float64   `json:"value"`                // Value is compared between records: kg, reps or seconds.
+	Reps            int       `json:"reps"`                 // Reps is the number of repetitions of the set.
+	Weight          float64   `json:"weight"`               // Weight is the load of the set.
+	WeightUnit      string    `json:"weightUnit,omitempty"` // WeightUnit is kg or lb when a weight was used.
+	DurationSeconds int       `json:"durationSeconds"`      // DurationSeconds is the time of timed exercises.
+	TrainingID      string    `json:"trainingId"`           // TrainingID links to the training the record was set in.
+	AchievedAt      time.Time `json:"achievedAt"`           // AchievedAt is when the training was completed.
+}
+
+// ActiveTraining is a training in progress whose runtime state is kept on the server.
+type ActiveTraining struct {
+	ID        string          `json:"id"`        // ID is the training identifier.
+	UserID    string          `json:"userId"`    // UserID owns the training.
+	WorkoutID string          `json:"workoutId"` // WorkoutID references the workout being trained.
+	State     json.RawMessage `json:"state"`     // State is the serialized training state.
+	CreatedAt time.Time       `json:"createdAt"` // CreatedAt records when the training was created.
+	UpdatedAt time.Time       `json:"updatedAt"` // UpdatedAt records the last state change.
+}
+
+// Class is a group training in which participants follow the timer of a coach.
+type Class struct {
+	ID          string     `json:"id"`                // ID is the unique class identifier.
+	CoachID     string     `json:"coachId"`           // CoachID is the user who drives the timer.
+	WorkoutID   string     `json:"workoutId"`         // WorkoutID references the workout being trained.
+	WorkoutName string     `json:"workoutName"`       // WorkoutName is the display name at start time.
+	TrainingID  string     `json:"trainingId"`        // TrainingID is the coach's training in progress.
+	JoinCode    string     `json:"joinCode"`          // JoinCode lets participants join while the class is open.
+	CreatedAt   time.Time  `json:"createdAt"`         // CreatedAt records when the coach started the class.
+	EndedAt     *time.Time `json:"endedAt,omitempty"` // EndedAt is set once the coach ended the class.
+}
+
+// ClassParticipant is a user following a class.
+type ClassParticipant struct {
+	ClassID    string          `json:"classId"`              // ClassID references the class.
+	UserID     string          `json:"userId"`               // UserID is the participant.
+	Name       string          `json:"name"`                 // Name is the participant's display name.
+	JoinedAt   time.Time       `json:"joinedAt"`             // JoinedAt records when the participant joined.
+	LeftAt     *time.Time      `json:"leftAt,omitempty"`     // LeftAt is set once the participant dropped out or the class ended.
+	TrainingID string          `json:"trainingId,omitempty"` // TrainingID references the participant's training log once written.
+	SeenAt     time.Time       `json:"seenAt"`               // SeenAt records when the participant was last connected or submitted results.
+	SeenIndex  int             `json:"seenIndex"`            // SeenIndex is the class step the participant was on at SeenAt.
+	Results    json.RawMessage `json:"-"`                    // Results holds the exercise results the participant submitted so far.
+}
+
+// Session represents a server-side login session.
+type Session struct {
+	ID         string     `json:"id"`                  // ID is the SHA-256 hash of the session token.
+	UserID     string     `json:"userId"`              // UserID owns the session.
+	UserAgent  string     `json:"userAgent"`           // UserAgent is the client that created the session.
+	IP         string     `json:"ip"`                  // IP is the remote address that created the session.
+	CreatedAt  time.Time  `json:"createdAt"`           // CreatedAt records when the session was issued.
+	LastSeenAt time.Time  `json:"lastSeenAt"`          // LastSeenAt records the last authenticated request.
+	ExpiresAt  time.Time  `json:"expiresAt"`           // ExpiresAt is when the session stops being valid.
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"` // RevokedAt is set once the session was revoked.
+}
+
+// IsActive reports whether the session is neither revoked nor expired at now.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// OneTimeToken is a single-use, time-limited token for password resets and email verification.
+type OneTimeToken struct {
+	ID        string     `json:"-"`                // ID is the SHA-256 hash of the token.
+	UserID    string     `json:"userId"`           // UserID owns the token.
+	Purpose   string     `json:"purpose"`          // Purpose scopes the token to a single flow.
+	CreatedAt time.Time  `json:"createdAt"`        // CreatedAt records when the token was issued.
+	ExpiresAt time.Time  `json:"expiresAt"`        // ExpiresAt is when the token stops being valid.
+	UsedAt    *time.Time `json:"usedAt,omitempty"` // UsedAt is set once the token was consumed.
+}
+
+// TOTP holds a user's two-factor authentication state.
+type TOTP struct {
+	Secret    string     // Secret is the base32 shared secret; empty when not enrolled.
+	EnabledAt *time.Time // EnabledAt is set once enrollment was confirmed with a valid code.
+	LastStep  int64      // LastStep is the last accepted time step, used to reject replays.
+}
+
+// APIToken represents a personal access token used for scripting and integrations.
+type APIToken struct {
+	ID         string     `json:"id"`                   // ID is the unique token identifier.
+	UserID     string     `json:"userId"`               // UserID owns the token.
+	Name       string     `json:"name"`                 // Name is the user-provided label.
+	TokenHash  string     `json:"-"`                    // TokenHash is the SHA-256 hash of the secret.
+	Prefix     string     `json:"prefix"`               // Prefix is the leading part of the secret for identification.
+	Scopes     []string   `json:"scopes"`               // Scopes lists the granted permissions.
+	CreatedAt  time.Time  `json:"createdAt"`            // CreatedAt records when the token was issued.
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"` // LastUsedAt records the last authenticated request.
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`  // ExpiresAt is when the token stops being valid, if ever.
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`  // RevokedAt is set once the token was revoked.
+}
+
+// IsActive reports whether the token is neither revoked nor expired at now.
+func (t *APIToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
+
+// Invitation lets people register while open registration is disabled.
+// Created by is plain text so invitations outlive the admin who issued them.
+type Invitation struct {
+	ID        string     `json:"id"`                  // ID is the unique invitation identifier.
+	TokenHash string     `json:"-"`                   // TokenHash is the SHA-256 hash of the invite token.
+	CreatedBy string     `json:"createdBy"`           // CreatedBy is the admin who issued the invitation.
+	Role      string     `json:"role"`                // Role is granted to invited users.
+	MaxUses   int        `json:"maxUses"`             // MaxUses caps how many accounts the invitation creates.
+	Uses      int        `json:"uses"`                // Uses counts the accounts created so far.
+	CreatedAt time.Time  `json:"createdAt"`           // CreatedAt records when the invitation was issued.
+	ExpiresAt time.Time  `json:"expiresAt"`           // ExpiresAt is when the invitation stops being valid.
+	RevokedAt *time.Time `json:"revokedAt,omitempty"` // RevokedAt is set once the invitation was revoked.
+}
+
+// Coach link states.
+const (
+	CoachLinkPending = "pending"
+	CoachLinkActive  = "active"
+)
+
+// CoachLink connects a coach with an athlete once the athlete accepted the invitation.
+type CoachLink struct {
+	ID         string     `json:"id"`                   // ID is the unique link identifier.
+	CoachID    string     `json:"coachId"`              // CoachID is the coaching user.
+	AthleteID  string     `json:"athleteId"`            // AthleteID is the coached user.
+	Status     string     `json:"status"`               // Status is pending until the athlete accepts.
+	CreatedAt  time.Time  `json:"createdAt"`            // CreatedAt records when the coach sent the invitation.
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"` // AcceptedAt is set once the athlete accepted.
+}
+
+// Organization roles; owners manage members, editors publish shared templates and exercises.
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleEditor = "editor"
+	OrgRoleMember = "member"
+)
+
+// Organization groups users that share a library of templates and exercises.
+type Organization struct {
+	ID        string    `json:"id"`        // ID is the unique organization identifier.
+	Name      string    `json:"name"`      // Name is the display name.
+	CreatedBy string    `json:"createdBy"` // CreatedBy is the user who founded the organization.
+	CreatedAt time.Time `json:"createdAt"` // CreatedAt records when the organization was created.
+}
+
+// OrgMembership is an organization as seen by one of its members.
+type OrgMembership struct {
+	Organization
+	Role   string `json:"role"`   // Role is the member's organization role.
+	Active bool   `json:"active"` // Active marks the organization the member currently works in.
+}
+
+// OrgMember is a user's membership in an organization.
+type OrgMember struct {
+	OrgID    string    `json:"orgId"`    // OrgID is the organization.
+	UserID   string    `json:"userId"`   // UserID is the member.
+	Name     string    `json:"name"`     // Name is the member's display name.
+	Role     string    `json:"role"`     // Role is the member's organization role.
+	JoinedAt time.Time `json:"joinedAt"` // JoinedAt records when the user joined.
+}
+
+// AuditEvent records a security-relevant or data-changing action.
+// Actor and resource ids are plain text so entries outlive deleted users and records.
+type AuditEvent struct {
+	ID         int64          `json:"id"`                  // ID orders events and serves as pagination cursor.
+	CreatedAt  time.Time      `json:"createdAt"`           // CreatedAt records when the action happened.
+	ActorID    string         `json:"actorId"`             // ActorID is the user who performed the action, if known.
+	Action     string         `json:"action"`              // Action is the business event name, e.g. workout_deleted.
+	Resource   string         `json:"resource"`            // Resource is the affected resource type.
+	ResourceID string         `json:"resourceId"`          // ResourceID identifies the affected resource.
+	Before     map[string]any `json:"before,omitempty"`    // Before summarizes the state prior to the change.
+	After      map[string]any `json:"after,omitempty"`     // After summarizes the state after the change.
+	RequestID  string         `json:"requestId,omitempty"` // RequestID correlates the event with access logs.
+	IP         string         `json:"ip,omitempty"`        // IP is the client address of the request.
+}
+
+// AuditFilter narrows an audit log listing; zero fields match everything.
+type AuditFilter struct {
+	ActorID    string     // ActorID matches the acting user.
+	Action     string     // Action matches the event name.
+	Resource   string     // Resource matches the resource type.
+	ResourceID string     // ResourceID matches the resource id.
+	Since      *time.Time // Since includes events at or after this time.
+	Until      *time.Time // Until includes events before this time.
+	BeforeID   int64      // BeforeID includes events with a smaller id (the pagination cursor).
+	Limit      int        // Limit caps the number of returned events.
+}
+
+// TrainingHistoryFilter narrows a training history listing of one user; zero fields match everything.
+type TrainingHistoryFilter struct {
+	UserID          string     // UserID owns the trainings.
+	WorkoutID       string     // WorkoutID matches the workout the training was based on.
+	Search          string     // Search matches the workout name or notes, case-insensitively.
+	From            *time.Time // From includes trainings started at or after this time.
+	To              *time.Time // To includes trainings started before this time.
+	BeforeStartedAt *time.Time // BeforeStartedAt and BeforeID form the pagination cursor.
+	BeforeID        string     // BeforeID breaks ties between trainings started at the same time.
+	Limit           int        // Limit caps the number of returned trainings.
 }
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TrainingEventsChannel is the notification channel that carries the ids of changed trainings in progress.
+const TrainingEventsChannel = "motus_training_events"
+
+// ListenTrainingEvents passes the id of every changed training in progress to handle until ctx is done.
+// Notifications reach all replicas that share the database.
+func (s *Store) ListenTrainingEvents(ctx context.Context, handle func(trainingID string)) error {
+	conn, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{TrainingEventsChannel}.Sanitize()); err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), "UNLISTEN *") // nolint:errcheck
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		handle(notification.Payload)
+	}
+}
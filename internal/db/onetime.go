@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// One-time token purposes.
+const (
+	TokenPurposePasswordReset = "password_reset"
+	TokenPurposeVerifyEmail   = "verify_email"
+	TokenPurposeLoginTOTP     = "login_totp"
+)
+
+// CreateOneTimeToken stores a token and invalidates older unused tokens with the same purpose.
+func (s *Store) CreateOneTimeToken(ctx context.Context, token OneTimeToken) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	userID := strings.TrimSpace(token.UserID)
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM one_time_tokens
+		WHERE user_id=$1 AND purpose=$2 AND used_at IS NULL
+	`, userID, token.Purpose); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO one_time_tokens(id, user_id, purpose, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, token.ID, userID, token.Purpose, token.CreatedAt, token.ExpiresAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ResetPassword consumes a reset token, stores the new password hash and revokes all sessions.
+func (s *Store) ResetPassword(ctx context.Context, tokenHash, passwordHash string, at time.Time) (string, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	userID, err := consumeOneTimeToken(ctx, tx, tokenHash, TokenPurposePasswordReset, at)
+	if err != nil {
+		return "", err
+	}
+	// Receiving the reset mail proves ownership of the address as well.
+	if _, err := tx.Exec(ctx, `
+		UPDATE users
+		SET password_hash=$1,
+			email_verified_at=COALESCE(email_verified_at, $2)
+		WHERE id=$3
+	`, strings.TrimSpace(passwordHash), at, userID); err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE sessions
+		SET revoked_at=$1
+		WHERE user_id=$2 AND revoked_at IS NULL
+	`, at, userID); err != nil {
+		return "", err
+	}
+	return userID, tx.Commit(ctx)
+}
+
+// VerifyEmail consumes a verification token and marks the owner's email as verified.
+func (s *Store) VerifyEmail(ctx context.Context, tokenHash string, at time.Time) (string, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	userID, err := consumeOneTimeToken(ctx, tx, tokenHash, TokenPurposeVerifyEmail, at)
+	if err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE users
+		SET email_verified_at=COALESCE(email_verified_at, $1)
+		WHERE id=$2
+	`, at, userID); err != nil {
+		return "", err
+	}
+	return userID, tx.Commit(ctx)
+}
+
+// ConsumeOneTimeToken marks a valid token as used and returns its owner.
+func (s *Store) ConsumeOneTimeToken(ctx context.Context, tokenHash, purpose string, at time.Time) (string, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	userID, err := consumeOneTimeToken(ctx, tx, tokenHash, purpose, at)
+	if err != nil {
+		return "", err
+	}
+	return userID, tx.Commit(ctx)
+}
+
+// consumeOneTimeToken marks a valid token as used and returns its owner.
+func consumeOneTimeToken(ctx context.Context, tx pgx.Tx, tokenHash, purpose string, at time.Time) (string, error) {
+	var userID string
+	err := tx.QueryRow(ctx, `
+		UPDATE one_time_tokens
+		SET used_at=$1
+		WHERE id=$2 AND purpose=$3 AND used_at IS NULL AND expires_at > $1
+		RETURNING user_id
+	`, at, tokenHash, purpose).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrOneTimeTokenNotFound
+		}
+		return "", err
+	}
+	return userID, nil
+}
@@ -0,0 +1,246 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateOrganization stores an organization and makes its creator the first owner.
+func (s *Store) CreateOrganization(ctx context.Context, org Organization) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	creator := strings.TrimSpace(org.CreatedBy)
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO organizations(id, name, created_by, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, org.ID, strings.TrimSpace(org.Name), creator, org.CreatedAt); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO organization_members(org_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+	`, org.ID, creator, OrgRoleOwner, org.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListOrganizationsForUser returns the organizations a user belongs to, sorted by name.
+func (s *Store) ListOrganizationsForUser(ctx context.Context, userID string) ([]OrgMembership, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT o.id, o.name, o.created_by, o.created_at, m.role, u.active_org_id = o.id
+		FROM organization_members m
+		JOIN organizations o ON o.id = m.org_id
+		JOIN users u ON u.id = m.user_id
+		WHERE m.user_id=$1
+		ORDER BY LOWER(o.name) ASC
+	`, strings.TrimSpace(userID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var memberships []OrgMembership
+	for rows.Next() {
+		var m OrgMembership
+		if err := rows.Scan(&m.ID, &m.Name, &m.CreatedBy, &m.CreatedAt, &m.Role, &m.Active); err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, m)
+	}
+	return memberships, rows.Err()
+}
+
+// GetOrganization fetches a single organization by id.
+func (s *Store) GetOrganization(ctx context.Context, id string) (*Organization, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT id, name, created_by, created_at
+		FROM organizations
+		WHERE id=$1
+	`, strings.TrimSpace(id))
+	var org Organization
+	if err := row.Scan(&org.ID, &org.Name, &org.CreatedBy, &org.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &org, nil
+}
+
+// DeleteOrganization removes an organization with its members, templates and shared exercises.
+func (s *Store) DeleteOrganization(ctx context.Context, id string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	orgID := strings.TrimSpace(id)
+	if _, err := tx.Exec(ctx, `
+		UPDATE workout_subset_exercises
+		SET exercise_id=''
+		WHERE exercise_id IN (SELECT id FROM exercises WHERE org_id=$1)
+	`, orgID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM exercises WHERE org_id=$1`, orgID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM workouts WHERE org_id=$1 AND is_template=TRUE`, orgID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE users SET active_org_id='' WHERE active_org_id=$1`, orgID); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM organizations WHERE id=$1`, orgID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrOrganizationNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+// ListOrgMembers returns the members of an organization, owners first.
+func (s *Store) ListOrgMembers(ctx context.Context, orgID string) ([]OrgMember, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT m.org_id, m.user_id, u.name, m.role, m.joined_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id=$1
+		ORDER BY m.role = $2 DESC, m.user_id ASC
+	`, strings.TrimSpace(orgID), OrgRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []OrgMember
+	for rows.Next() {
+		var m OrgMember
+		if err := rows.Scan(&m.OrgID, &m.UserID, &m.Name, &m.Role, &m.JoinedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// OrgRole returns the user's role in an organization, or "" if the user is no member.
+func (s *Store) OrgRole(ctx context.Context, orgID, userID string) (string, error) {
+	var role string
+	err := s.pool.QueryRow(ctx, `
+		SELECT role
+		FROM organization_members
+		WHERE org_id=$1 AND user_id=$2
+	`, strings.TrimSpace(orgID), strings.TrimSpace(userID)).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return role, err
+}
+
+// CountOrgOwners returns how many owners an organization has.
+func (s *Store) CountOrgOwners(ctx context.Context, orgID string) (int, error) {
+	var count int
+	err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM organization_members
+		WHERE org_id=$1 AND role=$2
+	`, strings.TrimSpace(orgID), OrgRoleOwner).Scan(&count)
+	return count, err
+}
+
+// AddOrgMember adds a user to an organization.
+func (s *Store) AddOrgMember(ctx context.Context, member OrgMember) error {
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO organization_members(org_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (org_id, user_id) DO NOTHING
+	`, strings.TrimSpace(member.OrgID), strings.TrimSpace(member.UserID), member.Role, member.JoinedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrOrgMemberExists
+	}
+	return nil
+}
+
+// UpdateOrgMemberRole changes the organization role of a member.
+func (s *Store) UpdateOrgMemberRole(ctx context.Context, orgID, userID, role string) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE organization_members
+		SET role=$3
+		WHERE org_id=$1 AND user_id=$2
+	`, strings.TrimSpace(orgID), strings.TrimSpace(userID), role)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrOrgMemberNotFound
+	}
+	return nil
+}
+
+// RemoveOrgMember removes a user from an organization and leaves its context if it was active.
+func (s *Store) RemoveOrgMember(ctx context.Context, orgID, userID string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	oid, uid := strings.TrimSpace(orgID), strings.TrimSpace(userID)
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM organization_members
+		WHERE org_id=$1 AND user_id=$2
+	`, oid, uid)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrOrgMemberNotFound
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE users
+		SET active_org_id=''
+		WHERE id=$1 AND active_org_id=$2
+	`, uid, oid); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ActiveOrganization returns the organization a user currently works in, or "" for none.
+func (s *Store) ActiveOrganization(ctx context.Context, userID string) (string, error) {
+	var orgID string
+	err := s.pool.QueryRow(ctx, `
+		SELECT active_org_id
+		FROM users
+		WHERE id=$1
+	`, strings.TrimSpace(userID)).Scan(&orgID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return orgID, err
+}
+
+// SetActiveOrganization switches the organization context of a user; "" leaves all organizations.
+func (s *Store) SetActiveOrganization(ctx context.Context, userID, orgID string) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE users
+		SET active_org_id=$2
+		WHERE id=$1
+	`, strings.TrimSpace(userID), strings.TrimSpace(orgID))
+	return err
+}
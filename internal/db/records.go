@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PersonalRecords returns every record a user has set, oldest first.
+func (s *Store) PersonalRecords(ctx context.Context, userID string) ([]PersonalRecord, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, user_id, exercise_id, exercise_name, kind, value, reps, weight, weight_unit, duration_seconds,
+			training_id, achieved_at
+		FROM personal_records
+		WHERE user_id=$1
+		ORDER BY achieved_at ASC, id ASC
+	`, strings.TrimSpace(userID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var records []PersonalRecord
+	for rows.Next() {
+		var rec PersonalRecord
+		if err := rows.Scan(
+			&rec.ID, &rec.UserID, &rec.ExerciseID, &rec.ExerciseName, &rec.Kind, &rec.Value, &rec.Reps, &rec.Weight,
+			&rec.WeightUnit, &rec.DurationSeconds, &rec.TrainingID, &rec.AchievedAt,
+		); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// AddPersonalRecords stores newly set records. Duplicate IDs and records of exercises missing
+// from the catalog are ignored.
+func (s *Store) AddPersonalRecords(ctx context.Context, records []PersonalRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	batch := &pgx.Batch{}
+	for _, rec := range records {
+		batch.Queue(`
+			INSERT INTO personal_records(
+				id, user_id, exercise_id, exercise_name, kind, value, reps, weight, weight_unit, duration_seconds,
+				training_id, achieved_at
+			)
+			SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+			WHERE EXISTS (SELECT 1 FROM exercises WHERE id=$3)
+			ON CONFLICT (id) DO NOTHING
+		`,
+			rec.ID, rec.UserID, rec.ExerciseID, rec.ExerciseName, rec.Kind, rec.Value, rec.Reps, rec.Weight,
+			rec.WeightUnit, rec.DurationSeconds, rec.TrainingID, rec.AchievedAt,
+		)
+	}
+	return s.pool.SendBatch(ctx, batch).Close()
+}
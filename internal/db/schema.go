@@ -7,7 +7,7 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
-const schemaVersionLatest = 2
+const schemaVersionLatest = 19
 
 type schemaMigration struct {
 	version    int
@@ -110,6 +110,304 @@ var schemaMigrations = []schemaMigration{
 				ADD COLUMN IF NOT EXISTS repeat_rest_name TEXT NOT NULL DEFAULT ''`,
 		},
 	},
+	{
+		version: 3,
+		name:    "sessions",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS sessions (
+            id TEXT PRIMARY KEY,
+            user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            user_agent TEXT NOT NULL DEFAULT '',
+            ip TEXT NOT NULL DEFAULT '',
+            created_at TIMESTAMPTZ NOT NULL,
+            last_seen_at TIMESTAMPTZ NOT NULL,
+            expires_at TIMESTAMPTZ NOT NULL,
+            revoked_at TIMESTAMPTZ
+        )`,
+			`CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions(user_id)`,
+		},
+	},
+	{
+		version: 4,
+		name:    "api tokens",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS api_tokens (
+            id TEXT PRIMARY KEY,
+            user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            name TEXT NOT NULL,
+            token_hash TEXT NOT NULL UNIQUE,
+            prefix TEXT NOT NULL DEFAULT '',
+            scopes TEXT[] NOT NULL DEFAULT '{}',
+            created_at TIMESTAMPTZ NOT NULL,
+            last_used_at TIMESTAMPTZ,
+            expires_at TIMESTAMPTZ,
+            revoked_at TIMESTAMPTZ
+        )`,
+			`CREATE INDEX IF NOT EXISTS api_tokens_user_id_idx ON api_tokens(user_id)`,
+		},
+	},
+	{
+		version: 5,
+		name:    "account recovery",
+		statements: []string{
+			`ALTER TABLE users
+				ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ`,
+			`UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL`,
+			`CREATE TABLE IF NOT EXISTS one_time_tokens (
+            id TEXT PRIMARY KEY,
+            user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            purpose TEXT NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL,
+            expires_at TIMESTAMPTZ NOT NULL,
+            used_at TIMESTAMPTZ
+        )`,
+			`CREATE INDEX IF NOT EXISTS one_time_tokens_user_id_idx ON one_time_tokens(user_id)`,
+		},
+	},
+	{
+		version: 6,
+		name:    "two-factor authentication",
+		statements: []string{
+			`ALTER TABLE users
+				ADD COLUMN IF NOT EXISTS totp_secret TEXT NOT NULL DEFAULT '',
+				ADD COLUMN IF NOT EXISTS totp_enabled_at TIMESTAMPTZ,
+				ADD COLUMN IF NOT EXISTS totp_last_step BIGINT NOT NULL DEFAULT 0`,
+			`CREATE TABLE IF NOT EXISTS recovery_codes (
+            user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            code_hash TEXT NOT NULL,
+            used_at TIMESTAMPTZ,
+            PRIMARY KEY (user_id, code_hash)
+        )`,
+		},
+	},
+	{
+		version: 7,
+		name:    "audit log",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS audit_events (
+            id BIGSERIAL PRIMARY KEY,
+            created_at TIMESTAMPTZ NOT NULL,
+            actor_id TEXT NOT NULL DEFAULT '',
+            action TEXT NOT NULL,
+            resource TEXT NOT NULL DEFAULT '',
+            resource_id TEXT NOT NULL DEFAULT '',
+            before JSONB,
+            after JSONB,
+            request_id TEXT NOT NULL DEFAULT '',
+            ip TEXT NOT NULL DEFAULT ''
+        )`,
+			`CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events(created_at)`,
+			`CREATE INDEX IF NOT EXISTS audit_events_actor_id_idx ON audit_events(actor_id, id)`,
+			`CREATE INDEX IF NOT EXISTS audit_events_resource_idx ON audit_events(resource, resource_id, id)`,
+		},
+	},
+	{
+		version: 8,
+		name:    "invitations",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS invitations (
+            id TEXT PRIMARY KEY,
+            token_hash TEXT NOT NULL UNIQUE,
+            created_by TEXT NOT NULL DEFAULT '',
+            is_admin BOOLEAN NOT NULL DEFAULT FALSE,
+            max_uses INT NOT NULL,
+            uses INT NOT NULL DEFAULT 0,
+            created_at TIMESTAMPTZ NOT NULL,
+            expires_at TIMESTAMPTZ NOT NULL,
+            revoked_at TIMESTAMPTZ
+        )`,
+		},
+	},
+	{
+		version: 9,
+		name:    "roles",
+		statements: []string{
+			`ALTER TABLE users
+				ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'member'`,
+			`UPDATE users SET role = 'admin' WHERE is_admin`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS is_admin`,
+			`ALTER TABLE invitations
+				ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'member'`,
+			`UPDATE invitations SET role = 'admin' WHERE is_admin`,
+			`ALTER TABLE invitations DROP COLUMN IF EXISTS is_admin`,
+		},
+	},
+	{
+		version: 10,
+		name:    "coaching",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS coach_links (
+            id TEXT PRIMARY KEY,
+            coach_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            athlete_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            status TEXT NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL,
+            accepted_at TIMESTAMPTZ,
+            UNIQUE (coach_id, athlete_id)
+        )`,
+			`CREATE INDEX IF NOT EXISTS coach_links_athlete_id_idx ON coach_links(athlete_id)`,
+			`ALTER TABLE workouts
+				ADD COLUMN IF NOT EXISTS assigned_by TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version: 11,
+		name:    "organizations",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS organizations (
+            id TEXT PRIMARY KEY,
+            name TEXT NOT NULL,
+            created_by TEXT NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL
+        )`,
+			`CREATE TABLE IF NOT EXISTS organization_members (
+            org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+            user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            role TEXT NOT NULL,
+            joined_at TIMESTAMPTZ NOT NULL,
+            PRIMARY KEY (org_id, user_id)
+        )`,
+			`CREATE INDEX IF NOT EXISTS organization_members_user_id_idx ON organization_members(user_id)`,
+			`ALTER TABLE users
+				ADD COLUMN IF NOT EXISTS active_org_id TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE workouts
+				ADD COLUMN IF NOT EXISTS org_id TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE exercises
+				ADD COLUMN IF NOT EXISTS org_id TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version: 12,
+		name:    "active_trainings",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS active_trainings (
+            id TEXT PRIMARY KEY,
+            user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            workout_id TEXT NOT NULL,
+            state JSONB NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL,
+            updated_at TIMESTAMPTZ NOT NULL
+        )`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS active_trainings_user_id_idx ON active_trainings(user_id)`,
+		},
+	},
+	{
+		version: 13,
+		name:    "classes",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS classes (
+            id TEXT PRIMARY KEY,
+            coach_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            workout_id TEXT NOT NULL,
+            workout_name TEXT NOT NULL,
+            training_id TEXT NOT NULL,
+            join_code TEXT NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL,
+            ended_at TIMESTAMPTZ
+        )`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS classes_open_join_code_idx ON classes(join_code) WHERE ended_at IS NULL`,
+			`CREATE TABLE IF NOT EXISTS class_participants (
+            class_id TEXT NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+            user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            joined_at TIMESTAMPTZ NOT NULL,
+            left_at TIMESTAMPTZ,
+            training_id TEXT NOT NULL DEFAULT '',
+            PRIMARY KEY (class_id, user_id)
+        )`,
+		},
+	},
+	{
+		version: 14,
+		name:    "training step exercises",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS training_step_exercises (
+            id TEXT PRIMARY KEY,
+            step_id TEXT NOT NULL REFERENCES training_steps(id) ON DELETE CASCADE,
+            exercise_order INT NOT NULL,
+            name TEXT NOT NULL,
+            reps INT NOT NULL DEFAULT 0,
+            weight DOUBLE PRECISION NOT NULL DEFAULT 0,
+            weight_unit TEXT NOT NULL DEFAULT '',
+            duration_seconds INT NOT NULL DEFAULT 0,
+            status TEXT NOT NULL DEFAULT 'completed'
+        )`,
+			`CREATE INDEX IF NOT EXISTS training_step_exercises_step_id_idx ON training_step_exercises(step_id)`,
+		},
+	},
+	{
+		version: 15,
+		name:    "training feedback",
+		statements: []string{
+			`ALTER TABLE workout_trainings
+				ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '',
+				ADD COLUMN IF NOT EXISTS rpe INT NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS mood INT NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS energy INT NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS bodyweight DOUBLE PRECISION NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS bodyweight_unit TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE training_steps
+				ADD COLUMN IF NOT EXISTS rpe INT NOT NULL DEFAULT 0`,
+		},
+	},
+	{
+		version: 16,
+		name:    "training history indexes",
+		statements: []string{
+			`CREATE INDEX IF NOT EXISTS workout_trainings_user_started_idx ON workout_trainings(user_id, started_at DESC, id DESC)`,
+			`CREATE INDEX IF NOT EXISTS training_steps_training_id_idx ON training_steps(training_id, step_order)`,
+		},
+	},
+	{
+		version: 17,
+		name:    "personal records",
+		statements: []string{
+			`ALTER TABLE training_step_exercises
+				ADD COLUMN IF NOT EXISTS exercise_id TEXT NOT NULL DEFAULT '',
+				ADD COLUMN IF NOT EXISTS exercise_type TEXT NOT NULL DEFAULT ''`,
+			`CREATE TABLE IF NOT EXISTS personal_records (
+            id TEXT PRIMARY KEY,
+            user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            exercise_id TEXT NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+            exercise_name TEXT NOT NULL,
+            kind TEXT NOT NULL,
+            value DOUBLE PRECISION NOT NULL,
+            reps INT NOT NULL DEFAULT 0,
+            weight DOUBLE PRECISION NOT NULL DEFAULT 0,
+            weight_unit TEXT NOT NULL DEFAULT '',
+            duration_seconds INT NOT NULL DEFAULT 0,
+            training_id TEXT NOT NULL REFERENCES workout_trainings(id) ON DELETE CASCADE,
+            achieved_at TIMESTAMPTZ NOT NULL
+        )`,
+			`CREATE INDEX IF NOT EXISTS personal_records_user_id_idx ON personal_records(user_id, exercise_id, achieved_at)`,
+		},
+	},
+	{
+		version: 18,
+		name:    "summary templates",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS summary_templates (
+            id TEXT PRIMARY KEY,
+            user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            name TEXT NOT NULL,
+            body TEXT NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL,
+            updated_at TIMESTAMPTZ NOT NULL
+        )`,
+			`CREATE INDEX IF NOT EXISTS summary_templates_user_id_idx ON summary_templates(user_id)`,
+		},
+	},
+	{
+		version: 19,
+		name:    "class participant results",
+		statements: []string{
+			`ALTER TABLE class_participants
+				ADD COLUMN IF NOT EXISTS results JSONB,
+				ADD COLUMN IF NOT EXISTS seen_at TIMESTAMPTZ,
+				ADD COLUMN IF NOT EXISTS seen_index INT NOT NULL DEFAULT 0`,
+			`UPDATE class_participants SET seen_at=joined_at WHERE seen_at IS NULL`,
+			`ALTER TABLE class_participants ALTER COLUMN seen_at SET NOT NULL`,
+		},
+	},
 }
 
 // EnsureSchema applies the baseline schema and any pending migrations.
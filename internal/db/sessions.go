@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateSession stores a new session and prunes expired sessions for the same user.
+func (s *Store) CreateSession(ctx context.Context, session Session) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM sessions
+		WHERE user_id=$1 AND (expires_at < NOW() OR revoked_at IS NOT NULL)
+	`, strings.TrimSpace(session.UserID)); err != nil {
+		return err
+	}
+	if err := insertSession(ctx, tx, session); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetSession fetches a session by its hashed token.
+func (s *Store) GetSession(ctx context.Context, id string) (*Session, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE id=$1
+	`, id)
+	var session Session
+	if err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IP,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RotateSession revokes an existing session and stores its replacement atomically.
+func (s *Store) RotateSession(ctx context.Context, oldID string, next Session) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE sessions
+		SET revoked_at=$1
+		WHERE id=$2 AND revoked_at IS NULL
+	`, next.CreatedAt, oldID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSessionNotFound
+	}
+	if err := insertSession(ctx, tx, next); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RevokeSession marks a single session as revoked.
+func (s *Store) RevokeSession(ctx context.Context, id string) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE sessions
+		SET revoked_at=NOW()
+		WHERE id=$1 AND revoked_at IS NULL
+	`, id)
+	return err
+}
+
+// RevokeUserSessions marks every active session of a user as revoked.
+func (s *Store) RevokeUserSessions(ctx context.Context, userID string) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE sessions
+		SET revoked_at=NOW()
+		WHERE user_id=$1 AND revoked_at IS NULL
+	`, strings.TrimSpace(userID))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// insertSession writes a session row inside an open transaction.
+func insertSession(ctx context.Context, tx pgx.Tx, session Session) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO sessions(
+			id,
+			user_id,
+			user_agent,
+			ip,
+			created_at,
+			last_seen_at,
+			expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		session.ID,
+		strings.TrimSpace(session.UserID),
+		session.UserAgent,
+		session.IP,
+		session.CreatedAt,
+		session.LastSeenAt,
+		session.ExpiresAt,
+	)
+	return err
+}
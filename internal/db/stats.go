@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// statsVolume sums reps times weight of completed exercise results in kilograms.
+const statsVolume = `SUM(e.reps * CASE WHEN e.weight_unit = 'lb' THEN e.weight * 0.45359237 ELSE e.weight END)`
+
+// StatsBuckets aggregates the trainings matching filter per period; periods without trainings are omitted.
+func (s *Store) StatsBuckets(ctx context.Context, filter StatsFilter) ([]StatsBucket, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH trainings AS (
+			SELECT id, rpe,
+				date_trunc($2, started_at AT TIME ZONE $3) AS bucket,
+				EXTRACT(EPOCH FROM completed_at - started_at) AS seconds
+			FROM workout_trainings
+			WHERE user_id=$1 AND started_at >= $4 AND started_at < $5
+		), steps AS (
+			SELECT training_id,
+				SUM(elapsed_millis) FILTER (WHERE step_type <> 'pause') / 1000 AS work,
+				SUM(elapsed_millis) FILTER (WHERE step_type = 'pause') / 1000 AS pause
+			FROM training_steps
+			WHERE training_id IN (SELECT id FROM trainings)
+			GROUP BY training_id
+		), volume AS (
+			SELECT st.training_id, `+statsVolume+` AS volume
+			FROM training_step_exercises e
+			JOIN training_steps st ON st.id = e.step_id
+			WHERE st.training_id IN (SELECT id FROM trainings) AND e.status = 'completed'
+			GROUP BY st.training_id
+		)
+		SELECT t.bucket, COUNT(*),
+			COALESCE(SUM(t.seconds), 0)::BIGINT,
+			COALESCE(SUM(s.work), 0)::BIGINT,
+			COALESCE(SUM(s.pause), 0)::BIGINT,
+			COALESCE(SUM(v.volume), 0)::DOUBLE PRECISION,
+			COALESCE(ROUND(SUM(t.rpe * t.seconds / 60)), 0)::INT
+		FROM trainings t
+		LEFT JOIN steps s ON s.training_id = t.id
+		LEFT JOIN volume v ON v.training_id = t.id
+		GROUP BY t.bucket
+		ORDER BY t.bucket ASC
+	`, strings.TrimSpace(filter.UserID), filter.Period, filter.TimeZone, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var buckets []StatsBucket
+	for rows.Next() {
+		var b StatsBucket
+		if err := rows.Scan(&b.Start, &b.Sessions, &b.TotalSeconds, &b.WorkSeconds, &b.PauseSeconds, &b.Volume, &b.Load); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
+
+// TrainingDays returns the distinct days in timeZone on which a user started a training, newest first.
+// The days are returned as midnight UTC.
+func (s *Store) TrainingDays(ctx context.Context, userID, timeZone string) ([]time.Time, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT (started_at AT TIME ZONE $2)::DATE AS day
+		FROM workout_trainings
+		WHERE user_id=$1
+		ORDER BY day DESC
+	`, strings.TrimSpace(userID), timeZone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var days []time.Time
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// TopExercises returns the exercises logged in most trainings matching filter.
+// Results without a catalog entry are grouped by name.
+func (s *Store) TopExercises(ctx context.Context, filter StatsFilter, limit int) ([]ExerciseStats, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT MAX(e.exercise_id), MAX(e.name),
+			COUNT(DISTINCT t.id), COUNT(*),
+			COALESCE(SUM(e.reps), 0)::INT,
+			COALESCE(`+statsVolume+`, 0)::DOUBLE PRECISION
+		FROM training_step_exercises e
+		JOIN training_steps st ON st.id = e.step_id
+		JOIN workout_trainings t ON t.id = st.training_id
+		WHERE t.user_id=$1 AND t.started_at >= $2 AND t.started_at < $3 AND e.status = 'completed'
+		GROUP BY COALESCE(NULLIF(e.exercise_id, ''), LOWER(e.name))
+		ORDER BY COUNT(DISTINCT t.id) DESC, COUNT(*) DESC, MAX(e.name) ASC
+		LIMIT $4
+	`, strings.TrimSpace(filter.UserID), filter.From, filter.To, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var stats []ExerciseStats
+	for rows.Next() {
+		var st ExerciseStats
+		if err := rows.Scan(&st.ExerciseID, &st.Name, &st.Sessions, &st.Sets, &st.Reps, &st.Volume); err != nil {
+			return nil, err
+		}
+		stats = append(stats, st)
+	}
+	return stats, rows.Err()
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateSummaryTemplate stores a new summary template.
+func (s *Store) CreateSummaryTemplate(ctx context.Context, tpl SummaryTemplate) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO summary_templates(id, user_id, name, body, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, tpl.ID, strings.TrimSpace(tpl.UserID), tpl.Name, tpl.Body, tpl.CreatedAt, tpl.UpdatedAt)
+	return err
+}
+
+// ListSummaryTemplates returns the summary templates of a user ordered by name.
+func (s *Store) ListSummaryTemplates(ctx context.Context, userID string) ([]SummaryTemplate, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, user_id, name, body, created_at, updated_at
+		FROM summary_templates
+		WHERE user_id=$1
+		ORDER BY LOWER(name) ASC, id ASC
+	`, strings.TrimSpace(userID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []SummaryTemplate
+	for rows.Next() {
+		var tpl SummaryTemplate
+		if err := rows.Scan(&tpl.ID, &tpl.UserID, &tpl.Name, &tpl.Body, &tpl.CreatedAt, &tpl.UpdatedAt); err != nil {
+			return nil, err
+		}
+		templates = append(templates, tpl)
+	}
+	return templates, rows.Err()
+}
+
+// GetSummaryTemplate fetches a summary template by id.
+func (s *Store) GetSummaryTemplate(ctx context.Context, id string) (*SummaryTemplate, error) {
+	var tpl SummaryTemplate
+	err := s.pool.QueryRow(ctx, `
+		SELECT id, user_id, name, body, created_at, updated_at
+		FROM summary_templates
+		WHERE id=$1
+	`, strings.TrimSpace(id)).Scan(&tpl.ID, &tpl.UserID, &tpl.Name, &tpl.Body, &tpl.CreatedAt, &tpl.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSummaryTemplateNotFound
+		}
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+// UpdateSummaryTemplate replaces the name and body of a summary template.
+func (s *Store) UpdateSummaryTemplate(ctx context.Context, tpl SummaryTemplate) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE summary_templates
+		SET name=$1, body=$2, updated_at=$3
+		WHERE id=$4
+	`, tpl.Name, tpl.Body, tpl.UpdatedAt, tpl.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSummaryTemplateNotFound
+	}
+	return nil
+}
+
+// DeleteSummaryTemplate removes a summary template.
+func (s *Store) DeleteSummaryTemplate(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM summary_templates
+		WHERE id=$1
+	`, strings.TrimSpace(id))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSummaryTemplateNotFound
+	}
+	return nil
+}
@@ -8,15 +8,30 @@ import (
 	"github.com/gi8lino/motus/internal/utils"
 )
 
-// ListTemplates returns all workout templates.
-func (s *Store) ListTemplates(ctx context.Context) ([]Workout, error) {
-	// Load all workouts flagged as templates.
-	rows, err := s.pool.Query(ctx, `
-		SELECT id, user_id, name, is_template, created_at
+// ListTemplates returns the global workout templates plus those of orgID, if set.
+func (s *Store) ListTemplates(ctx context.Context, orgID string) ([]Workout, error) {
+	// Load global templates and the ones shared within the organization.
+	return s.queryTemplates(ctx, `
+		SELECT id, user_id, name, is_template, org_id, created_at
 		FROM workouts
-		WHERE is_template=TRUE
+		WHERE is_template=TRUE AND (org_id='' OR org_id=$1)
 		ORDER BY created_at DESC
-	`)
+	`, strings.TrimSpace(orgID))
+}
+
+// TemplatesByUser returns all templates a user created, in any organization.
+func (s *Store) TemplatesByUser(ctx context.Context, userID string) ([]Workout, error) {
+	return s.queryTemplates(ctx, `
+		SELECT id, user_id, name, is_template, org_id, created_at
+		FROM workouts
+		WHERE is_template=TRUE AND user_id=$1
+		ORDER BY created_at DESC
+	`, strings.TrimSpace(userID))
+}
+
+// queryTemplates runs a template query and hydrates the steps of each row.
+func (s *Store) queryTemplates(ctx context.Context, query string, args ...any) ([]Workout, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +41,7 @@ func (s *Store) ListTemplates(ctx context.Context) ([]Workout, error) {
 	// Collect template rows and hydrate their steps.
 	for rows.Next() {
 		var w Workout
-		if err := rows.Scan(&w.ID, &w.UserID, &w.Name, &w.IsTemplate, &w.CreatedAt); err != nil {
+		if err := rows.Scan(&w.ID, &w.UserID, &w.Name, &w.IsTemplate, &w.OrgID, &w.CreatedAt); err != nil {
 			return nil, err
 		}
 		steps, err := s.WorkoutSteps(ctx, w.ID)
@@ -39,8 +54,8 @@ func (s *Store) ListTemplates(ctx context.Context) ([]Workout, error) {
 	return templates, rows.Err()
 }
 
-// CreateTemplateFromWorkout clones an existing workout as a template.
-func (s *Store) CreateTemplateFromWorkout(ctx context.Context, workoutID string, nameOverride string) (*Workout, error) {
+// CreateTemplateFromWorkout clones an existing workout as a template, shared within orgID if set.
+func (s *Store) CreateTemplateFromWorkout(ctx context.Context, workoutID, nameOverride, orgID string) (*Workout, error) {
 	// Clone a workout and persist it as a template.
 	src, err := s.WorkoutWithSteps(ctx, workoutID)
 	if err != nil {
@@ -52,6 +67,7 @@ func (s *Store) CreateTemplateFromWorkout(ctx context.Context, workoutID string,
 	template := &Workout{
 		UserID: src.UserID,
 		Name:   src.Name,
+		OrgID:  strings.TrimSpace(orgID),
 		Steps:  cloneSteps(src.Steps),
 	}
 	if trimmed := strings.TrimSpace(nameOverride); trimmed != "" {
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateAPIToken stores a new personal access token.
+func (s *Store) CreateAPIToken(ctx context.Context, token APIToken) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO api_tokens(
+			id,
+			user_id,
+			name,
+			token_hash,
+			prefix,
+			scopes,
+			created_at,
+			expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		token.ID,
+		strings.TrimSpace(token.UserID),
+		token.Name,
+		token.TokenHash,
+		token.Prefix,
+		token.Scopes,
+		token.CreatedAt,
+		token.ExpiresAt,
+	)
+	return err
+}
+
+// ListAPITokens returns the non-revoked tokens of a user, newest first.
+func (s *Store) ListAPITokens(ctx context.Context, userID string) ([]APIToken, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, user_id, name, token_hash, prefix, scopes, created_at, last_used_at, expires_at, revoked_at
+		FROM api_tokens
+		WHERE user_id=$1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`, strings.TrimSpace(userID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+	return tokens, rows.Err()
+}
+
+// GetAPITokenByHash fetches a token by the hash of its secret.
+func (s *Store) GetAPITokenByHash(ctx context.Context, hash string) (*APIToken, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT id, user_id, name, token_hash, prefix, scopes, created_at, last_used_at, expires_at, revoked_at
+		FROM api_tokens
+		WHERE token_hash=$1
+	`, hash)
+	token, err := scanAPIToken(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAPITokenNotFound
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+// TouchAPIToken records the last time a token authenticated a request.
+func (s *Store) TouchAPIToken(ctx context.Context, id string, at time.Time) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE api_tokens
+		SET last_used_at=$1
+		WHERE id=$2
+	`, at, id)
+	return err
+}
+
+// RevokeAPIToken marks a token of the given user as revoked.
+func (s *Store) RevokeAPIToken(ctx context.Context, userID, id string) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE api_tokens
+		SET revoked_at=NOW()
+		WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL
+	`, strings.TrimSpace(id), strings.TrimSpace(userID))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+// scanAPIToken maps a token row into an APIToken.
+func scanAPIToken(row pgx.Row) (*APIToken, error) {
+	var token APIToken
+	if err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.TokenHash,
+		&token.Prefix,
+		&token.Scopes,
+		&token.CreatedAt,
+		&token.LastUsedAt,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetTOTP returns the two-factor state of a user; unknown ids return ErrUserNotFound.
+func (s *Store) GetTOTP(ctx context.Context, userID string) (*TOTP, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT totp_secret, totp_enabled_at, totp_last_step
+		FROM users
+		WHERE id=$1
+	`, strings.TrimSpace(userID))
+	var t TOTP
+	if err := row.Scan(&t.Secret, &t.EnabledAt, &t.LastStep); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// SetPendingTOTP stores a secret that still needs to be confirmed with a code.
+func (s *Store) SetPendingTOTP(ctx context.Context, userID, secret string) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE users
+		SET totp_secret=$1, totp_last_step=0
+		WHERE id=$2 AND totp_enabled_at IS NULL
+	`, secret, strings.TrimSpace(userID))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("user not found or two-factor authentication already enabled")
+	}
+	return nil
+}
+
+// EnableTOTP activates the pending secret and replaces the recovery codes.
+func (s *Store) EnableTOTP(ctx context.Context, userID string, step int64, codeHashes []string, at time.Time) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	userID = strings.TrimSpace(userID)
+	tag, err := tx.Exec(ctx, `
+		UPDATE users
+		SET totp_enabled_at=$1, totp_last_step=$2
+		WHERE id=$3 AND totp_secret <> ''
+	`, at, step, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTOTPNotEnrolled
+	}
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// DisableTOTP removes the secret and all recovery codes of a user.
+func (s *Store) DisableTOTP(ctx context.Context, userID string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	userID = strings.TrimSpace(userID)
+	if _, err := tx.Exec(ctx, `
+		UPDATE users
+		SET totp_secret='', totp_enabled_at=NULL, totp_last_step=0
+		WHERE id=$1
+	`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ClaimTOTPStep records an accepted time step and reports false if it was already used.
+func (s *Store) ClaimTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE users
+		SET totp_last_step=$1
+		WHERE id=$2 AND totp_last_step < $1
+	`, step, strings.TrimSpace(userID))
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReplaceRecoveryCodes swaps all recovery codes of a user for new ones.
+func (s *Store) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	if err := replaceRecoveryCodes(ctx, tx, strings.TrimSpace(userID), codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UseRecoveryCode marks an unused recovery code as used and reports whether it was valid.
+func (s *Store) UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE recovery_codes
+		SET used_at=$1
+		WHERE user_id=$2 AND code_hash=$3 AND used_at IS NULL
+	`, at, strings.TrimSpace(userID), codeHash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// replaceRecoveryCodes deletes existing recovery codes and inserts new ones inside a transaction.
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string, codeHashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO recovery_codes(user_id, code_hash)
+			VALUES ($1, $2)
+		`, userID, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,78 +3,189 @@ package db
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 )
 
-// RecordTraining stores a completed workout training and optional step timings. Duplicate IDs are ignored.
-func (s *Store) RecordTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) error {
+// RecordTraining stores a completed workout training and optional step timings and reports whether it was written.
+// A training whose id is already logged is left untouched.
+func (s *Store) RecordTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) (bool, error) {
 	// Persist the training log and optional step timings in one transaction.
 	if log.ID == "" {
-		return errors.New("training id required")
+		return false, errors.New("training id required")
 	}
 	if log.StartedAt.IsZero() || log.CompletedAt.IsZero() {
-		return errors.New("training timestamps required")
+		return false, errors.New("training timestamps required")
 	}
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer tx.Rollback(ctx) // nolint:errcheck
-	if _, err := tx.Exec(ctx, `
+	tag, err := tx.Exec(ctx, `
 		INSERT INTO workout_trainings(
 			id,
 			workout_id,
 			workout_name,
 			user_id,
 			started_at,
-			completed_at
+			completed_at,
+			notes,
+			rpe,
+			mood,
+			energy,
+			bodyweight,
+			bodyweight_unit
 		)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		ON CONFLICT (id) DO NOTHING
 	`,
-		log.ID, log.WorkoutID, log.WorkoutName, log.UserID, log.StartedAt, log.CompletedAt); err != nil {
+		log.ID, log.WorkoutID, log.WorkoutName, log.UserID, log.StartedAt, log.CompletedAt,
+		log.Notes, log.RPE, log.Mood, log.Energy, log.Bodyweight, log.BodyweightUnit)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	if err := insertTrainingSteps(ctx, tx, log.ID, steps); err != nil {
+		return false, err
+	}
+	// A logged training is no longer in progress.
+	if _, err := tx.Exec(ctx, `
+		WITH deleted AS (
+			DELETE FROM active_trainings WHERE id=$1 RETURNING id
+		)
+		SELECT pg_notify($2, id) FROM deleted
+	`, log.ID, TrainingEventsChannel); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ReplaceTraining overwrites a logged training with new times, feedback and step timings.
+// Personal records set by the training are dropped so they can be detected again.
+func (s *Store) ReplaceTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) error {
+	if log.StartedAt.IsZero() || log.CompletedAt.IsZero() {
+		return errors.New("training timestamps required")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
 		return err
 	}
-	if len(steps) > 0 {
-		batch := &pgx.Batch{}
-		// Queue each step timing insert in the batch.
-		for _, st := range steps {
-			batch.Queue(
-				`
-					INSERT INTO training_steps(
-						id,
-						training_id,
-						step_order,
-						step_type,
-						name,
-						estimated_seconds,
-						elapsed_millis
-					)
-					VALUES ($1, $2, $3, $4, $5, $6, $7)
-					ON CONFLICT (id) DO NOTHING
-				`,
-				st.ID, log.ID, st.StepOrder, st.Type, st.Name, st.EstimatedSeconds, st.ElapsedMillis,
-			)
-		}
-		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE workout_trainings
+		SET started_at=$2, completed_at=$3, notes=$4, rpe=$5, mood=$6, energy=$7, bodyweight=$8, bodyweight_unit=$9
+		WHERE id=$1
+	`, strings.TrimSpace(log.ID), log.StartedAt, log.CompletedAt,
+		log.Notes, log.RPE, log.Mood, log.Energy, log.Bodyweight, log.BodyweightUnit)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTrainingNotFound
+	}
+	// Step exercise results are removed with their steps.
+	if _, err := tx.Exec(ctx, `DELETE FROM training_steps WHERE training_id=$1`, log.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM personal_records WHERE training_id=$1`, log.ID); err != nil {
+		return err
+	}
+	if err := insertTrainingSteps(ctx, tx, log.ID, steps); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// DeleteTraining removes a logged training with its step timings and personal records.
+func (s *Store) DeleteTraining(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM workout_trainings WHERE id=$1`, strings.TrimSpace(id))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTrainingNotFound
+	}
+	return nil
+}
+
+// UpdateTraining stores the notes and ratings of a logged training and the RPE of the given steps.
+func (s *Store) UpdateTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE workout_trainings
+		SET notes=$2, rpe=$3, mood=$4, energy=$5, bodyweight=$6, bodyweight_unit=$7
+		WHERE id=$1
+	`, strings.TrimSpace(log.ID), log.Notes, log.RPE, log.Mood, log.Energy, log.Bodyweight, log.BodyweightUnit)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTrainingNotFound
+	}
+	for _, st := range steps {
+		tag, err := tx.Exec(ctx, `
+			UPDATE training_steps
+			SET rpe=$3
+			WHERE id=$1 AND training_id=$2
+		`, strings.TrimSpace(st.ID), strings.TrimSpace(log.ID), st.RPE)
+		if err != nil {
 			return err
 		}
+		if tag.RowsAffected() == 0 {
+			return ErrTrainingStepNotFound
+		}
 	}
 	return tx.Commit(ctx)
 }
 
-// TrainingHistory returns recent trainings for a user.
-func (s *Store) TrainingHistory(ctx context.Context, userID string, limit int) ([]TrainingLog, error) {
-	// Load recent training logs for a user.
-	limit = max(limit, 25)
+// TrainingHistory returns trainings matching filter, newest first.
+func (s *Store) TrainingHistory(ctx context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error) {
+	args := []any{filter.UserID}
+	where := []string{"ws.user_id = $1"}
+	add := func(clause string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+	if filter.WorkoutID != "" {
+		add("ws.workout_id = $%d", filter.WorkoutID)
+	}
+	if filter.Search != "" {
+		add("(ws.workout_name ILIKE $%[1]d OR ws.notes ILIKE $%[1]d)", "%"+likeEscaper.Replace(filter.Search)+"%")
+	}
+	if filter.From != nil {
+		add("ws.started_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("ws.started_at < $%d", *filter.To)
+	}
+	if filter.BeforeStartedAt != nil {
+		args = append(args, *filter.BeforeStartedAt, filter.BeforeID)
+		where = append(where, fmt.Sprintf("(ws.started_at, ws.id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, filter.Limit)
+
 	rows, err := s.pool.Query(ctx, `
-		SELECT ws.id, ws.workout_id, COALESCE(w.name, ''), ws.user_id, ws.started_at, ws.completed_at
+		SELECT ws.id, ws.workout_id, COALESCE(w.name, ws.workout_name), ws.user_id, ws.started_at, ws.completed_at,
+			ws.notes, ws.rpe, ws.mood, ws.energy, ws.bodyweight, ws.bodyweight_unit
 		FROM workout_trainings ws
 		LEFT JOIN workouts w ON ws.workout_id = w.id
-		WHERE ws.user_id=$1
-		ORDER BY ws.started_at DESC
-		LIMIT $2`, userID, limit)
+		WHERE `+strings.Join(where, " AND ")+fmt.Sprintf(`
+		ORDER BY ws.started_at DESC, ws.id DESC
+		LIMIT $%d`, len(args)), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -82,8 +193,8 @@ func (s *Store) TrainingHistory(ctx context.Context, userID string, limit int) (
 	var history []TrainingLog
 	// Collect each training log row.
 	for rows.Next() {
-		var entry TrainingLog
-		if err := rows.Scan(&entry.ID, &entry.WorkoutID, &entry.WorkoutName, &entry.UserID, &entry.StartedAt, &entry.CompletedAt); err != nil {
+		entry, err := scanTraining(rows)
+		if err != nil {
 			return nil, err
 		}
 		history = append(history, entry)
@@ -91,26 +202,183 @@ func (s *Store) TrainingHistory(ctx context.Context, userID string, limit int) (
 	return history, rows.Err()
 }
 
+// likeEscaper escapes the wildcards of a LIKE pattern so user input matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// TrainingsByUser returns every training of a user, newest first.
+func (s *Store) TrainingsByUser(ctx context.Context, userID string) ([]TrainingLog, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, workout_id, workout_name, user_id, started_at, completed_at,
+			notes, rpe, mood, energy, bodyweight, bodyweight_unit
+		FROM workout_trainings
+		WHERE user_id=$1
+		ORDER BY started_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var trainings []TrainingLog
+	for rows.Next() {
+		entry, err := scanTraining(rows)
+		if err != nil {
+			return nil, err
+		}
+		trainings = append(trainings, entry)
+	}
+	return trainings, rows.Err()
+}
+
+// GetTraining fetches a single training log by id.
+func (s *Store) GetTraining(ctx context.Context, id string) (*TrainingLog, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT id, workout_id, workout_name, user_id, started_at, completed_at,
+			notes, rpe, mood, energy, bodyweight, bodyweight_unit
+		FROM workout_trainings
+		WHERE id=$1`, strings.TrimSpace(id))
+	entry, err := scanTraining(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTrainingNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
 // TrainingStepTimings returns stored step durations for a training.
 func (s *Store) TrainingStepTimings(ctx context.Context, trainingID string) ([]TrainingStepLog, error) {
-	// Load stored step durations for a training.
+	timings, err := s.StepTimingsForTrainings(ctx, []string{trainingID})
+	if err != nil {
+		return nil, err
+	}
+	return timings[trainingID], nil
+}
+
+// StepTimingsForTrainings returns stored step durations of several trainings grouped by training id.
+func (s *Store) StepTimingsForTrainings(ctx context.Context, trainingIDs []string) (map[string][]TrainingStepLog, error) {
+	timings := make(map[string][]TrainingStepLog, len(trainingIDs))
+	if len(trainingIDs) == 0 {
+		return timings, nil
+	}
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, training_id, step_order, step_type, name, estimated_seconds, elapsed_millis
+		SELECT id, training_id, step_order, step_type, name, estimated_seconds, elapsed_millis, rpe
 		FROM training_steps
-		WHERE training_id=$1
-		ORDER BY step_order ASC`, trainingID)
+		WHERE training_id = ANY($1)
+		ORDER BY training_id ASC, step_order ASC`, trainingIDs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var steps []TrainingStepLog
+	found := false
 	// Collect each step timing row.
 	for rows.Next() {
 		var st TrainingStepLog
-		if err := rows.Scan(&st.ID, &st.TrainingID, &st.StepOrder, &st.Type, &st.Name, &st.EstimatedSeconds, &st.ElapsedMillis); err != nil {
+		if err := rows.Scan(&st.ID, &st.TrainingID, &st.StepOrder, &st.Type, &st.Name, &st.EstimatedSeconds, &st.ElapsedMillis, &st.RPE); err != nil {
 			return nil, err
 		}
-		steps = append(steps, st)
+		timings[st.TrainingID] = append(timings[st.TrainingID], st)
+		found = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return timings, nil
+	}
+
+	exercises, err := s.trainingStepExercises(ctx, trainingIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, steps := range timings {
+		for i := range steps {
+			steps[i].Exercises = exercises[steps[i].ID]
+		}
+	}
+	return timings, nil
+}
+
+// trainingStepExercises returns the exercise results of trainings grouped by step id.
+func (s *Store) trainingStepExercises(ctx context.Context, trainingIDs []string) (map[string][]TrainingStepExercise, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT e.id, e.step_id, e.exercise_order, e.exercise_id, e.exercise_type, e.name, e.reps, e.weight, e.weight_unit, e.duration_seconds, e.status
+		FROM training_step_exercises e
+		JOIN training_steps s ON s.id = e.step_id
+		WHERE s.training_id = ANY($1)
+		ORDER BY e.step_id ASC, e.exercise_order ASC`, trainingIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	exercises := make(map[string][]TrainingStepExercise)
+	for rows.Next() {
+		var ex TrainingStepExercise
+		if err := rows.Scan(&ex.ID, &ex.StepID, &ex.ExerciseOrder, &ex.ExerciseID, &ex.Type, &ex.Name, &ex.Reps, &ex.Weight, &ex.WeightUnit, &ex.DurationSeconds, &ex.Status); err != nil {
+			return nil, err
+		}
+		exercises[ex.StepID] = append(exercises[ex.StepID], ex)
+	}
+	return exercises, rows.Err()
+}
+
+// scanTraining reads a training log row selected with its notes and ratings.
+func scanTraining(row pgx.Row) (TrainingLog, error) {
+	var entry TrainingLog
+	err := row.Scan(
+		&entry.ID, &entry.WorkoutID, &entry.WorkoutName, &entry.UserID, &entry.StartedAt, &entry.CompletedAt,
+		&entry.Notes, &entry.RPE, &entry.Mood, &entry.Energy, &entry.Bodyweight, &entry.BodyweightUnit,
+	)
+	return entry, err
+}
+
+// insertTrainingSteps stores the step timings of a training and their exercise results.
+func insertTrainingSteps(ctx context.Context, tx pgx.Tx, trainingID string, steps []TrainingStepLog) error {
+	if len(steps) == 0 {
+		return nil
+	}
+	batch := &pgx.Batch{}
+	// Queue each step timing insert in the batch.
+	for _, st := range steps {
+		batch.Queue(
+			`
+				INSERT INTO training_steps(
+					id,
+					training_id,
+					step_order,
+					step_type,
+					name,
+					estimated_seconds,
+					elapsed_millis,
+					rpe
+				)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				ON CONFLICT (id) DO NOTHING
+			`,
+			st.ID, trainingID, st.StepOrder, st.Type, st.Name, st.EstimatedSeconds, st.ElapsedMillis, st.RPE,
+		)
+		// Queue the actual exercise results of the step after the step itself.
+		for _, ex := range st.Exercises {
+			batch.Queue(
+				`
+					INSERT INTO training_step_exercises(
+						id,
+						step_id,
+						exercise_order,
+						exercise_id,
+						exercise_type,
+						name,
+						reps,
+						weight,
+						weight_unit,
+						duration_seconds,
+						status
+					)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+					ON CONFLICT (id) DO NOTHING
+				`,
+				ex.ID, st.ID, ex.ExerciseOrder, ex.ExerciseID, ex.Type, ex.Name, ex.Reps, ex.Weight, ex.WeightUnit, ex.DurationSeconds, ex.Status,
+			)
+		}
 	}
-	return steps, rows.Err()
+	return tx.SendBatch(ctx, batch).Close()
 }
@@ -6,32 +6,47 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+
 	"github.com/gi8lino/motus/internal/utils"
 )
 
-// CreateUser inserts a new user with the provided password hash.
+// CreateUser inserts a new, already verified user with the provided password hash.
 func (s *Store) CreateUser(ctx context.Context, email, avatarURL, passwordHash string) (*User, error) {
+	now := time.Now().UTC()
+	return s.insertUser(ctx, email, avatarURL, passwordHash, now, &now)
+}
+
+// CreatePendingUser inserts a new user whose email address still needs verification.
+func (s *Store) CreatePendingUser(ctx context.Context, email, passwordHash string) (*User, error) {
+	return s.insertUser(ctx, email, "", passwordHash, time.Now().UTC(), nil)
+}
+
+// insertUser stores a user row with the given verification state.
+func (s *Store) insertUser(ctx context.Context, email, avatarURL, passwordHash string, createdAt time.Time, verifiedAt *time.Time) (*User, error) {
 	// Normalize the user identifier and prepare the insert payload.
 	normalized := utils.NormalizeToken(email)
 	user := &User{
-		ID:        normalized,
-		Name:      normalized,
-		IsAdmin:   false,
-		AvatarURL: strings.TrimSpace(avatarURL),
-		CreatedAt: time.Now().UTC(),
+		ID:              normalized,
+		Name:            normalized,
+		Role:            RoleMember,
+		AvatarURL:       strings.TrimSpace(avatarURL),
+		CreatedAt:       createdAt,
+		EmailVerifiedAt: verifiedAt,
 	}
 	_, err := s.pool.Exec(ctx, `
 		INSERT INTO users(
 			id,
 			name,
-			is_admin,
+			role,
 			avatar_url,
 			password_hash,
-			created_at
+			created_at,
+			email_verified_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`,
-		user.ID, user.Name, user.IsAdmin, user.AvatarURL, strings.TrimSpace(passwordHash), user.CreatedAt)
+		user.ID, user.Name, user.Role, user.AvatarURL, strings.TrimSpace(passwordHash), user.CreatedAt, user.EmailVerifiedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +57,7 @@ func (s *Store) CreateUser(ctx context.Context, email, avatarURL, passwordHash s
 func (s *Store) ListUsers(ctx context.Context) ([]User, error) {
 	// Query all users ordered by creation time.
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, name, is_admin, avatar_url, created_at
+		SELECT id, name, role, avatar_url, created_at, email_verified_at, totp_enabled_at IS NOT NULL
 		FROM users
 		ORDER BY created_at ASC
 	`)
@@ -55,42 +70,51 @@ func (s *Store) ListUsers(ctx context.Context) ([]User, error) {
 	// Collect each user row into the result slice.
 	for rows.Next() {
 		var u User
-		if err := rows.Scan(&u.ID, &u.Name, &u.IsAdmin, &u.AvatarURL, &u.CreatedAt); err != nil {
+		if err := rows.Scan(&u.ID, &u.Name, &u.Role, &u.AvatarURL, &u.CreatedAt, &u.EmailVerifiedAt, &u.TOTPEnabled); err != nil {
 			return nil, err
 		}
+		u.SetRole(u.Role)
 		users = append(users, u)
 	}
 	return users, rows.Err()
 }
 
-// GetUser fetches a single user by id.
+// GetUser fetches a single user by id; unknown ids return ErrUserNotFound.
 func (s *Store) GetUser(ctx context.Context, id string) (*User, error) {
 	// Fetch the user row by id.
 	row := s.pool.QueryRow(ctx, `
-		SELECT id, name, is_admin, avatar_url, created_at
+		SELECT id, name, role, avatar_url, created_at, email_verified_at, totp_enabled_at IS NOT NULL
 		FROM users
 		WHERE id=$1
 	`, strings.TrimSpace(id))
 	var u User
-	if err := row.Scan(&u.ID, &u.Name, &u.IsAdmin, &u.AvatarURL, &u.CreatedAt); err != nil {
+	if err := row.Scan(&u.ID, &u.Name, &u.Role, &u.AvatarURL, &u.CreatedAt, &u.EmailVerifiedAt, &u.TOTPEnabled); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
 		return nil, err
 	}
+	u.SetRole(u.Role)
 	return &u, nil
 }
 
-// GetUserWithPassword fetches a user and password hash by id.
+// GetUserWithPassword fetches a user and password hash by id; unknown ids return ErrUserNotFound.
 func (s *Store) GetUserWithPassword(ctx context.Context, id string) (*User, string, error) {
 	// Fetch user metadata along with the stored password hash.
 	row := s.pool.QueryRow(ctx, `
-		SELECT id, name, is_admin, avatar_url, created_at, password_hash
+		SELECT id, name, role, avatar_url, created_at, email_verified_at, totp_enabled_at IS NOT NULL, password_hash
 		FROM users
 		WHERE id=$1
 	`, strings.TrimSpace(id))
 	var u User
 	var passwordHash string
-	if err := row.Scan(&u.ID, &u.Name, &u.IsAdmin, &u.AvatarURL, &u.CreatedAt, &passwordHash); err != nil {
+	if err := row.Scan(&u.ID, &u.Name, &u.Role, &u.AvatarURL, &u.CreatedAt, &u.EmailVerifiedAt, &u.TOTPEnabled, &passwordHash); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrUserNotFound
+		}
 		return nil, "", err
 	}
+	u.SetRole(u.Role)
 	return &u, passwordHash, nil
 }
 
@@ -111,21 +135,44 @@ func (s *Store) UpdateUserPassword(ctx context.Context, userID, passwordHash str
 	return nil
 }
 
-// UpdateUserAdmin sets the admin flag for a user.
-func (s *Store) UpdateUserAdmin(ctx context.Context, userID string, isAdmin bool) error {
-	// Toggle the admin flag for the target user.
-	tag, err := s.pool.Exec(ctx, `
-		UPDATE users
-		SET is_admin=$1
-		WHERE id=$2
-	`, isAdmin, strings.TrimSpace(userID))
+// UpdateUserRole sets the role for a user.
+// Demoting the last admin fails with ErrLastAdmin.
+func (s *Store) UpdateUserRole(ctx context.Context, userID, role string) error {
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
-	if tag.RowsAffected() == 0 {
-		return errors.New("user not found")
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	// Lock every admin so concurrent demotions cannot each count the other as the one left.
+	var admins int
+	if err := tx.QueryRow(ctx, `
+		SELECT count(*) FROM (SELECT id FROM users WHERE role=$1 FOR UPDATE) admins
+	`, RoleAdmin).Scan(&admins); err != nil {
+		return err
 	}
-	return nil
+	var current string
+	if err := tx.QueryRow(ctx, `
+		SELECT role FROM users WHERE id=$1 FOR UPDATE
+	`, strings.TrimSpace(userID)).Scan(&current); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if current == RoleAdmin && role != RoleAdmin && admins <= 1 {
+		return ErrLastAdmin
+	}
+
+	// Persist the role for the target user.
+	if _, err := tx.Exec(ctx, `
+		UPDATE users
+		SET role=$1
+		WHERE id=$2
+	`, role, strings.TrimSpace(userID)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 // UpdateUserName changes the display name for a user.
@@ -145,6 +192,49 @@ func (s *Store) UpdateUserName(ctx context.Context, userID, name string) error {
 	return nil
 }
 
+// DeleteUser removes a user together with everything cascading from it.
+// Templates move to templateOwnerID when set and are deleted otherwise; personal
+// exercises are deleted and unlinked from the workouts that remain.
+func (s *Store) DeleteUser(ctx context.Context, userID, templateOwnerID string) error {
+	userID = strings.TrimSpace(userID)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	if owner := strings.TrimSpace(templateOwnerID); owner != "" {
+		if _, err := tx.Exec(ctx, `
+			UPDATE workouts
+			SET user_id=$2
+			WHERE user_id=$1 AND is_template=TRUE
+		`, userID, owner); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE workout_subset_exercises
+		SET exercise_id=''
+		WHERE exercise_id IN (SELECT id FROM exercises WHERE owner_user_id=$1 AND is_core=FALSE)
+	`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM exercises
+		WHERE owner_user_id=$1 AND is_core=FALSE
+	`, userID); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM users WHERE id=$1`, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return tx.Commit(ctx)
+}
+
 // UpsertAdminUser ensures the admin user exists with the given password hash.
 func (s *Store) UpsertAdminUser(ctx context.Context, email, passwordHash string) (*User, bool, error) {
 	// Insert or update the bootstrap admin account.
@@ -162,16 +252,18 @@ func (s *Store) UpsertAdminUser(ctx context.Context, email, passwordHash string)
 			INSERT INTO users(
 				id,
 				name,
-				is_admin,
+				role,
 				avatar_url,
 				password_hash,
-				created_at
+				created_at,
+				email_verified_at
 			)
-			VALUES ($1, $2, TRUE, '', $3, $4)
+			VALUES ($1, $2, 'admin', '', $3, $4, $4)
 			ON CONFLICT (id) DO UPDATE
-			SET is_admin=TRUE,
-				password_hash=EXCLUDED.password_hash
-			RETURNING id, name, is_admin, avatar_url, created_at, (xmax = 0) AS created
+			SET role='admin',
+				password_hash=EXCLUDED.password_hash,
+				email_verified_at=COALESCE(users.email_verified_at, EXCLUDED.email_verified_at)
+			RETURNING id, name, role, avatar_url, created_at, email_verified_at, totp_enabled_at IS NOT NULL, (xmax = 0) AS created
 		`,
 		normalized,
 		normalized,
@@ -180,8 +272,9 @@ func (s *Store) UpsertAdminUser(ctx context.Context, email, passwordHash string)
 	)
 	var u User
 	var created bool
-	if err := row.Scan(&u.ID, &u.Name, &u.IsAdmin, &u.AvatarURL, &u.CreatedAt, &created); err != nil {
+	if err := row.Scan(&u.ID, &u.Name, &u.Role, &u.AvatarURL, &u.CreatedAt, &u.EmailVerifiedAt, &u.TOTPEnabled, &created); err != nil {
 		return nil, false, err
 	}
+	u.SetRole(u.Role)
 	return &u, created, nil
 }
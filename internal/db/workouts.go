@@ -29,9 +29,9 @@ func (s *Store) insertWorkout(ctx context.Context, w *Workout, isTemplate bool)
 	w.ID = utils.NewID()
 	w.CreatedAt = time.Now().UTC()
 	if _, err := tx.Exec(ctx, `
-		INSERT INTO workouts(id, user_id, name, is_template, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-	`, w.ID, w.UserID, w.Name, isTemplate, w.CreatedAt); err != nil {
+		INSERT INTO workouts(id, user_id, name, is_template, assigned_by, org_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, w.ID, w.UserID, w.Name, isTemplate, w.AssignedBy, w.OrgID, w.CreatedAt); err != nil {
 		return nil, err
 	}
 
@@ -99,7 +99,7 @@ func (s *Store) insertWorkout(ctx context.Context, w *Workout, isTemplate bool)
 func (s *Store) WorkoutsByUser(ctx context.Context, userID string) ([]Workout, error) {
 	// Load workouts and their steps for the given user.
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, user_id, name, is_template, created_at
+		SELECT id, user_id, name, is_template, assigned_by, created_at
 		FROM workouts
 		WHERE user_id=$1 AND is_template=FALSE
 		ORDER BY created_at DESC
@@ -113,7 +113,7 @@ func (s *Store) WorkoutsByUser(ctx context.Context, userID string) ([]Workout, e
 	// Collect workouts and hydrate each with steps.
 	for rows.Next() {
 		var w Workout
-		if err := rows.Scan(&w.ID, &w.UserID, &w.Name, &w.IsTemplate, &w.CreatedAt); err != nil {
+		if err := rows.Scan(&w.ID, &w.UserID, &w.Name, &w.IsTemplate, &w.AssignedBy, &w.CreatedAt); err != nil {
 			return nil, err
 		}
 		steps, err := s.WorkoutSteps(ctx, w.ID)
@@ -289,12 +289,12 @@ func (s *Store) WorkoutSteps(ctx context.Context, workoutID string) ([]WorkoutSt
 func (s *Store) WorkoutWithSteps(ctx context.Context, workoutID string) (*Workout, error) {
 	// Fetch the workout row and hydrate its steps.
 	row := s.pool.QueryRow(ctx, `
-		SELECT id, user_id, name, is_template, created_at
+		SELECT id, user_id, name, is_template, assigned_by, org_id, created_at
 		FROM workouts
 		WHERE id=$1
 	`, workoutID)
 	var w Workout
-	if err := row.Scan(&w.ID, &w.UserID, &w.Name, &w.IsTemplate, &w.CreatedAt); err != nil {
+	if err := row.Scan(&w.ID, &w.UserID, &w.Name, &w.IsTemplate, &w.AssignedBy, &w.OrgID, &w.CreatedAt); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrWorkoutNotFound
 		}
@@ -393,6 +393,36 @@ func (s *Store) UpdateWorkout(ctx context.Context, w *Workout) (*Workout, error)
 	return w, nil
 }
 
+// UpdateEstimatedSeconds sets the targets of steps and subsets of a workout, keyed by id.
+// Unlike UpdateWorkout it keeps every id, so logged trainings still line up with the workout.
+func (s *Store) UpdateEstimatedSeconds(ctx context.Context, workoutID string, steps, subsets map[string]int) error {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	for id, seconds := range steps {
+		if _, err := tx.Exec(ctx, `
+			UPDATE workout_steps
+			SET estimated_seconds=$1
+			WHERE id=$2 AND workout_id=$3
+		`, max(seconds, 0), id, workoutID); err != nil {
+			return err
+		}
+	}
+	for id, seconds := range subsets {
+		if _, err := tx.Exec(ctx, `
+			UPDATE workout_subsets
+			SET estimated_seconds=$1
+			WHERE id=$2 AND step_id IN (SELECT id FROM workout_steps WHERE workout_id=$3)
+		`, max(seconds, 0), id, workoutID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
 // cloneSteps copies workout steps for template/workout reuse.
 func cloneSteps(src []WorkoutStep) []WorkoutStep {
 	result := make([]WorkoutStep, len(src))
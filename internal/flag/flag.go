@@ -1,8 +1,12 @@
 package flag
 
 import (
+	"errors"
+	"fmt"
 	"net"
+	"net/netip"
 	"strings"
+	"time"
 
 	"github.com/gi8lino/motus/internal/logging"
 
@@ -18,8 +22,34 @@ type Options struct {
 	SiteRoot          string            // Root URL of the site
 	RoutePrefix       string            // Route prefix
 	AuthHeader        string            // Authentication header
+	TrustedProxies    []netip.Prefix    // Peers allowed to set the authentication header
+	AssertionHeader   string            // Header carrying a signed JWT assertion
+	AssertionJWKSFile string            // JWKS file used to verify the assertion
+	AssertionIssuer   string            // Required assertion issuer
+	AssertionAudience string            // Required assertion audience
 	AllowRegistration bool              // Allow user self-registration
 	AutoCreateUsers   bool              // Auto-create users in auth-header mode
+	SessionTTL        time.Duration     // Lifetime of local login sessions
+	RequireAdmin2FA   bool              // Withhold admin rights from local admins without two-factor authentication
+	LoginIPLimit      int               // Login attempts per minute and client address
+	LoginAccountLimit int               // Login attempts per minute and account
+	LockoutThreshold  int               // Failed logins before an account is locked
+	LockoutDuration   time.Duration     // First lockout, doubled on every further failure
+	LockoutMax        time.Duration     // Longest lockout
+	WriteLimit        int               // Write requests per minute and client address
+	AuditRetention    time.Duration     // How long audit events are kept
+	OIDCIssuer        string            // OpenID Connect issuer URL
+	OIDCClientID      string            // OpenID Connect client id
+	OIDCClientSecret  string            // OpenID Connect client secret
+	OIDCScopes        []string          // OpenID Connect scopes to request
+	OIDCGroupsClaim   string            // ID token claim listing the user's groups
+	OIDCAdminGroup    string            // Group that grants admin rights
+	SMTPHost          string            // SMTP relay host; empty logs mails instead
+	SMTPPort          int               // SMTP relay port
+	SMTPUsername      string            // SMTP auth username
+	SMTPPassword      string            // SMTP auth password
+	MailFrom          string            // Sender address of outgoing mails
+	MailLogFile       string            // File that receives logged mails when no SMTP relay is set
 	DatabaseURL       string            // Database URL
 	OverriddenValues  map[string]any    // Overridden values from environment
 	AdminEmail        string            // AdminEmail is the email address of the site admin
@@ -62,13 +92,129 @@ func ParseFlags(args []string, version string) (Options, error) {
 		Value()
 
 	tf.StringVar(&opts.AuthHeader, "auth-header", "", "Authentication header").
+		OneOfGroup("auth-mode").
 		Placeholder("HEADER").
 		Value()
 
+	trustedProxies := tf.StringSlice("trusted-proxies", nil, "CIDRs or addresses of proxies allowed to set the auth header (empty = any peer)").
+		Placeholder("CIDR").
+		Value()
+
+	tf.StringVar(&opts.AssertionHeader, "auth-assertion-header", "", "Header carrying a signed JWT that must vouch for the auth header user").
+		AllOrNone("assertion").
+		Placeholder("HEADER").
+		Value()
+
+	tf.StringVar(&opts.AssertionJWKSFile, "auth-assertion-jwks-file", "", "JWKS file used to verify the auth assertion").
+		AllOrNone("assertion").
+		Placeholder("FILE").
+		Value()
+
+	tf.StringVar(&opts.AssertionIssuer, "auth-assertion-issuer", "", "Required issuer of the auth assertion").
+		Placeholder("ISSUER").
+		Value()
+
+	tf.StringVar(&opts.AssertionAudience, "auth-assertion-audience", "", "Required audience of the auth assertion").
+		Placeholder("AUDIENCE").
+		Value()
+
+	tf.StringVar(&opts.OIDCIssuer, "oidc-issuer", "", "OpenID Connect issuer URL; enables OIDC login").
+		OneOfGroup("auth-mode").
+		AllOrNone("oidc").
+		Finalize(func(input string) string {
+			return strings.TrimRight(input, "/")
+		}).
+		Placeholder("URL").
+		Value()
+
+	tf.StringVar(&opts.OIDCClientID, "oidc-client-id", "", "OpenID Connect client id").
+		AllOrNone("oidc").
+		Placeholder("ID").
+		Value()
+
+	tf.StringVar(&opts.OIDCClientSecret, "oidc-client-secret", "", "OpenID Connect client secret (empty for public clients)").
+		OverriddenValueMaskFn(tinyflags.MaskFirstLast).
+		Placeholder("SECRET").
+		Value()
+
+	tf.StringSliceVar(&opts.OIDCScopes, "oidc-scopes", []string{"openid", "email", "profile"}, "OpenID Connect scopes to request").
+		Placeholder("SCOPE").
+		Value()
+
+	tf.StringVar(&opts.OIDCGroupsClaim, "oidc-groups-claim", "groups", "ID token claim listing the user's groups").
+		Placeholder("CLAIM").
+		Value()
+
+	tf.StringVar(&opts.OIDCAdminGroup, "oidc-admin-group", "", "Group that grants admin rights on OIDC login (empty = leave admin flag unchanged)").
+		Placeholder("GROUP").
+		Value()
+
 	tf.BoolVar(&opts.AllowRegistration, "allow-registration", false, "Allow user self-registration").
 		Value()
 
-	tf.BoolVar(&opts.AutoCreateUsers, "auto-create-users", false, "Auto-create users when auth-header is enabled").
+	tf.BoolVar(&opts.AutoCreateUsers, "auto-create-users", false, "Auto-create users when auth-header or OIDC is enabled").
+		Value()
+
+	tf.DurationVar(&opts.SessionTTL, "session-ttl", 720*time.Hour, "Lifetime of local login sessions").
+		Placeholder("DURATION").
+		Value()
+
+	tf.BoolVar(&opts.RequireAdmin2FA, "require-admin-2fa", false, "Grant admin rights to local accounts only after they enabled two-factor authentication").
+		Value()
+
+	tf.IntVar(&opts.LoginIPLimit, "login-ip-limit", 20, "Login attempts per minute and client address (0 = unlimited)").
+		Placeholder("N").
+		Value()
+
+	tf.IntVar(&opts.LoginAccountLimit, "login-account-limit", 10, "Login attempts per minute and account (0 = unlimited)").
+		Placeholder("N").
+		Value()
+
+	tf.IntVar(&opts.LockoutThreshold, "login-lockout-threshold", 5, "Failed logins before an account is locked (0 = never)").
+		Placeholder("N").
+		Value()
+
+	tf.DurationVar(&opts.LockoutDuration, "login-lockout-duration", time.Minute, "First account lockout, doubled on every further failure").
+		Placeholder("DURATION").
+		Value()
+
+	tf.DurationVar(&opts.LockoutMax, "login-lockout-max", time.Hour, "Longest account lockout").
+		Placeholder("DURATION").
+		Value()
+
+	tf.IntVar(&opts.WriteLimit, "write-limit", 120, "Write requests per minute and client address (0 = unlimited)").
+		Placeholder("N").
+		Value()
+
+	tf.DurationVar(&opts.AuditRetention, "audit-retention", 0, "How long audit events are kept (0 = forever)").
+		Placeholder("DURATION").
+		Value()
+
+	tf.StringVar(&opts.SMTPHost, "smtp-host", "", "SMTP relay host for password reset and verification mails (empty = log mails)").
+		AllOrNone("smtp").
+		Placeholder("HOST").
+		Value()
+
+	tf.IntVar(&opts.SMTPPort, "smtp-port", 587, "SMTP relay port (465 = implicit TLS)").
+		Placeholder("PORT").
+		Value()
+
+	tf.StringVar(&opts.SMTPUsername, "smtp-username", "", "SMTP auth username").
+		Placeholder("USER").
+		Value()
+
+	tf.StringVar(&opts.SMTPPassword, "smtp-password", "", "SMTP auth password").
+		OverriddenValueMaskFn(tinyflags.MaskFirstLast).
+		Placeholder("PASSWORD").
+		Value()
+
+	tf.StringVar(&opts.MailFrom, "mail-from", "", "Sender address of outgoing mails").
+		AllOrNone("smtp").
+		Placeholder("EMAIL").
+		Value()
+
+	tf.StringVar(&opts.MailLogFile, "mail-log-file", "", "Append mails to this file instead of the log when no SMTP relay is set").
+		Placeholder("FILE").
 		Value()
 
 	tf.StringVar(&opts.CoreExercisesFile, "core-exercises-file", "", "Path to a YAML file describing core exercises to seed at startup").
@@ -97,6 +243,35 @@ func ParseFlags(args []string, version string) (Options, error) {
 		return opts, err
 	}
 
+	if opts.OIDCIssuer != "" && opts.AllowRegistration {
+		return opts, errors.New("--allow-registration cannot be combined with --oidc-issuer")
+	}
+	if opts.AuthHeader == "" && (len(*trustedProxies) > 0 || opts.AssertionHeader != "") {
+		return opts, errors.New("--trusted-proxies and --auth-assertion-header require --auth-header")
+	}
+	if opts.RequireAdmin2FA && (opts.AuthHeader != "" || opts.OIDCIssuer != "") {
+		return opts, errors.New("--require-admin-2fa only applies to local logins and cannot be combined with --auth-header or --oidc-issuer")
+	}
+	if opts.LoginIPLimit < 0 || opts.LoginAccountLimit < 0 || opts.LockoutThreshold < 0 || opts.WriteLimit < 0 {
+		return opts, errors.New("rate limits must not be negative")
+	}
+	if opts.AuditRetention < 0 {
+		return opts, errors.New("--audit-retention must not be negative")
+	}
+	if opts.SMTPHost != "" && opts.MailLogFile != "" {
+		return opts, errors.New("--mail-log-file cannot be combined with --smtp-host")
+	}
+	for _, value := range *trustedProxies {
+		if value == "" {
+			continue
+		}
+		prefix, err := parsePrefix(value)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --trusted-proxies value %q: %w", value, err)
+		}
+		opts.TrustedProxies = append(opts.TrustedProxies, prefix)
+	}
+
 	opts.ListenAddr = (*listenAddr).String()
 	opts.LogFormat = logging.LogFormat(*logFormat)
 	opts.OverriddenValues = tf.OverriddenValues()
@@ -106,3 +281,18 @@ func ParseFlags(args []string, version string) (Options, error) {
 
 	return opts, nil
 }
+
+// parsePrefix accepts a CIDR or a bare address, which is treated as a single-host prefix.
+func parsePrefix(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		return prefix.Masked(), err
+	}
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
@@ -1,9 +1,11 @@
 package flag
 
 import (
+	"net/netip"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/containeroo/tinyflags"
 	"github.com/stretchr/testify/assert"
@@ -52,6 +54,20 @@ func TestParseFlags(t *testing.T) {
 		assert.Equal(t, "", cfg.AuthHeader, "default auth header")
 		assert.False(t, cfg.AllowRegistration, "default allow registration")
 		assert.False(t, cfg.AutoCreateUsers, "default auto-create users")
+		assert.Equal(t, 720*time.Hour, cfg.SessionTTL, "default session ttl")
+		assert.False(t, cfg.RequireAdmin2FA, "default require admin 2fa")
+		assert.Equal(t, 20, cfg.LoginIPLimit, "default login ip limit")
+		assert.Equal(t, 10, cfg.LoginAccountLimit, "default login account limit")
+		assert.Equal(t, 5, cfg.LockoutThreshold, "default lockout threshold")
+		assert.Equal(t, time.Minute, cfg.LockoutDuration, "default lockout duration")
+		assert.Equal(t, time.Hour, cfg.LockoutMax, "default lockout max")
+		assert.Equal(t, 120, cfg.WriteLimit, "default write limit")
+		assert.Equal(t, time.Duration(0), cfg.AuditRetention, "default audit retention")
+		assert.Equal(t, "", cfg.OIDCIssuer, "default oidc issuer")
+		assert.Equal(t, []string{"openid", "email", "profile"}, cfg.OIDCScopes, "default oidc scopes")
+		assert.Equal(t, "groups", cfg.OIDCGroupsClaim, "default oidc groups claim")
+		assert.Equal(t, "", cfg.SMTPHost, "default smtp host")
+		assert.Equal(t, 587, cfg.SMTPPort, "default smtp port")
 		assert.Equal(t, testDatabaseURL, cfg.DatabaseURL, "database url")
 		assert.Equal(t, "", cfg.AdminEmail, "default admin email")
 		assert.Equal(t, "", cfg.AdminPassword, "default admin password")
@@ -116,6 +132,213 @@ func TestParseFlags(t *testing.T) {
 		assert.Equal(t, logging.LogFormat("text"), cfg.LogFormat)
 	})
 
+	t.Run("oidc values", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{
+			"--database-url", testDatabaseURL,
+			"--oidc-issuer", "https://id.example.com/",
+			"--oidc-client-id", "motus",
+			"--oidc-client-secret", "secret",
+			"--oidc-scopes", "openid,email",
+			"--oidc-admin-group", "motus-admins",
+		}
+		cfg, err := ParseFlags(args, "0.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "https://id.example.com", cfg.OIDCIssuer)
+		assert.Equal(t, "motus", cfg.OIDCClientID)
+		assert.Equal(t, "secret", cfg.OIDCClientSecret)
+		assert.Equal(t, []string{"openid", "email"}, cfg.OIDCScopes)
+		assert.Equal(t, "motus-admins", cfg.OIDCAdminGroup)
+	})
+
+	t.Run("oidc requires client id", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{"--database-url", testDatabaseURL, "--oidc-issuer", "https://id.example.com"}
+		_, err := ParseFlags(args, "0.0.0")
+		require.Error(t, err)
+	})
+
+	t.Run("oidc excludes auth header", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{
+			"--database-url", testDatabaseURL,
+			"--auth-header", "X-User-Email",
+			"--oidc-issuer", "https://id.example.com",
+			"--oidc-client-id", "motus",
+		}
+		_, err := ParseFlags(args, "0.0.0")
+		require.Error(t, err)
+	})
+
+	t.Run("oidc excludes registration", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{
+			"--database-url", testDatabaseURL,
+			"--oidc-issuer", "https://id.example.com",
+			"--oidc-client-id", "motus",
+			"--allow-registration",
+		}
+		_, err := ParseFlags(args, "0.0.0")
+		require.EqualError(t, err, "--allow-registration cannot be combined with --oidc-issuer")
+	})
+
+	t.Run("trusted proxies and assertion", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{
+			"--database-url", testDatabaseURL,
+			"--auth-header", "Cf-Access-Authenticated-User-Email",
+			"--trusted-proxies", "10.0.0.1/8,192.168.1.10,fd00::/8",
+			"--auth-assertion-header", "Cf-Access-Jwt-Assertion",
+			"--auth-assertion-jwks-file", "/etc/motus/jwks.json",
+			"--auth-assertion-audience", "motus-app",
+		}
+		cfg, err := ParseFlags(args, "0.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, []netip.Prefix{
+			netip.MustParsePrefix("10.0.0.0/8"),
+			netip.MustParsePrefix("192.168.1.10/32"),
+			netip.MustParsePrefix("fd00::/8"),
+		}, cfg.TrustedProxies)
+		assert.Equal(t, "Cf-Access-Jwt-Assertion", cfg.AssertionHeader)
+		assert.Equal(t, "/etc/motus/jwks.json", cfg.AssertionJWKSFile)
+		assert.Equal(t, "motus-app", cfg.AssertionAudience)
+	})
+
+	t.Run("invalid trusted proxy", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{"--database-url", testDatabaseURL, "--auth-header", "X-User", "--trusted-proxies", "nope"}
+		_, err := ParseFlags(args, "0.0.0")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid --trusted-proxies value "nope"`)
+	})
+
+	t.Run("trusted proxies require auth header", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{"--database-url", testDatabaseURL, "--trusted-proxies", "10.0.0.0/8"}
+		_, err := ParseFlags(args, "0.0.0")
+		require.EqualError(t, err, "--trusted-proxies and --auth-assertion-header require --auth-header")
+	})
+
+	t.Run("assertion requires jwks file", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{"--database-url", testDatabaseURL, "--auth-header", "X-User", "--auth-assertion-header", "X-Jwt"}
+		_, err := ParseFlags(args, "0.0.0")
+		require.Error(t, err)
+	})
+
+	t.Run("smtp values", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{
+			"--database-url", testDatabaseURL,
+			"--smtp-host", "smtp.example.com",
+			"--smtp-port", "465",
+			"--smtp-username", "motus",
+			"--smtp-password", "secret",
+			"--mail-from", "motus@example.com",
+		}
+		cfg, err := ParseFlags(args, "0.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "smtp.example.com", cfg.SMTPHost)
+		assert.Equal(t, 465, cfg.SMTPPort)
+		assert.Equal(t, "motus", cfg.SMTPUsername)
+		assert.Equal(t, "secret", cfg.SMTPPassword)
+		assert.Equal(t, "motus@example.com", cfg.MailFrom)
+	})
+
+	t.Run("smtp requires mail from", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{"--database-url", testDatabaseURL, "--smtp-host", "smtp.example.com"}
+		_, err := ParseFlags(args, "0.0.0")
+		require.Error(t, err)
+	})
+
+	t.Run("mail log file excludes smtp", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{
+			"--database-url", testDatabaseURL,
+			"--smtp-host", "smtp.example.com",
+			"--mail-from", "motus@example.com",
+			"--mail-log-file", "/tmp/mail.log",
+		}
+		_, err := ParseFlags(args, "0.0.0")
+		require.EqualError(t, err, "--mail-log-file cannot be combined with --smtp-host")
+	})
+
+	t.Run("require admin 2fa", func(t *testing.T) {
+		clearEnv(t)
+
+		cfg, err := ParseFlags([]string{"--database-url", testDatabaseURL, "--require-admin-2fa"}, "0.0.0")
+		require.NoError(t, err)
+		assert.True(t, cfg.RequireAdmin2FA)
+	})
+
+	t.Run("rate limits", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{
+			"--database-url", testDatabaseURL,
+			"--login-ip-limit", "0",
+			"--login-account-limit", "3",
+			"--login-lockout-threshold", "2",
+			"--login-lockout-duration", "30s",
+			"--login-lockout-max", "10m",
+			"--write-limit", "60",
+		}
+		cfg, err := ParseFlags(args, "0.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, 0, cfg.LoginIPLimit)
+		assert.Equal(t, 3, cfg.LoginAccountLimit)
+		assert.Equal(t, 2, cfg.LockoutThreshold)
+		assert.Equal(t, 30*time.Second, cfg.LockoutDuration)
+		assert.Equal(t, 10*time.Minute, cfg.LockoutMax)
+		assert.Equal(t, 60, cfg.WriteLimit)
+	})
+
+	t.Run("negative rate limit", func(t *testing.T) {
+		clearEnv(t)
+
+		_, err := ParseFlags([]string{"--database-url", testDatabaseURL, "--write-limit=-1"}, "0.0.0")
+		require.EqualError(t, err, "rate limits must not be negative")
+	})
+
+	t.Run("audit retention", func(t *testing.T) {
+		clearEnv(t)
+
+		cfg, err := ParseFlags([]string{"--database-url", testDatabaseURL, "--audit-retention", "2160h"}, "0.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, 2160*time.Hour, cfg.AuditRetention)
+	})
+
+	t.Run("negative audit retention", func(t *testing.T) {
+		clearEnv(t)
+
+		_, err := ParseFlags([]string{"--database-url", testDatabaseURL, "--audit-retention=-1h"}, "0.0.0")
+		require.EqualError(t, err, "--audit-retention must not be negative")
+	})
+
+	t.Run("require admin 2fa with auth header", func(t *testing.T) {
+		clearEnv(t)
+
+		args := []string{
+			"--database-url", testDatabaseURL,
+			"--auth-header", "X-User-Email",
+			"--require-admin-2fa",
+		}
+		_, err := ParseFlags(args, "0.0.0")
+		require.EqualError(t, err, "--require-admin-2fa only applies to local logins and cannot be combined with --auth-header or --oidc-issuer")
+	})
+
 	t.Run("parsing error", func(t *testing.T) {
 		clearEnv(t)
 		args := []string{"--database-url", testDatabaseURL, "--invalid"}
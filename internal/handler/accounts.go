@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/audit"
+)
+
+// errLocalAuthDisabled is returned by password flows when an external login is configured.
+var errLocalAuthDisabled = errors.New("passwords are managed externally")
+
+// ForgotPassword mails a reset link when the address belongs to a local user.
+func (a *API) ForgotPassword() http.HandlerFunc {
+	type forgotPasswordRequest struct {
+		Email string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+
+		req, err := decode[forgotPasswordRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		if err := a.Accounts.RequestPasswordReset(r.Context(), req.Email); err != nil {
+			a.logRequestError(r, "request_password_reset_failed", "request password reset failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusAccepted, statusResponse{Status: "ok"})
+	}
+}
+
+// ResetPassword sets a new password from a reset link and signs out all sessions.
+func (a *API) ResetPassword() http.HandlerFunc {
+	type resetPasswordRequest struct {
+		Token       string `json:"token"`
+		NewPassword string `json:"newPassword"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+
+		req, err := decode[resetPasswordRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		userID, err := a.Accounts.ResetPassword(r.Context(), req.Token, req.NewPassword)
+		if err != nil {
+			a.logRequestError(r, "reset_password_failed", "reset password failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("user password reset",
+			"event", "user_password_reset",
+			"resource", "user",
+			"resource_id", userID,
+			"user_id", userID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: userID, Action: "user_password_reset", Resource: "user", ResourceID: userID})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// VerifyEmail confirms a self-registered user's email address.
+func (a *API) VerifyEmail() http.HandlerFunc {
+	type verifyEmailRequest struct {
+		Token string `json:"token"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+
+		req, err := decode[verifyEmailRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		userID, err := a.Accounts.VerifyEmail(r.Context(), req.Token)
+		if err != nil {
+			a.logRequestError(r, "verify_email_failed", "verify email failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("user email verified",
+			"event", "user_email_verified",
+			"resource", "user",
+			"resource_id", userID,
+			"user_id", userID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: userID, Action: "user_email_verified", Resource: "user", ResourceID: userID})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// ResendVerification mails a new verification link to an unverified user.
+func (a *API) ResendVerification() http.HandlerFunc {
+	type resendVerificationRequest struct {
+		Email string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+
+		req, err := decode[resendVerificationRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		if err := a.Accounts.ResendVerification(r.Context(), req.Email); err != nil {
+			a.logRequestError(r, "resend_verification_failed", "resend verification failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusAccepted, statusResponse{Status: "ok"})
+	}
+}
+
+// SendUserPasswordReset lets an admin mail a reset link to a user.
+func (a *API) SendUserPasswordReset() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		id := r.PathValue("id")
+		if err := a.Accounts.SendPasswordReset(r.Context(), actor, id); err != nil {
+			a.logRequestError(r, "send_password_reset_failed", "send password reset failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("user password reset sent",
+			"event", "user_password_reset_sent",
+			"resource", "user",
+			"resource_id", id,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "user_password_reset_sent", Resource: "user", ResourceID: id})
+		a.respondJSON(w, http.StatusAccepted, statusResponse{Status: "ok"})
+	}
+}
+
+// requireLocalAuth rejects password flows unless Motus manages passwords itself.
+func (a *API) requireLocalAuth(w http.ResponseWriter, r *http.Request) bool {
+	if a.authMode() == authModeLocal {
+		return true
+	}
+	a.logRequestError(r, "local_auth_disabled", "local auth disabled", errLocalAuthDisabled)
+	a.respondJSON(w, http.StatusForbidden, apiError{Error: errLocalAuthDisabled.Error()})
+	return false
+}
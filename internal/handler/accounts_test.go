@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/mailer"
+	"github.com/gi8lino/motus/internal/service/accounts"
+)
+
+// fakeAccountStore keeps users and one-time tokens in memory and satisfies accounts.Store.
+type fakeAccountStore struct {
+	mu        sync.Mutex
+	users     map[string]*db.User
+	tokens    map[string]db.OneTimeToken
+	passwords map[string]string
+}
+
+func newFakeAccountStore(userIDs ...string) *fakeAccountStore {
+	store := &fakeAccountStore{
+		users:     map[string]*db.User{},
+		tokens:    map[string]db.OneTimeToken{},
+		passwords: map[string]string{},
+	}
+	for _, id := range userIDs {
+		store.users[id] = &db.User{ID: id}
+	}
+	return store
+}
+
+func (f *fakeAccountStore) GetUser(_ context.Context, id string) (*db.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, ok := f.users[id]
+	if !ok {
+		return nil, db.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (f *fakeAccountStore) CreateOneTimeToken(_ context.Context, token db.OneTimeToken) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens[token.ID] = token
+	return nil
+}
+
+func (f *fakeAccountStore) ResetPassword(_ context.Context, tokenHash, passwordHash string, at time.Time) (string, error) {
+	userID, err := f.consume(tokenHash, db.TokenPurposePasswordReset, at)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.passwords[userID] = passwordHash
+	return userID, nil
+}
+
+func (f *fakeAccountStore) VerifyEmail(_ context.Context, tokenHash string, at time.Time) (string, error) {
+	userID, err := f.consume(tokenHash, db.TokenPurposeVerifyEmail, at)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if user, ok := f.users[userID]; ok {
+		user.EmailVerifiedAt = &at
+	}
+	return userID, nil
+}
+
+func (f *fakeAccountStore) consume(tokenHash, purpose string, at time.Time) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	token, ok := f.tokens[tokenHash]
+	if !ok || token.Purpose != purpose || token.UsedAt != nil || !token.ExpiresAt.After(at) {
+		return "", db.ErrOneTimeTokenNotFound
+	}
+	token.UsedAt = &at
+	f.tokens[tokenHash] = token
+	return token.UserID, nil
+}
+
+// fakeMailer records sent messages.
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+}
+
+func (f *fakeMailer) Send(_ context.Context, msg mailer.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func (f *fakeMailer) messages() []mailer.Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]mailer.Message(nil), f.sent...)
+}
+
+// mailedToken extracts the secret of the link sent in the last message.
+func mailedToken(t *testing.T, mail *fakeMailer, param string) string {
+	t.Helper()
+	msgs := mail.messages()
+	require.NotEmpty(t, msgs)
+	_, after, ok := strings.Cut(msgs[len(msgs)-1].Body, "?"+param+"=")
+	require.True(t, ok)
+	token, _, _ := strings.Cut(after, "\n")
+	return token
+}
+
+func TestAccountsHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Forgot and reset password", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeAccountStore("user@example.com")
+		mail := &fakeMailer{}
+		api := &API{Accounts: accounts.New(store, mail, "http://localhost:8080")}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/password/forgot", strings.NewReader(`{"email":"user@example.com"}`))
+		rec := httptest.NewRecorder()
+		api.ForgotPassword().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		token := mailedToken(t, mail, "resetToken")
+		body := `{"token":"` + token + `","newPassword":"new-secret"}`
+		req = httptest.NewRequest(http.MethodPost, "/api/password/reset", strings.NewReader(body))
+		rec = httptest.NewRecorder()
+		api.ResetPassword().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.NotEmpty(t, store.passwords["user@example.com"])
+
+		req = httptest.NewRequest(http.MethodPost, "/api/password/reset", strings.NewReader(body))
+		rec = httptest.NewRecorder()
+		api.ResetPassword().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "token is invalid or expired")
+	})
+
+	t.Run("Forgot password hides unknown users", func(t *testing.T) {
+		t.Parallel()
+		mail := &fakeMailer{}
+		api := &API{Accounts: accounts.New(newFakeAccountStore(), mail, "http://localhost:8080")}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/password/forgot", strings.NewReader(`{"email":"ghost@example.com"}`))
+		rec := httptest.NewRecorder()
+		api.ForgotPassword().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Empty(t, mail.messages())
+	})
+
+	t.Run("Disabled in proxy mode", func(t *testing.T) {
+		t.Parallel()
+		api := &API{AuthHeader: "X-User", Accounts: accounts.New(newFakeAccountStore(), &fakeMailer{}, "")}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/password/forgot", strings.NewReader(`{"email":"user@example.com"}`))
+		rec := httptest.NewRecorder()
+		api.ForgotPassword().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("Resend and verify email", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeAccountStore("user@example.com")
+		mail := &fakeMailer{}
+		api := &API{Accounts: accounts.New(store, mail, "http://localhost:8080")}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/email/verify/resend", strings.NewReader(`{"email":"user@example.com"}`))
+		rec := httptest.NewRecorder()
+		api.ResendVerification().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		token := mailedToken(t, mail, "verifyToken")
+		req = httptest.NewRequest(http.MethodPost, "/api/email/verify", strings.NewReader(`{"token":"`+token+`"}`))
+		rec = httptest.NewRecorder()
+		api.VerifyEmail().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.NotNil(t, store.users["user@example.com"].EmailVerifiedAt)
+	})
+
+	t.Run("Admin sends reset link", func(t *testing.T) {
+		t.Parallel()
+		mail := &fakeMailer{}
+		api := &API{Accounts: accounts.New(newFakeAccountStore("user@example.com"), mail, "http://localhost:8080")}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/users/user@example.com/password-reset", nil)
+		req.SetPathValue("id", "user@example.com")
+		signInAdmin(t, api, req, "admin@example.com")
+		rec := httptest.NewRecorder()
+		api.SendUserPasswordReset().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		require.Len(t, mail.messages(), 1)
+		assert.Equal(t, "user@example.com", mail.messages()[0].To)
+	})
+
+	t.Run("Non-admin cannot send reset link", func(t *testing.T) {
+		t.Parallel()
+		api := &API{Accounts: accounts.New(newFakeAccountStore("user@example.com"), &fakeMailer{}, "http://localhost:8080")}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/users/user@example.com/password-reset", nil)
+		req.SetPathValue("id", "user@example.com")
+		signIn(t, api, req, "other@example.com")
+		rec := httptest.NewRecorder()
+		api.SendUserPasswordReset().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
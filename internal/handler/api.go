@@ -3,34 +3,71 @@ package handler
 import (
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/gi8lino/motus/internal/auth"
 	"github.com/gi8lino/motus/internal/db"
 	"github.com/gi8lino/motus/internal/logging"
+	"github.com/gi8lino/motus/internal/mailer"
+	"github.com/gi8lino/motus/internal/oidc"
+	"github.com/gi8lino/motus/internal/service/accounts"
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/classes"
+	"github.com/gi8lino/motus/internal/service/coaching"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
 	"github.com/gi8lino/motus/internal/service/exercises"
+	"github.com/gi8lino/motus/internal/service/invitations"
+	"github.com/gi8lino/motus/internal/service/organizations"
+	"github.com/gi8lino/motus/internal/service/pacing"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/privacy"
+	"github.com/gi8lino/motus/internal/service/sessions"
 	"github.com/gi8lino/motus/internal/service/sounds"
+	"github.com/gi8lino/motus/internal/service/stats"
+	"github.com/gi8lino/motus/internal/service/summary"
 	"github.com/gi8lino/motus/internal/service/templates"
+	"github.com/gi8lino/motus/internal/service/tokens"
 	"github.com/gi8lino/motus/internal/service/trainings"
 	"github.com/gi8lino/motus/internal/service/users"
 	"github.com/gi8lino/motus/internal/service/workouts"
+	"github.com/gi8lino/motus/internal/utils"
 )
 
 // API bundles shared handler dependencies and runtime configuration.
 type API struct {
-	Origin            string             // Origin is used for CORS configuration.
-	Version           string             // Version is the build version string.
-	Commit            string             // Commit is the build commit SHA.
-	HealthStore       db.HealthChecker   // HealthStore supports health checks.
-	AuthStore         auth.Store         // AuthStore resolves users for auth.
-	Users             *users.Service     // Users provides user operations.
-	Exercises         *exercises.Service // Exercises provides exercise operations.
-	Workouts          *workouts.Service  // Workouts provides workout operations.
-	Templates         *templates.Service // Templates provides template operations.
-	Trainings         *trainings.Service // Trainings provides training operations.
-	Logger            *slog.Logger       // Logger reports server activity.
-	AuthHeader        string             // AuthHeader specifies the proxy auth header.
-	AllowRegistration bool               // AllowRegistration toggles self-serve user creation.
-	AutoCreateUsers   bool               // AutoCreateUsers toggles proxy-driven user creation.
+	Origin            string                 // Origin is used for CORS configuration.
+	Version           string                 // Version is the build version string.
+	Commit            string                 // Commit is the build commit SHA.
+	HealthStore       db.HealthChecker       // HealthStore supports health checks.
+	AuthStore         auth.Store             // AuthStore resolves users for auth.
+	Users             *users.Service         // Users provides user operations.
+	Sessions          *sessions.Service      // Sessions issues and revokes login sessions.
+	Tokens            *tokens.Service        // Tokens manages personal API tokens.
+	Accounts          *accounts.Service      // Accounts handles password resets and email verification.
+	Invitations       *invitations.Service   // Invitations issues invite links for closed registration.
+	Exercises         *exercises.Service     // Exercises provides exercise operations.
+	Workouts          *workouts.Service      // Workouts provides workout operations.
+	Templates         *templates.Service     // Templates provides template operations.
+	Trainings         *trainings.Service     // Trainings provides training operations.
+	Stats             *stats.Service         // Stats aggregates training totals, trends and streaks.
+	Pacing            *pacing.Service        // Pacing compares workout targets with logged durations.
+	Summary           *summary.Service       // Summary renders training summaries through templates.
+	Classes           *classes.Service       // Classes runs group trainings driven by a coach.
+	Audit             *audit.Service         // Audit persists and lists audit events.
+	Privacy           *privacy.Service       // Privacy exports and deletes personal data.
+	Coaching          *coaching.Service      // Coaching links coaches with athletes.
+	Organizations     *organizations.Service // Organizations groups users around shared libraries.
+	OIDC              *oidc.Provider         // OIDC is set when Motus acts as an OpenID Connect relying party.
+	Logger            *slog.Logger           // Logger reports server activity.
+	AuthHeader        string                 // AuthHeader specifies the proxy auth header.
+	ProxyTrust        *auth.ProxyTrust       // ProxyTrust restricts who may set the proxy auth header.
+	AllowRegistration bool                   // AllowRegistration toggles self-serve user creation.
+	AutoCreateUsers   bool                   // AutoCreateUsers toggles proxy-driven user creation.
+	RequireAdminTOTP  bool                   // RequireAdminTOTP withholds admin rights from local admins without two-factor authentication.
+	CookiePath        string                 // CookiePath scopes the session cookie to the route prefix.
+	SecureCookies     bool                   // SecureCookies marks the session cookie as Secure.
 }
 
 // apiError is a generic error response.
@@ -48,8 +85,14 @@ func NewAPI(
 	store *db.Store,
 	logger *slog.Logger,
 	authHeader, origin, version, commit string,
-	allowRegistration, autoCreateUsers bool,
+	allowRegistration, autoCreateUsers, requireAdminTOTP bool,
+	sessionTTL time.Duration,
+	oidcProvider *oidc.Provider,
+	proxyTrust *auth.ProxyTrust,
+	mail mailer.Mailer,
 ) *API {
+	cookiePath, secureCookies := cookieScope(origin)
+	trainingsService := trainings.New(store, sounds.URLByKey)
 	return &API{
 		Origin:            origin,
 		Version:           version,
@@ -57,17 +100,43 @@ func NewAPI(
 		HealthStore:       store,
 		AuthStore:         store,
 		Users:             users.New(store, authHeader, allowRegistration),
+		Sessions:          sessions.New(store, sessionTTL),
+		Tokens:            tokens.New(store),
+		Accounts:          accounts.New(store, mail, origin),
+		Invitations:       invitations.New(store, origin),
 		Exercises:         exercises.New(store),
 		Workouts:          workouts.New(store),
 		Templates:         templates.New(store),
-		Trainings:         trainings.New(store, sounds.URLByKey),
+		Trainings:         trainingsService,
+		Stats:             stats.New(store),
+		Pacing:            pacing.New(store),
+		Summary:           summary.New(store),
+		Classes:           classes.New(store, trainingsService),
+		Audit:             audit.New(store),
+		Privacy:           privacy.New(store),
+		Coaching:          coaching.New(store),
+		Organizations:     organizations.New(store),
+		OIDC:              oidcProvider,
 		Logger:            logger,
 		AuthHeader:        authHeader,
+		ProxyTrust:        proxyTrust,
 		AllowRegistration: allowRegistration,
 		AutoCreateUsers:   autoCreateUsers,
+		RequireAdminTOTP:  requireAdminTOTP,
+		CookiePath:        cookiePath,
+		SecureCookies:     secureCookies,
 	}
 }
 
+// cookieScope derives the cookie path and Secure flag from the site root.
+func cookieScope(origin string) (path string, secure bool) {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return "/", true
+	}
+	return utils.DefaultIfZero(strings.TrimRight(u.Path, "/"), "/"), u.Scheme != "http"
+}
+
 // respondJSON writes a JSON response.
 func (a *API) respondJSON(w http.ResponseWriter, status int, v any) {
 	if err := encode(w, status, v); err != nil {
@@ -93,9 +162,40 @@ func (a *API) logRequestError(r *http.Request, event, message string, err error)
 	)
 }
 
-// resolveUserID selects the user id from auth header or request payload.
-func (a *API) resolveUserID(r *http.Request, fallback string) (string, error) {
-	return auth.ResolveUserID(r, a.AuthStore, a.AuthHeader, a.AutoCreateUsers, fallback)
+// ResolveUserID returns the authenticated user id from the proxy header or session cookie.
+func (a *API) ResolveUserID(r *http.Request) (string, error) {
+	return auth.ResolveUserID(r, a.AuthStore, a.AuthHeader, a.ProxyTrust, a.AutoCreateUsers)
+}
+
+// ResolveActor returns the authenticated caller with its role for policy checks.
+// Local admins without two-factor authentication act as members when RequireAdminTOTP is set.
+func (a *API) ResolveActor(r *http.Request) (policy.Actor, error) {
+	actor, err := auth.ResolveActor(r, a.AuthStore, a.AuthHeader, a.ProxyTrust, a.AutoCreateUsers)
+	if err != nil || !actor.IsAdmin || !a.adminRequiresTOTP() {
+		return actor, err
+	}
+	user, err := a.AuthStore.GetUser(r.Context(), actor.UserID)
+	if err != nil {
+		return policy.Actor{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), "auth")
+	}
+	if !user.TOTPEnabled {
+		actor = policy.NewActor(actor.UserID, db.RoleMember)
+	}
+	return actor, nil
+}
+
+// resolveOptionalActor returns the caller of a public endpoint; anonymous callers get an empty actor.
+func (a *API) resolveOptionalActor(r *http.Request) (policy.Actor, error) {
+	actor, err := a.ResolveActor(r)
+	if err != nil && errpkg.IsKind(err, errpkg.ErrorUnauthorized) {
+		return policy.Actor{}, nil
+	}
+	return actor, err
+}
+
+// adminRequiresTOTP reports whether admin rights depend on two-factor authentication.
+func (a *API) adminRequiresTOTP() bool {
+	return a.RequireAdminTOTP && a.authMode() == authModeLocal
 }
 
 // WithCORS adds CORS headers to the handler.
@@ -105,8 +205,8 @@ func WithCORS(origin string, next http.Handler) http.Handler {
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", origin)
-		w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		if r.Method == http.MethodOptions {
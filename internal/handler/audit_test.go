@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/logging"
+	"github.com/gi8lino/motus/internal/service/audit"
+)
+
+// fakeAuditStore keeps audit events in memory.
+type fakeAuditStore struct {
+	mu     sync.Mutex
+	events []db.AuditEvent
+	filter db.AuditFilter
+}
+
+func (f *fakeAuditStore) CreateAuditEvent(_ context.Context, event db.AuditEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	event.ID = int64(len(f.events) + 1)
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeAuditStore) ListAuditEvents(_ context.Context, filter db.AuditFilter) ([]db.AuditEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filter = filter
+	return f.events, nil
+}
+
+func (f *fakeAuditStore) DeleteAuditEventsBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestAuditHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Logout records actor and request", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeAuditStore{}
+		api := &API{Audit: audit.New(store)}
+		req := httptest.NewRequest(http.MethodPost, "/api/logout", nil)
+		req.RemoteAddr = "192.0.2.7:4711"
+		signIn(t, api, req, "user@example.com")
+		req = req.WithContext(logging.WithRequestID(req.Context(), "req-1"))
+		rec := httptest.NewRecorder()
+
+		api.Logout().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Len(t, store.events, 1)
+		event := store.events[0]
+		assert.Equal(t, "user_logout", event.Action)
+		assert.Equal(t, "session", event.Resource)
+		assert.Equal(t, "user@example.com", event.ActorID)
+		assert.Equal(t, "req-1", event.RequestID)
+		assert.Equal(t, "192.0.2.7", event.IP)
+	})
+
+	t.Run("ListAuditEvents returns a page", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeAuditStore{events: []db.AuditEvent{
+			{ID: 3, Action: "workout_deleted"},
+			{ID: 2, Action: "workout_updated"},
+		}}
+		api := &API{Audit: audit.New(store)}
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/audit?action=workout_deleted&resourceId=w1&limit=1", nil)
+		signInAdmin(t, api, req, "admin@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ListAuditEvents().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var page audit.Page
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+		require.Len(t, page.Events, 1)
+		assert.Equal(t, "3", page.NextCursor)
+		assert.Equal(t, "workout_deleted", store.filter.Action)
+		assert.Equal(t, "w1", store.filter.ResourceID)
+	})
+
+	t.Run("ListAuditEvents rejects invalid filters", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Audit: audit.New(&fakeAuditStore{})}
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/audit?since=yesterday", nil)
+		signInAdmin(t, api, req, "admin@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ListAuditEvents().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("ListAuditEvents requires admin", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Audit: audit.New(&fakeAuditStore{})}
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/audit", nil)
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ListAuditEvents().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
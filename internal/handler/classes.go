@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/classes"
+)
+
+// StartClass opens a class for a workout of the current coach.
+func (a *API) StartClass() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[classes.StartRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		view, err := a.Classes.Start(r.Context(), actor, req)
+		if err != nil {
+			a.logRequestError(r, "start_class_failed", "start class failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("class started",
+			"event", "class_started",
+			"resource", "class",
+			"resource_id", view.ID,
+			"user_id", actor.UserID,
+			"workout_id", view.WorkoutID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "class_started",
+			Resource:   "class",
+			ResourceID: view.ID,
+			After:      map[string]any{"workoutId": view.WorkoutID, "trainingId": view.TrainingID},
+		})
+		a.respondJSON(w, http.StatusCreated, view)
+	}
+}
+
+// JoinClass adds the current user to the open class with the given join code.
+func (a *API) JoinClass() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[classes.JoinRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		view, err := a.Classes.Join(r.Context(), actor, req)
+		if err != nil {
+			a.logRequestError(r, "join_class_failed", "join class failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("class joined",
+			"event", "class_joined",
+			"resource", "class",
+			"resource_id", view.ID,
+			"user_id", actor.UserID,
+		)
+		a.respondJSON(w, http.StatusOK, view)
+	}
+}
+
+// GetClass returns a class for its coach or a participant.
+func (a *API) GetClass() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		view, err := a.Classes.Get(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "get_class_failed", "get class failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, view)
+	}
+}
+
+// ClassEvents streams the coach's timer of a class to its participants as Server-Sent Events.
+func (a *API) ClassEvents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		state, events, err := a.Classes.Follow(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "follow_class_failed", "follow class failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.streamTraining(w, r, state, events)
+	}
+}
+
+// LeaveClass drops the current user out of a class and logs what they trained so far.
+func (a *API) LeaveClass() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[classes.LeaveRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		participant, err := a.Classes.Leave(r.Context(), actor, r.PathValue("id"), req)
+		if err != nil {
+			a.logRequestError(r, "leave_class_failed", "leave class failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("class left",
+			"event", "class_left",
+			"resource", "class",
+			"resource_id", participant.ClassID,
+			"user_id", actor.UserID,
+			"training_id", participant.TrainingID,
+		)
+		a.respondJSON(w, http.StatusOK, participant)
+	}
+}
+
+// SubmitClassResults stores the exercise results the current user recorded so far in a class.
+func (a *API) SubmitClassResults() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[classes.ResultsRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		if err := a.Classes.SubmitResults(r.Context(), actor, r.PathValue("id"), req); err != nil {
+			a.logRequestError(r, "submit_class_results_failed", "submit class results failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// EndClass closes a class and writes the training logs of its participants.
+func (a *API) EndClass() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		view, err := a.Classes.End(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "end_class_failed", "end class failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("class ended",
+			"event", "class_ended",
+			"resource", "class",
+			"resource_id", view.ID,
+			"user_id", actor.UserID,
+			"participants", len(view.Participants),
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "class_ended",
+			Resource:   "class",
+			ResourceID: view.ID,
+			After:      map[string]any{"participants": len(view.Participants)},
+		})
+		a.respondJSON(w, http.StatusOK, view)
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/classes"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/sounds"
+	"github.com/gi8lino/motus/internal/service/trainings"
+)
+
+// fakeClassStore keeps one class with its participants and counts written logs.
+type fakeClassStore struct {
+	class        *db.Class
+	participants map[string]db.ClassParticipant
+}
+
+func (f *fakeClassStore) CreateClass(_ context.Context, class db.Class) error {
+	f.class = &class
+	return nil
+}
+
+func (f *fakeClassStore) GetClass(_ context.Context, id string) (*db.Class, error) {
+	if f.class == nil || f.class.ID != id {
+		return nil, db.ErrClassNotFound
+	}
+	class := *f.class
+	return &class, nil
+}
+
+func (f *fakeClassStore) OpenClassByCode(ctx context.Context, code string) (*db.Class, error) {
+	if f.class == nil || f.class.JoinCode != code || f.class.EndedAt != nil {
+		return nil, db.ErrClassNotFound
+	}
+	return f.GetClass(ctx, f.class.ID)
+}
+
+func (f *fakeClassStore) EndClass(_ context.Context, _ string, at time.Time) error {
+	f.class.EndedAt = &at
+	return nil
+}
+
+func (f *fakeClassStore) AddClassParticipant(_ context.Context, participant db.ClassParticipant) error {
+	f.participants[participant.UserID] = participant
+	return nil
+}
+
+func (f *fakeClassStore) GetClassParticipant(_ context.Context, _, userID string) (*db.ClassParticipant, error) {
+	participant, ok := f.participants[userID]
+	if !ok {
+		return nil, db.ErrClassParticipantNotFound
+	}
+	return &participant, nil
+}
+
+func (f *fakeClassStore) ListClassParticipants(context.Context, string) ([]db.ClassParticipant, error) {
+	var participants []db.ClassParticipant
+	for _, participant := range f.participants {
+		participants = append(participants, participant)
+	}
+	return participants, nil
+}
+
+func (f *fakeClassStore) SeeClassParticipant(_ context.Context, _, userID string, at time.Time, index int) error {
+	participant := f.participants[userID]
+	participant.SeenAt = at
+	participant.SeenIndex = index
+	f.participants[userID] = participant
+	return nil
+}
+
+func (f *fakeClassStore) SaveClassResults(_ context.Context, _, userID string, results json.RawMessage, at time.Time, index int) error {
+	participant := f.participants[userID]
+	participant.Results = results
+	participant.SeenAt = at
+	participant.SeenIndex = index
+	f.participants[userID] = participant
+	return nil
+}
+
+func (f *fakeClassStore) LeaveClass(_ context.Context, _, userID, trainingID string, at time.Time) error {
+	participant := f.participants[userID]
+	participant.LeftAt = &at
+	participant.TrainingID = trainingID
+	f.participants[userID] = participant
+	return nil
+}
+
+func (f *fakeClassStore) AssignWorkout(_ context.Context, workoutID, athleteID, assignedBy, name string) (*db.Workout, error) {
+	return &db.Workout{ID: workoutID + "-" + athleteID, UserID: athleteID, Name: name, AssignedBy: assignedBy}, nil
+}
+
+func TestClassesHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Coach runs a class for a participant", func(t *testing.T) {
+		t.Parallel()
+
+		trainingStore := &fakeTrainingStore{workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
+			return &db.Workout{ID: "w1", UserID: "coach@example.com", Name: "Class", Steps: []db.WorkoutStep{
+				{ID: "s1", Type: "pause", Name: "Warmup", EstimatedSeconds: 60},
+			}}, nil
+		}}
+		var logged []db.TrainingLog
+		trainingStore.recordTrainingFn = func(_ context.Context, log db.TrainingLog, _ []db.TrainingStepLog) (bool, error) {
+			logged = append(logged, log)
+			return true, nil
+		}
+		classStore := &fakeClassStore{participants: map[string]db.ClassParticipant{}}
+		timer := trainings.New(trainingStore, sounds.URLByKey)
+		api := &API{Trainings: timer, Classes: classes.New(classStore, timer)}
+		asCoach := func(req *http.Request) {
+			signIn(t, api, req, "coach@example.com")
+			store := api.AuthStore.(*fakeSessionStore)
+			store.mu.Lock()
+			store.roles["coach@example.com"] = db.RoleCoach
+			store.mu.Unlock()
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/classes", strings.NewReader(`{"workoutId":"w1"}`))
+		asCoach(req)
+		rec := httptest.NewRecorder()
+		api.StartClass().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var started classes.ClassView
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&started))
+		require.NotEmpty(t, started.JoinCode)
+
+		req = httptest.NewRequest(http.MethodPost, "/api/classes/join", strings.NewReader(`{"code":"`+started.JoinCode+`"}`))
+		signIn(t, api, req, "athlete@example.com")
+		rec = httptest.NewRecorder()
+		api.JoinClass().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		_, err := timer.Start(context.Background(), policy.NewActor("coach@example.com", db.RoleCoach), started.TrainingID)
+		require.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodPut, "/api/classes/"+started.ID+"/results", strings.NewReader(`{"steps":[{"id":"s1","results":[{"exerciseId":"squat","name":"Squat","reps":8,"weight":60,"weightUnit":"kg","status":"completed"}]}]}`))
+		req.SetPathValue("id", started.ID)
+		signIn(t, api, req, "athlete@example.com")
+		rec = httptest.NewRecorder()
+		api.SubmitClassResults().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.NotEmpty(t, classStore.participants["athlete@example.com"].Results)
+
+		req = httptest.NewRequest(http.MethodPost, "/api/classes/"+started.ID+"/end", nil)
+		req.SetPathValue("id", started.ID)
+		asCoach(req)
+		rec = httptest.NewRecorder()
+		api.EndClass().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var ended classes.ClassView
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&ended))
+		require.NotNil(t, ended.EndedAt)
+		require.Len(t, ended.Participants, 1)
+		assert.NotEmpty(t, ended.Participants[0].TrainingID)
+		require.Len(t, logged, 1)
+		assert.Equal(t, "athlete@example.com", logged[0].UserID)
+		assert.Equal(t, "w1-athlete@example.com", logged[0].WorkoutID)
+		require.NotEmpty(t, trainingStore.records)
+		assert.Equal(t, "athlete@example.com", trainingStore.records[0].UserID)
+		assert.Nil(t, trainingStore.active)
+	})
+
+	t.Run("Members cannot start classes", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Classes: classes.New(&fakeClassStore{}, trainings.New(&fakeTrainingStore{}, sounds.URLByKey))}
+		req := httptest.NewRequest(http.MethodPost, "/api/classes", strings.NewReader(`{"workoutId":"w1"}`))
+		signIn(t, api, req, "member@example.com")
+		rec := httptest.NewRecorder()
+
+		api.StartClass().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
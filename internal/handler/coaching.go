@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/coaching"
+)
+
+// ListCoachLinks returns the coach links of the current user, including pending invitations.
+func (a *API) ListCoachLinks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		links, err := a.Coaching.List(r.Context(), actor)
+		if err != nil {
+			a.logRequestError(r, "list_coach_links_failed", "list coach links failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, links)
+	}
+}
+
+// InviteAthlete asks an athlete to accept the current user as coach.
+func (a *API) InviteAthlete() http.HandlerFunc {
+	type inviteAthleteRequest struct {
+		AthleteID string `json:"athleteId"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[inviteAthleteRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		link, err := a.Coaching.Invite(r.Context(), actor, req.AthleteID)
+		if err != nil {
+			a.logRequestError(r, "invite_athlete_failed", "invite athlete failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("athlete invited",
+			"event", "athlete_invited",
+			"resource", "coach_link",
+			"resource_id", link.ID,
+			"user_id", actor.UserID,
+			"athlete_id", link.AthleteID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "athlete_invited",
+			Resource:   "coach_link",
+			ResourceID: link.ID,
+			After:      map[string]any{"athleteId": link.AthleteID},
+		})
+		a.respondJSON(w, http.StatusCreated, link)
+	}
+}
+
+// AcceptCoachLink lets the invited athlete accept a coach.
+func (a *API) AcceptCoachLink() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		link, err := a.Coaching.Accept(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "accept_coach_link_failed", "accept coach link failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("coach link accepted",
+			"event", "coach_link_accepted",
+			"resource", "coach_link",
+			"resource_id", link.ID,
+			"user_id", actor.UserID,
+			"coach_id", link.CoachID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "coach_link_accepted",
+			Resource:   "coach_link",
+			ResourceID: link.ID,
+			After:      map[string]any{"coachId": link.CoachID},
+		})
+		a.respondJSON(w, http.StatusOK, link)
+	}
+}
+
+// RemoveCoachLink ends a coaching relationship or declines an invitation.
+func (a *API) RemoveCoachLink() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		link, err := a.Coaching.Remove(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "remove_coach_link_failed", "remove coach link failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("coach link removed",
+			"event", "coach_link_removed",
+			"resource", "coach_link",
+			"resource_id", link.ID,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "coach_link_removed",
+			Resource:   "coach_link",
+			ResourceID: link.ID,
+			Before:     map[string]any{"coachId": link.CoachID, "athleteId": link.AthleteID, "status": link.Status},
+		})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// AssignWorkout copies a workout into the library of a coached athlete.
+func (a *API) AssignWorkout() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[coaching.AssignRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		workout, err := a.Coaching.Assign(r.Context(), actor, r.PathValue("id"), req)
+		if err != nil {
+			a.logRequestError(r, "assign_workout_failed", "assign workout failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("workout assigned",
+			"event", "workout_assigned",
+			"resource", "workout",
+			"resource_id", workout.ID,
+			"user_id", actor.UserID,
+			"athlete_id", workout.UserID,
+			"source_workout_id", req.WorkoutID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "workout_assigned",
+			Resource:   "workout",
+			ResourceID: workout.ID,
+			After:      map[string]any{"athleteId": workout.UserID, "sourceWorkoutId": req.WorkoutID, "name": workout.Name},
+		})
+		a.respondJSON(w, http.StatusCreated, workout)
+	}
+}
+
+// ListAthleteHistory returns the recent trainings of a coached athlete.
+func (a *API) ListAthleteHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		items, err := a.Coaching.History(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "list_athlete_history_failed", "list athlete history failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, items)
+	}
+}
+
+// AthleteTrainingSteps returns the step timings of a training of a coached athlete.
+func (a *API) AthleteTrainingSteps() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		steps, err := a.Coaching.StepTimings(r.Context(), actor, r.PathValue("id"), r.PathValue("trainingId"))
+		if err != nil {
+			a.logRequestError(r, "fetch_athlete_step_timings_failed", "fetch athlete step timings failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, steps)
+	}
+}
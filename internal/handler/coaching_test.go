@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/coaching"
+)
+
+// fakeCoachingStore keeps a single pending link from coach@example.com to athlete@example.com.
+type fakeCoachingStore struct {
+	created  *db.CoachLink
+	accepted bool
+}
+
+func (f *fakeCoachingStore) OrgRole(context.Context, string, string) (string, error) { return "", nil }
+
+func (f *fakeCoachingStore) ActiveOrganization(context.Context, string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeCoachingStore) GetUser(_ context.Context, id string) (*db.User, error) {
+	return &db.User{ID: id, Role: db.RoleMember}, nil
+}
+
+func (f *fakeCoachingStore) CreateCoachLink(_ context.Context, link db.CoachLink) error {
+	f.created = &link
+	return nil
+}
+
+func (f *fakeCoachingStore) ListCoachLinks(context.Context, string) ([]db.CoachLink, error) {
+	return nil, nil
+}
+
+func (f *fakeCoachingStore) GetCoachLink(_ context.Context, id string) (*db.CoachLink, error) {
+	return &db.CoachLink{ID: id, CoachID: "coach@example.com", AthleteID: "athlete@example.com", Status: db.CoachLinkPending}, nil
+}
+
+func (f *fakeCoachingStore) AcceptCoachLink(context.Context, string, time.Time) error {
+	f.accepted = true
+	return nil
+}
+
+func (f *fakeCoachingStore) DeleteCoachLink(context.Context, string) error { return nil }
+
+func (f *fakeCoachingStore) IsCoachOf(context.Context, string, string) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeCoachingStore) WorkoutWithSteps(context.Context, string) (*db.Workout, error) {
+	return nil, db.ErrWorkoutNotFound
+}
+
+func (f *fakeCoachingStore) AssignWorkout(context.Context, string, string, string, string) (*db.Workout, error) {
+	return nil, db.ErrWorkoutNotFound
+}
+
+func (f *fakeCoachingStore) GetTraining(context.Context, string) (*db.TrainingLog, error) {
+	return nil, db.ErrTrainingNotFound
+}
+
+func (f *fakeCoachingStore) TrainingHistory(context.Context, db.TrainingHistoryFilter) ([]db.TrainingLog, error) {
+	return nil, nil
+}
+
+func (f *fakeCoachingStore) StepTimingsForTrainings(context.Context, []string) (map[string][]db.TrainingStepLog, error) {
+	return nil, nil
+}
+
+func (f *fakeCoachingStore) TrainingStepTimings(context.Context, string) ([]db.TrainingStepLog, error) {
+	return nil, nil
+}
+
+func TestCoachingHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("InviteAthlete creates a pending link", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeCoachingStore{}
+		api := &API{Coaching: coaching.New(store)}
+		req := httptest.NewRequest(http.MethodPost, "/api/coaching/links", strings.NewReader(`{"athleteId":"athlete@example.com"}`))
+		signInAdmin(t, api, req, "admin@example.com")
+		rec := httptest.NewRecorder()
+
+		api.InviteAthlete().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var link db.CoachLink
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &link))
+		assert.Equal(t, db.CoachLinkPending, link.Status)
+		assert.Equal(t, "athlete@example.com", link.AthleteID)
+		require.NotNil(t, store.created)
+	})
+
+	t.Run("AcceptCoachLink is reserved for the athlete", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeCoachingStore{}
+		api := &API{Coaching: coaching.New(store)}
+		req := httptest.NewRequest(http.MethodPost, "/api/coaching/links/l1/accept", nil)
+		req.SetPathValue("id", "l1")
+		signIn(t, api, req, "coach@example.com")
+		rec := httptest.NewRecorder()
+
+		api.AcceptCoachLink().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.False(t, store.accepted)
+	})
+
+	t.Run("ListAthleteHistory requires a coach link", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Coaching: coaching.New(&fakeCoachingStore{})}
+		req := httptest.NewRequest(http.MethodGet, "/api/coaching/athletes/athlete@example.com/trainings/history", nil)
+		req.SetPathValue("id", "athlete@example.com")
+		signIn(t, api, req, "member@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ListAthleteHistory().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
@@ -1,21 +1,37 @@
 package handler
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/db"
+)
 
 // configResponse describes runtime settings exposed to the SPA.
 type configResponse struct {
+	AuthMode          string `json:"authMode"`          // AuthMode is one of local, proxy or oidc.
 	AuthHeaderEnabled bool   `json:"authHeaderEnabled"` // AuthHeaderEnabled indicates proxy auth usage.
 	AllowRegistration bool   `json:"allowRegistration"` // AllowRegistration enables local sign-up.
+	RequireAdminTOTP  bool   `json:"requireAdminTotp"`  // RequireAdminTOTP limits admin rights to accounts with two-factor authentication.
 	Version           string `json:"version"`           // Version is the build version string.
 	Commit            string `json:"commit"`            // Commit is the build commit SHA.
 }
 
+// Auth modes reported to the SPA.
+const (
+	authModeLocal = "local"
+	authModeProxy = "proxy"
+	authModeOIDC  = "oidc"
+)
+
 // Config returns runtime configuration for the SPA.
 func (a *API) Config() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		mode := a.authMode()
 		a.respondJSON(w, http.StatusOK, configResponse{
-			AuthHeaderEnabled: a.AuthHeader != "",
+			AuthMode:          mode,
+			AuthHeaderEnabled: mode == authModeProxy,
 			AllowRegistration: a.AllowRegistration,
+			RequireAdminTOTP:  a.adminRequiresTOTP(),
 			Version:           a.Version,
 			Commit:            a.Commit,
 		})
@@ -25,10 +41,10 @@ func (a *API) Config() http.HandlerFunc {
 // CurrentUser resolves the authenticated user.
 func (a *API) CurrentUser() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID, err := a.resolveUserID(r, "")
+		userID, err := a.ResolveUserID(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
@@ -39,6 +55,24 @@ func (a *API) CurrentUser() http.HandlerFunc {
 			return
 		}
 
+		// Report the effective role so the SPA hides admin views that would be rejected.
+		if user.IsAdmin && !user.TOTPEnabled && a.adminRequiresTOTP() {
+			user.SetRole(db.RoleMember)
+		}
+
+		a.refreshSession(w, r)
 		a.respondJSON(w, http.StatusOK, user)
 	}
 }
+
+// authMode reports which login flow is active.
+func (a *API) authMode() string {
+	switch {
+	case a.AuthHeader != "":
+		return authModeProxy
+	case a.OIDC != nil:
+		return authModeOIDC
+	default:
+		return authModeLocal
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/oidc"
 	"github.com/gi8lino/motus/internal/service/users"
 )
 
@@ -37,6 +38,7 @@ func TestConfig(t *testing.T) {
 
 		var payload configResponse
 		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.Equal(t, "proxy", payload.AuthMode)
 		assert.True(t, payload.AuthHeaderEnabled)
 		assert.True(t, payload.AllowRegistration)
 		assert.Equal(t, "v1", payload.Version)
@@ -44,6 +46,14 @@ func TestConfig(t *testing.T) {
 	})
 }
 
+func TestAuthMode(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "local", (&API{}).authMode())
+	assert.Equal(t, "proxy", (&API{AuthHeader: "X-User"}).authMode())
+	assert.Equal(t, "oidc", (&API{OIDC: &oidc.Provider{}}).authMode())
+}
+
 func TestCurrentUser(t *testing.T) {
 	t.Parallel()
 	t.Run("Returns current user", func(t *testing.T) {
@@ -57,7 +67,7 @@ func TestCurrentUser(t *testing.T) {
 		api := &API{Users: users.New(store, "", false)}
 		h := api.CurrentUser()
 		req := httptest.NewRequest(http.MethodGet, "/api/users/current", nil)
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -80,7 +90,7 @@ func TestCurrentUser(t *testing.T) {
 		api := &API{Users: users.New(store, "", false), Logger: logger}
 		h := api.CurrentUser()
 		req := httptest.NewRequest(http.MethodGet, "/api/users/current", nil)
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -88,7 +98,7 @@ func TestCurrentUser(t *testing.T) {
 		require.Equal(t, http.StatusUnauthorized, rec.Code)
 	})
 
-	t.Run("Returns bad request when missing user", func(t *testing.T) {
+	t.Run("Returns unauthorized when missing session", func(t *testing.T) {
 		t.Parallel()
 		logger := slog.New(slog.NewTextHandler(&strings.Builder{}, nil))
 		api := &API{Users: users.New(&fakeUserStore{}, "", false), Logger: logger}
@@ -98,6 +108,6 @@ func TestCurrentUser(t *testing.T) {
 
 		h.ServeHTTP(rec, req)
 
-		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
 	})
 }
@@ -1,18 +1,23 @@
 package handler
 
-import "net/http"
+import (
+	"net/http"
 
-// ListExercises returns the exercise catalog for the current user.
+	"github.com/gi8lino/motus/internal/service/audit"
+)
+
+// ListExercises returns the exercise catalog for the current user and organization.
+// The optional org query parameter picks another organization of the caller.
 func (a *API) ListExercises() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID, err := a.resolveUserID(r, "")
+		actor, err := a.ResolveActor(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
-		items, err := a.Exercises.List(r.Context(), userID)
+		items, err := a.Exercises.List(r.Context(), actor, r.URL.Query().Get("org"))
 		if err != nil {
 			a.logRequestError(r, "list_exercises_failed", "list exercises failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -28,12 +33,13 @@ func (a *API) CreateExercise() http.HandlerFunc {
 	type createExerciseRequest struct {
 		Name   string `json:"name"`
 		IsCore bool   `json:"isCore"`
+		OrgID  string `json:"orgId"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID, err := a.resolveUserID(r, "")
+		actor, err := a.ResolveActor(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
@@ -44,7 +50,7 @@ func (a *API) CreateExercise() http.HandlerFunc {
 			return
 		}
 
-		exercise, err := a.Exercises.Create(r.Context(), userID, req.Name, req.IsCore)
+		exercise, err := a.Exercises.Create(r.Context(), actor, req.Name, req.IsCore, req.OrgID)
 		if err != nil {
 			a.logRequestError(r, "create_exercise_failed", "create exercise failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -55,9 +61,17 @@ func (a *API) CreateExercise() http.HandlerFunc {
 			"event", "exercise_created",
 			"resource", "exercise",
 			"resource_id", exercise.ID,
-			"user_id", userID,
+			"user_id", actor.UserID,
 			"is_core", exercise.IsCore,
+			"org_id", exercise.OrgID,
 		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "exercise_created",
+			Resource:   "exercise",
+			ResourceID: exercise.ID,
+			After:      map[string]any{"name": exercise.Name, "isCore": exercise.IsCore, "orgId": exercise.OrgID},
+		})
 		a.respondJSON(w, http.StatusCreated, exercise)
 	}
 }
@@ -70,10 +84,10 @@ func (a *API) UpdateExercise() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
 
-		userID, err := a.resolveUserID(r, "")
+		actor, err := a.ResolveActor(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
@@ -84,7 +98,7 @@ func (a *API) UpdateExercise() http.HandlerFunc {
 			return
 		}
 
-		updated, err := a.Exercises.Update(r.Context(), userID, id, req.Name)
+		updated, err := a.Exercises.Update(r.Context(), actor, id, req.Name)
 		if err != nil {
 			a.logRequestError(r, "update_exercise_failed", "update exercise failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -95,8 +109,15 @@ func (a *API) UpdateExercise() http.HandlerFunc {
 			"event", "exercise_updated",
 			"resource", "exercise",
 			"resource_id", updated.ID,
-			"user_id", userID,
+			"user_id", actor.UserID,
 		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "exercise_updated",
+			Resource:   "exercise",
+			ResourceID: updated.ID,
+			After:      map[string]any{"name": updated.Name},
+		})
 		a.respondJSON(w, http.StatusOK, updated)
 	}
 }
@@ -106,14 +127,14 @@ func (a *API) DeleteExercise() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
 
-		userID, err := a.resolveUserID(r, "")
+		actor, err := a.ResolveActor(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "exercise delete user", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
-		if err := a.Exercises.Delete(r.Context(), userID, id); err != nil {
+		if err := a.Exercises.Delete(r.Context(), actor, id); err != nil {
 			a.logRequestError(r, "delete_exercise_failed", "delete exercise failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
@@ -123,8 +144,9 @@ func (a *API) DeleteExercise() http.HandlerFunc {
 			"event", "exercise_deleted",
 			"resource", "exercise",
 			"resource_id", id,
-			"user_id", userID,
+			"user_id", actor.UserID,
 		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "exercise_deleted", Resource: "exercise", ResourceID: id})
 		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
 	}
 }
@@ -142,6 +164,7 @@ func (a *API) BackfillExercises() http.HandlerFunc {
 			"event", "exercise_backfill",
 			"resource", "exercise",
 		)
+		a.recordAudit(r, audit.Event{Action: "exercise_backfill", Resource: "exercise"})
 		a.respondJSON(w, http.StatusOK, statusResponse{Status: "ok"})
 	}
 }
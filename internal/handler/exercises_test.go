@@ -19,8 +19,10 @@ type fakeExercisesStore struct {
 	getUserFn                func(context.Context, string) (*db.User, error)
 	updateUserNameFn         func(context.Context, string, string) error
 	createUserFn             func(context.Context, string, string, string) (*db.User, error)
-	listExercisesFn          func(context.Context, string) ([]db.Exercise, error)
+	listExercisesFn          func(context.Context, string, string) ([]db.Exercise, error)
 	createExerciseFn         func(context.Context, string, string, bool) (*db.Exercise, error)
+	createOrgExerciseFn      func(context.Context, string, string) (*db.Exercise, error)
+	orgRoles                 map[string]string // orgRoles maps "org/user" to an organization role.
 	getExerciseFn            func(context.Context, string) (*db.Exercise, error)
 	renameExerciseFn         func(context.Context, string, string) (*db.Exercise, error)
 	replaceExerciseForUserFn func(context.Context, string, string, string, string) error
@@ -49,11 +51,26 @@ func (f *fakeExercisesStore) CreateUser(ctx context.Context, email, avatarURL, p
 	return f.createUserFn(ctx, email, avatarURL, passwordHash)
 }
 
-func (f *fakeExercisesStore) ListExercises(ctx context.Context, userID string) ([]db.Exercise, error) {
+func (f *fakeExercisesStore) OrgRole(_ context.Context, orgID, userID string) (string, error) {
+	return f.orgRoles[orgID+"/"+userID], nil
+}
+
+func (f *fakeExercisesStore) ActiveOrganization(context.Context, string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeExercisesStore) ListExercises(ctx context.Context, userID, orgID string) ([]db.Exercise, error) {
 	if f.listExercisesFn == nil {
 		return nil, nil
 	}
-	return f.listExercisesFn(ctx, userID)
+	return f.listExercisesFn(ctx, userID, orgID)
+}
+
+func (f *fakeExercisesStore) CreateOrgExercise(ctx context.Context, name, orgID string) (*db.Exercise, error) {
+	if f.createOrgExerciseFn == nil {
+		return nil, nil
+	}
+	return f.createOrgExerciseFn(ctx, name, orgID)
 }
 
 func (f *fakeExercisesStore) CreateExercise(ctx context.Context, name, userID string, isCore bool) (*db.Exercise, error) {
@@ -102,13 +119,13 @@ func TestExercisesHandlers(t *testing.T) {
 	t.Parallel()
 	t.Run("List exercises", func(t *testing.T) {
 		t.Parallel()
-		store := &fakeExercisesStore{listExercisesFn: func(_ context.Context, userID string) ([]db.Exercise, error) {
+		store := &fakeExercisesStore{listExercisesFn: func(_ context.Context, userID, _ string) ([]db.Exercise, error) {
 			return []db.Exercise{{ID: "ex1", Name: "Burpee"}}, nil
 		}}
 		api := &API{Exercises: exercises.New(store)}
 		h := api.ListExercises()
 		req := httptest.NewRequest(http.MethodGet, "/api/exercises", nil)
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -134,7 +151,7 @@ func TestExercisesHandlers(t *testing.T) {
 		h := api.CreateExercise()
 		body := strings.NewReader(`{"name":"Burpee","isCore":false}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/exercises", body)
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -145,11 +162,32 @@ func TestExercisesHandlers(t *testing.T) {
 		assert.Equal(t, "ex1", payload.ID)
 	})
 
+	t.Run("Admin without two-factor cannot create core exercise", func(t *testing.T) {
+		t.Parallel()
+		called := false
+		store := &fakeExercisesStore{
+			createExerciseFn: func(context.Context, string, string, bool) (*db.Exercise, error) {
+				called = true
+				return &db.Exercise{ID: "ex1", Name: "Burpee", IsCore: true}, nil
+			},
+		}
+		api := &API{Exercises: exercises.New(store), RequireAdminTOTP: true}
+		body := strings.NewReader(`{"name":"Burpee","isCore":true}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/exercises", body)
+		signInAdmin(t, api, req, "admin@example.com")
+		rec := httptest.NewRecorder()
+
+		api.CreateExercise().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.False(t, called)
+	})
+
 	t.Run("Update exercise", func(t *testing.T) {
 		t.Parallel()
 		store := &fakeExercisesStore{
 			getUserFn: func(context.Context, string) (*db.User, error) {
-				return &db.User{ID: "user@example.com", IsAdmin: true}, nil
+				return &db.User{ID: "user@example.com", Role: db.RoleAdmin}, nil
 			},
 			getExerciseFn: func(context.Context, string) (*db.Exercise, error) {
 				return &db.Exercise{ID: "ex1", Name: "Burpee", OwnerUserID: "user@example.com"}, nil
@@ -163,7 +201,7 @@ func TestExercisesHandlers(t *testing.T) {
 		body := strings.NewReader(`{"name":"Burpee 2"}`)
 		req := httptest.NewRequest(http.MethodPut, "/api/exercises/ex1", body)
 		req.SetPathValue("id", "ex1")
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -178,7 +216,7 @@ func TestExercisesHandlers(t *testing.T) {
 		t.Parallel()
 		store := &fakeExercisesStore{
 			getUserFn: func(context.Context, string) (*db.User, error) {
-				return &db.User{ID: "user@example.com", IsAdmin: true}, nil
+				return &db.User{ID: "user@example.com", Role: db.RoleAdmin}, nil
 			},
 			getExerciseFn: func(context.Context, string) (*db.Exercise, error) {
 				return &db.Exercise{ID: "ex1", Name: "Burpee", OwnerUserID: "user@example.com"}, nil
@@ -191,7 +229,7 @@ func TestExercisesHandlers(t *testing.T) {
 		h := api.DeleteExercise()
 		req := httptest.NewRequest(http.MethodDelete, "/api/exercises/ex1", nil)
 		req.SetPathValue("id", "ex1")
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/invitations"
+)
+
+// ListInvitations returns all invitations that were not revoked for admins.
+func (a *API) ListInvitations() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		items, err := a.Invitations.List(r.Context(), actor)
+		if err != nil {
+			a.logRequestError(r, "list_invitations_failed", "list invitations failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, items)
+	}
+}
+
+// CreateInvitation issues an invitation and returns its token and link once.
+func (a *API) CreateInvitation() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[invitations.CreateRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		created, err := a.Invitations.Create(r.Context(), actor, req)
+		if err != nil {
+			a.logRequestError(r, "create_invitation_failed", "create invitation failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("invitation created",
+			"event", "invitation_created",
+			"resource", "invitation",
+			"resource_id", created.ID,
+			"user_id", actor.UserID,
+			"max_uses", created.MaxUses,
+			"role", created.Role,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "invitation_created",
+			Resource:   "invitation",
+			ResourceID: created.ID,
+			After:      map[string]any{"maxUses": created.MaxUses, "role": created.Role, "expiresAt": created.ExpiresAt},
+		})
+		a.respondJSON(w, http.StatusCreated, created)
+	}
+}
+
+// RevokeInvitation invalidates an invitation for admins.
+func (a *API) RevokeInvitation() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		id := r.PathValue("id")
+		if err := a.Invitations.Revoke(r.Context(), actor, id); err != nil {
+			a.logRequestError(r, "revoke_invitation_failed", "revoke invitation failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("invitation revoked",
+			"event", "invitation_revoked",
+			"resource", "invitation",
+			"resource_id", id,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "invitation_revoked", Resource: "invitation", ResourceID: id})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
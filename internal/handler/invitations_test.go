@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/invitations"
+)
+
+// fakeInvitationStore keeps invitations in memory.
+type fakeInvitationStore struct {
+	mu          sync.Mutex
+	invitations []db.Invitation
+}
+
+func (f *fakeInvitationStore) CreateInvitation(_ context.Context, invitation db.Invitation) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.invitations = append(f.invitations, invitation)
+	return nil
+}
+
+func (f *fakeInvitationStore) ListInvitations(context.Context) ([]db.Invitation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.invitations, nil
+}
+
+func (f *fakeInvitationStore) RevokeInvitation(context.Context, string) error {
+	return db.ErrInvitationNotFound
+}
+
+func TestInvitationsHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("CreateInvitation returns the link once", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeInvitationStore{}
+		api := &API{Invitations: invitations.New(store, "https://motus.example.com")}
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/invitations", strings.NewReader(`{"maxUses":5,"role":"coach"}`))
+		signInAdmin(t, api, req, "admin@example.com")
+		rec := httptest.NewRecorder()
+
+		api.CreateInvitation().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var created invitations.Created
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		assert.Equal(t, "https://motus.example.com/?invite="+created.Token, created.URL)
+		assert.Equal(t, 5, created.MaxUses)
+		assert.Equal(t, db.RoleCoach, created.Role)
+
+		listReq := httptest.NewRequest(http.MethodGet, "/api/admin/invitations", nil)
+		signInAdmin(t, api, listReq, "admin@example.com")
+		listRec := httptest.NewRecorder()
+		api.ListInvitations().ServeHTTP(listRec, listReq)
+
+		require.Equal(t, http.StatusOK, listRec.Code)
+		assert.Contains(t, listRec.Body.String(), created.ID)
+		assert.NotContains(t, listRec.Body.String(), created.Token)
+	})
+
+	t.Run("CreateInvitation requires admin", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Invitations: invitations.New(&fakeInvitationStore{}, "")}
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/invitations", strings.NewReader(`{}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.CreateInvitation().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("RevokeInvitation reports unknown ids", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Invitations: invitations.New(&fakeInvitationStore{}, "")}
+		req := httptest.NewRequest(http.MethodDelete, "/api/admin/invitations/missing", nil)
+		req.SetPathValue("id", "missing")
+		signInAdmin(t, api, req, "admin@example.com")
+		rec := httptest.NewRecorder()
+
+		api.RevokeInvitation().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gi8lino/motus/internal/auth"
+	"github.com/gi8lino/motus/internal/oidc"
+	"github.com/gi8lino/motus/internal/service/audit"
+)
+
+// oidcCookieName carries the state, nonce and PKCE verifier between redirect and callback.
+const oidcCookieName = "motus_oidc"
+
+// oidcFlowTTL bounds how long a login may stay at the issuer.
+const oidcFlowTTL = 10 * time.Minute
+
+// errOIDCDisabled is returned when OIDC routes are hit without a configured issuer.
+var errOIDCDisabled = errors.New("oidc login is not configured")
+
+// errOIDCState is returned when the callback does not match the started login.
+var errOIDCState = errors.New("oidc state mismatch")
+
+// OIDCLogin starts the authorization-code flow and redirects to the issuer.
+func (a *API) OIDCLogin() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.OIDC == nil {
+			a.respondJSON(w, http.StatusNotFound, apiError{Error: errOIDCDisabled.Error()})
+			return
+		}
+
+		flow := oidc.NewAuthRequest()
+		target, err := a.OIDC.AuthCodeURL(r.Context(), flow)
+		if err != nil {
+			a.logRequestError(r, "oidc_discovery_failed", "oidc discovery failed", err)
+			a.respondJSON(w, http.StatusBadGateway, apiError{Error: "identity provider unavailable"})
+			return
+		}
+
+		a.setOIDCCookie(w, flow)
+		http.Redirect(w, r, target, http.StatusFound)
+	}
+}
+
+// OIDCCallback completes the flow, provisions the user and starts a session.
+func (a *API) OIDCCallback() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.OIDC == nil {
+			a.respondJSON(w, http.StatusNotFound, apiError{Error: errOIDCDisabled.Error()})
+			return
+		}
+
+		flow, ok := oidcFlow(r)
+		a.clearOIDCCookie(w)
+		query := r.URL.Query()
+		if !ok || query.Get("state") != flow.State {
+			a.logRequestError(r, "oidc_state_mismatch", "oidc state mismatch", errOIDCState)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: errOIDCState.Error()})
+			return
+		}
+		if issuerErr := query.Get("error"); issuerErr != "" {
+			err := errors.New(issuerErr + ": " + query.Get("error_description"))
+			a.logRequestError(r, "oidc_login_denied", "oidc login denied", err)
+			a.respondJSON(w, http.StatusUnauthorized, apiError{Error: "login was denied by the identity provider"})
+			return
+		}
+
+		identity, err := a.OIDC.Exchange(r.Context(), query.Get("code"), flow)
+		if err != nil {
+			a.logRequestError(r, "oidc_exchange_failed", "oidc exchange failed", err)
+			status := http.StatusBadGateway
+			if errors.Is(err, oidc.ErrLogin) {
+				status = http.StatusUnauthorized
+			}
+			a.respondJSON(w, status, apiError{Error: "oidc login failed"})
+			return
+		}
+
+		user, err := a.Users.SignInExternal(r.Context(), identity.Email, identity.IsAdmin, a.AutoCreateUsers)
+		if err != nil {
+			a.logRequestError(r, "oidc_sign_in_failed", "oidc sign in failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		// Drop any session presented with the login to prevent session fixation.
+		if err := a.Sessions.Revoke(r.Context(), auth.SessionToken(r)); err != nil {
+			a.logRequestError(r, "revoke_session_failed", "revoke session failed", err)
+		}
+		if err := a.startSession(w, r, user.ID); err != nil {
+			a.logRequestError(r, "create_session_failed", "create session failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("user login",
+			"event", "user_login",
+			"resource", "user",
+			"resource_id", user.ID,
+			"user_id", user.ID,
+			"method", "oidc",
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    user.ID,
+			Action:     "user_login",
+			Resource:   "user",
+			ResourceID: user.ID,
+			After:      map[string]any{"method": "oidc"},
+		})
+		http.Redirect(w, r, a.cookiePath(), http.StatusFound)
+	}
+}
+
+// setOIDCCookie stores the pending login for the callback.
+func (a *API) setOIDCCookie(w http.ResponseWriter, flow oidc.AuthRequest) {
+	payload, _ := json.Marshal(flow)
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(payload),
+		Path:     a.cookiePath(),
+		MaxAge:   int(oidcFlowTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   a.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// clearOIDCCookie drops the pending login cookie.
+func (a *API) clearOIDCCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    "",
+		Path:     a.cookiePath(),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   a.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// oidcFlow decodes the pending login from the request cookie.
+func oidcFlow(r *http.Request) (oidc.AuthRequest, bool) {
+	cookie, err := r.Cookie(oidcCookieName)
+	if err != nil {
+		return oidc.AuthRequest{}, false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return oidc.AuthRequest{}, false
+	}
+	var flow oidc.AuthRequest
+	if err := json.Unmarshal(raw, &flow); err != nil || flow.State == "" {
+		return oidc.AuthRequest{}, false
+	}
+	return flow, true
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/auth"
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/oidc"
+	"github.com/gi8lino/motus/internal/oidc/oidctest"
+	"github.com/gi8lino/motus/internal/service/sessions"
+	"github.com/gi8lino/motus/internal/service/users"
+)
+
+// newOIDCAPI wires an API against a mock issuer with the given user store.
+func newOIDCAPI(t *testing.T, userStore *fakeUserStore, autoCreate bool) (*API, *oidctest.Issuer) {
+	t.Helper()
+	issuer := oidctest.NewIssuer(t, "motus", "secret")
+	sessionStore := newFakeSessionStore()
+	api := &API{
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		AuthStore:       sessionStore,
+		Sessions:        sessions.New(sessionStore, time.Hour),
+		Users:           users.New(userStore, "", false),
+		AutoCreateUsers: autoCreate,
+		OIDC: oidc.New(oidc.Config{
+			Issuer:       issuer.URL,
+			ClientID:     "motus",
+			ClientSecret: "secret",
+			RedirectURL:  "http://motus.local/api/auth/oidc/callback",
+			GroupsClaim:  "groups",
+			AdminGroup:   "motus-admins",
+		}, nil),
+	}
+	return api, issuer
+}
+
+// startOIDCLogin runs the login redirect and returns the issuer URL and flow cookie.
+func startOIDCLogin(t *testing.T, api *API) (string, *http.Cookie) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	api.OIDCLogin().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/auth/oidc/login", nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == oidcCookieName {
+			assert.True(t, cookie.HttpOnly)
+			return rec.Header().Get("Location"), cookie
+		}
+	}
+	t.Fatal("oidc flow cookie not set")
+	return "", nil
+}
+
+// oidcCallbackRequest builds the callback request the issuer redirects back to.
+func oidcCallbackRequest(authURL, code string, cookie *http.Cookie) *http.Request {
+	parsed, _ := url.Parse(authURL)
+	query := url.Values{"code": {code}, "state": {parsed.Query().Get("state")}}
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/callback?"+query.Encode(), nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	return req
+}
+
+func TestOIDC(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Login and callback start a session", func(t *testing.T) {
+		t.Parallel()
+		var created string
+		var promoted bool
+		userStore := &fakeUserStore{
+			getUserFn: func(context.Context, string) (*db.User, error) {
+				return nil, db.ErrUserNotFound
+			},
+			createUserFn: func(_ context.Context, email, _, _ string) (*db.User, error) {
+				created = email
+				return &db.User{ID: email}, nil
+			},
+			updateUserRoleFn: func(_ context.Context, _ string, role string) error {
+				promoted = role == db.RoleAdmin
+				return nil
+			},
+		}
+		api, issuer := newOIDCAPI(t, userStore, true)
+
+		authURL, cookie := startOIDCLogin(t, api)
+		assert.True(t, strings.HasPrefix(authURL, issuer.URL+"/authorize?"))
+		code := issuer.Authorize(t, authURL, map[string]any{
+			"sub":    "42",
+			"email":  "ada@example.com",
+			"groups": []string{"motus-admins"},
+		})
+
+		rec := httptest.NewRecorder()
+		api.OIDCCallback().ServeHTTP(rec, oidcCallbackRequest(authURL, code, cookie))
+
+		require.Equal(t, http.StatusFound, rec.Code, rec.Body.String())
+		assert.Equal(t, "/", rec.Header().Get("Location"))
+		assert.Equal(t, "ada@example.com", created)
+		assert.True(t, promoted)
+
+		session := sessionCookie(rec)
+		require.NotNil(t, session)
+		req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+		req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: session.Value})
+		userID, err := api.ResolveUserID(req)
+		require.NoError(t, err)
+		assert.Equal(t, "ada@example.com", userID)
+	})
+
+	t.Run("Rejects unknown users without auto-create", func(t *testing.T) {
+		t.Parallel()
+		userStore := &fakeUserStore{
+			getUserFn: func(context.Context, string) (*db.User, error) {
+				return nil, db.ErrUserNotFound
+			},
+		}
+		api, issuer := newOIDCAPI(t, userStore, false)
+
+		authURL, cookie := startOIDCLogin(t, api)
+		code := issuer.Authorize(t, authURL, map[string]any{"email": "ada@example.com"})
+
+		rec := httptest.NewRecorder()
+		api.OIDCCallback().ServeHTTP(rec, oidcCallbackRequest(authURL, code, cookie))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Nil(t, sessionCookie(rec))
+	})
+
+	t.Run("Rejects callback without flow cookie", func(t *testing.T) {
+		t.Parallel()
+		api, issuer := newOIDCAPI(t, &fakeUserStore{}, true)
+
+		authURL, _ := startOIDCLogin(t, api)
+		code := issuer.Authorize(t, authURL, map[string]any{"email": "ada@example.com"})
+
+		rec := httptest.NewRecorder()
+		api.OIDCCallback().ServeHTTP(rec, oidcCallbackRequest(authURL, code, nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "oidc state mismatch")
+	})
+
+	t.Run("Rejects issuer errors", func(t *testing.T) {
+		t.Parallel()
+		api, _ := newOIDCAPI(t, &fakeUserStore{}, true)
+
+		authURL, cookie := startOIDCLogin(t, api)
+		parsed, err := url.Parse(authURL)
+		require.NoError(t, err)
+		query := url.Values{"error": {"access_denied"}, "state": {parsed.Query().Get("state")}}
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/callback?"+query.Encode(), nil)
+		req.AddCookie(cookie)
+
+		rec := httptest.NewRecorder()
+		api.OIDCCallback().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("Routes return not found when disabled", func(t *testing.T) {
+		t.Parallel()
+		api := &API{}
+
+		rec := httptest.NewRecorder()
+		api.OIDCLogin().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/auth/oidc/login", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Local login is disabled", func(t *testing.T) {
+		t.Parallel()
+		api, _ := newOIDCAPI(t, &fakeUserStore{}, true)
+		req := httptest.NewRequest(http.MethodPost, "/api/login", strings.NewReader(`{"email":"ada@example.com","password":"secret"}`))
+
+		rec := httptest.NewRecorder()
+		api.Login().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
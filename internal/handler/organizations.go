@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/organizations"
+)
+
+// ListOrganizations returns the organizations of the current user.
+func (a *API) ListOrganizations() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		items, err := a.Organizations.List(r.Context(), actor)
+		if err != nil {
+			a.logRequestError(r, "list_organizations_failed", "list organizations failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, items)
+	}
+}
+
+// CreateOrganization founds an organization owned by the current user.
+func (a *API) CreateOrganization() http.HandlerFunc {
+	type createOrganizationRequest struct {
+		Name string `json:"name"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[createOrganizationRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		org, err := a.Organizations.Create(r.Context(), actor, req.Name)
+		if err != nil {
+			a.logRequestError(r, "create_organization_failed", "create organization failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("organization created",
+			"event", "organization_created",
+			"resource", "organization",
+			"resource_id", org.ID,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "organization_created",
+			Resource:   "organization",
+			ResourceID: org.ID,
+			After:      map[string]any{"name": org.Name},
+		})
+		a.respondJSON(w, http.StatusCreated, org)
+	}
+}
+
+// DeleteOrganization removes an organization with its shared library.
+func (a *API) DeleteOrganization() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		org, err := a.Organizations.Delete(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "delete_organization_failed", "delete organization failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("organization deleted",
+			"event", "organization_deleted",
+			"resource", "organization",
+			"resource_id", org.ID,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "organization_deleted",
+			Resource:   "organization",
+			ResourceID: org.ID,
+			Before:     map[string]any{"name": org.Name},
+		})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// ListOrgMembers returns the members of an organization.
+func (a *API) ListOrgMembers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		members, err := a.Organizations.Members(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "list_org_members_failed", "list organization members failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, members)
+	}
+}
+
+// AddOrgMember adds a user to an organization.
+func (a *API) AddOrgMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[organizations.MemberRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		member, err := a.Organizations.AddMember(r.Context(), actor, r.PathValue("id"), req)
+		if err != nil {
+			a.logRequestError(r, "add_org_member_failed", "add organization member failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("organization member added",
+			"event", "org_member_added",
+			"resource", "organization",
+			"resource_id", member.OrgID,
+			"user_id", actor.UserID,
+			"member_id", member.UserID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "org_member_added",
+			Resource:   "organization",
+			ResourceID: member.OrgID,
+			After:      map[string]any{"userId": member.UserID, "role": member.Role},
+		})
+		a.respondJSON(w, http.StatusCreated, member)
+	}
+}
+
+// UpdateOrgMember changes the organization role of a member.
+func (a *API) UpdateOrgMember() http.HandlerFunc {
+	type updateOrgMemberRequest struct {
+		Role string `json:"role"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[updateOrgMemberRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		orgID, memberID := r.PathValue("id"), r.PathValue("userId")
+		if err := a.Organizations.UpdateMember(r.Context(), actor, orgID, memberID, req.Role); err != nil {
+			a.logRequestError(r, "update_org_member_failed", "update organization member failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("organization member updated",
+			"event", "org_member_updated",
+			"resource", "organization",
+			"resource_id", orgID,
+			"user_id", actor.UserID,
+			"member_id", memberID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "org_member_updated",
+			Resource:   "organization",
+			ResourceID: orgID,
+			After:      map[string]any{"userId": memberID, "role": req.Role},
+		})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// RemoveOrgMember removes a user from an organization; members may remove themselves.
+func (a *API) RemoveOrgMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		orgID, memberID := r.PathValue("id"), r.PathValue("userId")
+		if err := a.Organizations.RemoveMember(r.Context(), actor, orgID, memberID); err != nil {
+			a.logRequestError(r, "remove_org_member_failed", "remove organization member failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("organization member removed",
+			"event", "org_member_removed",
+			"resource", "organization",
+			"resource_id", orgID,
+			"user_id", actor.UserID,
+			"member_id", memberID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "org_member_removed",
+			Resource:   "organization",
+			ResourceID: orgID,
+			Before:     map[string]any{"userId": memberID},
+		})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// SwitchOrganization changes the organization context of the current user.
+func (a *API) SwitchOrganization() http.HandlerFunc {
+	type switchOrganizationRequest struct {
+		OrgID string `json:"orgId"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[switchOrganizationRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		if err := a.Organizations.Switch(r.Context(), actor, req.OrgID); err != nil {
+			a.logRequestError(r, "switch_organization_failed", "switch organization failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("organization switched",
+			"event", "organization_switched",
+			"resource", "organization",
+			"resource_id", req.OrgID,
+			"user_id", actor.UserID,
+		)
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
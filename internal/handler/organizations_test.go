@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/organizations"
+)
+
+// fakeOrganizationsStore serves org "o1" owned by owner@example.com.
+type fakeOrganizationsStore struct {
+	created *db.Organization
+	active  string
+}
+
+func (f *fakeOrganizationsStore) OrgRole(_ context.Context, orgID, userID string) (string, error) {
+	if orgID == "o1" && userID == "owner@example.com" {
+		return db.OrgRoleOwner, nil
+	}
+	return "", nil
+}
+
+func (f *fakeOrganizationsStore) ActiveOrganization(context.Context, string) (string, error) {
+	return f.active, nil
+}
+
+func (f *fakeOrganizationsStore) GetUser(_ context.Context, id string) (*db.User, error) {
+	return &db.User{ID: id}, nil
+}
+
+func (f *fakeOrganizationsStore) CreateOrganization(_ context.Context, org db.Organization) error {
+	f.created = &org
+	return nil
+}
+
+func (f *fakeOrganizationsStore) ListOrganizationsForUser(context.Context, string) ([]db.OrgMembership, error) {
+	return nil, nil
+}
+
+func (f *fakeOrganizationsStore) GetOrganization(_ context.Context, id string) (*db.Organization, error) {
+	return &db.Organization{ID: id, Name: "Club"}, nil
+}
+
+func (f *fakeOrganizationsStore) DeleteOrganization(context.Context, string) error { return nil }
+
+func (f *fakeOrganizationsStore) ListOrgMembers(context.Context, string) ([]db.OrgMember, error) {
+	return nil, nil
+}
+
+func (f *fakeOrganizationsStore) CountOrgOwners(context.Context, string) (int, error) { return 1, nil }
+
+func (f *fakeOrganizationsStore) AddOrgMember(context.Context, db.OrgMember) error { return nil }
+
+func (f *fakeOrganizationsStore) UpdateOrgMemberRole(context.Context, string, string, string) error {
+	return nil
+}
+
+func (f *fakeOrganizationsStore) RemoveOrgMember(context.Context, string, string) error { return nil }
+
+func (f *fakeOrganizationsStore) SetActiveOrganization(_ context.Context, _, orgID string) error {
+	f.active = orgID
+	return nil
+}
+
+func TestOrganizationHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("CreateOrganization", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeOrganizationsStore{}
+		api := &API{Organizations: organizations.New(store)}
+		req := httptest.NewRequest(http.MethodPost, "/api/organizations", strings.NewReader(`{"name":"Club"}`))
+		signIn(t, api, req, "owner@example.com")
+		rec := httptest.NewRecorder()
+
+		api.CreateOrganization().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var org db.Organization
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&org))
+		assert.Equal(t, "Club", org.Name)
+		require.NotNil(t, store.created)
+		assert.Equal(t, "owner@example.com", store.created.CreatedBy)
+	})
+
+	t.Run("SwitchOrganization", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeOrganizationsStore{}
+		api := &API{Organizations: organizations.New(store)}
+		req := httptest.NewRequest(http.MethodPut, "/api/me/organization", strings.NewReader(`{"orgId":"o1"}`))
+		signIn(t, api, req, "owner@example.com")
+		rec := httptest.NewRecorder()
+
+		api.SwitchOrganization().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "o1", store.active)
+	})
+
+	t.Run("UpdateOrgMember keeps the last owner", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Organizations: organizations.New(&fakeOrganizationsStore{})}
+		req := httptest.NewRequest(http.MethodPut, "/api/organizations/o1/members/owner@example.com", strings.NewReader(`{"role":"member"}`))
+		req.SetPathValue("id", "o1")
+		req.SetPathValue("userId", "owner@example.com")
+		signIn(t, api, req, "owner@example.com")
+		rec := httptest.NewRecorder()
+
+		api.UpdateOrgMember().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/audit"
+)
+
+// GetWorkoutPacing compares the targets of a workout with its logged trainings.
+func (a *API) GetWorkoutPacing() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		analysis, err := a.Pacing.Analyze(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "analyze_pacing_failed", "analyze pacing failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, analysis)
+	}
+}
+
+// ApplyWorkoutPacing replaces the targets of a workout with the suggested ones.
+func (a *API) ApplyWorkoutPacing() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		result, err := a.Pacing.Apply(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "apply_pacing_failed", "apply pacing failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("workout pacing applied",
+			"event", "workout_pacing_applied",
+			"resource", "workout",
+			"resource_id", result.Workout.ID,
+			"user_id", result.Workout.UserID,
+			"count", result.Applied,
+		)
+		if result.Applied > 0 {
+			a.recordAudit(r, audit.Event{
+				ActorID:    actor.UserID,
+				Action:     "workout_pacing_applied",
+				Resource:   "workout",
+				ResourceID: result.Workout.ID,
+				After:      map[string]any{"applied": result.Applied},
+			})
+		}
+		a.respondJSON(w, http.StatusOK, result)
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/pacing"
+)
+
+// fakePacingStore serves one pause workout whose trainings always overrun.
+type fakePacingStore struct {
+	workout db.Workout
+	applied map[string]int
+}
+
+func newFakePacingStore() *fakePacingStore {
+	return &fakePacingStore{workout: db.Workout{
+		ID:     "w1",
+		UserID: "user@example.com",
+		Steps:  []db.WorkoutStep{{ID: "rest", Type: "pause", Name: "Rest", EstimatedSeconds: 60}},
+	}}
+}
+
+func (f *fakePacingStore) WorkoutWithSteps(_ context.Context, id string) (*db.Workout, error) {
+	if id != f.workout.ID {
+		return nil, db.ErrWorkoutNotFound
+	}
+	workout := f.workout
+	return &workout, nil
+}
+
+func (f *fakePacingStore) TrainingHistory(context.Context, db.TrainingHistoryFilter) ([]db.TrainingLog, error) {
+	return []db.TrainingLog{{ID: "t1"}, {ID: "t2"}, {ID: "t3"}}, nil
+}
+
+func (f *fakePacingStore) StepTimingsForTrainings(_ context.Context, ids []string) (map[string][]db.TrainingStepLog, error) {
+	steps := make(map[string][]db.TrainingStepLog, len(ids))
+	for _, id := range ids {
+		steps[id] = []db.TrainingStepLog{{TrainingID: id, Name: "Rest", Type: "pause", ElapsedMillis: 90_000}}
+	}
+	return steps, nil
+}
+
+func (f *fakePacingStore) UpdateEstimatedSeconds(_ context.Context, _ string, steps, _ map[string]int) error {
+	f.applied = steps
+	f.workout.Steps[0].EstimatedSeconds = steps["rest"]
+	return nil
+}
+
+func TestPacingHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetWorkoutPacing returns targets", func(t *testing.T) {
+		t.Parallel()
+		api := &API{Pacing: pacing.New(newFakePacingStore())}
+		req := httptest.NewRequest(http.MethodGet, "/api/workouts/w1/pacing", nil)
+		req.SetPathValue("id", "w1")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.GetWorkoutPacing().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var analysis pacing.Analysis
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &analysis))
+		require.Len(t, analysis.Targets, 1)
+		assert.Equal(t, 90, analysis.Targets[0].SuggestedSeconds)
+		assert.Equal(t, 1.5, analysis.Targets[0].OverrunRatio)
+	})
+
+	t.Run("ApplyWorkoutPacing updates the workout", func(t *testing.T) {
+		t.Parallel()
+		store := newFakePacingStore()
+		api := &API{Pacing: pacing.New(store)}
+		req := httptest.NewRequest(http.MethodPost, "/api/workouts/w1/pacing/apply", nil)
+		req.SetPathValue("id", "w1")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ApplyWorkoutPacing().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, map[string]int{"rest": 90}, store.applied)
+		var result pacing.ApplyResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.Equal(t, 1, result.Applied)
+		assert.Equal(t, 90, result.Workout.Steps[0].EstimatedSeconds)
+	})
+
+	t.Run("Unknown workout", func(t *testing.T) {
+		t.Parallel()
+		api := &API{Pacing: pacing.New(newFakePacingStore())}
+		req := httptest.NewRequest(http.MethodGet, "/api/workouts/missing/pacing", nil)
+		req.SetPathValue("id", "missing")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.GetWorkoutPacing().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/privacy"
+)
+
+// errAccountDeletion is returned when an API token tries to delete an account.
+var errAccountDeletion = errors.New("api tokens cannot delete accounts")
+
+// ExportMe streams an archive with all personal data of the current user.
+func (a *API) ExportMe() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+		a.exportUser(w, r, actor, actor.UserID)
+	}
+}
+
+// ExportUser streams an archive with all personal data of any user for admins.
+func (a *API) ExportUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+		a.exportUser(w, r, actor, r.PathValue("id"))
+	}
+}
+
+// DeleteMe deletes the current user's account and signs them out.
+func (a *API) DeleteMe() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[privacy.DeleteRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, ok := a.resolveSessionActor(w, r, "account_deletion_denied", errAccountDeletion)
+		if !ok {
+			return
+		}
+
+		if !a.deleteUser(w, r, actor, actor.UserID, req) {
+			return
+		}
+		a.clearSessionCookie(w)
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// DeleteUser deletes any user's account for admins.
+func (a *API) DeleteUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[privacy.DeleteRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, ok := a.resolveSessionActor(w, r, "account_deletion_denied", errAccountDeletion)
+		if !ok {
+			return
+		}
+
+		if !a.deleteUser(w, r, actor, r.PathValue("id"), req) {
+			return
+		}
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// exportUser writes the data archive of userID as a download.
+func (a *API) exportUser(w http.ResponseWriter, r *http.Request, actor policy.Actor, userID string) {
+	export, err := a.Privacy.Export(r.Context(), actor, userID)
+	if err != nil {
+		a.logRequestError(r, "export_user_data_failed", "export user data failed", err)
+		a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+		return
+	}
+
+	a.businessLogger(r).Info("user data exported",
+		"event", "user_data_exported",
+		"resource", "user",
+		"resource_id", export.Profile.ID,
+		"user_id", actor.UserID,
+	)
+	a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "user_data_exported", Resource: "user", ResourceID: export.Profile.ID})
+
+	filename := fmt.Sprintf("motus-export-%s.zip", export.ExportedAt.Format("2006-01-02"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	if err := privacy.WriteArchive(w, export); err != nil {
+		a.logRequestError(r, "write_export_archive_failed", "write export archive failed", err)
+	}
+}
+
+// deleteUser removes userID and reports whether it succeeded; on failure the error was written.
+func (a *API) deleteUser(w http.ResponseWriter, r *http.Request, actor policy.Actor, userID string, req privacy.DeleteRequest) bool {
+	if err := a.Privacy.Delete(r.Context(), actor, userID, req); err != nil {
+		a.logRequestError(r, "delete_user_failed", "delete user failed", err)
+		a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+		return false
+	}
+
+	a.businessLogger(r).Info("user deleted",
+		"event", "user_deleted",
+		"resource", "user",
+		"resource_id", userID,
+		"user_id", actor.UserID,
+	)
+	a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "user_deleted", Resource: "user", ResourceID: userID})
+	return true
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/privacy"
+)
+
+// fakePrivacyStore serves a single user without password and records deletions.
+type fakePrivacyStore struct {
+	deleted string
+}
+
+func (f *fakePrivacyStore) GetUserWithPassword(_ context.Context, id string) (*db.User, string, error) {
+	return &db.User{ID: id}, "", nil
+}
+
+func (f *fakePrivacyStore) ListUsers(context.Context) ([]db.User, error) { return nil, nil }
+
+func (f *fakePrivacyStore) WorkoutsByUser(_ context.Context, userID string) ([]db.Workout, error) {
+	return []db.Workout{{ID: "w1", UserID: userID}}, nil
+}
+
+func (f *fakePrivacyStore) TemplatesByUser(context.Context, string) ([]db.Workout, error) {
+	return nil, nil
+}
+
+func (f *fakePrivacyStore) ListExercises(context.Context, string, string) ([]db.Exercise, error) {
+	return nil, nil
+}
+
+func (f *fakePrivacyStore) TrainingsByUser(context.Context, string) ([]db.TrainingLog, error) {
+	return nil, nil
+}
+
+func (f *fakePrivacyStore) TrainingStepTimings(context.Context, string) ([]db.TrainingStepLog, error) {
+	return nil, nil
+}
+
+func (f *fakePrivacyStore) ListAPITokens(context.Context, string) ([]db.APIToken, error) {
+	return nil, nil
+}
+
+func (f *fakePrivacyStore) DeleteUser(_ context.Context, userID, _ string) error {
+	f.deleted = userID
+	return nil
+}
+
+func TestPrivacyHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ExportMe returns a zip archive", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Privacy: privacy.New(&fakePrivacyStore{})}
+		req := httptest.NewRequest(http.MethodGet, "/api/me/export", nil)
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ExportMe().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "motus-export-")
+		_, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+		require.NoError(t, err)
+	})
+
+	t.Run("DeleteMe deletes the account and signs out", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakePrivacyStore{}
+		api := &API{Privacy: privacy.New(store)}
+		req := httptest.NewRequest(http.MethodDelete, "/api/me", strings.NewReader(`{"confirm":"user@example.com"}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.DeleteMe().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "user@example.com", store.deleted)
+		cookie := sessionCookie(rec)
+		require.NotNil(t, cookie)
+		assert.Equal(t, -1, cookie.MaxAge)
+	})
+
+	t.Run("DeleteMe requires confirmation", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakePrivacyStore{}
+		api := &API{Privacy: privacy.New(store)}
+		req := httptest.NewRequest(http.MethodDelete, "/api/me", strings.NewReader(`{}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.DeleteMe().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Empty(t, store.deleted)
+	})
+
+	t.Run("DeleteMe rejects API tokens", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Privacy: privacy.New(&fakePrivacyStore{})}
+		req := httptest.NewRequest(http.MethodDelete, "/api/me", strings.NewReader(`{"confirm":"user@example.com"}`))
+		req.Header.Set("Authorization", "Bearer motus_secret")
+		rec := httptest.NewRecorder()
+
+		api.DeleteMe().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), errAccountDeletion.Error())
+	})
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gi8lino/motus/internal/auth"
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/sessions"
+)
+
+// Logout revokes the current session and clears the session cookie.
+func (a *API) Logout() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Resolve the user before revoking so the audit log can name them.
+		userID, _ := a.ResolveUserID(r)
+		if token := auth.SessionToken(r); token != "" {
+			if err := a.Sessions.Revoke(r.Context(), token); err != nil {
+				a.logRequestError(r, "revoke_session_failed", "revoke session failed", err)
+				a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+				return
+			}
+		}
+
+		a.clearSessionCookie(w)
+		a.businessLogger(r).Info("user logout",
+			"event", "user_logout",
+			"resource", "session",
+		)
+		a.recordAudit(r, audit.Event{ActorID: userID, Action: "user_logout", Resource: "session"})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// LogoutAll revokes every session of the current user.
+func (a *API) LogoutAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := a.ResolveUserID(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		count, err := a.Sessions.RevokeAll(r.Context(), userID)
+		if err != nil {
+			a.logRequestError(r, "revoke_sessions_failed", "revoke sessions failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.clearSessionCookie(w)
+		a.businessLogger(r).Info("user logout all",
+			"event", "user_logout_all",
+			"resource", "session",
+			"user_id", userID,
+			"count", count,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:  userID,
+			Action:   "user_logout_all",
+			Resource: "session",
+			After:    map[string]any{"revoked": count},
+		})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// startSession issues a new session for the user and sets the session cookie.
+func (a *API) startSession(w http.ResponseWriter, r *http.Request, userID string) error {
+	issued, err := a.Sessions.Create(r.Context(), userID, r.UserAgent(), clientIP(r))
+	if err != nil {
+		return err
+	}
+	a.setSessionCookie(w, issued)
+	return nil
+}
+
+// refreshSession rotates an aging session cookie; failures leave the current session untouched.
+func (a *API) refreshSession(w http.ResponseWriter, r *http.Request) {
+	token := auth.SessionToken(r)
+	if a.AuthHeader != "" || a.Sessions == nil || token == "" {
+		return
+	}
+	issued, rotated, err := a.Sessions.Rotate(r.Context(), token)
+	if err != nil {
+		a.logRequestError(r, "rotate_session_failed", "rotate session failed", err)
+		return
+	}
+	if rotated {
+		a.setSessionCookie(w, issued)
+	}
+}
+
+// setSessionCookie writes the session token as an HttpOnly cookie.
+func (a *API) setSessionCookie(w http.ResponseWriter, issued sessions.Issued) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieName,
+		Value:    issued.Token,
+		Path:     a.cookiePath(),
+		Expires:  issued.Session.ExpiresAt,
+		MaxAge:   int(time.Until(issued.Session.ExpiresAt).Seconds()),
+		HttpOnly: true,
+		Secure:   a.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// clearSessionCookie instructs the browser to drop the session cookie.
+func (a *API) clearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieName,
+		Value:    "",
+		Path:     a.cookiePath(),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   a.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// cookiePath returns the configured cookie path or the root path.
+func (a *API) cookiePath() string {
+	if a.CookiePath == "" {
+		return "/"
+	}
+	return a.CookiePath
+}
+
+// clientIP returns the remote host without the port.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
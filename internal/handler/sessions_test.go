@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/auth"
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/sessions"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// fakeSessionStore keeps sessions and API tokens in memory and satisfies auth.Store, sessions.Store, and tokens.Store.
+type fakeSessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]db.Session
+	tokens   map[string]db.APIToken
+	roles    map[string]string
+	totp     map[string]bool
+}
+
+func newFakeSessionStore() *fakeSessionStore {
+	return &fakeSessionStore{
+		sessions: map[string]db.Session{},
+		tokens:   map[string]db.APIToken{},
+		roles:    map[string]string{},
+		totp:     map[string]bool{},
+	}
+}
+
+func (f *fakeSessionStore) GetUser(_ context.Context, id string) (*db.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user := &db.User{ID: id, TOTPEnabled: f.totp[id]}
+	user.SetRole(utils.DefaultIfZero(f.roles[id], db.RoleMember))
+	return user, nil
+}
+
+func (f *fakeSessionStore) CreateUser(_ context.Context, email, _, _ string) (*db.User, error) {
+	return &db.User{ID: email}, nil
+}
+
+func (f *fakeSessionStore) CreateSession(_ context.Context, session db.Session) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions[session.ID] = session
+	return nil
+}
+
+func (f *fakeSessionStore) GetSession(_ context.Context, id string) (*db.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	session, ok := f.sessions[id]
+	if !ok {
+		return nil, db.ErrSessionNotFound
+	}
+	return &session, nil
+}
+
+func (f *fakeSessionStore) RotateSession(ctx context.Context, oldID string, next db.Session) error {
+	if err := f.RevokeSession(ctx, oldID); err != nil {
+		return err
+	}
+	return f.CreateSession(ctx, next)
+}
+
+func (f *fakeSessionStore) RevokeSession(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	session, ok := f.sessions[id]
+	if !ok {
+		return db.ErrSessionNotFound
+	}
+	now := time.Now()
+	session.RevokedAt = &now
+	f.sessions[id] = session
+	return nil
+}
+
+func (f *fakeSessionStore) RevokeUserSessions(_ context.Context, userID string) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var count int64
+	now := time.Now()
+	for id, session := range f.sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			f.sessions[id] = session
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeSessionStore) CreateAPIToken(_ context.Context, token db.APIToken) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens[token.ID] = token
+	return nil
+}
+
+func (f *fakeSessionStore) ListAPITokens(_ context.Context, userID string) ([]db.APIToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var items []db.APIToken
+	for _, token := range f.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			items = append(items, token)
+		}
+	}
+	return items, nil
+}
+
+func (f *fakeSessionStore) RevokeAPIToken(_ context.Context, userID, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	token, ok := f.tokens[id]
+	if !ok || token.UserID != userID || token.RevokedAt != nil {
+		return db.ErrAPITokenNotFound
+	}
+	now := time.Now()
+	token.RevokedAt = &now
+	f.tokens[id] = token
+	return nil
+}
+
+func (f *fakeSessionStore) GetAPITokenByHash(_ context.Context, hash string) (*db.APIToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, token := range f.tokens {
+		if token.TokenHash == hash {
+			return &token, nil
+		}
+	}
+	return nil, db.ErrAPITokenNotFound
+}
+
+func (f *fakeSessionStore) TouchAPIToken(_ context.Context, id string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if token, ok := f.tokens[id]; ok {
+		token.LastUsedAt = &at
+		f.tokens[id] = token
+	}
+	return nil
+}
+
+// signIn attaches a fresh session cookie for userID to req, wiring an in-memory session store into api when needed.
+func signIn(t *testing.T, api *API, req *http.Request, userID string) {
+	t.Helper()
+	if api.Sessions == nil {
+		store := newFakeSessionStore()
+		api.AuthStore = store
+		api.Sessions = sessions.New(store, time.Hour)
+	}
+	issued, err := api.Sessions.Create(context.Background(), userID, "test", "127.0.0.1")
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: issued.Token})
+}
+
+// signInAdmin attaches a session cookie for userID and marks the user as admin.
+func signInAdmin(t *testing.T, api *API, req *http.Request, userID string) {
+	t.Helper()
+	signIn(t, api, req, userID)
+	store, ok := api.AuthStore.(*fakeSessionStore)
+	require.True(t, ok, "signInAdmin requires the in-memory session store")
+	store.mu.Lock()
+	store.roles[userID] = db.RoleAdmin
+	store.mu.Unlock()
+}
+
+// sessionCookie returns the session cookie set on the response, if any.
+func sessionCookie(rec *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == auth.SessionCookieName {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestSessionsHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Logout revokes the current session", func(t *testing.T) {
+		t.Parallel()
+		api := &API{}
+		req := httptest.NewRequest(http.MethodPost, "/api/logout", nil)
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.Logout().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		cookie := sessionCookie(rec)
+		require.NotNil(t, cookie)
+		assert.Empty(t, cookie.Value)
+		assert.Negative(t, cookie.MaxAge)
+
+		_, err := api.ResolveUserID(req)
+		require.Error(t, err)
+	})
+
+	t.Run("Logout without session", func(t *testing.T) {
+		t.Parallel()
+		api := &API{Sessions: sessions.New(newFakeSessionStore(), time.Hour)}
+		req := httptest.NewRequest(http.MethodPost, "/api/logout", nil)
+		rec := httptest.NewRecorder()
+
+		api.Logout().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("Logout all revokes every session", func(t *testing.T) {
+		t.Parallel()
+		api := &API{}
+		req := httptest.NewRequest(http.MethodPost, "/api/logout/all", nil)
+		signIn(t, api, req, "user@example.com")
+		other := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+		signIn(t, api, other, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.LogoutAll().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		_, err := api.ResolveUserID(other)
+		require.Error(t, err)
+	})
+
+	t.Run("Logout all requires session", func(t *testing.T) {
+		t.Parallel()
+		api := &API{}
+		req := httptest.NewRequest(http.MethodPost, "/api/logout/all", nil)
+		signIn(t, api, req, "user@example.com")
+		req.Header.Del("Cookie")
+		rec := httptest.NewRecorder()
+
+		api.LogoutAll().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestCookieScope(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Secure with prefix", func(t *testing.T) {
+		t.Parallel()
+		path, secure := cookieScope("https://example.com/motus")
+		assert.Equal(t, "/motus", path)
+		assert.True(t, secure)
+	})
+
+	t.Run("Plain http root", func(t *testing.T) {
+		t.Parallel()
+		path, secure := cookieScope("http://localhost:8080")
+		assert.Equal(t, "/", path)
+		assert.False(t, secure)
+	})
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/stats"
+)
+
+// GetStats returns the training totals, trends and streaks of the current user.
+func (a *API) GetStats() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		query := r.URL.Query()
+		summary, err := a.Stats.Summary(r.Context(), actor, stats.Query{
+			Period:   query.Get("period"),
+			TimeZone: query.Get("tz"),
+		})
+		if err != nil {
+			a.logRequestError(r, "get_stats_failed", "get stats failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, summary)
+	}
+}
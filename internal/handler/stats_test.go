@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/stats"
+)
+
+// fakeStatsStore returns fixed aggregates and records the last filter.
+type fakeStatsStore struct {
+	filter db.StatsFilter
+}
+
+func (f *fakeStatsStore) StatsBuckets(_ context.Context, filter db.StatsFilter) ([]db.StatsBucket, error) {
+	f.filter = filter
+	return []db.StatsBucket{{Start: filter.From, Sessions: 2, TotalSeconds: 600}}, nil
+}
+
+func (f *fakeStatsStore) TrainingDays(context.Context, string, string) ([]time.Time, error) {
+	return nil, nil
+}
+
+func (f *fakeStatsStore) TopExercises(context.Context, db.StatsFilter, int) ([]db.ExerciseStats, error) {
+	return []db.ExerciseStats{{Name: "Squat", Sessions: 2}}, nil
+}
+
+func TestStatsHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetStats passes period and zone", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStatsStore{}
+		api := &API{Stats: stats.New(store)}
+		req := httptest.NewRequest(http.MethodGet, "/api/me/stats?period=month&tz=Europe/Zurich", nil)
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.GetStats().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "user@example.com", store.filter.UserID)
+		assert.Equal(t, "month", store.filter.Period)
+		assert.Equal(t, "Europe/Zurich", store.filter.TimeZone)
+
+		var summary stats.Summary
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+		assert.Len(t, summary.Buckets, 12)
+		assert.Equal(t, 2, summary.Totals.Sessions)
+		require.Len(t, summary.TopExercises, 1)
+		assert.Equal(t, "Squat", summary.TopExercises[0].Name)
+	})
+
+	t.Run("GetStats rejects unknown zones", func(t *testing.T) {
+		t.Parallel()
+		api := &API{Stats: stats.New(&fakeStatsStore{})}
+		req := httptest.NewRequest(http.MethodGet, "/api/me/stats?tz=Nowhere/Land", nil)
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.GetStats().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
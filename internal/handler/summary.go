@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/summary"
+)
+
+// GetTrainingSummary renders a completed training as copyable text.
+func (a *API) GetTrainingSummary() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		query := r.URL.Query()
+		rendered, err := a.Summary.Render(r.Context(), actor, r.PathValue("id"), summary.Query{
+			Format:   query.Get("format"),
+			TimeZone: query.Get("tz"),
+		})
+		if err != nil {
+			a.logRequestError(r, "render_summary_failed", "render summary failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, rendered)
+	}
+}
+
+// ListSummaryTemplates returns the summary templates of the current user.
+func (a *API) ListSummaryTemplates() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		templates, err := a.Summary.List(r.Context(), actor)
+		if err != nil {
+			a.logRequestError(r, "list_summary_templates_failed", "list summary templates failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, templates)
+	}
+}
+
+// CreateSummaryTemplate stores a new summary template for the current user.
+func (a *API) CreateSummaryTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[summary.TemplateRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		created, err := a.Summary.Create(r.Context(), actor, req)
+		if err != nil {
+			a.logRequestError(r, "create_summary_template_failed", "create summary template failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("summary template created",
+			"event", "summary_template_created",
+			"resource", "summary_template",
+			"resource_id", created.ID,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "summary_template_created",
+			Resource:   "summary_template",
+			ResourceID: created.ID,
+			After:      map[string]any{"name": created.Name},
+		})
+		a.respondJSON(w, http.StatusCreated, created)
+	}
+}
+
+// UpdateSummaryTemplate changes a summary template of the current user.
+func (a *API) UpdateSummaryTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[summary.TemplateRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		updated, err := a.Summary.Update(r.Context(), actor, r.PathValue("id"), req)
+		if err != nil {
+			a.logRequestError(r, "update_summary_template_failed", "update summary template failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("summary template updated",
+			"event", "summary_template_updated",
+			"resource", "summary_template",
+			"resource_id", updated.ID,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "summary_template_updated",
+			Resource:   "summary_template",
+			ResourceID: updated.ID,
+			After:      map[string]any{"name": updated.Name},
+		})
+		a.respondJSON(w, http.StatusOK, updated)
+	}
+}
+
+// DeleteSummaryTemplate removes a summary template of the current user.
+func (a *API) DeleteSummaryTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		id := r.PathValue("id")
+		if err := a.Summary.Delete(r.Context(), actor, id); err != nil {
+			a.logRequestError(r, "delete_summary_template_failed", "delete summary template failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("summary template deleted",
+			"event", "summary_template_deleted",
+			"resource", "summary_template",
+			"resource_id", id,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "summary_template_deleted", Resource: "summary_template", ResourceID: id})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
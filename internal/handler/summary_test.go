@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/summary"
+)
+
+// fakeSummaryStore serves one completed training and keeps templates in memory.
+type fakeSummaryStore struct {
+	templates map[string]db.SummaryTemplate
+}
+
+func newFakeSummaryStore() *fakeSummaryStore {
+	return &fakeSummaryStore{templates: map[string]db.SummaryTemplate{}}
+}
+
+func (f *fakeSummaryStore) GetTraining(_ context.Context, id string) (*db.TrainingLog, error) {
+	if id != "t1" {
+		return nil, db.ErrTrainingNotFound
+	}
+	started := time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+	return &db.TrainingLog{
+		ID:          id,
+		UserID:      "user@example.com",
+		WorkoutName: "Leg Day",
+		StartedAt:   started,
+		CompletedAt: started.Add(30 * time.Minute),
+	}, nil
+}
+
+func (f *fakeSummaryStore) TrainingStepTimings(_ context.Context, trainingID string) ([]db.TrainingStepLog, error) {
+	return []db.TrainingStepLog{{TrainingID: trainingID, Name: "Squats", Type: "set", ElapsedMillis: 60_000}}, nil
+}
+
+func (f *fakeSummaryStore) CreateSummaryTemplate(_ context.Context, tpl db.SummaryTemplate) error {
+	f.templates[tpl.ID] = tpl
+	return nil
+}
+
+func (f *fakeSummaryStore) ListSummaryTemplates(_ context.Context, userID string) ([]db.SummaryTemplate, error) {
+	var templates []db.SummaryTemplate
+	for _, tpl := range f.templates {
+		if tpl.UserID == userID {
+			templates = append(templates, tpl)
+		}
+	}
+	return templates, nil
+}
+
+func (f *fakeSummaryStore) GetSummaryTemplate(_ context.Context, id string) (*db.SummaryTemplate, error) {
+	tpl, ok := f.templates[id]
+	if !ok {
+		return nil, db.ErrSummaryTemplateNotFound
+	}
+	return &tpl, nil
+}
+
+func (f *fakeSummaryStore) UpdateSummaryTemplate(_ context.Context, tpl db.SummaryTemplate) error {
+	f.templates[tpl.ID] = tpl
+	return nil
+}
+
+func (f *fakeSummaryStore) DeleteSummaryTemplate(_ context.Context, id string) error {
+	delete(f.templates, id)
+	return nil
+}
+
+func TestSummaryHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetTrainingSummary renders markdown", func(t *testing.T) {
+		t.Parallel()
+		api := &API{Summary: summary.New(newFakeSummaryStore())}
+		req := httptest.NewRequest(http.MethodGet, "/api/trainings/t1/summary?format=markdown", nil)
+		req.SetPathValue("id", "t1")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.GetTrainingSummary().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var rendered summary.Summary
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rendered))
+		assert.Equal(t, summary.FormatMarkdown, rendered.Format)
+		assert.Contains(t, rendered.Text, "Leg Day")
+		assert.Contains(t, rendered.Text, "Squats")
+	})
+
+	t.Run("GetTrainingSummary rejects unknown formats", func(t *testing.T) {
+		t.Parallel()
+		api := &API{Summary: summary.New(newFakeSummaryStore())}
+		req := httptest.NewRequest(http.MethodGet, "/api/trainings/t1/summary?format=nope", nil)
+		req.SetPathValue("id", "t1")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.GetTrainingSummary().ServeHTTP(rec, req)
+
+		assert.NotEqual(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("CreateSummaryTemplate stores the template", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeSummaryStore()
+		api := &API{Summary: summary.New(store)}
+		body := `{"name":"Short","body":"{{.WorkoutName}} in {{.Duration}}"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/me/summary-templates", strings.NewReader(body))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.CreateSummaryTemplate().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var created db.SummaryTemplate
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		assert.Equal(t, "Short", created.Name)
+		assert.Contains(t, store.templates, created.ID)
+	})
+
+	t.Run("CreateSummaryTemplate rejects broken templates", func(t *testing.T) {
+		t.Parallel()
+		api := &API{Summary: summary.New(newFakeSummaryStore())}
+		body := `{"name":"Broken","body":"{{.Nope"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/me/summary-templates", strings.NewReader(body))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.CreateSummaryTemplate().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("DeleteSummaryTemplate of another user", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeSummaryStore()
+		store.templates["st1"] = db.SummaryTemplate{ID: "st1", UserID: "other@example.com", Name: "Theirs", Body: "x"}
+		api := &API{Summary: summary.New(store)}
+		req := httptest.NewRequest(http.MethodDelete, "/api/me/summary-templates/st1", nil)
+		req.SetPathValue("id", "st1")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.DeleteSummaryTemplate().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, store.templates, "st1")
+	})
+}
@@ -1,11 +1,23 @@
 package handler
 
-import "net/http"
+import (
+	"net/http"
 
-// ListTemplates returns all shared templates.
+	"github.com/gi8lino/motus/internal/service/audit"
+)
+
+// ListTemplates returns the global templates plus those of the current organization.
+// The optional org query parameter picks another organization of the caller.
 func (a *API) ListTemplates() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		items, err := a.Templates.List(r.Context())
+		actor, err := a.resolveOptionalActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		items, err := a.Templates.List(r.Context(), actor, r.URL.Query().Get("org"))
 		if err != nil {
 			a.logRequestError(r, "list_templates_failed", "list templates failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -21,6 +33,7 @@ func (a *API) CreateTemplate() http.HandlerFunc {
 	type createTemplateRequest struct {
 		WorkoutID string `json:"workoutId"`
 		Name      string `json:"name"`
+		OrgID     string `json:"orgId"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		req, err := decode[createTemplateRequest](r)
@@ -30,7 +43,14 @@ func (a *API) CreateTemplate() http.HandlerFunc {
 			return
 		}
 
-		template, err := a.Templates.Create(r.Context(), req.WorkoutID, req.Name)
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		template, err := a.Templates.Create(r.Context(), actor, req.WorkoutID, req.Name, req.OrgID)
 		if err != nil {
 			a.logRequestError(r, "create_template_failed", "create template failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -41,8 +61,17 @@ func (a *API) CreateTemplate() http.HandlerFunc {
 			"event", "template_created",
 			"resource", "template",
 			"resource_id", template.ID,
+			"user_id", actor.UserID,
 			"workout_id", req.WorkoutID,
+			"org_id", template.OrgID,
 		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "template_created",
+			Resource:   "template",
+			ResourceID: template.ID,
+			After:      map[string]any{"name": template.Name, "workoutId": req.WorkoutID, "orgId": template.OrgID},
+		})
 		a.respondJSON(w, http.StatusCreated, template)
 	}
 }
@@ -50,7 +79,14 @@ func (a *API) CreateTemplate() http.HandlerFunc {
 // GetTemplate returns a template by id.
 func (a *API) GetTemplate() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		template, err := a.Templates.Get(r.Context(), r.PathValue("id"))
+		actor, err := a.resolveOptionalActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		template, err := a.Templates.Get(r.Context(), actor, r.PathValue("id"))
 		if err != nil {
 			a.logRequestError(r, "get_template_failed", "get template failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -75,15 +111,15 @@ func (a *API) ApplyTemplate() http.HandlerFunc {
 			return
 		}
 
-		resolvedUserID, err := a.resolveUserID(r, req.UserID)
+		actor, err := a.ResolveActor(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
-		req.UserID = resolvedUserID
+		req.UserID = actor.UserID
 
-		workout, err := a.Templates.Apply(r.Context(), r.PathValue("id"), req.UserID, req.Name)
+		workout, err := a.Templates.Apply(r.Context(), actor, r.PathValue("id"), req.Name)
 		if err != nil {
 			a.logRequestError(r, "apply_template_failed", "apply template failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -97,6 +133,13 @@ func (a *API) ApplyTemplate() http.HandlerFunc {
 			"user_id", req.UserID,
 			"workout_id", workout.ID,
 		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    req.UserID,
+			Action:     "template_applied",
+			Resource:   "template",
+			ResourceID: r.PathValue("id"),
+			After:      map[string]any{"workoutId": workout.ID},
+		})
 		a.respondJSON(w, http.StatusCreated, workout)
 	}
 }
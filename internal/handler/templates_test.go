@@ -16,24 +16,30 @@ import (
 )
 
 type fakeTemplateStore struct {
-	listTemplatesFn           func(context.Context) ([]db.Workout, error)
-	createTemplateFn          func(context.Context, string, string) (*db.Workout, error)
+	listTemplatesFn           func(context.Context, string) ([]db.Workout, error)
+	createTemplateFn          func(context.Context, string, string, string) (*db.Workout, error)
 	createWorkoutFromTemplate func(context.Context, string, string, string) (*db.Workout, error)
 	workoutWithStepsFn        func(context.Context, string) (*db.Workout, error)
 }
 
-func (f *fakeTemplateStore) ListTemplates(ctx context.Context) ([]db.Workout, error) {
+func (f *fakeTemplateStore) OrgRole(context.Context, string, string) (string, error) { return "", nil }
+
+func (f *fakeTemplateStore) ActiveOrganization(context.Context, string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeTemplateStore) ListTemplates(ctx context.Context, orgID string) ([]db.Workout, error) {
 	if f.listTemplatesFn == nil {
 		return nil, nil
 	}
-	return f.listTemplatesFn(ctx)
+	return f.listTemplatesFn(ctx, orgID)
 }
 
-func (f *fakeTemplateStore) CreateTemplateFromWorkout(ctx context.Context, workoutID, name string) (*db.Workout, error) {
+func (f *fakeTemplateStore) CreateTemplateFromWorkout(ctx context.Context, workoutID, name, orgID string) (*db.Workout, error) {
 	if f.createTemplateFn == nil {
 		return nil, nil
 	}
-	return f.createTemplateFn(ctx, workoutID, name)
+	return f.createTemplateFn(ctx, workoutID, name, orgID)
 }
 
 func (f *fakeTemplateStore) CreateWorkoutFromTemplate(ctx context.Context, templateID, userID, name string) (*db.Workout, error) {
@@ -52,12 +58,13 @@ func (f *fakeTemplateStore) WorkoutWithSteps(ctx context.Context, id string) (*d
 
 func TestTemplatesHandlers(t *testing.T) {
 	t.Run("List templates", func(t *testing.T) {
-		store := &fakeTemplateStore{listTemplatesFn: func(context.Context) ([]db.Workout, error) {
+		store := &fakeTemplateStore{listTemplatesFn: func(context.Context, string) ([]db.Workout, error) {
 			return []db.Workout{{ID: "t1", Name: "Template"}}, nil
 		}}
 		api := &API{Templates: templates.New(store)}
 		h := api.ListTemplates()
 		req := httptest.NewRequest(http.MethodGet, "/api/templates", nil)
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -69,14 +76,31 @@ func TestTemplatesHandlers(t *testing.T) {
 		assert.Equal(t, "t1", payload[0].ID)
 	})
 
+	t.Run("List templates of a foreign organization", func(t *testing.T) {
+		api := &API{Templates: templates.New(&fakeTemplateStore{})}
+		req := httptest.NewRequest(http.MethodGet, "/api/templates?org=o1", nil)
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ListTemplates().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
 	t.Run("Create template", func(t *testing.T) {
-		store := &fakeTemplateStore{createTemplateFn: func(context.Context, string, string) (*db.Workout, error) {
-			return &db.Workout{ID: "t1", Name: "Template"}, nil
-		}}
+		store := &fakeTemplateStore{
+			workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
+				return &db.Workout{ID: "w1", UserID: "user@example.com", Name: "Workout"}, nil
+			},
+			createTemplateFn: func(context.Context, string, string, string) (*db.Workout, error) {
+				return &db.Workout{ID: "t1", Name: "Template"}, nil
+			},
+		}
 		api := &API{Templates: templates.New(store)}
 		h := api.CreateTemplate()
 		body := strings.NewReader(`{"workoutId":"w1","name":"Template"}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/templates", body)
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -95,6 +119,7 @@ func TestTemplatesHandlers(t *testing.T) {
 		h := api.GetTemplate()
 		req := httptest.NewRequest(http.MethodGet, "/api/templates/t1", nil)
 		req.SetPathValue("id", "t1")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -106,14 +131,19 @@ func TestTemplatesHandlers(t *testing.T) {
 	})
 
 	t.Run("Apply template", func(t *testing.T) {
-		store := &fakeTemplateStore{createWorkoutFromTemplate: func(context.Context, string, string, string) (*db.Workout, error) {
-			return &db.Workout{ID: "w1", Name: "Copy"}, nil
-		}}
+		store := &fakeTemplateStore{
+			workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
+				return &db.Workout{ID: "t1", Name: "Template", IsTemplate: true}, nil
+			},
+			createWorkoutFromTemplate: func(context.Context, string, string, string) (*db.Workout, error) {
+				return &db.Workout{ID: "w1", Name: "Copy"}, nil
+			},
+		}
 		api := &API{Templates: templates.New(store)}
 		h := api.ApplyTemplate()
 		body := strings.NewReader(`{"userId":"user@example.com","name":"Copy"}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/templates/t1/apply", body)
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		req.SetPathValue("id", "t1")
 		rec := httptest.NewRecorder()
 
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/auth"
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/tokens"
+)
+
+// errTokenManagement is returned when an API token tries to manage tokens.
+var errTokenManagement = errors.New("api tokens cannot manage tokens")
+
+// ListTokens returns the active API tokens of the current user.
+func (a *API) ListTokens() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, ok := a.resolveTokenOwner(w, r)
+		if !ok {
+			return
+		}
+
+		items, err := a.Tokens.List(r.Context(), actor.UserID)
+		if err != nil {
+			a.logRequestError(r, "list_api_tokens_failed", "list api tokens failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, items)
+	}
+}
+
+// CreateToken issues a new API token and returns its secret once.
+func (a *API) CreateToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[tokens.CreateRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, ok := a.resolveTokenOwner(w, r)
+		if !ok {
+			return
+		}
+
+		created, err := a.Tokens.Create(r.Context(), actor, req)
+		if err != nil {
+			a.logRequestError(r, "create_api_token_failed", "create api token failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("api token created",
+			"event", "api_token_created",
+			"resource", "api_token",
+			"resource_id", created.ID,
+			"user_id", actor.UserID,
+			"scopes", created.Scopes,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "api_token_created",
+			Resource:   "api_token",
+			ResourceID: created.ID,
+			After:      map[string]any{"name": created.Name, "scopes": created.Scopes},
+		})
+		a.respondJSON(w, http.StatusCreated, created)
+	}
+}
+
+// RevokeToken revokes one of the current user's API tokens.
+func (a *API) RevokeToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, ok := a.resolveTokenOwner(w, r)
+		if !ok {
+			return
+		}
+
+		id := r.PathValue("id")
+		if err := a.Tokens.Revoke(r.Context(), actor.UserID, id); err != nil {
+			a.logRequestError(r, "revoke_api_token_failed", "revoke api token failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("api token revoked",
+			"event", "api_token_revoked",
+			"resource", "api_token",
+			"resource_id", id,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "api_token_revoked", Resource: "api_token", ResourceID: id})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// resolveTokenOwner resolves the caller and rejects requests authenticated by an API token.
+func (a *API) resolveTokenOwner(w http.ResponseWriter, r *http.Request) (policy.Actor, bool) {
+	return a.resolveSessionActor(w, r, "api_token_management_denied", errTokenManagement)
+}
+
+// resolveSessionActor resolves the caller and rejects requests authenticated by an API token with denied.
+func (a *API) resolveSessionActor(w http.ResponseWriter, r *http.Request, event string, denied error) (policy.Actor, bool) {
+	if auth.BearerToken(r) != "" {
+		a.logRequestError(r, event, denied.Error(), denied)
+		a.respondJSON(w, http.StatusForbidden, apiError{Error: denied.Error()})
+		return policy.Actor{}, false
+	}
+
+	actor, err := a.ResolveActor(r)
+	if err != nil {
+		a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+		a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+		return policy.Actor{}, false
+	}
+	return actor, true
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/service/tokens"
+)
+
+// newTokensAPI returns an API wired to the in-memory session and token store.
+func newTokensAPI(t *testing.T) *API {
+	t.Helper()
+	api := &API{}
+	signIn(t, api, httptest.NewRequest(http.MethodGet, "/", nil), "user@example.com")
+	store, ok := api.AuthStore.(*fakeSessionStore)
+	require.True(t, ok)
+	api.Tokens = tokens.New(store)
+	return api
+}
+
+func TestTokensHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("CreateToken returns the secret once", func(t *testing.T) {
+		t.Parallel()
+		api := newTokensAPI(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/me/tokens", strings.NewReader(`{"name":"CLI","scopes":["write"]}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.CreateToken().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var created tokens.Created
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		assert.True(t, strings.HasPrefix(created.Token, tokens.TokenPrefix))
+		assert.Equal(t, []string{tokens.ScopeWrite}, created.Scopes)
+		assert.NotContains(t, rec.Body.String(), "tokenHash")
+
+		listReq := httptest.NewRequest(http.MethodGet, "/api/me/tokens", nil)
+		signIn(t, api, listReq, "user@example.com")
+		listRec := httptest.NewRecorder()
+		api.ListTokens().ServeHTTP(listRec, listReq)
+
+		require.Equal(t, http.StatusOK, listRec.Code)
+		assert.Contains(t, listRec.Body.String(), created.ID)
+		assert.NotContains(t, listRec.Body.String(), created.Token)
+	})
+
+	t.Run("CreateToken rejects admin scope for members", func(t *testing.T) {
+		t.Parallel()
+		api := newTokensAPI(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/me/tokens", strings.NewReader(`{"name":"CLI","scopes":["admin"]}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.CreateToken().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("Bearer tokens authenticate but cannot manage tokens", func(t *testing.T) {
+		t.Parallel()
+		api := newTokensAPI(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/me/tokens", strings.NewReader(`{"name":"CLI","scopes":["write"]}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+		api.CreateToken().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var created tokens.Created
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+		userID, err := api.ResolveUserID(bearerRequest(http.MethodGet, "/api/me", created.Token))
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", userID)
+
+		listRec := httptest.NewRecorder()
+		api.ListTokens().ServeHTTP(listRec, bearerRequest(http.MethodGet, "/api/me/tokens", created.Token))
+		assert.Equal(t, http.StatusForbidden, listRec.Code)
+		assert.Contains(t, listRec.Body.String(), "api tokens cannot manage tokens")
+	})
+
+	t.Run("RevokeToken invalidates the token", func(t *testing.T) {
+		t.Parallel()
+		api := newTokensAPI(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/me/tokens", strings.NewReader(`{"name":"CLI"}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+		api.CreateToken().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var created tokens.Created
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+		revokeReq := httptest.NewRequest(http.MethodDelete, "/api/me/tokens/"+created.ID, nil)
+		revokeReq.SetPathValue("id", created.ID)
+		signIn(t, api, revokeReq, "user@example.com")
+		revokeRec := httptest.NewRecorder()
+		api.RevokeToken().ServeHTTP(revokeRec, revokeReq)
+		require.Equal(t, http.StatusNoContent, revokeRec.Code)
+
+		_, err := api.ResolveUserID(bearerRequest(http.MethodGet, "/api/me", created.Token))
+		require.Error(t, err)
+	})
+
+	t.Run("RevokeToken returns not found for other users", func(t *testing.T) {
+		t.Parallel()
+		api := newTokensAPI(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/me/tokens", strings.NewReader(`{"name":"CLI"}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+		api.CreateToken().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var created tokens.Created
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+		revokeReq := httptest.NewRequest(http.MethodDelete, "/api/me/tokens/"+created.ID, nil)
+		revokeReq.SetPathValue("id", created.ID)
+		signIn(t, api, revokeReq, "other@example.com")
+		revokeRec := httptest.NewRecorder()
+		api.RevokeToken().ServeHTTP(revokeRec, revokeReq)
+		assert.Equal(t, http.StatusNotFound, revokeRec.Code)
+	})
+}
+
+// bearerRequest builds a request authenticated with an API token.
+func bearerRequest(method, target, token string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/audit"
+)
+
+// errTOTPManagement is returned when an API token tries to change two-factor settings.
+var errTOTPManagement = errors.New("api tokens cannot manage two-factor authentication")
+
+// totpChallengeResponse asks the client to complete the login with a second factor.
+type totpChallengeResponse struct {
+	TOTPRequired bool   `json:"totpRequired"`
+	Challenge    string `json:"challenge"`
+}
+
+// recoveryCodesResponse returns freshly generated recovery codes.
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// LoginTOTP completes a login challenge with a TOTP or recovery code.
+func (a *API) LoginTOTP() http.HandlerFunc {
+	type loginTOTPRequest struct {
+		Challenge string `json:"challenge"`
+		Code      string `json:"code"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+
+		req, err := decode[loginTOTPRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		user, err := a.Users.VerifyLogin(r.Context(), req.Challenge, req.Code)
+		if err != nil {
+			a.logRequestError(r, "login_totp_failed", "login totp failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.completeLogin(w, r, user)
+	}
+}
+
+// BeginTOTP starts two-factor enrollment for the current user.
+func (a *API) BeginTOTP() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+		actor, ok := a.resolveSessionActor(w, r, "totp_management_denied", errTOTPManagement)
+		if !ok {
+			return
+		}
+
+		enrollment, err := a.Users.BeginTOTP(r.Context(), actor.UserID)
+		if err != nil {
+			a.logRequestError(r, "begin_totp_failed", "begin totp failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, enrollment)
+	}
+}
+
+// ConfirmTOTP enables two-factor authentication and returns the recovery codes.
+func (a *API) ConfirmTOTP() http.HandlerFunc {
+	type confirmTOTPRequest struct {
+		Code string `json:"code"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+		actor, ok := a.resolveSessionActor(w, r, "totp_management_denied", errTOTPManagement)
+		if !ok {
+			return
+		}
+
+		req, err := decode[confirmTOTPRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		codes, err := a.Users.ConfirmTOTP(r.Context(), actor.UserID, req.Code)
+		if err != nil {
+			a.logRequestError(r, "confirm_totp_failed", "confirm totp failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("two-factor authentication enabled",
+			"event", "totp_enabled",
+			"resource", "user",
+			"resource_id", actor.UserID,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "totp_enabled", Resource: "user", ResourceID: actor.UserID})
+		a.respondJSON(w, http.StatusOK, recoveryCodesResponse{RecoveryCodes: codes})
+	}
+}
+
+// DisableTOTP turns two-factor authentication off after re-checking the password.
+func (a *API) DisableTOTP() http.HandlerFunc {
+	type disableTOTPRequest struct {
+		Password string `json:"password"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+		actor, ok := a.resolveSessionActor(w, r, "totp_management_denied", errTOTPManagement)
+		if !ok {
+			return
+		}
+
+		req, err := decode[disableTOTPRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		if err := a.Users.DisableTOTP(r.Context(), actor.UserID, req.Password); err != nil {
+			a.logRequestError(r, "disable_totp_failed", "disable totp failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("two-factor authentication disabled",
+			"event", "totp_disabled",
+			"resource", "user",
+			"resource_id", actor.UserID,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "totp_disabled", Resource: "user", ResourceID: actor.UserID})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// RegenerateRecoveryCodes replaces the current user's recovery codes.
+func (a *API) RegenerateRecoveryCodes() http.HandlerFunc {
+	type regenerateRequest struct {
+		Code string `json:"code"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requireLocalAuth(w, r) {
+			return
+		}
+		actor, ok := a.resolveSessionActor(w, r, "totp_management_denied", errTOTPManagement)
+		if !ok {
+			return
+		}
+
+		req, err := decode[regenerateRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		codes, err := a.Users.RegenerateRecoveryCodes(r.Context(), actor.UserID, req.Code)
+		if err != nil {
+			a.logRequestError(r, "regenerate_recovery_codes_failed", "regenerate recovery codes failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("recovery codes regenerated",
+			"event", "recovery_codes_regenerated",
+			"resource", "user",
+			"resource_id", actor.UserID,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "recovery_codes_regenerated", Resource: "user", ResourceID: actor.UserID})
+		a.respondJSON(w, http.StatusOK, recoveryCodesResponse{RecoveryCodes: codes})
+	}
+}
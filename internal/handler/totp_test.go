@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/sessions"
+	"github.com/gi8lino/motus/internal/service/users"
+	"github.com/gi8lino/motus/internal/totp"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Login asks for the second factor", func(t *testing.T) {
+		t.Parallel()
+
+		hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+		require.NoError(t, err)
+		store := &fakeUserStore{getUserWithPasswordFn: func(context.Context, string) (*db.User, string, error) {
+			verified := time.Now()
+			return &db.User{ID: "user@example.com", EmailVerifiedAt: &verified, TOTPEnabled: true}, string(hash), nil
+		}}
+		sessionStore := newFakeSessionStore()
+		api := &API{
+			Users:     users.New(store, "", false),
+			AuthStore: sessionStore,
+			Sessions:  sessions.New(sessionStore, time.Hour),
+		}
+		req := httptest.NewRequest(http.MethodPost, "/api/login", strings.NewReader(`{"email":"user@example.com","password":"secret"}`))
+		rec := httptest.NewRecorder()
+
+		api.Login().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var payload totpChallengeResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.True(t, payload.TOTPRequired)
+		assert.NotEmpty(t, payload.Challenge)
+		assert.Nil(t, sessionCookie(rec))
+	})
+
+	t.Run("LoginTOTP starts a session", func(t *testing.T) {
+		t.Parallel()
+
+		enabled := time.Now()
+		store := &fakeUserStore{
+			consumeOneTimeTokenFn: func(context.Context, string, string, time.Time) (string, error) {
+				return "user@example.com", nil
+			},
+			getTOTPFn: func(context.Context, string) (*db.TOTP, error) {
+				return &db.TOTP{Secret: testTOTPSecret, EnabledAt: &enabled}, nil
+			},
+			getUserFn: func(_ context.Context, id string) (*db.User, error) {
+				return &db.User{ID: id}, nil
+			},
+		}
+		sessionStore := newFakeSessionStore()
+		api := &API{
+			Users:     users.New(store, "", false),
+			AuthStore: sessionStore,
+			Sessions:  sessions.New(sessionStore, time.Hour),
+		}
+		code, err := totp.Code(testTOTPSecret, totp.Step(time.Now()))
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/login/totp", strings.NewReader(`{"challenge":"c","code":"`+code+`"}`))
+		rec := httptest.NewRecorder()
+
+		api.LoginTOTP().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.NotNil(t, sessionCookie(rec))
+	})
+
+	t.Run("LoginTOTP rejects expired challenge", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Users: users.New(&fakeUserStore{}, "", false)}
+		req := httptest.NewRequest(http.MethodPost, "/api/login/totp", strings.NewReader(`{"challenge":"c","code":"123456"}`))
+		rec := httptest.NewRecorder()
+
+		api.LoginTOTP().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("ConfirmTOTP returns recovery codes", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Users: users.New(&fakeUserStore{
+			getTOTPFn: func(context.Context, string) (*db.TOTP, error) {
+				return &db.TOTP{Secret: testTOTPSecret}, nil
+			},
+		}, "", false)}
+		code, err := totp.Code(testTOTPSecret, totp.Step(time.Now()))
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/me/totp/confirm", strings.NewReader(`{"code":"`+code+`"}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ConfirmTOTP().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var payload recoveryCodesResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.Len(t, payload.RecoveryCodes, totp.RecoveryCodeCount)
+	})
+
+	t.Run("BeginTOTP rejects API tokens", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{Users: users.New(&fakeUserStore{}, "", false)}
+		req := httptest.NewRequest(http.MethodPost, "/api/me/totp", nil)
+		req.Header.Set("Authorization", "Bearer motus_secret")
+		rec := httptest.NewRecorder()
+
+		api.BeginTOTP().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), errTOTPManagement.Error())
+	})
+
+	t.Run("BeginTOTP disabled with auth header", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{AuthHeader: "X-User", Users: users.New(&fakeUserStore{}, "X-User", false)}
+		req := httptest.NewRequest(http.MethodPost, "/api/me/totp", nil)
+		rec := httptest.NewRecorder()
+
+		api.BeginTOTP().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestResolveActorRequireAdminTOTP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Admin without two-factor acts as member", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{RequireAdminTOTP: true}
+		req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+		signInAdmin(t, api, req, "admin@example.com")
+
+		actor, err := api.ResolveActor(req)
+		require.NoError(t, err)
+		assert.Equal(t, "admin@example.com", actor.UserID)
+		assert.False(t, actor.IsAdmin)
+	})
+
+	t.Run("Admin with two-factor keeps admin rights", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{RequireAdminTOTP: true}
+		req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+		signInAdmin(t, api, req, "admin@example.com")
+		store, ok := api.AuthStore.(*fakeSessionStore)
+		require.True(t, ok)
+		store.mu.Lock()
+		store.totp["admin@example.com"] = true
+		store.mu.Unlock()
+
+		actor, err := api.ResolveActor(req)
+		require.NoError(t, err)
+		assert.True(t, actor.IsAdmin)
+	})
+
+	t.Run("Policy off", func(t *testing.T) {
+		t.Parallel()
+
+		api := &API{}
+		req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+		signInAdmin(t, api, req, "admin@example.com")
+
+		actor, err := api.ResolveActor(req)
+		require.NoError(t, err)
+		assert.True(t, actor.IsAdmin)
+	})
+}
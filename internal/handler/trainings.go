@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"time"
 
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/policy"
 	"github.com/gi8lino/motus/internal/service/trainings"
 )
 
+// sseHeartbeat is how often an idle training event stream sends a keep-alive comment.
+const sseHeartbeat = 25 * time.Second
+
 // CreateTraining initializes a new training state from a workout.
 func (a *API) CreateTraining() http.HandlerFunc {
 	type createTrainingRequest struct {
@@ -24,7 +31,14 @@ func (a *API) CreateTraining() http.HandlerFunc {
 			return
 		}
 
-		state, err := a.Trainings.CreateState(r.Context(), req.WorkoutID)
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		state, err := a.Trainings.CreateState(r.Context(), actor, req.WorkoutID)
 		if err != nil {
 			a.logRequestError(r, "create_training_state_failed", "create training state failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -45,33 +59,220 @@ func (a *API) CreateTraining() http.HandlerFunc {
 	}
 }
 
-// ListTrainingHistory returns completed trainings for the current user.
-func (a *API) ListTrainingHistory() http.HandlerFunc {
+// ActiveTraining returns the training the current user has in progress so any device can resume it.
+func (a *API) ActiveTraining() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		state, err := a.Trainings.Active(r.Context(), actor)
+		if err != nil {
+			a.logRequestError(r, "get_active_training_failed", "get active training failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, state)
+	}
+}
+
+// StartTraining starts the clock of a created training.
+func (a *API) StartTraining() http.HandlerFunc {
+	return a.changeTraining("training_started", "training started", a.Trainings.Start)
+}
+
+// PauseTraining pauses a running training.
+func (a *API) PauseTraining() http.HandlerFunc {
+	return a.changeTraining("training_paused", "training paused", a.Trainings.Pause)
+}
+
+// ResumeTraining resumes a paused training.
+func (a *API) ResumeTraining() http.HandlerFunc {
+	return a.changeTraining("training_resumed", "training resumed", a.Trainings.Resume)
+}
+
+// AdvanceTraining completes the current step of a training; ?from=<index> guards against double advances.
+func (a *API) AdvanceTraining() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID := r.PathValue("id")
+		from := r.URL.Query().Get("from")
+		a.changeTraining("training_advanced", "training advanced",
+			func(ctx context.Context, actor policy.Actor, id string) (trainings.TrainingState, error) {
+				return a.Trainings.Advance(ctx, actor, id, from)
+			},
+		)(w, r)
+	}
+}
 
-		resolvedID, err := a.resolveUserID(r, userID)
+// TrainingEvents streams the state of a training in progress as Server-Sent Events.
+// Each change made from any device or replica is sent as a "state" event; an "end" event
+// follows once the training is logged, aborted or replaced.
+func (a *API) TrainingEvents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		state, events, err := a.Trainings.Watch(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "watch_training_failed", "watch training failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.streamTraining(w, r, state, events)
+	}
+}
+
+// AbortTraining discards a training in progress without logging it.
+func (a *API) AbortTraining() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		state, err := a.Trainings.Abort(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "abort_training_failed", "abort training failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("training aborted",
+			"event", "training_aborted",
+			"resource", "training",
+			"resource_id", state.TrainingID,
+			"user_id", actor.UserID,
+			"workout_id", state.WorkoutID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "training_aborted",
+			Resource:   "training",
+			ResourceID: state.TrainingID,
+			Before:     map[string]any{"workoutId": state.WorkoutID, "currentIndex": state.CurrentIndex},
+		})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// streamTraining writes state and every later state from events as Server-Sent Events until the
+// client disconnects or events is closed, which is announced with an "end" event.
+func (a *API) streamTraining(w http.ResponseWriter, r *http.Request, state trainings.TrainingState, events <-chan trainings.TrainingState) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	stream := http.NewResponseController(w)
+
+	if err := writeEvent(w, "state", state); err != nil || stream.Flush() != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			// Comments keep proxies from closing an idle stream.
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case next, ok := <-events:
+			if !ok {
+				writeEvent(w, "end", statusResponse{Status: "ended"}) // nolint:errcheck
+				stream.Flush()                                        // nolint:errcheck
+				return
+			}
+			if err := writeEvent(w, "state", next); err != nil {
+				return
+			}
+		}
+		if err := stream.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// changeTraining applies a state change to the training in the path and returns the new state.
+func (a *API) changeTraining(event, message string, change func(context.Context, policy.Actor, string) (trainings.TrainingState, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		state, err := change(r.Context(), actor, r.PathValue("id"))
+		if err != nil {
+			a.logRequestError(r, "change_training_failed", "change training failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
-		items, err := a.Trainings.BuildTrainingHistory(r.Context(), resolvedID, 25)
+		a.businessLogger(r).Info(message,
+			"event", event,
+			"resource", "training",
+			"resource_id", state.TrainingID,
+			"user_id", actor.UserID,
+			"current_index", state.CurrentIndex,
+		)
+		a.respondJSON(w, http.StatusOK, state)
+	}
+}
+
+// ListTrainingHistory returns a filtered page of completed trainings for the current user.
+func (a *API) ListTrainingHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		query := r.URL.Query()
+		page, err := a.Trainings.BuildTrainingHistory(r.Context(), actor, r.PathValue("id"), trainings.HistoryQuery{
+			From:      query.Get("from"),
+			To:        query.Get("to"),
+			WorkoutID: query.Get("workoutId"),
+			Search:    query.Get("q"),
+			Cursor:    query.Get("cursor"),
+			Limit:     query.Get("limit"),
+		})
 		if err != nil {
 			a.logRequestError(r, "build_training_history_failed", "build training history failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
-		a.respondJSON(w, http.StatusOK, items)
+		a.respondJSON(w, http.StatusOK, page)
 	}
 }
 
-// TrainingSteps returns stored step timings for a training.
+// TrainingSteps returns stored step timings for a training of the current user.
 func (a *API) TrainingSteps() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		steps, err := a.Trainings.FetchStepTimings(r.Context(), r.PathValue("id"))
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		steps, err := a.Trainings.FetchStepTimings(r.Context(), actor, r.PathValue("id"))
 		if err != nil {
 			a.logRequestError(r, "fetch_step_timings_failed", "fetch step timings failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -82,6 +283,12 @@ func (a *API) TrainingSteps() http.HandlerFunc {
 	}
 }
 
+// completeTrainingResponse is the logged training with the personal records it set.
+type completeTrainingResponse struct {
+	trainings.TrainingLog
+	PersonalRecords []trainings.PersonalRecord `json:"personalRecords"`
+}
+
 // CompleteTraining records a completed training and its step timings.
 func (a *API) CompleteTraining() http.HandlerFunc {
 	type completeTrainingRequest struct {
@@ -92,6 +299,13 @@ func (a *API) CompleteTraining() http.HandlerFunc {
 		StartedAt   time.Time                     `json:"startedAt"`
 		CompletedAt time.Time                     `json:"completedAt"`
 		Steps       []trainings.TrainingStepState `json:"steps"`
+
+		Notes          string  `json:"notes"`
+		RPE            int     `json:"rpe"`
+		Mood           int     `json:"mood"`
+		Energy         int     `json:"energy"`
+		Bodyweight     float64 `json:"bodyweight"`
+		BodyweightUnit string  `json:"bodyweightUnit"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		req, err := decode[completeTrainingRequest](r)
@@ -101,15 +315,15 @@ func (a *API) CompleteTraining() http.HandlerFunc {
 			return
 		}
 
-		resolvedUserID, err := a.resolveUserID(r, req.UserID)
+		actor, err := a.ResolveActor(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
-		req.UserID = resolvedUserID
+		req.UserID = actor.UserID
 
-		log, err := a.Trainings.RecordTraining(r.Context(), trainings.CompleteRequest{
+		log, records, err := a.Trainings.RecordTraining(r.Context(), actor, trainings.CompleteRequest{
 			TrainingID:  req.TrainingID,
 			WorkoutID:   req.WorkoutID,
 			WorkoutName: req.WorkoutName,
@@ -117,6 +331,13 @@ func (a *API) CompleteTraining() http.HandlerFunc {
 			StartedAt:   req.StartedAt,
 			CompletedAt: req.CompletedAt,
 			Steps:       req.Steps,
+
+			Notes:          req.Notes,
+			RPE:            req.RPE,
+			Mood:           req.Mood,
+			Energy:         req.Energy,
+			Bodyweight:     req.Bodyweight,
+			BodyweightUnit: req.BodyweightUnit,
 		})
 		if err != nil {
 			a.logRequestError(r, "record_training_failed", "record training failed", err)
@@ -131,7 +352,206 @@ func (a *API) CompleteTraining() http.HandlerFunc {
 			"user_id", log.UserID,
 			"workout_id", log.WorkoutID,
 			"count", len(req.Steps),
+			"personal_records", len(records),
 		)
-		a.respondJSON(w, http.StatusCreated, log)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "training_completed",
+			Resource:   "training",
+			ResourceID: log.ID,
+			After:      map[string]any{"workoutId": log.WorkoutID, "steps": len(req.Steps)},
+		})
+		if records == nil {
+			records = []trainings.PersonalRecord{}
+		}
+		a.respondJSON(w, http.StatusCreated, completeTrainingResponse{TrainingLog: log, PersonalRecords: records})
+	}
+}
+
+// UpdateTraining changes the notes and ratings of a logged training.
+func (a *API) UpdateTraining() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[trainings.UpdateRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		item, err := a.Trainings.UpdateTraining(r.Context(), actor, r.PathValue("id"), req)
+		if err != nil {
+			a.logRequestError(r, "update_training_failed", "update training failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("training updated",
+			"event", "training_updated",
+			"resource", "training",
+			"resource_id", item.ID,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "training_updated",
+			Resource:   "training",
+			ResourceID: item.ID,
+			After:      map[string]any{"rpe": item.RPE, "mood": item.Mood, "energy": item.Energy, "steps": len(req.Steps)},
+		})
+		a.respondJSON(w, http.StatusOK, item)
+	}
+}
+
+// replaceTrainingResponse returns a replaced training and the personal records it sets.
+type replaceTrainingResponse struct {
+	trainings.TrainingHistoryItem
+	PersonalRecords []trainings.PersonalRecord `json:"personalRecords"`
+}
+
+// ReplaceTraining overwrites the times, steps and feedback of a logged training.
+func (a *API) ReplaceTraining() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[trainings.ReplaceRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		item, records, err := a.Trainings.ReplaceTraining(r.Context(), actor, r.PathValue("id"), req)
+		if err != nil {
+			a.logRequestError(r, "replace_training_failed", "replace training failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("training replaced",
+			"event", "training_replaced",
+			"resource", "training",
+			"resource_id", item.ID,
+			"user_id", actor.UserID,
+			"count", len(item.Steps),
+			"personal_records", len(records),
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "training_replaced",
+			Resource:   "training",
+			ResourceID: item.ID,
+			After:      map[string]any{"startedAt": item.StartedAt, "completedAt": item.CompletedAt, "steps": len(item.Steps)},
+		})
+		if records == nil {
+			records = []trainings.PersonalRecord{}
+		}
+		a.respondJSON(w, http.StatusOK, replaceTrainingResponse{TrainingHistoryItem: item, PersonalRecords: records})
+	}
+}
+
+// DeleteTraining removes a logged training.
+func (a *API) DeleteTraining() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		id := r.PathValue("id")
+		if err := a.Trainings.DeleteTraining(r.Context(), actor, id); err != nil {
+			a.logRequestError(r, "delete_training_failed", "delete training failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("training deleted",
+			"event", "training_deleted",
+			"resource", "training",
+			"resource_id", id,
+			"user_id", actor.UserID,
+		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "training_deleted", Resource: "training", ResourceID: id})
+		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
+	}
+}
+
+// LogManualTraining records a training done without the app from its workout definition.
+func (a *API) LogManualTraining() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[trainings.ManualRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		log, records, err := a.Trainings.LogManual(r.Context(), actor, req)
+		if err != nil {
+			a.logRequestError(r, "log_manual_training_failed", "log manual training failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.businessLogger(r).Info("training logged manually",
+			"event", "training_logged_manually",
+			"resource", "training",
+			"resource_id", log.ID,
+			"user_id", log.UserID,
+			"workout_id", log.WorkoutID,
+			"personal_records", len(records),
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "training_logged_manually",
+			Resource:   "training",
+			ResourceID: log.ID,
+			After:      map[string]any{"workoutId": log.WorkoutID, "startedAt": log.StartedAt},
+		})
+		if records == nil {
+			records = []trainings.PersonalRecord{}
+		}
+		a.respondJSON(w, http.StatusCreated, completeTrainingResponse{TrainingLog: log, PersonalRecords: records})
+	}
+}
+
+// ListPersonalRecords returns the personal records of the current user grouped by exercise.
+func (a *API) ListPersonalRecords() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		records, err := a.Trainings.Records(r.Context(), actor)
+		if err != nil {
+			a.logRequestError(r, "list_personal_records_failed", "list personal records failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		a.respondJSON(w, http.StatusOK, records)
 	}
 }
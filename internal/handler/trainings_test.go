@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"net/http"
@@ -13,15 +14,22 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/policy"
 	"github.com/gi8lino/motus/internal/service/sounds"
 	"github.com/gi8lino/motus/internal/service/trainings"
 )
 
 type fakeTrainingStore struct {
 	workoutWithStepsFn    func(context.Context, string) (*db.Workout, error)
-	trainingHistoryFn     func(context.Context, string, int) ([]db.TrainingLog, error)
+	trainingHistoryFn     func(context.Context, db.TrainingHistoryFilter) ([]db.TrainingLog, error)
 	trainingStepTimingsFn func(context.Context, string) ([]db.TrainingStepLog, error)
-	recordTrainingFn      func(context.Context, db.TrainingLog, []db.TrainingStepLog) error
+	recordTrainingFn      func(context.Context, db.TrainingLog, []db.TrainingStepLog) (bool, error)
+	getTrainingFn         func(context.Context, string) (*db.TrainingLog, error)
+	updateTrainingFn      func(context.Context, db.TrainingLog, []db.TrainingStepLog) error
+	replaceTrainingFn     func(context.Context, db.TrainingLog, []db.TrainingStepLog) error
+	deleteTrainingFn      func(context.Context, string) error
+	active                *db.ActiveTraining
+	records               []db.PersonalRecord
 }
 
 func (f *fakeTrainingStore) WorkoutWithSteps(ctx context.Context, id string) (*db.Workout, error) {
@@ -31,11 +39,11 @@ func (f *fakeTrainingStore) WorkoutWithSteps(ctx context.Context, id string) (*d
 	return f.workoutWithStepsFn(ctx, id)
 }
 
-func (f *fakeTrainingStore) TrainingHistory(ctx context.Context, userID string, limit int) ([]db.TrainingLog, error) {
+func (f *fakeTrainingStore) TrainingHistory(ctx context.Context, filter db.TrainingHistoryFilter) ([]db.TrainingLog, error) {
 	if f.trainingHistoryFn == nil {
 		return nil, nil
 	}
-	return f.trainingHistoryFn(ctx, userID, limit)
+	return f.trainingHistoryFn(ctx, filter)
 }
 
 func (f *fakeTrainingStore) TrainingStepTimings(ctx context.Context, trainingID string) ([]db.TrainingStepLog, error) {
@@ -45,13 +53,101 @@ func (f *fakeTrainingStore) TrainingStepTimings(ctx context.Context, trainingID
 	return f.trainingStepTimingsFn(ctx, trainingID)
 }
 
-func (f *fakeTrainingStore) RecordTraining(ctx context.Context, log db.TrainingLog, steps []db.TrainingStepLog) error {
+func (f *fakeTrainingStore) StepTimingsForTrainings(ctx context.Context, trainingIDs []string) (map[string][]db.TrainingStepLog, error) {
+	timings := make(map[string][]db.TrainingStepLog, len(trainingIDs))
+	for _, id := range trainingIDs {
+		steps, err := f.TrainingStepTimings(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		timings[id] = steps
+	}
+	return timings, nil
+}
+
+func (f *fakeTrainingStore) RecordTraining(ctx context.Context, log db.TrainingLog, steps []db.TrainingStepLog) (bool, error) {
 	if f.recordTrainingFn == nil {
-		return nil
+		return true, nil
 	}
 	return f.recordTrainingFn(ctx, log, steps)
 }
 
+func (f *fakeTrainingStore) GetTraining(ctx context.Context, id string) (*db.TrainingLog, error) {
+	if f.getTrainingFn == nil {
+		return nil, db.ErrTrainingNotFound
+	}
+	return f.getTrainingFn(ctx, id)
+}
+
+func (f *fakeTrainingStore) UpdateTraining(ctx context.Context, log db.TrainingLog, steps []db.TrainingStepLog) error {
+	if f.updateTrainingFn == nil {
+		return nil
+	}
+	return f.updateTrainingFn(ctx, log, steps)
+}
+
+func (f *fakeTrainingStore) ReplaceTraining(ctx context.Context, log db.TrainingLog, steps []db.TrainingStepLog) error {
+	if f.replaceTrainingFn == nil {
+		return nil
+	}
+	return f.replaceTrainingFn(ctx, log, steps)
+}
+
+func (f *fakeTrainingStore) DeleteTraining(ctx context.Context, id string) error {
+	if f.deleteTrainingFn == nil {
+		return nil
+	}
+	return f.deleteTrainingFn(ctx, id)
+}
+
+func (f *fakeTrainingStore) CreateActiveTraining(_ context.Context, training db.ActiveTraining) error {
+	f.active = &training
+	return nil
+}
+
+func (f *fakeTrainingStore) GetActiveTraining(_ context.Context, id string) (*db.ActiveTraining, error) {
+	if f.active == nil || f.active.ID != id {
+		return nil, db.ErrActiveTrainingNotFound
+	}
+	return f.active, nil
+}
+
+func (f *fakeTrainingStore) ActiveTrainingForUser(_ context.Context, userID string) (*db.ActiveTraining, error) {
+	if f.active == nil || f.active.UserID != userID {
+		return nil, db.ErrActiveTrainingNotFound
+	}
+	return f.active, nil
+}
+
+func (f *fakeTrainingStore) UpdateActiveTraining(_ context.Context, training db.ActiveTraining, prev time.Time) error {
+	if f.active == nil || f.active.ID != training.ID {
+		return db.ErrActiveTrainingNotFound
+	}
+	if !f.active.UpdatedAt.Equal(prev) {
+		return db.ErrActiveTrainingConflict
+	}
+	f.active.State = training.State
+	f.active.UpdatedAt = training.UpdatedAt
+	return nil
+}
+
+func (f *fakeTrainingStore) DeleteActiveTraining(_ context.Context, id string) error {
+	if f.active == nil || f.active.ID != id {
+		return db.ErrActiveTrainingNotFound
+	}
+	f.active = nil
+	return nil
+}
+
+func (f *fakeTrainingStore) PersonalRecords(context.Context, string) ([]db.PersonalRecord, error) {
+	return f.records, nil
+}
+
+func (f *fakeTrainingStore) AddPersonalRecords(_ context.Context, records []db.PersonalRecord) error {
+	f.records = append(f.records, records...)
+	return nil
+}
+
 func TestTrainingsHandlers(t *testing.T) {
 	t.Run("Create training", func(t *testing.T) {
 		store := &fakeTrainingStore{workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
@@ -68,6 +164,7 @@ func TestTrainingsHandlers(t *testing.T) {
 		h := api.CreateTraining()
 		body := strings.NewReader(`{"workoutId":"w1"}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/trainings", body)
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -82,7 +179,7 @@ func TestTrainingsHandlers(t *testing.T) {
 
 	t.Run("List training history", func(t *testing.T) {
 		store := &fakeTrainingStore{
-			trainingHistoryFn: func(context.Context, string, int) ([]db.TrainingLog, error) {
+			trainingHistoryFn: func(context.Context, db.TrainingHistoryFilter) ([]db.TrainingLog, error) {
 				return []db.TrainingLog{{ID: "s1", WorkoutID: "w1", UserID: "user@example.com"}}, nil
 			},
 			trainingStepTimingsFn: func(context.Context, string) ([]db.TrainingStepLog, error) {
@@ -93,25 +190,56 @@ func TestTrainingsHandlers(t *testing.T) {
 		h := api.ListTrainingHistory()
 		req := httptest.NewRequest(http.MethodGet, "/api/users/user@example.com/trainings", nil)
 		req.SetPathValue("id", "user@example.com")
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
 
 		require.Equal(t, http.StatusOK, rec.Code)
-		var payload []map[string]any
+		var payload trainings.HistoryPage
 		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
-		require.Len(t, payload, 1)
+		require.Len(t, payload.Items, 1)
+		assert.Empty(t, payload.NextCursor)
+	})
+
+	t.Run("Training history filters", func(t *testing.T) {
+		var got db.TrainingHistoryFilter
+		store := &fakeTrainingStore{
+			trainingHistoryFn: func(_ context.Context, filter db.TrainingHistoryFilter) ([]db.TrainingLog, error) {
+				got = filter
+				return nil, nil
+			},
+		}
+		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
+		h := api.ListTrainingHistory()
+		req := httptest.NewRequest(http.MethodGet, "/api/users/user@example.com/trainings/history?from=2024-01-01&workoutId=w1&q=legs&limit=10", nil)
+		req.SetPathValue("id", "user@example.com")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "w1", got.WorkoutID)
+		assert.Equal(t, "legs", got.Search)
+		assert.Equal(t, 11, got.Limit)
+		require.NotNil(t, got.From)
 	})
 
 	t.Run("Training steps", func(t *testing.T) {
-		store := &fakeTrainingStore{trainingStepTimingsFn: func(context.Context, string) ([]db.TrainingStepLog, error) {
-			return []db.TrainingStepLog{{ID: "s1-0", TrainingID: "s1", StepOrder: 0}}, nil
-		}}
+		store := &fakeTrainingStore{
+			getTrainingFn: func(context.Context, string) (*db.TrainingLog, error) {
+				return &db.TrainingLog{ID: "s1", UserID: "user@example.com"}, nil
+			},
+			trainingStepTimingsFn: func(context.Context, string) ([]db.TrainingStepLog, error) {
+				return []db.TrainingStepLog{{ID: "s1-0", TrainingID: "s1", StepOrder: 0}}, nil
+			},
+		}
 		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
 		h := api.TrainingSteps()
 		req := httptest.NewRequest(http.MethodGet, "/api/trainings/s1/steps", nil)
 		req.SetPathValue("id", "s1")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -124,12 +252,12 @@ func TestTrainingsHandlers(t *testing.T) {
 	})
 
 	t.Run("Complete training", func(t *testing.T) {
-		store := &fakeTrainingStore{recordTrainingFn: func(context.Context, db.TrainingLog, []db.TrainingStepLog) error { return nil }}
+		store := &fakeTrainingStore{recordTrainingFn: func(context.Context, db.TrainingLog, []db.TrainingStepLog) (bool, error) { return true, nil }}
 		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
 		h := api.CompleteTraining()
 		body := strings.NewReader(`{"trainingId":"s1","workoutId":"w1","workoutName":"Workout","userId":"user@example.com","startedAt":"2024-01-01T00:00:00Z","completedAt":"2024-01-01T00:00:10Z","steps":[{"id":"s1","name":"Step","type":"set","elapsedMillis":1000}]}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/trainings/complete", body)
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -140,4 +268,277 @@ func TestTrainingsHandlers(t *testing.T) {
 		assert.Equal(t, "s1", payload.ID)
 		assert.WithinDuration(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), payload.StartedAt, time.Second)
 	})
+
+	t.Run("Complete training flags personal records", func(t *testing.T) {
+		store := &fakeTrainingStore{}
+		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
+		h := api.CompleteTraining()
+		body := strings.NewReader(`{"trainingId":"s1","workoutId":"w1","userId":"user@example.com","startedAt":"2024-01-01T00:00:00Z","completedAt":"2024-01-01T00:30:00Z","steps":[{"id":"s1","name":"Squats","type":"set","results":[{"exerciseId":"squat","name":"Squat","reps":5,"weight":100}]}]}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/trainings/complete", body)
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var payload struct {
+			ID              string              `json:"id"`
+			PersonalRecords []db.PersonalRecord `json:"personalRecords"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.Equal(t, "s1", payload.ID)
+		require.Len(t, payload.PersonalRecords, 3)
+		assert.Equal(t, db.RecordMaxWeight, payload.PersonalRecords[0].Kind)
+		assert.Len(t, store.records, 3)
+	})
+
+	t.Run("Update training", func(t *testing.T) {
+		var updated db.TrainingLog
+		store := &fakeTrainingStore{
+			getTrainingFn: func(_ context.Context, id string) (*db.TrainingLog, error) {
+				return &db.TrainingLog{
+					ID:          id,
+					UserID:      "user@example.com",
+					StartedAt:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+					CompletedAt: time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC),
+				}, nil
+			},
+			updateTrainingFn: func(_ context.Context, log db.TrainingLog, _ []db.TrainingStepLog) error {
+				updated = log
+				return nil
+			},
+		}
+		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
+		req := httptest.NewRequest(http.MethodPatch, "/api/trainings/s1", strings.NewReader(`{"notes":"Felt strong","rpe":6}`))
+		req.SetPathValue("id", "s1")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.UpdateTraining().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "Felt strong", updated.Notes)
+		var payload trainings.TrainingHistoryItem
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.Equal(t, 6, payload.RPE)
+		assert.Equal(t, 270, payload.Load)
+	})
+
+	t.Run("Replace training", func(t *testing.T) {
+		var replaced db.TrainingLog
+		store := &fakeTrainingStore{
+			getTrainingFn: func(_ context.Context, id string) (*db.TrainingLog, error) {
+				return &db.TrainingLog{
+					ID:          id,
+					WorkoutID:   "w1",
+					UserID:      "user@example.com",
+					StartedAt:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+					CompletedAt: time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC),
+				}, nil
+			},
+			replaceTrainingFn: func(_ context.Context, log db.TrainingLog, _ []db.TrainingStepLog) error {
+				replaced = log
+				return nil
+			},
+		}
+		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
+		body := `{"completedAt":"2024-01-01T10:30:00Z","steps":[{"id":"s1","name":"Step","type":"set","elapsedMillis":1800000}]}`
+		req := httptest.NewRequest(http.MethodPut, "/api/trainings/t1", strings.NewReader(body))
+		req.SetPathValue("id", "t1")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ReplaceTraining().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC), replaced.CompletedAt)
+		var payload struct {
+			Steps           []db.TrainingStepLog `json:"steps"`
+			PersonalRecords []db.PersonalRecord  `json:"personalRecords"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.Len(t, payload.Steps, 1)
+		assert.NotNil(t, payload.PersonalRecords)
+	})
+
+	t.Run("Delete training of another user", func(t *testing.T) {
+		deleted := false
+		store := &fakeTrainingStore{
+			getTrainingFn: func(_ context.Context, id string) (*db.TrainingLog, error) {
+				return &db.TrainingLog{ID: id, UserID: "owner@example.com"}, nil
+			},
+			deleteTrainingFn: func(context.Context, string) error {
+				deleted = true
+				return nil
+			},
+		}
+		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
+		req := httptest.NewRequest(http.MethodDelete, "/api/trainings/t1", nil)
+		req.SetPathValue("id", "t1")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.DeleteTraining().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.False(t, deleted)
+	})
+
+	t.Run("Log manual training", func(t *testing.T) {
+		var recorded []db.TrainingStepLog
+		store := &fakeTrainingStore{
+			workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
+				return &db.Workout{
+					ID:     "w1",
+					UserID: "user@example.com",
+					Name:   "Workout",
+					Steps:  []db.WorkoutStep{{ID: "s1", Type: "pause", Name: "Rest", EstimatedSeconds: 90}},
+				}, nil
+			},
+			recordTrainingFn: func(_ context.Context, _ db.TrainingLog, steps []db.TrainingStepLog) (bool, error) {
+				recorded = steps
+				return true, nil
+			},
+		}
+		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
+		req := httptest.NewRequest(http.MethodPost, "/api/trainings/manual", strings.NewReader(`{"workoutId":"w1","startedAt":"2024-01-01T10:00:00Z"}`))
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.LogManualTraining().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, int64(90_000), recorded[0].ElapsedMillis)
+		var payload db.TrainingLog
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 1, 30, 0, time.UTC), payload.CompletedAt)
+	})
+
+	t.Run("Start, resume on another device and abort", func(t *testing.T) {
+		store := &fakeTrainingStore{workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
+			return &db.Workout{ID: "w1", UserID: "user@example.com", Name: "Workout", Steps: []db.WorkoutStep{
+				{ID: "s1", Type: "pause", Name: "Rest", EstimatedSeconds: 10},
+			}}, nil
+		}}
+		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
+		ctx := context.Background()
+		state, err := api.Trainings.CreateState(ctx, policy.NewActor("user@example.com", db.RoleMember), "w1")
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/trainings/"+state.TrainingID+"/start", nil)
+		req.SetPathValue("id", state.TrainingID)
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+		api.StartTraining().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/me/trainings/active", nil)
+		signIn(t, api, req, "user@example.com")
+		rec = httptest.NewRecorder()
+		api.ActiveTraining().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var active trainings.TrainingState
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&active))
+		assert.Equal(t, state.TrainingID, active.TrainingID)
+		assert.True(t, active.Running)
+
+		req = httptest.NewRequest(http.MethodPost, "/api/trainings/"+state.TrainingID+"/abort", nil)
+		req.SetPathValue("id", state.TrainingID)
+		signIn(t, api, req, "user@example.com")
+		rec = httptest.NewRecorder()
+		api.AbortTraining().ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Nil(t, store.active)
+	})
+
+	t.Run("Training events stream changes until the training ends", func(t *testing.T) {
+		store := &fakeTrainingStore{workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
+			return &db.Workout{ID: "w1", UserID: "user@example.com", Name: "Workout", Steps: []db.WorkoutStep{
+				{ID: "s1", Type: "pause", Name: "Rest", EstimatedSeconds: 10},
+				{ID: "s2", Type: "pause", Name: "Rest", EstimatedSeconds: 10},
+			}}, nil
+		}}
+		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
+		owner := policy.NewActor("user@example.com", db.RoleMember)
+		ctx := context.Background()
+		state, err := api.Trainings.CreateState(ctx, owner, "w1")
+		require.NoError(t, err)
+		_, err = api.Trainings.Start(ctx, owner, state.TrainingID)
+		require.NoError(t, err)
+
+		mux := http.NewServeMux()
+		mux.Handle("GET /api/trainings/{id}/events", api.TrainingEvents())
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/api/trainings/"+state.TrainingID+"/events", nil)
+		require.NoError(t, err)
+		signIn(t, api, req, "user@example.com")
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close() // nolint:errcheck
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		events := bufio.NewReader(resp.Body)
+		next := func() (string, string) {
+			t.Helper()
+			var event, data string
+			for {
+				line, err := events.ReadString('\n')
+				require.NoError(t, err)
+				line = strings.TrimSuffix(line, "\n")
+				switch {
+				case line == "":
+					return event, data
+				case strings.HasPrefix(line, "event: "):
+					event = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					data = strings.TrimPrefix(line, "data: ")
+				}
+			}
+		}
+
+		event, data := next()
+		assert.Equal(t, "state", event)
+		assert.Contains(t, data, `"currentIndex":0`)
+
+		_, err = api.Trainings.Advance(ctx, owner, state.TrainingID, "")
+		require.NoError(t, err)
+		api.Trainings.Publish(ctx, state.TrainingID)
+		event, data = next()
+		assert.Equal(t, "state", event)
+		assert.Contains(t, data, `"currentIndex":1`)
+
+		_, err = api.Trainings.Abort(ctx, owner, state.TrainingID)
+		require.NoError(t, err)
+		api.Trainings.Publish(ctx, state.TrainingID)
+		event, _ = next()
+		assert.Equal(t, "end", event)
+	})
+
+	t.Run("Training events of other users are forbidden", func(t *testing.T) {
+		store := &fakeTrainingStore{active: &db.ActiveTraining{ID: "t1", UserID: "owner@example.com", State: []byte(`{}`)}}
+		api := &API{Trainings: trainings.New(store, sounds.URLByKey)}
+		req := httptest.NewRequest(http.MethodGet, "/api/trainings/t1/events", nil)
+		req.SetPathValue("id", "t1")
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.TrainingEvents().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("No active training", func(t *testing.T) {
+		api := &API{Trainings: trainings.New(&fakeTrainingStore{}, sounds.URLByKey)}
+		req := httptest.NewRequest(http.MethodGet, "/api/me/trainings/active", nil)
+		signIn(t, api, req, "user@example.com")
+		rec := httptest.NewRecorder()
+
+		api.ActiveTraining().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
 }
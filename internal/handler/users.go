@@ -1,6 +1,12 @@
 package handler
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/auth"
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/users"
+)
 
 // GetUsers lists all users.
 func (a *API) GetUsers() http.HandlerFunc {
@@ -16,22 +22,83 @@ func (a *API) GetUsers() http.HandlerFunc {
 	}
 }
 
-// CreateUser registers a new local user.
+// CreateUser registers a new local user, either through an invite link or by self-registration.
 func (a *API) CreateUser() http.HandlerFunc {
 	type createUserRequest struct {
+		Email       string `json:"email"`
+		AvatarURL   string `json:"avatarUrl"`
+		Password    string `json:"password"`
+		InviteToken string `json:"inviteToken"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[createUserRequest](r)
+		if err != nil {
+			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
+			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			return
+		}
+
+		// Self-registration creates an unverified account; an admin vouched for invited accounts, so they skip verification.
+		invited := req.InviteToken != ""
+		var user *users.User
+		if invited {
+			user, err = a.Users.AcceptInvitation(r.Context(), req.Email, req.AvatarURL, req.Password, req.InviteToken)
+		} else {
+			user, err = a.Users.Register(r.Context(), req.Email, req.Password)
+		}
+		if err != nil {
+			a.logRequestError(r, "create_user_failed", "create user failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		// The account exists either way; the user can request a new link if mailing fails.
+		if !invited {
+			if err := a.Accounts.SendVerification(r.Context(), user.ID); err != nil {
+				a.logRequestError(r, "send_verification_failed", "send verification failed", err)
+			}
+		}
+
+		a.businessLogger(r).Info("user created",
+			"event", "user_created",
+			"resource", "user",
+			"resource_id", user.ID,
+			"user_id", user.ID,
+		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    user.ID,
+			Action:     "user_created",
+			Resource:   "user",
+			ResourceID: user.ID,
+			After:      map[string]any{"role": user.Role, "selfRegistration": !invited, "invited": invited},
+		})
+		a.respondJSON(w, http.StatusCreated, user)
+	}
+}
+
+// AdminCreateUser creates a verified account on behalf of an administrator.
+func (a *API) AdminCreateUser() http.HandlerFunc {
+	type adminCreateUserRequest struct {
 		Email     string `json:"email"`
 		AvatarURL string `json:"avatarUrl"`
 		Password  string `json:"password"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
-		req, err := decode[createUserRequest](r)
+		req, err := decode[adminCreateUserRequest](r)
 		if err != nil {
 			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
 			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
 			return
 		}
 
-		user, err := a.Users.Create(r.Context(), req.Email, req.AvatarURL, req.Password)
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		user, err := a.Users.Create(r.Context(), actor, req.Email, req.AvatarURL, req.Password)
 		if err != nil {
 			a.logRequestError(r, "create_user_failed", "create user failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -42,16 +109,23 @@ func (a *API) CreateUser() http.HandlerFunc {
 			"event", "user_created",
 			"resource", "user",
 			"resource_id", user.ID,
-			"user_id", user.ID,
+			"user_id", actor.UserID,
 		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "user_created",
+			Resource:   "user",
+			ResourceID: user.ID,
+			After:      map[string]any{"role": user.Role, "selfRegistration": false, "invited": false},
+		})
 		a.respondJSON(w, http.StatusCreated, user)
 	}
 }
 
-// UpdateUserRole toggles admin access.
+// UpdateUserRole assigns a role to a user.
 func (a *API) UpdateUserRole() http.HandlerFunc {
 	type updateUserRoleRequest struct {
-		IsAdmin bool `json:"isAdmin"`
+		Role string `json:"role"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
@@ -63,7 +137,13 @@ func (a *API) UpdateUserRole() http.HandlerFunc {
 			return
 		}
 
-		if err := a.Users.UpdateRole(r.Context(), id, req.IsAdmin); err != nil {
+		// Read the current role first so the audit log records the transition.
+		var before map[string]any
+		if user, err := a.Users.Get(r.Context(), id); err == nil && user != nil {
+			before = map[string]any{"role": user.Role}
+		}
+
+		if err := a.Users.UpdateRole(r.Context(), id, req.Role); err != nil {
 			a.logRequestError(r, "update_user_role_failed", "update user role failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
@@ -74,8 +154,15 @@ func (a *API) UpdateUserRole() http.HandlerFunc {
 			"resource", "user",
 			"resource_id", id,
 			"user_id", id,
-			"is_admin", req.IsAdmin,
+			"role", req.Role,
 		)
+		a.recordAudit(r, audit.Event{
+			Action:     "user_role_updated",
+			Resource:   "user",
+			ResourceID: id,
+			Before:     before,
+			After:      map[string]any{"role": req.Role},
+		})
 		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
 	}
 }
@@ -87,6 +174,11 @@ func (a *API) Login() http.HandlerFunc {
 		Password string `json:"password"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
+		if a.authMode() == authModeOIDC {
+			a.respondJSON(w, http.StatusForbidden, apiError{Error: "local login is disabled"})
+			return
+		}
+
 		req, err := decode[loginRequest](r)
 		if err != nil {
 			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
@@ -94,21 +186,47 @@ func (a *API) Login() http.HandlerFunc {
 			return
 		}
 
-		user, err := a.Users.Login(r.Context(), req.Email, req.Password)
+		result, err := a.Users.Login(r.Context(), req.Email, req.Password)
 		if err != nil {
 			a.logRequestError(r, "login_user_failed", "login user failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
+		if result.Challenge != "" {
+			a.respondJSON(w, http.StatusOK, totpChallengeResponse{TOTPRequired: true, Challenge: result.Challenge})
+			return
+		}
 
-		a.businessLogger(r).Info("user login",
-			"event", "user_login",
-			"resource", "user",
-			"resource_id", user.ID,
-			"user_id", user.ID,
-		)
-		a.respondJSON(w, http.StatusOK, user)
+		a.completeLogin(w, r, result.User)
+	}
+}
+
+// completeLogin replaces any presented session with a new one for user.
+func (a *API) completeLogin(w http.ResponseWriter, r *http.Request, user *users.User) {
+	// Drop any session presented with the login to prevent session fixation.
+	if err := a.Sessions.Revoke(r.Context(), auth.SessionToken(r)); err != nil {
+		a.logRequestError(r, "revoke_session_failed", "revoke session failed", err)
+	}
+	if err := a.startSession(w, r, user.ID); err != nil {
+		a.logRequestError(r, "create_session_failed", "create session failed", err)
+		a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+		return
 	}
+
+	a.businessLogger(r).Info("user login",
+		"event", "user_login",
+		"resource", "user",
+		"resource_id", user.ID,
+		"user_id", user.ID,
+	)
+	a.recordAudit(r, audit.Event{
+		ActorID:    user.ID,
+		Action:     "user_login",
+		Resource:   "user",
+		ResourceID: user.ID,
+		After:      map[string]any{"method": "password"},
+	})
+	a.respondJSON(w, http.StatusOK, user)
 }
 
 // ChangePassword updates the password for the current user.
@@ -118,10 +236,10 @@ func (a *API) ChangePassword() http.HandlerFunc {
 		NewPassword     string `json:"newPassword"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID, err := a.resolveUserID(r, "")
+		userID, err := a.ResolveUserID(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
@@ -144,6 +262,7 @@ func (a *API) ChangePassword() http.HandlerFunc {
 			"resource_id", userID,
 			"user_id", userID,
 		)
+		a.recordAudit(r, audit.Event{ActorID: userID, Action: "user_password_changed", Resource: "user", ResourceID: userID})
 		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
 	}
 }
@@ -154,10 +273,10 @@ func (a *API) UpdateUserName() http.HandlerFunc {
 		Name string `json:"name"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID, err := a.resolveUserID(r, "")
+		userID, err := a.ResolveUserID(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
@@ -180,6 +299,13 @@ func (a *API) UpdateUserName() http.HandlerFunc {
 			"resource_id", userID,
 			"user_id", userID,
 		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    userID,
+			Action:     "user_name_updated",
+			Resource:   "user",
+			ResourceID: userID,
+			After:      map[string]any{"name": req.Name},
+		})
 		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
 	}
 }
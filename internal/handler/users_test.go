@@ -7,13 +7,18 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gi8lino/motus/internal/auth"
 	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/accounts"
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/sessions"
 	"github.com/gi8lino/motus/internal/service/users"
 )
 
@@ -22,9 +27,15 @@ type fakeUserStore struct {
 	listUsersFn           func(context.Context) ([]db.User, error)
 	getUserWithPasswordFn func(context.Context, string) (*db.User, string, error)
 	updateUserPasswordFn  func(context.Context, string, string) error
-	updateUserAdminFn     func(context.Context, string, bool) error
+	updateUserRoleFn      func(context.Context, string, string) error
 	updateUserNameFn      func(context.Context, string, string) error
 	createUserFn          func(context.Context, string, string, string) (*db.User, error)
+	createPendingUserFn   func(context.Context, string, string) (*db.User, error)
+	createInvitedUserFn   func(context.Context, string, string, string, string, time.Time) (*db.User, error)
+	createOneTimeTokenFn  func(context.Context, db.OneTimeToken) error
+	consumeOneTimeTokenFn func(context.Context, string, string, time.Time) (string, error)
+	getTOTPFn             func(context.Context, string) (*db.TOTP, error)
+	enableTOTPFn          func(context.Context, string, int64, []string, time.Time) error
 }
 
 func (f *fakeUserStore) GetUser(ctx context.Context, id string) (*db.User, error) {
@@ -55,11 +66,11 @@ func (f *fakeUserStore) UpdateUserPassword(ctx context.Context, id, passwordHash
 	return f.updateUserPasswordFn(ctx, id, passwordHash)
 }
 
-func (f *fakeUserStore) UpdateUserAdmin(ctx context.Context, id string, isAdmin bool) error {
-	if f.updateUserAdminFn == nil {
+func (f *fakeUserStore) UpdateUserRole(ctx context.Context, id, role string) error {
+	if f.updateUserRoleFn == nil {
 		return nil
 	}
-	return f.updateUserAdminFn(ctx, id, isAdmin)
+	return f.updateUserRoleFn(ctx, id, role)
 }
 
 func (f *fakeUserStore) UpdateUserName(ctx context.Context, id, name string) error {
@@ -76,6 +87,60 @@ func (f *fakeUserStore) CreateUser(ctx context.Context, email, avatarURL, passwo
 	return f.createUserFn(ctx, email, avatarURL, passwordHash)
 }
 
+func (f *fakeUserStore) CreatePendingUser(ctx context.Context, email, passwordHash string) (*db.User, error) {
+	if f.createPendingUserFn == nil {
+		return nil, nil
+	}
+	return f.createPendingUserFn(ctx, email, passwordHash)
+}
+
+func (f *fakeUserStore) CreateInvitedUser(ctx context.Context, tokenHash, email, avatarURL, passwordHash string, at time.Time) (*db.User, error) {
+	if f.createInvitedUserFn == nil {
+		return nil, db.ErrInvitationInvalid
+	}
+	return f.createInvitedUserFn(ctx, tokenHash, email, avatarURL, passwordHash, at)
+}
+
+func (f *fakeUserStore) CreateOneTimeToken(ctx context.Context, token db.OneTimeToken) error {
+	if f.createOneTimeTokenFn == nil {
+		return nil
+	}
+	return f.createOneTimeTokenFn(ctx, token)
+}
+
+func (f *fakeUserStore) ConsumeOneTimeToken(ctx context.Context, tokenHash, purpose string, at time.Time) (string, error) {
+	if f.consumeOneTimeTokenFn == nil {
+		return "", db.ErrOneTimeTokenNotFound
+	}
+	return f.consumeOneTimeTokenFn(ctx, tokenHash, purpose, at)
+}
+
+func (f *fakeUserStore) GetTOTP(ctx context.Context, userID string) (*db.TOTP, error) {
+	if f.getTOTPFn == nil {
+		return &db.TOTP{}, nil
+	}
+	return f.getTOTPFn(ctx, userID)
+}
+
+func (f *fakeUserStore) SetPendingTOTP(context.Context, string, string) error { return nil }
+
+func (f *fakeUserStore) EnableTOTP(ctx context.Context, userID string, step int64, codeHashes []string, at time.Time) error {
+	if f.enableTOTPFn == nil {
+		return nil
+	}
+	return f.enableTOTPFn(ctx, userID, step, codeHashes, at)
+}
+
+func (f *fakeUserStore) DisableTOTP(context.Context, string) error { return nil }
+
+func (f *fakeUserStore) ClaimTOTPStep(context.Context, string, int64) (bool, error) { return true, nil }
+
+func (f *fakeUserStore) ReplaceRecoveryCodes(context.Context, string, []string) error { return nil }
+
+func (f *fakeUserStore) UseRecoveryCode(context.Context, string, string, time.Time) (bool, error) {
+	return false, nil
+}
+
 func TestUsersHandlers(t *testing.T) {
 	t.Run("List users", func(t *testing.T) {
 		store := &fakeUserStore{listUsersFn: func(context.Context) ([]db.User, error) {
@@ -96,10 +161,16 @@ func TestUsersHandlers(t *testing.T) {
 	})
 
 	t.Run("Create user", func(t *testing.T) {
-		store := &fakeUserStore{createUserFn: func(context.Context, string, string, string) (*db.User, error) {
-			return &db.User{ID: "user@example.com"}, nil
+		store := &fakeUserStore{createPendingUserFn: func(_ context.Context, email, _ string) (*db.User, error) {
+			return &db.User{ID: email}, nil
 		}}
-		api := &API{Users: users.New(store, "", true), AllowRegistration: true}
+		mail := &fakeMailer{}
+		api := &API{
+			Users:             users.New(store, "", true),
+			Sessions:          sessions.New(newFakeSessionStore(), time.Hour),
+			Accounts:          accounts.New(newFakeAccountStore(), mail, "http://localhost:8080"),
+			AllowRegistration: true,
+		}
 		h := api.CreateUser()
 		body := strings.NewReader(`{"email":"user@example.com","avatarUrl":"","password":"secret"}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/users", body)
@@ -111,20 +182,143 @@ func TestUsersHandlers(t *testing.T) {
 		var payload db.User
 		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
 		assert.Equal(t, "user@example.com", payload.ID)
+		assert.Nil(t, payload.EmailVerifiedAt)
+		assert.Nil(t, sessionCookie(rec), "self-registration waits for email verification")
+		require.Len(t, mail.messages(), 1)
+		assert.Contains(t, mail.messages()[0].Body, "?verifyToken=")
+	})
+
+	t.Run("Create user with a forged session cookie stays unverified", func(t *testing.T) {
+		store := &fakeUserStore{
+			createPendingUserFn: func(_ context.Context, email, _ string) (*db.User, error) {
+				return &db.User{ID: email}, nil
+			},
+			createUserFn: func(context.Context, string, string, string) (*db.User, error) {
+				t.Fatal("forged cookie created a verified account")
+				return nil, nil
+			},
+		}
+		api := &API{
+			Users:             users.New(store, "", true),
+			Sessions:          sessions.New(newFakeSessionStore(), time.Hour),
+			Accounts:          accounts.New(newFakeAccountStore(), &fakeMailer{}, "http://localhost:8080"),
+			AllowRegistration: true,
+		}
+		body := strings.NewReader(`{"email":"user@example.com","password":"secret"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/users", body)
+		req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: "forged"})
+		rec := httptest.NewRecorder()
+
+		api.CreateUser().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var payload db.User
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.Nil(t, payload.EmailVerifiedAt)
+	})
+
+	t.Run("Admin creates verified user", func(t *testing.T) {
+		verified := time.Now()
+		store := &fakeUserStore{createUserFn: func(_ context.Context, email, _, _ string) (*db.User, error) {
+			return &db.User{ID: email, EmailVerifiedAt: &verified}, nil
+		}}
+		auditStore := &fakeAuditStore{}
+		api := &API{Users: users.New(store, "", false), Audit: audit.New(auditStore)}
+		body := strings.NewReader(`{"email":"user@example.com","password":"secret"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/users", body)
+		signInAdmin(t, api, req, "admin@example.com")
+		rec := httptest.NewRecorder()
+
+		api.AdminCreateUser().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var payload db.User
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.NotNil(t, payload.EmailVerifiedAt)
+		require.Len(t, auditStore.events, 1)
+		assert.Equal(t, "admin@example.com", auditStore.events[0].ActorID)
+		assert.Equal(t, "user@example.com", auditStore.events[0].ResourceID)
+	})
+
+	t.Run("Members cannot create verified users", func(t *testing.T) {
+		api := &API{Users: users.New(&fakeUserStore{}, "", true)}
+		body := strings.NewReader(`{"email":"user@example.com","password":"secret"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/users", body)
+		signIn(t, api, req, "member@example.com")
+		rec := httptest.NewRecorder()
+
+		api.AdminCreateUser().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("Create user with invitation", func(t *testing.T) {
+		verified := time.Now()
+		store := &fakeUserStore{createInvitedUserFn: func(_ context.Context, _, email, _, _ string, _ time.Time) (*db.User, error) {
+			return &db.User{ID: email, IsAdmin: true, EmailVerifiedAt: &verified}, nil
+		}}
+		mail := &fakeMailer{}
+		api := &API{
+			Users:    users.New(store, "", false),
+			Sessions: sessions.New(newFakeSessionStore(), time.Hour),
+			Accounts: accounts.New(newFakeAccountStore(), mail, "http://localhost:8080"),
+		}
+		h := api.CreateUser()
+		body := strings.NewReader(`{"email":"user@example.com","password":"secret","inviteToken":"invite"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/users", body)
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var payload db.User
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
+		assert.True(t, payload.IsAdmin)
+		assert.NotNil(t, payload.EmailVerifiedAt)
+		assert.Empty(t, mail.messages(), "invited users skip email verification")
+	})
+
+	t.Run("Create user with invalid invitation", func(t *testing.T) {
+		api := &API{Users: users.New(&fakeUserStore{}, "", false)}
+		h := api.CreateUser()
+		body := strings.NewReader(`{"email":"user@example.com","password":"secret","inviteToken":"invite"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/users", body)
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
 	})
 
 	t.Run("Update user role", func(t *testing.T) {
-		store := &fakeUserStore{updateUserAdminFn: func(context.Context, string, bool) error { return nil }}
+		var updated string
+		store := &fakeUserStore{updateUserRoleFn: func(_ context.Context, _ string, role string) error {
+			updated = role
+			return nil
+		}}
 		api := &API{Users: users.New(store, "", false)}
 		h := api.UpdateUserRole()
-		body := strings.NewReader(`{"isAdmin":true}`)
-		req := httptest.NewRequest(http.MethodPut, "/api/users/user@example.com/admin", body)
+		body := strings.NewReader(`{"role":"catalog-editor"}`)
+		req := httptest.NewRequest(http.MethodPut, "/api/users/user@example.com/role", body)
 		req.SetPathValue("id", "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
 
 		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, db.RoleCatalogEditor, updated)
+	})
+
+	t.Run("Update user role rejects unknown roles", func(t *testing.T) {
+		api := &API{Users: users.New(&fakeUserStore{}, "", false)}
+		h := api.UpdateUserRole()
+		req := httptest.NewRequest(http.MethodPut, "/api/users/user@example.com/role", strings.NewReader(`{"role":"owner"}`))
+		req.SetPathValue("id", "user@example.com")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
 	t.Run("Login", func(t *testing.T) {
@@ -132,9 +326,16 @@ func TestUsersHandlers(t *testing.T) {
 		require.NoError(t, err)
 
 		store := &fakeUserStore{getUserWithPasswordFn: func(context.Context, string) (*db.User, string, error) {
-			return &db.User{ID: "user@example.com"}, string(hash), nil
+			verified := time.Now()
+			return &db.User{ID: "user@example.com", EmailVerifiedAt: &verified}, string(hash), nil
 		}}
-		api := &API{Users: users.New(store, "", false)}
+		sessionStore := newFakeSessionStore()
+		api := &API{
+			Users:         users.New(store, "", false),
+			AuthStore:     sessionStore,
+			Sessions:      sessions.New(sessionStore, time.Hour),
+			SecureCookies: true,
+		}
 		h := api.Login()
 		body := strings.NewReader(`{"email":"user@example.com","password":"secret"}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/login", body)
@@ -146,6 +347,19 @@ func TestUsersHandlers(t *testing.T) {
 		var payload db.User
 		require.NoError(t, json.NewDecoder(rec.Body).Decode(&payload))
 		assert.Equal(t, "user@example.com", payload.ID)
+
+		cookie := sessionCookie(rec)
+		require.NotNil(t, cookie)
+		assert.NotEmpty(t, cookie.Value)
+		assert.True(t, cookie.HttpOnly)
+		assert.True(t, cookie.Secure)
+		assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+
+		next := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+		next.AddCookie(cookie)
+		userID, err := api.ResolveUserID(next)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", userID)
 	})
 
 	t.Run("Change password", func(t *testing.T) {
@@ -162,7 +376,7 @@ func TestUsersHandlers(t *testing.T) {
 		h := api.ChangePassword()
 		body := strings.NewReader(`{"currentPassword":"secret","newPassword":"new"}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/users/password", body)
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
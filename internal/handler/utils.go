@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
@@ -18,6 +19,16 @@ func encode[T any](w http.ResponseWriter, status int, v T) error {
 	return nil
 }
 
+// writeEvent writes a value as a JSON encoded Server-Sent Event.
+func writeEvent(w io.Writer, event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
 // decode decodes a value from JSON and returns it.
 func decode[T any](r *http.Request) (T, error) {
 	var v T
@@ -36,6 +47,8 @@ func serviceStatus(err error) int {
 		return http.StatusForbidden
 	case errpkg.IsKind(err, errpkg.ErrorNotFound):
 		return http.StatusNotFound
+	case errpkg.IsKind(err, errpkg.ErrorConflict):
+		return http.StatusConflict
 	case errpkg.IsKind(err, errpkg.ErrorUnauthorized):
 		return http.StatusUnauthorized
 	case errpkg.IsKind(err, errpkg.ErrorInternal):
@@ -91,6 +91,12 @@ func TestServiceStatus(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, serviceStatus(err))
 	})
 
+	t.Run("Conflict -> 409", func(t *testing.T) {
+		t.Parallel()
+		err := &errpkg.Error{Kind: errpkg.ErrorConflict, Err: errors.New("conflict error")}
+		assert.Equal(t, http.StatusConflict, serviceStatus(err))
+	})
+
 	t.Run("Unauthorized -> 401", func(t *testing.T) {
 		t.Parallel()
 		err := &errpkg.Error{Kind: errpkg.ErrorUnauthorized, Err: errors.New("unauthorized error")}
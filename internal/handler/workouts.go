@@ -4,22 +4,21 @@ import (
 	"net/http"
 
 	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/audit"
 	"github.com/gi8lino/motus/internal/service/workouts"
 )
 
 // GetWorkouts lists workouts for the current user.
 func (a *API) GetWorkouts() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID := r.PathValue("id")
-
-		resolvedID, err := a.resolveUserID(r, userID)
+		actor, err := a.ResolveActor(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 
-		workouts, err := a.Workouts.List(r.Context(), resolvedID)
+		workouts, err := a.Workouts.List(r.Context(), actor, r.PathValue("id"))
 		if err != nil {
 			a.logRequestError(r, "list_workouts_failed", "list workouts failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -33,8 +32,6 @@ func (a *API) GetWorkouts() http.HandlerFunc {
 // CreateWorkout stores a new workout for the current user.
 func (a *API) CreateWorkout() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID := r.PathValue("id")
-
 		req, err := decode[workouts.WorkoutRequest](r)
 		if err != nil {
 			a.logRequestError(r, "decode_request_failed", "decode request failed", err)
@@ -42,15 +39,15 @@ func (a *API) CreateWorkout() http.HandlerFunc {
 			return
 		}
 
-		resolvedUserID, err := a.resolveUserID(r, userID)
+		actor, err := a.ResolveActor(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
-		req.UserID = resolvedUserID
+		req.UserID = r.PathValue("id")
 
-		created, err := a.Workouts.Create(r.Context(), req)
+		created, err := a.Workouts.Create(r.Context(), actor, req)
 		if err != nil {
 			a.logRequestError(r, "create_workout_failed", "create workout failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -64,6 +61,13 @@ func (a *API) CreateWorkout() http.HandlerFunc {
 			"user_id", created.UserID,
 			"count", len(created.Steps),
 		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "workout_created",
+			Resource:   "workout",
+			ResourceID: created.ID,
+			After:      workoutSummary(created),
+		})
 		a.respondJSON(w, http.StatusCreated, created)
 	}
 }
@@ -73,7 +77,14 @@ func (a *API) GetWorkout() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
 
-		workout, err := a.Workouts.Get(r.Context(), id)
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		workout, err := a.Workouts.Get(r.Context(), actor, id)
 		if err != nil {
 			a.logRequestError(r, "get_workout_failed", "get workout failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -89,7 +100,14 @@ func (a *API) ExportWorkout() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
 
-		workout, err := a.Workouts.Export(r.Context(), id)
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		workout, err := a.Workouts.Export(r.Context(), actor, id)
 		if err != nil {
 			a.logRequestError(r, "export_workout_failed", "export workout failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -120,10 +138,10 @@ func (a *API) ImportWorkout() http.HandlerFunc {
 			return
 		}
 
-		resolvedUserID, err := a.resolveUserID(r, req.UserID)
+		resolvedUserID, err := a.ResolveUserID(r)
 		if err != nil {
 			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-			a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
 		}
 		req.UserID = resolvedUserID
@@ -141,6 +159,13 @@ func (a *API) ImportWorkout() http.HandlerFunc {
 			"resource_id", created.ID,
 			"user_id", created.UserID,
 		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    resolvedUserID,
+			Action:     "workout_imported",
+			Resource:   "workout",
+			ResourceID: created.ID,
+			After:      workoutSummary(created),
+		})
 		a.respondJSON(w, http.StatusCreated, created)
 	}
 }
@@ -157,25 +182,14 @@ func (a *API) UpdateWorkout() http.HandlerFunc {
 			return
 		}
 
-		if a.AuthHeader != "" {
-			resolvedUserID, err := a.resolveUserID(r, "")
-			if err != nil {
-				a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
-				a.respondJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
-				return
-			}
-			req.UserID = resolvedUserID
-		} else if req.UserID == "" {
-			current, err := a.Workouts.Get(r.Context(), id)
-			if err != nil {
-				a.logRequestError(r, "get_workout_failed", "get workout failed", err)
-				a.respondJSON(w, http.StatusNotFound, apiError{Error: err.Error()})
-				return
-			}
-			req.UserID = current.UserID
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
 		}
 
-		updated, err := a.Workouts.Update(r.Context(), id, req)
+		updated, err := a.Workouts.Update(r.Context(), actor, id, req)
 		if err != nil {
 			a.logRequestError(r, "update_workout_failed", "update workout failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
@@ -189,6 +203,13 @@ func (a *API) UpdateWorkout() http.HandlerFunc {
 			"user_id", updated.UserID,
 			"count", len(updated.Steps),
 		)
+		a.recordAudit(r, audit.Event{
+			ActorID:    actor.UserID,
+			Action:     "workout_updated",
+			Resource:   "workout",
+			ResourceID: updated.ID,
+			After:      workoutSummary(updated),
+		})
 		a.respondJSON(w, http.StatusOK, updated)
 	}
 }
@@ -198,7 +219,14 @@ func (a *API) DeleteWorkout() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
 
-		if err := a.Workouts.Delete(r.Context(), id); err != nil {
+		actor, err := a.ResolveActor(r)
+		if err != nil {
+			a.logRequestError(r, "resolve_user_id_failed", "resolve user id failed", err)
+			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
+			return
+		}
+
+		if err := a.Workouts.Delete(r.Context(), actor, id); err != nil {
 			a.logRequestError(r, "delete_workout_failed", "delete workout failed", err)
 			a.respondJSON(w, serviceStatus(err), apiError{Error: err.Error()})
 			return
@@ -208,7 +236,14 @@ func (a *API) DeleteWorkout() http.HandlerFunc {
 			"event", "workout_deleted",
 			"resource", "workout",
 			"resource_id", id,
+			"user_id", actor.UserID,
 		)
+		a.recordAudit(r, audit.Event{ActorID: actor.UserID, Action: "workout_deleted", Resource: "workout", ResourceID: id})
 		a.respondJSON(w, http.StatusNoContent, statusResponse{Status: "ok"})
 	}
 }
+
+// workoutSummary is the audit log snapshot of a workout.
+func workoutSummary(workout *db.Workout) map[string]any {
+	return map[string]any{"name": workout.Name, "steps": len(workout.Steps)}
+}
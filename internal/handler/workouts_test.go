@@ -91,7 +91,7 @@ func TestWorkoutsHandlers(t *testing.T) {
 		h := api.GetWorkouts()
 		req := httptest.NewRequest(http.MethodGet, "/api/workouts", nil)
 		req.SetPathValue("id", "user@example.com")
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -112,7 +112,7 @@ func TestWorkoutsHandlers(t *testing.T) {
 		body := strings.NewReader(`{"name":"Workout","steps":[{"type":"set","name":"Step","subsets":[{"name":"Main","exercises":[{"name":"Lift","reps":"5"}]}]}]}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/workouts", body)
 		req.SetPathValue("id", "user@example.com")
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -125,12 +125,13 @@ func TestWorkoutsHandlers(t *testing.T) {
 
 	t.Run("Get workout", func(t *testing.T) {
 		store := &fakeWorkoutStore{workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
-			return &db.Workout{ID: "w1", Name: "Workout"}, nil
+			return &db.Workout{ID: "w1", UserID: "user@example.com", Name: "Workout"}, nil
 		}}
 		api := &API{Workouts: workouts.New(store)}
 		h := api.GetWorkout()
 		req := httptest.NewRequest(http.MethodGet, "/api/workouts/w1", nil)
 		req.SetPathValue("id", "w1")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -143,12 +144,13 @@ func TestWorkoutsHandlers(t *testing.T) {
 
 	t.Run("Export workout", func(t *testing.T) {
 		store := &fakeWorkoutStore{workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
-			return &db.Workout{ID: "w1", Name: "Workout"}, nil
+			return &db.Workout{ID: "w1", UserID: "user@example.com", Name: "Workout"}, nil
 		}}
 		api := &API{Workouts: workouts.New(store)}
 		h := api.ExportWorkout()
 		req := httptest.NewRequest(http.MethodGet, "/api/workouts/w1/export", nil)
 		req.SetPathValue("id", "w1")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -167,7 +169,7 @@ func TestWorkoutsHandlers(t *testing.T) {
 		h := api.ImportWorkout()
 		body := strings.NewReader(`{"userId":"user@example.com","workout":{"name":"Imported","steps":[{"type":"set","name":"Step","subsets":[{"name":"Main","exercises":[{"name":"Lift","reps":"5"}]}]}]}}`)
 		req := httptest.NewRequest(http.MethodPost, "/api/workouts/import", body)
-		req.Header.Set("X-User-ID", "user@example.com")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -179,14 +181,20 @@ func TestWorkoutsHandlers(t *testing.T) {
 	})
 
 	t.Run("Update workout", func(t *testing.T) {
-		store := &fakeWorkoutStore{updateWorkoutFn: func(context.Context, *db.Workout) (*db.Workout, error) {
-			return &db.Workout{ID: "w1", Name: "Updated"}, nil
-		}}
+		store := &fakeWorkoutStore{
+			workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
+				return &db.Workout{ID: "w1", UserID: "user@example.com", Name: "Workout"}, nil
+			},
+			updateWorkoutFn: func(context.Context, *db.Workout) (*db.Workout, error) {
+				return &db.Workout{ID: "w1", Name: "Updated"}, nil
+			},
+		}
 		api := &API{Workouts: workouts.New(store)}
 		h := api.UpdateWorkout()
 		body := strings.NewReader(`{"userId":"user@example.com","name":"Updated","steps":[{"type":"set","name":"Step","subsets":[{"name":"Main","exercises":[{"name":"Lift","reps":"5"}]}]}]}`)
 		req := httptest.NewRequest(http.MethodPut, "/api/workouts/w1", body)
 		req.SetPathValue("id", "w1")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
@@ -198,15 +206,68 @@ func TestWorkoutsHandlers(t *testing.T) {
 	})
 
 	t.Run("Delete workout", func(t *testing.T) {
-		store := &fakeWorkoutStore{deleteWorkoutFn: func(context.Context, string) error { return nil }}
+		store := &fakeWorkoutStore{
+			workoutWithStepsFn: func(context.Context, string) (*db.Workout, error) {
+				return &db.Workout{ID: "w1", UserID: "user@example.com", Name: "Workout"}, nil
+			},
+			deleteWorkoutFn: func(context.Context, string) error { return nil },
+		}
 		api := &API{Workouts: workouts.New(store)}
 		h := api.DeleteWorkout()
 		req := httptest.NewRequest(http.MethodDelete, "/api/workouts/w1", nil)
 		req.SetPathValue("id", "w1")
+		signIn(t, api, req, "user@example.com")
 		rec := httptest.NewRecorder()
 
 		h.ServeHTTP(rec, req)
 
 		require.Equal(t, http.StatusNoContent, rec.Code)
 	})
+	t.Run("Ownership", func(t *testing.T) {
+		owned := func(context.Context, string) (*db.Workout, error) {
+			return &db.Workout{ID: "w1", UserID: "owner@example.com", Name: "Workout"}, nil
+		}
+		body := `{"name":"Workout","steps":[{"type":"set","name":"Step","subsets":[{"name":"Main","exercises":[{"name":"Lift","reps":"5"}]}]}]}`
+
+		tests := []struct {
+			name    string
+			handler func(*API) http.HandlerFunc
+			method  string
+			pathID  string
+			body    string
+			admin   bool
+			want    int
+		}{
+			{name: "get foreign workout", handler: (*API).GetWorkout, method: http.MethodGet, pathID: "w1", want: http.StatusForbidden},
+			{name: "export foreign workout", handler: (*API).ExportWorkout, method: http.MethodGet, pathID: "w1", want: http.StatusForbidden},
+			{name: "update foreign workout", handler: (*API).UpdateWorkout, method: http.MethodPut, pathID: "w1", body: body, want: http.StatusForbidden},
+			{name: "delete foreign workout", handler: (*API).DeleteWorkout, method: http.MethodDelete, pathID: "w1", want: http.StatusForbidden},
+			{name: "list foreign workouts", handler: (*API).GetWorkouts, method: http.MethodGet, pathID: "owner@example.com", want: http.StatusForbidden},
+			{name: "create for another user", handler: (*API).CreateWorkout, method: http.MethodPost, pathID: "owner@example.com", body: body, want: http.StatusForbidden},
+			{name: "admin reads foreign workout", handler: (*API).GetWorkout, method: http.MethodGet, pathID: "w1", admin: true, want: http.StatusOK},
+			{name: "admin deletes foreign workout", handler: (*API).DeleteWorkout, method: http.MethodDelete, pathID: "w1", admin: true, want: http.StatusNoContent},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				store := &fakeWorkoutStore{
+					workoutWithStepsFn: owned,
+					deleteWorkoutFn:    func(context.Context, string) error { return nil },
+				}
+				api := &API{Workouts: workouts.New(store)}
+				req := httptest.NewRequest(tc.method, "/api/workouts/"+tc.pathID, strings.NewReader(tc.body))
+				req.SetPathValue("id", tc.pathID)
+				if tc.admin {
+					signInAdmin(t, api, req, "admin@example.com")
+				} else {
+					signIn(t, api, req, "intruder@example.com")
+				}
+				rec := httptest.NewRecorder()
+
+				tc.handler(api).ServeHTTP(rec, req)
+
+				assert.Equal(t, tc.want, rec.Code)
+			})
+		}
+	})
 }
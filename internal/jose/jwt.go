@@ -0,0 +1,184 @@
+package jose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Leeway tolerates clock skew when checking exp, nbf and iat.
+const Leeway = time.Minute
+
+// ErrInvalidToken marks tokens that failed parsing, signature or time checks.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims holds the decoded JWT payload.
+type Claims map[string]any
+
+// header is the JOSE header of a compact JWS.
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks the signature of a compact JWS and its exp/nbf claims.
+func Verify(token string, keys *KeySet, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+
+	var hdr header
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	key, ok := keys.Key(hdr.Kid)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, hdr.Kid)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	if err := verifySignature(hdr.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: payload: %v", ErrInvalidToken, err)
+	}
+	exp, ok := claims.Time("exp")
+	if !ok {
+		return nil, fmt.Errorf("%w: exp is required", ErrInvalidToken)
+	}
+	if now.After(exp.Add(Leeway)) {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if nbf, ok := claims.Time("nbf"); ok && now.Add(Leeway).Before(nbf) {
+		return nil, fmt.Errorf("%w: not yet valid", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+// KeyID returns the kid header of a compact JWS without verifying it.
+func KeyID(token string) string {
+	encoded, _, _ := strings.Cut(token, ".")
+	var hdr header
+	if err := decodeSegment(encoded, &hdr); err != nil {
+		return ""
+	}
+	return hdr.Kid
+}
+
+// String returns a string claim or "" when missing.
+func (c Claims) String(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+// Strings returns a claim that may be a single string or a list of strings.
+func (c Claims) Strings(name string) []string {
+	switch value := c[name].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		out := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// Bool returns a boolean claim and whether it was present.
+func (c Claims) Bool(name string) (value, ok bool) {
+	value, ok = c[name].(bool)
+	return value, ok
+}
+
+// Time returns a NumericDate claim.
+func (c Claims) Time(name string) (time.Time, bool) {
+	value, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(value), 0), true
+}
+
+// HasAudience reports whether aud contains the given audience.
+func (c Claims) HasAudience(audience string) bool {
+	for _, aud := range c.Strings("aud") {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// verifySignature checks the JWS signature for the supported algorithms.
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	var h hash.Hash
+	var hashID crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		h, hashID = sha256.New(), crypto.SHA256
+	case "RS384", "ES384":
+		h, hashID = sha512.New384(), crypto.SHA384
+	case "RS512":
+		h, hashID = sha512.New(), crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	h.Write([]byte(signingInput))
+	digest := h.Sum(nil)
+
+	if strings.HasPrefix(alg, "RS") {
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match algorithm")
+		}
+		if err := rsa.VerifyPKCS1v15(pub, hashID, digest, signature); err != nil {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+
+	pub, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("key type does not match algorithm")
+	}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	if len(signature) != 2*size {
+		return errors.New("signature mismatch")
+	}
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+	if !ecdsa.Verify(pub, digest, r, s) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// decodeSegment decodes a base64url JSON segment.
+func decodeSegment(segment string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
@@ -0,0 +1,128 @@
+package jose
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	set, err := ParseKeySet(rsaJWKS(t, "k1", &testRSAKey.PublicKey))
+	require.NoError(t, err)
+
+	t.Run("Valid RS256 token", func(t *testing.T) {
+		t.Parallel()
+		token := signRS256(t, "k1", testRSAKey, map[string]any{
+			"sub": "user",
+			"aud": []string{"a", "b"},
+			"exp": now.Add(time.Hour).Unix(),
+		})
+
+		claims, err := Verify(token, set, now)
+		require.NoError(t, err)
+		assert.Equal(t, "user", claims.String("sub"))
+		assert.True(t, claims.HasAudience("b"))
+		assert.False(t, claims.HasAudience("c"))
+		assert.Equal(t, "k1", KeyID(token))
+	})
+
+	t.Run("Valid ES256 token", func(t *testing.T) {
+		t.Parallel()
+		key := newECKey(t)
+		ecSet, err := ParseKeySet(ecJWKS(t, "ec", &key.PublicKey))
+		require.NoError(t, err)
+		token := signES256(t, "ec", key, map[string]any{"exp": now.Add(time.Hour).Unix()})
+
+		_, err = Verify(token, ecSet, now)
+		require.NoError(t, err)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		t.Parallel()
+		token := signRS256(t, "k1", testRSAKey, map[string]any{"exp": now.Add(-2 * Leeway).Unix()})
+
+		_, err := Verify(token, set, now)
+		require.ErrorIs(t, err, ErrInvalidToken)
+		assert.EqualError(t, err, "invalid token: expired")
+	})
+
+	t.Run("Not yet valid", func(t *testing.T) {
+		t.Parallel()
+		token := signRS256(t, "k1", testRSAKey, map[string]any{
+			"exp": now.Add(time.Hour).Unix(),
+			"nbf": now.Add(2 * Leeway).Unix(),
+		})
+
+		_, err := Verify(token, set, now)
+		assert.EqualError(t, err, "invalid token: not yet valid")
+	})
+
+	t.Run("Missing exp", func(t *testing.T) {
+		t.Parallel()
+		token := signRS256(t, "k1", testRSAKey, map[string]any{"sub": "user"})
+
+		_, err := Verify(token, set, now)
+		assert.EqualError(t, err, "invalid token: exp is required")
+	})
+
+	t.Run("Unknown key", func(t *testing.T) {
+		t.Parallel()
+		token := signRS256(t, "k2", testRSAKey, map[string]any{"exp": now.Add(time.Hour).Unix()})
+
+		_, err := Verify(token, set, now)
+		assert.EqualError(t, err, `invalid token: unknown key "k2"`)
+	})
+
+	t.Run("Tampered payload", func(t *testing.T) {
+		t.Parallel()
+		token := signRS256(t, "k1", testRSAKey, map[string]any{"sub": "user", "exp": now.Add(time.Hour).Unix()})
+		forged := signRS256(t, "k1", testRSAKey, map[string]any{"sub": "admin", "exp": now.Add(time.Hour).Unix()})
+		parts := strings.Split(token, ".")
+		parts[1] = strings.Split(forged, ".")[1]
+
+		_, err := Verify(strings.Join(parts, "."), set, now)
+		assert.EqualError(t, err, "invalid token: signature mismatch")
+	})
+
+	t.Run("Rejects alg none", func(t *testing.T) {
+		t.Parallel()
+		input := signingInput(t, "none", "k1", map[string]any{"exp": now.Add(time.Hour).Unix()})
+
+		_, err := Verify(input+".", set, now)
+		assert.EqualError(t, err, `invalid token: unsupported algorithm "none"`)
+	})
+
+	t.Run("Malformed token", func(t *testing.T) {
+		t.Parallel()
+		_, err := Verify("abc", set, now)
+		assert.EqualError(t, err, "invalid token: malformed")
+	})
+}
+
+func TestClaims(t *testing.T) {
+	t.Parallel()
+
+	claims := Claims{
+		"email":          "a@example.com",
+		"email_verified": true,
+		"groups":         []any{"admins", 1, "users"},
+		"aud":            "client",
+	}
+
+	assert.Equal(t, "a@example.com", claims.String("email"))
+	assert.Equal(t, "", claims.String("missing"))
+	assert.Equal(t, []string{"admins", "users"}, claims.Strings("groups"))
+	assert.Equal(t, []string{"client"}, claims.Strings("aud"))
+	assert.Nil(t, claims.Strings("missing"))
+	verified, ok := claims.Bool("email_verified")
+	assert.True(t, ok)
+	assert.True(t, verified)
+	_, ok = claims.Bool("missing")
+	assert.False(t, ok)
+}
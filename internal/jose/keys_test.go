@@ -0,0 +1,78 @@
+package jose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeySet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RSA key", func(t *testing.T) {
+		t.Parallel()
+		set, err := ParseKeySet(rsaJWKS(t, "k1", &testRSAKey.PublicKey))
+		require.NoError(t, err)
+
+		_, ok := set.Key("k1")
+		assert.True(t, ok)
+		_, ok = set.Key("")
+		assert.True(t, ok, "single key sets match an empty kid")
+		_, ok = set.Key("other")
+		assert.False(t, ok)
+	})
+
+	t.Run("EC key", func(t *testing.T) {
+		t.Parallel()
+		key := newECKey(t)
+		set, err := ParseKeySet(ecJWKS(t, "ec", &key.PublicKey))
+		require.NoError(t, err)
+
+		_, ok := set.Key("ec")
+		assert.True(t, ok)
+	})
+
+	t.Run("Skips encryption keys", func(t *testing.T) {
+		t.Parallel()
+		_, err := ParseKeySet([]byte(`{"keys":[{"kty":"RSA","use":"enc","n":"AQAB","e":"AQAB"}]}`))
+		require.Error(t, err)
+		assert.EqualError(t, err, "jwks contains no signing keys")
+	})
+
+	t.Run("Rejects unsupported key types", func(t *testing.T) {
+		t.Parallel()
+		_, err := ParseKeySet([]byte(`{"keys":[{"kty":"oct","kid":"x","k":"c2VjcmV0"}]}`))
+		require.Error(t, err)
+		assert.EqualError(t, err, `key "x": unsupported key type "oct"`)
+	})
+
+	t.Run("Rejects invalid JSON", func(t *testing.T) {
+		t.Parallel()
+		_, err := ParseKeySet([]byte(`{`))
+		require.Error(t, err)
+	})
+}
+
+func TestLoadKeySet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reads file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		require.NoError(t, os.WriteFile(path, rsaJWKS(t, "k1", &testRSAKey.PublicKey), 0o600))
+
+		set, err := LoadKeySet(path)
+		require.NoError(t, err)
+		_, ok := set.Key("k1")
+		assert.True(t, ok)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadKeySet(filepath.Join(t.TempDir(), "missing.json"))
+		require.Error(t, err)
+	})
+}
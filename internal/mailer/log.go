@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Log writes emails to a logger instead of delivering them.
+// It is meant for development and for installations without an SMTP relay.
+type Log struct {
+	logger *slog.Logger
+}
+
+// NewLog creates a mailer that logs every message.
+func NewLog(logger *slog.Logger) *Log {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Log{logger: logger}
+}
+
+// Send logs the message including its body.
+func (l *Log) Send(ctx context.Context, msg Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+	l.logger.InfoContext(ctx, "mail not delivered, logging instead",
+		"event", "mail_logged",
+		"to", msg.To,
+		"subject", msg.Subject,
+		"body", msg.Body,
+	)
+	return nil
+}
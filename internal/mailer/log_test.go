@@ -0,0 +1,35 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogSend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Logs message", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		m := NewLog(slog.New(slog.NewTextHandler(&buf, nil)))
+
+		err := m.Send(context.Background(), Message{To: "user@example.com", Subject: "Verify", Body: "open the link"})
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "event=mail_logged")
+		assert.Contains(t, buf.String(), "to=user@example.com")
+		assert.Contains(t, buf.String(), `body="open the link"`)
+	})
+
+	t.Run("Requires recipient", func(t *testing.T) {
+		t.Parallel()
+		m := NewLog(nil)
+		err := m.Send(context.Background(), Message{Subject: "Verify"})
+		require.Error(t, err)
+		assert.EqualError(t, err, "recipient is required")
+	})
+}
@@ -0,0 +1,31 @@
+// Package mailer delivers transactional emails such as password resets.
+package mailer
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Message is a plain-text email.
+type Message struct {
+	To      string // To is the recipient address.
+	Subject string // Subject is the single-line subject.
+	Body    string // Body is the plain-text content.
+}
+
+// Mailer sends transactional emails.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// validate rejects messages that are empty or would allow header injection.
+func (m Message) validate() error {
+	if strings.TrimSpace(m.To) == "" {
+		return errors.New("recipient is required")
+	}
+	if strings.ContainsAny(m.To, "\r\n") || strings.ContainsAny(m.Subject, "\r\n") {
+		return errors.New("recipient and subject must be a single line")
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts a single plain-text SMTP session and records the envelope.
+type fakeSMTPServer struct {
+	addr string
+	mu   sync.Mutex
+	from string
+	rcpt string
+	data string
+	done chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() }) // nolint:errcheck
+
+	srv := &fakeSMTPServer{addr: ln.Addr().String(), done: make(chan struct{})}
+	go func() {
+		defer close(srv.done)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close() // nolint:errcheck
+		srv.serve(textproto.NewConn(conn))
+	}()
+	return srv
+}
+
+func (s *fakeSMTPServer) serve(c *textproto.Conn) {
+	_ = c.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			_ = c.PrintfLine("250-localhost")
+			_ = c.PrintfLine("250 8BITMIME")
+		case "MAIL":
+			s.mu.Lock()
+			s.from = line
+			s.mu.Unlock()
+			_ = c.PrintfLine("250 OK")
+		case "RCPT":
+			s.mu.Lock()
+			s.rcpt = line
+			s.mu.Unlock()
+			_ = c.PrintfLine("250 OK")
+		case "DATA":
+			_ = c.PrintfLine("354 go ahead")
+			data, err := c.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.data = string(data)
+			s.mu.Unlock()
+			_ = c.PrintfLine("250 OK")
+		case "QUIT":
+			_ = c.PrintfLine("221 bye")
+			return
+		default:
+			_ = c.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) mailer(t *testing.T) *SMTP {
+	t.Helper()
+	host, port, err := net.SplitHostPort(s.addr)
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return &SMTP{Host: host, Port: p, From: "motus@example.com"}
+}
+
+func TestSMTPSend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Delivers message", func(t *testing.T) {
+		t.Parallel()
+		srv := newFakeSMTPServer(t)
+		m := srv.mailer(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := m.Send(ctx, Message{To: "user@example.com", Subject: "Reset your password", Body: "line one\nline two\n"})
+		require.NoError(t, err)
+		<-srv.done
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		assert.Equal(t, "MAIL FROM:<motus@example.com> BODY=8BITMIME", srv.from)
+		assert.Equal(t, "RCPT TO:<user@example.com>", srv.rcpt)
+		assert.Contains(t, srv.data, "To: user@example.com\n")
+		assert.Contains(t, srv.data, "Subject: Reset your password\n")
+		assert.Contains(t, srv.data, "\nline one\nline two\n")
+	})
+
+	t.Run("Rejects header injection", func(t *testing.T) {
+		t.Parallel()
+		m := &SMTP{Host: "127.0.0.1", Port: 1, From: "motus@example.com"}
+		err := m.Send(context.Background(), Message{To: "user@example.com", Subject: "hi\r\nBcc: other@example.com"})
+		require.Error(t, err)
+		assert.EqualError(t, err, "recipient and subject must be a single line")
+	})
+
+	t.Run("Dial failure", func(t *testing.T) {
+		t.Parallel()
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().(*net.TCPAddr)
+		require.NoError(t, ln.Close())
+
+		m := &SMTP{Host: "127.0.0.1", Port: addr.Port, From: "motus@example.com"}
+		err = m.Send(context.Background(), Message{To: "user@example.com", Subject: "hi"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dial smtp")
+	})
+}
+
+func TestSMTPFormat(t *testing.T) {
+	t.Parallel()
+
+	m := &SMTP{From: "motus@example.com"}
+	raw := string(m.format(Message{To: "a@example.com", Subject: "Grüezi", Body: "hello"}, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(raw)))
+	header, err := reader.ReadMIMEHeader()
+	require.NoError(t, err)
+	assert.Equal(t, "motus@example.com", header.Get("From"))
+	assert.Equal(t, "=?utf-8?q?Gr=C3=BCezi?=", header.Get("Subject"))
+	assert.Equal(t, "Tue, 02 Jan 2024 03:04:05 +0000", header.Get("Date"))
+	assert.Equal(t, "text/plain; charset=utf-8", header.Get("Content-Type"))
+	assert.True(t, strings.HasSuffix(raw, "\r\n\r\nhello"))
+}
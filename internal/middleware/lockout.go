@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+// maxBackoffShift caps the exponent so the doubled duration cannot overflow.
+const maxBackoffShift = 30
+
+// Lockout blocks keys after repeated failures with an exponentially growing delay.
+// A nil Lockout never locks.
+type Lockout struct {
+	threshold int
+	base      time.Duration
+	max       time.Duration
+	now       func() time.Time
+	mu        sync.Mutex
+	entries   map[string]*lockEntry
+	swept     time.Time
+}
+
+// lockEntry tracks the failures of a single key.
+type lockEntry struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// NewLockout locks a key for base after threshold failures and doubles the lock
+// for every further failure up to ceiling. Failures are forgotten after ceiling without one.
+// It returns nil, which disables lockouts, when threshold or base is not positive.
+func NewLockout(threshold int, base, ceiling time.Duration) *Lockout {
+	if threshold <= 0 || base <= 0 {
+		return nil
+	}
+	return &Lockout{
+		threshold: threshold,
+		base:      base,
+		max:       max(base, ceiling),
+		now:       time.Now,
+		entries:   map[string]*lockEntry{},
+	}
+}
+
+// Locked returns how long key stays locked, or zero.
+func (l *Lockout) Locked(key string) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[key]
+	if !ok {
+		return 0
+	}
+	return max(0, entry.lockedUntil.Sub(l.now()))
+}
+
+// Fail records a failure for key and returns the lock it triggered, or zero.
+func (l *Lockout) Fail(key string) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	entry, ok := l.entries[key]
+	if !ok || now.Sub(entry.lastFailure) > l.max {
+		entry = &lockEntry{}
+		l.entries[key] = entry
+	}
+	entry.failures++
+	entry.lastFailure = now
+	if entry.failures < l.threshold {
+		return 0
+	}
+
+	lock := l.max
+	if shift := entry.failures - l.threshold; shift < maxBackoffShift {
+		lock = min(l.max, l.base<<shift)
+	}
+	entry.lockedUntil = now.Add(lock)
+	return lock
+}
+
+// Reset forgets all failures of key, e.g. after a successful login.
+func (l *Lockout) Reset(key string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.entries, key)
+}
+
+// sweep drops entries whose failures have expired; it runs at most once per max.
+func (l *Lockout) sweep(now time.Time) {
+	if now.Sub(l.swept) < l.max {
+		return
+	}
+	l.swept = now
+	for key, entry := range l.entries {
+		if now.Sub(entry.lastFailure) > l.max && !now.Before(entry.lockedUntil) {
+			delete(l.entries, key)
+		}
+	}
+}
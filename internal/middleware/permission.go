@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// ActorResolver returns the authenticated caller for a request.
+type ActorResolver func(r *http.Request) (policy.Actor, error)
+
+// RequirePermission blocks requests whose authenticated caller lacks permission.
+func RequirePermission(resolve ActorResolver, permission policy.Permission) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor, err := resolve(r)
+			if err != nil || actor.UserID == "" || !actor.Can(permission) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte("forbidden"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// headerResolver resolves the caller from the given header for tests; roles maps user ids to roles.
+func headerResolver(header string, roles map[string]string) ActorResolver {
+	return func(r *http.Request) (policy.Actor, error) {
+		id := r.Header.Get(header)
+		if id == "" {
+			return policy.Actor{}, errors.New("unauthenticated")
+		}
+		role := roles[id]
+		if role == "" {
+			role = db.RoleMember
+		}
+		return policy.NewActor(id, role), nil
+	}
+}
+
+// TestRequirePermission covers permission guard behavior for missing, unprivileged, and privileged users.
+func TestRequirePermission(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		handler := RequirePermission(headerResolver("X-User-ID", nil), policy.PermUsersManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, "forbidden", rec.Body.String())
+	})
+
+	t.Run("non-admin user", func(t *testing.T) {
+		t.Parallel()
+
+		handler := RequirePermission(headerResolver("X-User-ID", nil), policy.PermUsersManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User-ID", "user@example.com")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, "forbidden", rec.Body.String())
+	})
+
+	t.Run("admin user", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		handler := RequirePermission(headerResolver("X-User-ID", map[string]string{"admin@example.com": db.RoleAdmin}), policy.PermUsersManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("ok"))
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User-ID", "admin@example.com")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		require.True(t, called)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "ok", rec.Body.String())
+	})
+
+	t.Run("custom resolver", func(t *testing.T) {
+		t.Parallel()
+
+		handler := RequirePermission(headerResolver("X-User-Email", map[string]string{"admin@example.com": db.RoleAdmin}), policy.PermUsersManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User-Email", "admin@example.com")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("role with permission", func(t *testing.T) {
+		t.Parallel()
+
+		roles := map[string]string{"editor@example.com": db.RoleCatalogEditor}
+		handler := RequirePermission(headerResolver("X-User-ID", roles), policy.PermCatalogWrite)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User-ID", "editor@example.com")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("role without permission", func(t *testing.T) {
+		t.Parallel()
+
+		roles := map[string]string{"editor@example.com": db.RoleCatalogEditor}
+		handler := RequirePermission(headerResolver("X-User-ID", roles), policy.PermUsersManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User-ID", "editor@example.com")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
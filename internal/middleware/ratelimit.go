@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gi8lino/motus/internal/logging"
+)
+
+// KeyFunc returns the rate limit key of a request; an empty key skips the limit.
+type KeyFunc func(r *http.Request) string
+
+// Limiter is a set of token buckets keyed by client address, account or similar.
+// Each bucket holds up to limit tokens and refills limit tokens per window.
+// A nil Limiter allows everything.
+type Limiter struct {
+	limit   float64
+	rate    float64 // rate is the refill rate in tokens per second.
+	now     func() time.Time
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// bucket is the token count of a single key.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewLimiter returns a limiter allowing limit requests per window and key.
+// It returns nil, which disables limiting, when limit or window is not positive.
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	if limit <= 0 || window <= 0 {
+		return nil
+	}
+	return &Limiter{
+		limit:   float64(limit),
+		rate:    float64(limit) / window.Seconds(),
+		now:     time.Now,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Allow takes a token for key and otherwise reports how long until one is available.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.limit, updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.limit, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep drops buckets that have refilled completely; it runs at most once per refill period.
+func (l *Limiter) sweep(now time.Time) {
+	refill := time.Duration(l.limit / l.rate * float64(time.Second))
+	if now.Sub(l.swept) < refill {
+		return
+	}
+	l.swept = now
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimit rejects requests with 429 once the limiter's bucket for their key is empty.
+// scope names the limit in log events.
+func RateLimit(limiter *Limiter, key KeyFunc, scope string, logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			k := key(r)
+			if k == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if ok, wait := limiter.Allow(k); !ok {
+				logging.BusinessLogger(logger, r.Context()).Warn("rate limit exceeded",
+					"event", "rate_limited",
+					"scope", scope,
+					"key", k,
+					"retry_after_s", retryAfterSeconds(wait),
+				)
+				tooManyRequests(w, wait)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientIP keys requests by the address of the connecting peer.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// WriteKey keys state-changing requests by client address and skips safe methods.
+func WriteKey(r *http.Request) string {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ""
+	default:
+		return ClientIP(r)
+	}
+}
+
+// tooManyRequests writes a 429 JSON error with a Retry-After header.
+func tooManyRequests(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(wait)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write([]byte(`{"error":"too many requests"}` + "\n"))
+}
+
+// retryAfterSeconds rounds wait up to whole seconds, at least one.
+func retryAfterSeconds(wait time.Duration) int {
+	return max(1, int(math.Ceil(wait.Seconds())))
+}
@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced time source.
+type fakeClock struct{ t time.Time }
+
+func newFakeClock() *fakeClock { return &fakeClock{t: time.Unix(1_700_000_000, 0)} }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// limiterWithClock returns a per-minute limiter driven by c.
+func limiterWithClock(limit int, c *fakeClock) *Limiter {
+	l := NewLimiter(limit, time.Minute)
+	l.now = c.now
+	return l
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, NewLimiter(0, time.Minute))
+		var l *Limiter
+		ok, _ := l.Allow("key")
+		assert.True(t, ok)
+	})
+
+	t.Run("Burst then refill", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		l := limiterWithClock(2, clock)
+
+		for range 2 {
+			ok, _ := l.Allow("a")
+			require.True(t, ok)
+		}
+		ok, wait := l.Allow("a")
+		assert.False(t, ok)
+		assert.Equal(t, 30*time.Second, wait)
+
+		other, _ := l.Allow("b")
+		assert.True(t, other, "keys have separate buckets")
+
+		clock.advance(30 * time.Second)
+		ok, _ = l.Allow("a")
+		assert.True(t, ok)
+	})
+
+	t.Run("Sweeps idle buckets", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		l := limiterWithClock(1, clock)
+		l.Allow("a")
+		clock.advance(2 * time.Minute)
+		l.Allow("b")
+		assert.Len(t, l.buckets, 1)
+	})
+}
+
+func TestLockout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, NewLockout(0, time.Minute, time.Hour))
+		var l *Lockout
+		assert.Zero(t, l.Fail("key"))
+		assert.Zero(t, l.Locked("key"))
+	})
+
+	t.Run("Exponential backoff", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		l := NewLockout(3, time.Minute, 5*time.Minute)
+		l.now = clock.now
+
+		assert.Zero(t, l.Fail("a"))
+		assert.Zero(t, l.Fail("a"))
+		assert.Zero(t, l.Locked("a"))
+		assert.Equal(t, time.Minute, l.Fail("a"))
+		assert.Equal(t, time.Minute, l.Locked("a"))
+		assert.Equal(t, 2*time.Minute, l.Fail("a"))
+		assert.Equal(t, 4*time.Minute, l.Fail("a"))
+		assert.Equal(t, 5*time.Minute, l.Fail("a"), "capped at max")
+
+		clock.advance(5 * time.Minute)
+		assert.Zero(t, l.Locked("a"))
+	})
+
+	t.Run("Reset and expiry", func(t *testing.T) {
+		t.Parallel()
+		clock := newFakeClock()
+		l := NewLockout(2, time.Minute, 10*time.Minute)
+		l.now = clock.now
+
+		l.Fail("a")
+		l.Reset("a")
+		assert.Zero(t, l.Fail("a"), "reset forgets failures")
+
+		clock.advance(11 * time.Minute)
+		assert.Zero(t, l.Fail("a"), "old failures expire")
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusCreated) })
+
+	t.Run("Rejects with Retry-After", func(t *testing.T) {
+		t.Parallel()
+		var logs strings.Builder
+		h := RateLimit(NewLimiter(1, time.Minute), WriteKey, "write", slog.New(slog.NewTextHandler(&logs, nil)))(ok)
+
+		first := httptest.NewRecorder()
+		h.ServeHTTP(first, httptest.NewRequest(http.MethodPost, "/workouts/import", nil))
+		assert.Equal(t, http.StatusCreated, first.Code)
+
+		second := httptest.NewRecorder()
+		h.ServeHTTP(second, httptest.NewRequest(http.MethodPost, "/workouts/import", nil))
+		assert.Equal(t, http.StatusTooManyRequests, second.Code)
+		assert.Equal(t, "60", second.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"error":"too many requests"}`, second.Body.String())
+		assert.Contains(t, logs.String(), "event=rate_limited")
+		assert.Contains(t, logs.String(), "scope=write")
+	})
+
+	t.Run("Skips safe methods", func(t *testing.T) {
+		t.Parallel()
+		h := RateLimit(NewLimiter(1, time.Minute), WriteKey, "write", logger)(ok)
+		for range 3 {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/workouts/w1", nil))
+			assert.Equal(t, http.StatusCreated, rec.Code)
+		}
+	})
+
+	t.Run("Nil limiter", func(t *testing.T) {
+		t.Parallel()
+		h := RateLimit(nil, WriteKey, "write", logger)(ok)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+}
+
+func TestLoginRateLimit(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	// login accepts only the password "secret" and echoes the body it received.
+	login := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"secret"`) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(body)
+	})
+	attempt := func(h http.Handler, ip, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	const wrong = `{"email":"User@Example.com","password":"wrong"}`
+	const right = `{"email":"user@example.com","password":"secret"}`
+
+	t.Run("Locks account after failures", func(t *testing.T) {
+		t.Parallel()
+		var logs strings.Builder
+		h := LoginRateLimit(LoginLimits{
+			Lockout: NewLockout(2, time.Minute, time.Hour),
+		}, slog.New(slog.NewTextHandler(&logs, nil)))(login)
+
+		assert.Equal(t, http.StatusUnauthorized, attempt(h, "10.0.0.1", wrong).Code)
+		assert.Equal(t, http.StatusUnauthorized, attempt(h, "10.0.0.2", wrong).Code)
+		assert.Contains(t, logs.String(), "event=login_lockout")
+
+		rec := attempt(h, "10.0.0.3", right)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code, "lock applies across addresses")
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("Success resets failures", func(t *testing.T) {
+		t.Parallel()
+		h := LoginRateLimit(LoginLimits{Lockout: NewLockout(2, time.Minute, time.Hour)}, logger)(login)
+
+		attempt(h, "10.0.0.1", wrong)
+		rec := attempt(h, "10.0.0.1", right)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, right, rec.Body.String(), "body is passed through")
+		assert.Equal(t, http.StatusUnauthorized, attempt(h, "10.0.0.1", wrong).Code)
+	})
+
+	t.Run("Per IP and per account buckets", func(t *testing.T) {
+		t.Parallel()
+		h := LoginRateLimit(LoginLimits{
+			IP:      NewLimiter(2, time.Minute),
+			Account: NewLimiter(1, time.Minute),
+		}, logger)(login)
+
+		assert.Equal(t, http.StatusOK, attempt(h, "10.0.0.1", right).Code)
+		assert.Equal(t, http.StatusTooManyRequests, attempt(h, "10.0.0.2", right).Code, "account bucket")
+		assert.Equal(t, http.StatusUnauthorized, attempt(h, "10.0.0.1", `{"email":"other@example.com"}`).Code)
+		assert.Equal(t, http.StatusTooManyRequests, attempt(h, "10.0.0.1", `{"email":"third@example.com"}`).Code, "ip bucket")
+	})
+}
@@ -13,3 +13,8 @@ func (rw *responseRecorder) WriteHeader(statusCode int) {
 	rw.statusCode = statusCode
 	rw.ResponseWriter.WriteHeader(statusCode)
 }
+
+// Unwrap returns the wrapped writer so http.ResponseController can flush streamed responses.
+func (rw *responseRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
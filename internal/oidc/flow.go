@@ -0,0 +1,162 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/jose"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// ErrLogin marks failures caused by the issuer response rather than Motus itself.
+var ErrLogin = errors.New("oidc login failed")
+
+// AuthRequest carries the per-login secrets kept by the browser between redirect and callback.
+type AuthRequest struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// Identity is the verified user information extracted from the ID token.
+type Identity struct {
+	Subject string // Subject is the issuer's stable user id.
+	Email   string // Email maps to users.id.
+	IsAdmin *bool  // IsAdmin is nil when no admin group is configured.
+}
+
+// NewAuthRequest generates fresh state, nonce and PKCE verifier values.
+func NewAuthRequest() AuthRequest {
+	return AuthRequest{
+		State:    utils.NewToken(),
+		Nonce:    utils.NewToken(),
+		Verifier: utils.NewToken(),
+	}
+}
+
+// AuthCodeURL returns the issuer URL the browser is redirected to.
+func (p *Provider) AuthCodeURL(ctx context.Context, req AuthRequest) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	target, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("authorization endpoint: %w", err)
+	}
+
+	query := target.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", strings.Join(p.scopes(), " "))
+	query.Set("state", req.State)
+	query.Set("nonce", req.Nonce)
+	query.Set("code_challenge", codeChallenge(req.Verifier))
+	query.Set("code_challenge_method", "S256")
+	target.RawQuery = query.Encode()
+	return target.String(), nil
+}
+
+// Exchange redeems the authorization code and verifies the returned ID token.
+func (p *Provider) Exchange(ctx context.Context, code string, req AuthRequest) (Identity, error) {
+	if strings.TrimSpace(code) == "" {
+		return Identity{}, fmt.Errorf("%w: code is required", ErrLogin)
+	}
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", req.Verifier)
+	form.Set("client_id", p.cfg.ClientID)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Identity{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if p.cfg.ClientSecret != "" {
+		httpReq.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	body, err := p.do(httpReq)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: token exchange: %v", ErrLogin, err)
+	}
+	var tokenResp struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return Identity{}, fmt.Errorf("%w: decode token response: %v", ErrLogin, err)
+	}
+	if tokenResp.IDToken == "" {
+		return Identity{}, fmt.Errorf("%w: id_token missing", ErrLogin)
+	}
+	return p.verifyIDToken(ctx, tokenResp.IDToken, req.Nonce)
+}
+
+// verifyIDToken checks signature, issuer, audience and nonce and maps the claims.
+func (p *Provider) verifyIDToken(ctx context.Context, raw, nonce string) (Identity, error) {
+	keys, err := p.keySet(ctx, jose.KeyID(raw))
+	if err != nil {
+		return Identity{}, err
+	}
+	claims, err := jose.Verify(raw, keys, p.now())
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrLogin, err)
+	}
+	if strings.TrimRight(claims.String("iss"), "/") != p.cfg.Issuer {
+		return Identity{}, fmt.Errorf("%w: issuer mismatch", ErrLogin)
+	}
+	if !claims.HasAudience(p.cfg.ClientID) {
+		return Identity{}, fmt.Errorf("%w: audience mismatch", ErrLogin)
+	}
+	if claims.String("nonce") != nonce {
+		return Identity{}, fmt.Errorf("%w: nonce mismatch", ErrLogin)
+	}
+	return p.identity(claims)
+}
+
+// identity maps verified claims onto a Motus identity.
+func (p *Provider) identity(claims jose.Claims) (Identity, error) {
+	email, err := utils.NormalizeEmail(claims.String("email"))
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: email claim: %v", ErrLogin, err)
+	}
+	if verified, ok := claims.Bool("email_verified"); ok && !verified {
+		return Identity{}, fmt.Errorf("%w: email is not verified", ErrLogin)
+	}
+
+	identity := Identity{Subject: claims.String("sub"), Email: email}
+	if p.cfg.AdminGroup != "" {
+		isAdmin := slices.Contains(claims.Strings(p.cfg.GroupsClaim), p.cfg.AdminGroup)
+		identity.IsAdmin = &isAdmin
+	}
+	return identity, nil
+}
+
+// scopes returns the configured scopes, always including openid.
+func (p *Provider) scopes() []string {
+	if slices.Contains(p.cfg.Scopes, "openid") {
+		return p.cfg.Scopes
+	}
+	return append([]string{"openid"}, p.cfg.Scopes...)
+}
+
+// codeChallenge derives the S256 PKCE challenge for verifier.
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
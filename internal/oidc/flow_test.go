@@ -0,0 +1,205 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/oidc/oidctest"
+)
+
+// newTestProvider wires a provider against a fresh mock issuer.
+func newTestProvider(t *testing.T, adminGroup string) (*Provider, *oidctest.Issuer) {
+	t.Helper()
+	issuer := oidctest.NewIssuer(t, "motus", "secret")
+	provider := New(Config{
+		Issuer:       issuer.URL,
+		ClientID:     "motus",
+		ClientSecret: "secret",
+		RedirectURL:  "http://motus.local/api/auth/oidc/callback",
+		GroupsClaim:  "groups",
+		AdminGroup:   adminGroup,
+	}, nil)
+	return provider, issuer
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	t.Parallel()
+
+	provider, issuer := newTestProvider(t, "")
+	req := NewAuthRequest()
+
+	raw, err := provider.AuthCodeURL(context.Background(), req)
+	require.NoError(t, err)
+
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	query := u.Query()
+	assert.Equal(t, issuer.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "motus", query.Get("client_id"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, req.State, query.Get("state"))
+	assert.Equal(t, req.Nonce, query.Get("nonce"))
+	assert.Equal(t, codeChallenge(req.Verifier), query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestExchange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Maps email and admin group", func(t *testing.T) {
+		t.Parallel()
+		provider, issuer := newTestProvider(t, "motus-admins")
+		req := NewAuthRequest()
+		authURL, err := provider.AuthCodeURL(context.Background(), req)
+		require.NoError(t, err)
+		code := issuer.Authorize(t, authURL, map[string]any{
+			"sub":            "123",
+			"email":          "Ada@Example.com ",
+			"email_verified": true,
+			"groups":         []string{"motus-admins"},
+		})
+
+		identity, err := provider.Exchange(context.Background(), code, req)
+		require.NoError(t, err)
+		assert.Equal(t, "123", identity.Subject)
+		assert.Equal(t, "ada@example.com", identity.Email)
+		require.NotNil(t, identity.IsAdmin)
+		assert.True(t, *identity.IsAdmin)
+	})
+
+	t.Run("Leaves admin flag untouched without admin group", func(t *testing.T) {
+		t.Parallel()
+		provider, issuer := newTestProvider(t, "")
+		req := NewAuthRequest()
+		authURL, err := provider.AuthCodeURL(context.Background(), req)
+		require.NoError(t, err)
+		code := issuer.Authorize(t, authURL, map[string]any{"email": "ada@example.com"})
+
+		identity, err := provider.Exchange(context.Background(), code, req)
+		require.NoError(t, err)
+		assert.Nil(t, identity.IsAdmin)
+	})
+
+	t.Run("Rejects wrong PKCE verifier", func(t *testing.T) {
+		t.Parallel()
+		provider, issuer := newTestProvider(t, "")
+		req := NewAuthRequest()
+		authURL, err := provider.AuthCodeURL(context.Background(), req)
+		require.NoError(t, err)
+		code := issuer.Authorize(t, authURL, map[string]any{"email": "ada@example.com"})
+
+		req.Verifier = "tampered"
+		_, err = provider.Exchange(context.Background(), code, req)
+		require.ErrorIs(t, err, ErrLogin)
+	})
+
+	t.Run("Rejects nonce mismatch", func(t *testing.T) {
+		t.Parallel()
+		provider, issuer := newTestProvider(t, "")
+		req := NewAuthRequest()
+		authURL, err := provider.AuthCodeURL(context.Background(), req)
+		require.NoError(t, err)
+		code := issuer.Authorize(t, authURL, map[string]any{"email": "ada@example.com"})
+
+		req.Nonce = "other"
+		_, err = provider.Exchange(context.Background(), code, req)
+		require.ErrorIs(t, err, ErrLogin)
+		assert.Contains(t, err.Error(), "nonce mismatch")
+	})
+
+	t.Run("Rejects unverified email", func(t *testing.T) {
+		t.Parallel()
+		provider, issuer := newTestProvider(t, "")
+		req := NewAuthRequest()
+		authURL, err := provider.AuthCodeURL(context.Background(), req)
+		require.NoError(t, err)
+		code := issuer.Authorize(t, authURL, map[string]any{"email": "ada@example.com", "email_verified": false})
+
+		_, err = provider.Exchange(context.Background(), code, req)
+		require.ErrorIs(t, err, ErrLogin)
+		assert.Contains(t, err.Error(), "email is not verified")
+	})
+
+	t.Run("Rejects missing email", func(t *testing.T) {
+		t.Parallel()
+		provider, issuer := newTestProvider(t, "")
+		req := NewAuthRequest()
+		authURL, err := provider.AuthCodeURL(context.Background(), req)
+		require.NoError(t, err)
+		code := issuer.Authorize(t, authURL, map[string]any{"sub": "123"})
+
+		_, err = provider.Exchange(context.Background(), code, req)
+		require.ErrorIs(t, err, ErrLogin)
+	})
+
+	t.Run("Rejects empty code", func(t *testing.T) {
+		t.Parallel()
+		provider, _ := newTestProvider(t, "")
+
+		_, err := provider.Exchange(context.Background(), "", NewAuthRequest())
+		require.ErrorIs(t, err, ErrLogin)
+	})
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Rejects foreign audience", func(t *testing.T) {
+		t.Parallel()
+		provider, issuer := newTestProvider(t, "")
+		token := issuer.Sign(map[string]any{
+			"iss":   issuer.URL,
+			"aud":   "someone-else",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": "n",
+			"email": "ada@example.com",
+		})
+
+		_, err := provider.verifyIDToken(context.Background(), token, "n")
+		require.ErrorIs(t, err, ErrLogin)
+		assert.Contains(t, err.Error(), "audience mismatch")
+	})
+
+	t.Run("Rejects foreign issuer", func(t *testing.T) {
+		t.Parallel()
+		provider, issuer := newTestProvider(t, "")
+		token := issuer.Sign(map[string]any{
+			"iss":   "https://evil.example.com",
+			"aud":   "motus",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": "n",
+			"email": "ada@example.com",
+		})
+
+		_, err := provider.verifyIDToken(context.Background(), token, "n")
+		require.ErrorIs(t, err, ErrLogin)
+		assert.Contains(t, err.Error(), "issuer mismatch")
+	})
+}
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Caches metadata", func(t *testing.T) {
+		t.Parallel()
+		provider, _ := newTestProvider(t, "")
+		first, err := provider.discover(context.Background())
+		require.NoError(t, err)
+		second, err := provider.discover(context.Background())
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+	})
+
+	t.Run("Fails for unreachable issuer", func(t *testing.T) {
+		t.Parallel()
+		provider := New(Config{Issuer: "http://127.0.0.1:1", ClientID: "motus"}, nil)
+		_, err := provider.discover(context.Background())
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,152 @@
+// Package oidc implements an OpenID Connect relying party using the authorization-code flow with PKCE.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gi8lino/motus/internal/jose"
+)
+
+// DefaultScopes are requested when no scopes are configured.
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+// Config describes the relying party registration at the issuer.
+type Config struct {
+	Issuer       string   // Issuer is the OIDC issuer URL.
+	ClientID     string   // ClientID is the registered client id.
+	ClientSecret string   // ClientSecret is optional for public clients.
+	RedirectURL  string   // RedirectURL is the callback registered at the issuer.
+	Scopes       []string // Scopes are requested in addition to openid.
+	GroupsClaim  string   // GroupsClaim names the claim listing the user's groups.
+	AdminGroup   string   // AdminGroup grants admin rights when present in GroupsClaim.
+}
+
+// Provider talks to an OIDC issuer; discovery and keys are fetched lazily and cached.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	now    func() time.Time
+
+	mu       sync.Mutex
+	metadata *metadata
+	keys     *jose.KeySet
+}
+
+// metadata holds the discovery document fields used by the flow.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// New creates a provider for cfg; a nil client uses a client with a 10s timeout.
+func New(cfg Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	cfg.Issuer = strings.TrimRight(cfg.Issuer, "/")
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = DefaultScopes
+	}
+	return &Provider{cfg: cfg, client: client, now: time.Now}
+}
+
+// discover returns the cached discovery document, fetching it on first use.
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var meta metadata
+	if err := p.getJSON(ctx, p.cfg.Issuer+"/.well-known/openid-configuration", &meta); err != nil {
+		return nil, fmt.Errorf("discovery: %w", err)
+	}
+	if strings.TrimRight(meta.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("discovery: issuer mismatch %q", meta.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("discovery: missing endpoints")
+	}
+	p.metadata = &meta
+	return p.metadata, nil
+}
+
+// keySet returns the issuer keys, refreshing them when kid is unknown.
+func (p *Provider) keySet(ctx context.Context, kid string) (*jose.KeySet, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.keys.Key(kid); ok {
+		return p.keys, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	keys, err := jose.ParseKeySet(body)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	return p.keys, nil
+}
+
+// getJSON fetches and decodes a JSON document.
+func (p *Provider) getJSON(ctx context.Context, target string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	body, err := p.do(req)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// do executes req and returns the body of a 2xx response.
+func (p *Provider) do(req *http.Request) ([]byte, error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s: status %d", req.Method, redact(req.URL), resp.StatusCode)
+	}
+	return body, nil
+}
+
+// redact strips the query string from URLs used in error messages.
+func redact(u *url.URL) string {
+	clean := *u
+	clean.RawQuery = ""
+	return clean.String()
+}
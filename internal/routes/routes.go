@@ -8,16 +8,24 @@ import (
 
 	"github.com/gi8lino/motus/internal/handler"
 	"github.com/gi8lino/motus/internal/middleware"
+	"github.com/gi8lino/motus/internal/service/policy"
 
 	"github.com/containeroo/httpprefix"
 )
 
+// Limits configures request throttling; zero values disable it.
+type Limits struct {
+	Login middleware.LoginLimits // Login protects the login endpoints against brute force.
+	Write *middleware.Limiter    // Write caps state-changing API requests per client address.
+}
+
 // NewRouter wires HTTP routes for Motus.
 func NewRouter(
 	spaFS fs.FS,
 	routePrefix string,
 	logger *slog.Logger,
 	api *handler.API,
+	limits Limits,
 	debug bool,
 ) (http.Handler, error) {
 	mux := http.NewServeMux()
@@ -47,18 +55,51 @@ func NewRouter(
 	apiMux := http.NewServeMux()
 	apiMux.Handle("GET /config", api.Config())
 	apiMux.Handle("GET /me", api.CurrentUser())
-	apiMux.Handle("POST /login", api.Login())
+	apiMux.Handle("POST /login",
+		middleware.Chain(api.Login(), middleware.LoginRateLimit(limits.Login, api.Logger)),
+	)
+	apiMux.Handle("POST /login/totp",
+		middleware.Chain(api.LoginTOTP(), middleware.LoginRateLimit(limits.Login, api.Logger)),
+	)
+	apiMux.Handle("GET /auth/oidc/login", api.OIDCLogin())
+	apiMux.Handle("GET /auth/oidc/callback", api.OIDCCallback())
+	apiMux.Handle("POST /password/forgot", api.ForgotPassword())
+	apiMux.Handle("POST /password/reset", api.ResetPassword())
+	apiMux.Handle("POST /email/verify", api.VerifyEmail())
+	apiMux.Handle("POST /email/verify/resend", api.ResendVerification())
+	apiMux.Handle("POST /logout", api.Logout())
+	apiMux.Handle("POST /logout/all", api.LogoutAll())
+	apiMux.Handle("GET /me/export", api.ExportMe())
+	apiMux.Handle("DELETE /me", api.DeleteMe())
 	apiMux.Handle("PUT /me/password", api.ChangePassword())
 	apiMux.Handle("PUT /me/name", api.UpdateUserName())
-	apiMux.Handle("GET /users",
-		middleware.Chain(api.GetUsers(), middleware.RequireAdmin(api.Users, api.AuthHeader)),
-	)
+	apiMux.Handle("GET /me/tokens", api.ListTokens())
+	apiMux.Handle("POST /me/tokens", api.CreateToken())
+	apiMux.Handle("DELETE /me/tokens/{id}", api.RevokeToken())
+	apiMux.Handle("POST /me/totp", api.BeginTOTP())
+	apiMux.Handle("POST /me/totp/confirm", api.ConfirmTOTP())
+	apiMux.Handle("POST /me/totp/disable", api.DisableTOTP())
+	apiMux.Handle("POST /me/totp/recovery-codes", api.RegenerateRecoveryCodes())
+	apiMux.Handle("PUT /me/organization", api.SwitchOrganization())
+	manageUsers := middleware.RequirePermission(api.ResolveActor, policy.PermUsersManage)
+	apiMux.Handle("GET /users", middleware.Chain(api.GetUsers(), manageUsers))
 	apiMux.Handle("POST /users", api.CreateUser())
-	apiMux.Handle("PUT /users/{id}/admin",
-		middleware.Chain(api.UpdateUserRole(),
-			middleware.RequireAdmin(api.Users, api.AuthHeader),
+	apiMux.Handle("POST /users/{id}/password-reset",
+		middleware.Chain(api.SendUserPasswordReset(), manageUsers),
+	)
+	apiMux.Handle("GET /users/{id}/export", middleware.Chain(api.ExportUser(), manageUsers))
+	apiMux.Handle("DELETE /users/{id}", middleware.Chain(api.DeleteUser(), manageUsers))
+	apiMux.Handle("PUT /users/{id}/role", middleware.Chain(api.UpdateUserRole(), manageUsers))
+
+	apiMux.Handle("GET /admin/audit",
+		middleware.Chain(api.ListAuditEvents(),
+			middleware.RequirePermission(api.ResolveActor, policy.PermAuditRead),
 		),
 	)
+	apiMux.Handle("POST /admin/users", middleware.Chain(api.AdminCreateUser(), manageUsers))
+	apiMux.Handle("GET /admin/invitations", middleware.Chain(api.ListInvitations(), manageUsers))
+	apiMux.Handle("POST /admin/invitations", middleware.Chain(api.CreateInvitation(), manageUsers))
+	apiMux.Handle("DELETE /admin/invitations/{id}", middleware.Chain(api.RevokeInvitation(), manageUsers))
 
 	apiMux.Handle("GET /users/{id}/workouts", api.GetWorkouts())
 	apiMux.Handle("POST /users/{id}/workouts", api.CreateWorkout())
@@ -67,6 +108,8 @@ func NewRouter(
 	apiMux.Handle("POST /workouts/import", api.ImportWorkout())
 	apiMux.Handle("PUT /workouts/{id}", api.UpdateWorkout())
 	apiMux.Handle("DELETE /workouts/{id}", api.DeleteWorkout())
+	apiMux.Handle("GET /workouts/{id}/pacing", api.GetWorkoutPacing())
+	apiMux.Handle("POST /workouts/{id}/pacing/apply", api.ApplyWorkoutPacing())
 
 	apiMux.Handle("GET /templates", api.ListTemplates())
 	apiMux.Handle("POST /templates", api.CreateTemplate())
@@ -80,18 +123,63 @@ func NewRouter(
 	apiMux.Handle("POST /exercises/backfill",
 		middleware.Chain(
 			api.BackfillExercises(),
-			middleware.RequireAdmin(api.Users, api.AuthHeader),
+			middleware.RequirePermission(api.ResolveActor, policy.PermCatalogWrite),
 		),
 	)
 
 	apiMux.Handle("GET /sounds", api.ListSounds())
 
+	apiMux.Handle("GET /organizations", api.ListOrganizations())
+	apiMux.Handle("POST /organizations", api.CreateOrganization())
+	apiMux.Handle("DELETE /organizations/{id}", api.DeleteOrganization())
+	apiMux.Handle("GET /organizations/{id}/members", api.ListOrgMembers())
+	apiMux.Handle("POST /organizations/{id}/members", api.AddOrgMember())
+	apiMux.Handle("PUT /organizations/{id}/members/{userId}", api.UpdateOrgMember())
+	apiMux.Handle("DELETE /organizations/{id}/members/{userId}", api.RemoveOrgMember())
+
+	apiMux.Handle("GET /coaching/links", api.ListCoachLinks())
+	apiMux.Handle("POST /coaching/links", api.InviteAthlete())
+	apiMux.Handle("POST /coaching/links/{id}/accept", api.AcceptCoachLink())
+	apiMux.Handle("DELETE /coaching/links/{id}", api.RemoveCoachLink())
+	apiMux.Handle("POST /coaching/athletes/{id}/workouts", api.AssignWorkout())
+	apiMux.Handle("GET /coaching/athletes/{id}/trainings/history", api.ListAthleteHistory())
+	apiMux.Handle("GET /coaching/athletes/{id}/trainings/{trainingId}/steps", api.AthleteTrainingSteps())
+
 	apiMux.Handle("POST /trainings", api.CreateTraining())
 	apiMux.Handle("GET /users/{id}/trainings/history", api.ListTrainingHistory())
 	apiMux.Handle("POST /trainings/complete", api.CompleteTraining())
+	apiMux.Handle("POST /trainings/manual", api.LogManualTraining())
+	apiMux.Handle("PATCH /trainings/{id}", api.UpdateTraining())
+	apiMux.Handle("PUT /trainings/{id}", api.ReplaceTraining())
+	apiMux.Handle("DELETE /trainings/{id}", api.DeleteTraining())
+	apiMux.Handle("GET /trainings/{id}/steps", api.TrainingSteps())
+	apiMux.Handle("POST /trainings/{id}/start", api.StartTraining())
+	apiMux.Handle("POST /trainings/{id}/pause", api.PauseTraining())
+	apiMux.Handle("POST /trainings/{id}/resume", api.ResumeTraining())
+	apiMux.Handle("POST /trainings/{id}/advance", api.AdvanceTraining())
+	apiMux.Handle("POST /trainings/{id}/abort", api.AbortTraining())
+	apiMux.Handle("GET /trainings/{id}/events", api.TrainingEvents())
+	apiMux.Handle("GET /me/trainings/active", api.ActiveTraining())
+	apiMux.Handle("GET /me/records", api.ListPersonalRecords())
+	apiMux.Handle("GET /me/stats", api.GetStats())
+	apiMux.Handle("GET /trainings/{id}/summary", api.GetTrainingSummary())
+	apiMux.Handle("GET /me/summary-templates", api.ListSummaryTemplates())
+	apiMux.Handle("POST /me/summary-templates", api.CreateSummaryTemplate())
+	apiMux.Handle("PUT /me/summary-templates/{id}", api.UpdateSummaryTemplate())
+	apiMux.Handle("DELETE /me/summary-templates/{id}", api.DeleteSummaryTemplate())
+
+	apiMux.Handle("POST /classes", api.StartClass())
+	apiMux.Handle("POST /classes/join", api.JoinClass())
+	apiMux.Handle("GET /classes/{id}", api.GetClass())
+	apiMux.Handle("GET /classes/{id}/events", api.ClassEvents())
+	apiMux.Handle("PUT /classes/{id}/results", api.SubmitClassResults())
+	apiMux.Handle("POST /classes/{id}/leave", api.LeaveClass())
+	apiMux.Handle("POST /classes/{id}/end", api.EndClass())
 
 	// Mount API under /api
-	mux.Handle("/api/", http.StripPrefix("/api", apiMux))
+	mux.Handle("/api/", http.StripPrefix("/api",
+		middleware.Chain(apiMux, middleware.RateLimit(limits.Write, middleware.WriteKey, "write", api.Logger)),
+	))
 
 	// SPA
 	mux.Handle("/", handler.SPA(spaContent, routePrefix))
@@ -1,18 +1,44 @@
 package routes
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 
+	"github.com/gi8lino/motus/internal/auth"
+	"github.com/gi8lino/motus/internal/db"
 	"github.com/gi8lino/motus/internal/handler"
+	"github.com/gi8lino/motus/internal/mailer"
+	"github.com/gi8lino/motus/internal/service/accounts"
+	"github.com/gi8lino/motus/internal/service/audit"
+	"github.com/gi8lino/motus/internal/service/classes"
+	"github.com/gi8lino/motus/internal/service/coaching"
+	"github.com/gi8lino/motus/internal/service/exercises"
+	"github.com/gi8lino/motus/internal/service/invitations"
+	"github.com/gi8lino/motus/internal/service/organizations"
+	"github.com/gi8lino/motus/internal/service/pacing"
+	"github.com/gi8lino/motus/internal/service/privacy"
+	"github.com/gi8lino/motus/internal/service/sessions"
+	"github.com/gi8lino/motus/internal/service/sounds"
+	"github.com/gi8lino/motus/internal/service/stats"
+	"github.com/gi8lino/motus/internal/service/summary"
+	"github.com/gi8lino/motus/internal/service/templates"
+	"github.com/gi8lino/motus/internal/service/tokens"
+	"github.com/gi8lino/motus/internal/service/trainings"
+	"github.com/gi8lino/motus/internal/service/users"
+	"github.com/gi8lino/motus/internal/service/workouts"
 )
 
 func TestNewRouter(t *testing.T) {
@@ -31,7 +57,7 @@ func TestNewRouter(t *testing.T) {
 		Commit:            "abc123",
 	}
 
-	router, err := NewRouter(webFS, "/motus", logger, api, false)
+	router, err := NewRouter(webFS, "/motus", logger, api, Limits{}, false)
 	require.NoError(t, err)
 
 	t.Run("GET /motus/", func(t *testing.T) {
@@ -63,3 +89,648 @@ func TestNewRouter(t *testing.T) {
 		assert.Equal(t, "abc123", payload["commit"])
 	})
 }
+
+// authzStore is an in-memory store backing every service used by the router.
+type authzStore struct {
+	mu           sync.Mutex
+	passwordHash string
+	sessions     map[string]db.Session
+}
+
+func newAuthzStore(t *testing.T) *authzStore {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	return &authzStore{passwordHash: string(hash), sessions: map[string]db.Session{}}
+}
+
+func (s *authzStore) Ping(context.Context) error { return nil }
+
+func (s *authzStore) ListUsers(context.Context) ([]db.User, error) {
+	return []db.User{{ID: authzOwner}, {ID: authzOther}, {ID: authzAdmin, Role: db.RoleAdmin, IsAdmin: true}}, nil
+}
+
+// authzUser returns id with its role: authzAdmin is an admin, authzOwner coaches authzOther.
+func authzUser(id string) *db.User {
+	user := &db.User{ID: id}
+	switch id {
+	case authzAdmin:
+		user.SetRole(db.RoleAdmin)
+	case authzOwner:
+		user.SetRole(db.RoleCoach)
+	default:
+		user.SetRole(db.RoleMember)
+	}
+	return user
+}
+
+func (s *authzStore) GetUser(_ context.Context, id string) (*db.User, error) {
+	return authzUser(id), nil
+}
+
+func (s *authzStore) CreateUser(_ context.Context, email, _, _ string) (*db.User, error) {
+	return &db.User{ID: email}, nil
+}
+
+func (s *authzStore) CreatePendingUser(_ context.Context, email, _ string) (*db.User, error) {
+	return &db.User{ID: email}, nil
+}
+
+func (s *authzStore) CreateInvitedUser(_ context.Context, _, email, _, _ string, _ time.Time) (*db.User, error) {
+	return &db.User{ID: email}, nil
+}
+
+func (s *authzStore) UpdateUserRole(context.Context, string, string) error { return nil }
+
+func (s *authzStore) GetUserWithPassword(_ context.Context, id string) (*db.User, string, error) {
+	user := authzUser(id)
+	verified := time.Now()
+	user.EmailVerifiedAt = &verified
+	return user, s.passwordHash, nil
+}
+
+func (s *authzStore) DeleteUser(context.Context, string, string) error { return nil }
+
+func (s *authzStore) UpdateUserPassword(context.Context, string, string) error { return nil }
+
+func (s *authzStore) UpdateUserName(context.Context, string, string) error { return nil }
+
+func (s *authzStore) ListExercises(context.Context, string, string) ([]db.Exercise, error) {
+	return []db.Exercise{{ID: "e1", Name: "Row", OwnerUserID: authzOwner}}, nil
+}
+
+func (s *authzStore) CreateExercise(_ context.Context, name, userID string, isCore bool) (*db.Exercise, error) {
+	return &db.Exercise{ID: "e2", Name: name, OwnerUserID: userID, IsCore: isCore}, nil
+}
+
+func (s *authzStore) CreateOrgExercise(_ context.Context, name, orgID string) (*db.Exercise, error) {
+	return &db.Exercise{ID: "e3", Name: name, IsCore: true, OrgID: orgID}, nil
+}
+
+func (s *authzStore) GetExercise(_ context.Context, id string) (*db.Exercise, error) {
+	return &db.Exercise{ID: id, Name: "Row", OwnerUserID: authzOwner}, nil
+}
+
+func (s *authzStore) RenameExercise(_ context.Context, id, name string) (*db.Exercise, error) {
+	return &db.Exercise{ID: id, Name: name, OwnerUserID: authzOwner}, nil
+}
+
+func (s *authzStore) DeleteExercise(context.Context, string) error { return nil }
+
+func (s *authzStore) BackfillCoreExercises(context.Context) error { return nil }
+
+func (s *authzStore) CreateWorkout(_ context.Context, workout *db.Workout) (*db.Workout, error) {
+	workout.ID = "w2"
+	return workout, nil
+}
+
+func (s *authzStore) UpdateWorkout(_ context.Context, workout *db.Workout) (*db.Workout, error) {
+	return workout, nil
+}
+
+func (s *authzStore) UpdateEstimatedSeconds(context.Context, string, map[string]int, map[string]int) error {
+	return nil
+}
+
+func (s *authzStore) WorkoutsByUser(_ context.Context, userID string) ([]db.Workout, error) {
+	return []db.Workout{{ID: "w1", UserID: userID, Name: "Workout"}}, nil
+}
+
+func (s *authzStore) WorkoutWithSteps(_ context.Context, id string) (*db.Workout, error) {
+	workout := &db.Workout{
+		ID:     id,
+		UserID: authzOwner,
+		Name:   "Workout",
+		Steps:  []db.WorkoutStep{{ID: "s1", Type: "set", Name: "Step"}},
+	}
+	if id == "t1" {
+		workout.IsTemplate = true
+	}
+	return workout, nil
+}
+
+func (s *authzStore) DeleteWorkout(context.Context, string) error { return nil }
+
+func (s *authzStore) ListTemplates(context.Context, string) ([]db.Workout, error) {
+	return []db.Workout{{ID: "t1", Name: "Template", IsTemplate: true}}, nil
+}
+
+func (s *authzStore) TemplatesByUser(context.Context, string) ([]db.Workout, error) { return nil, nil }
+
+func (s *authzStore) CreateTemplateFromWorkout(_ context.Context, _, name, orgID string) (*db.Workout, error) {
+	return &db.Workout{ID: "t2", Name: name, IsTemplate: true, OrgID: orgID}, nil
+}
+
+func (s *authzStore) CreateWorkoutFromTemplate(_ context.Context, _, userID, name string) (*db.Workout, error) {
+	return &db.Workout{ID: "w3", UserID: userID, Name: name}, nil
+}
+
+func (s *authzStore) TrainingsByUser(_ context.Context, userID string) ([]db.TrainingLog, error) {
+	return []db.TrainingLog{{ID: "tr1", WorkoutID: "w1", UserID: userID}}, nil
+}
+
+func (s *authzStore) TrainingStepTimings(context.Context, string) ([]db.TrainingStepLog, error) {
+	return nil, nil
+}
+
+func (s *authzStore) RecordTraining(context.Context, db.TrainingLog, []db.TrainingStepLog) (bool, error) {
+	return true, nil
+}
+
+func (s *authzStore) TrainingHistory(_ context.Context, filter db.TrainingHistoryFilter) ([]db.TrainingLog, error) {
+	return []db.TrainingLog{{ID: "tr1", WorkoutID: "w1", UserID: filter.UserID}}, nil
+}
+
+func (s *authzStore) StepTimingsForTrainings(context.Context, []string) (map[string][]db.TrainingStepLog, error) {
+	return nil, nil
+}
+
+func (s *authzStore) UpdateTraining(context.Context, db.TrainingLog, []db.TrainingStepLog) error {
+	return nil
+}
+
+func (s *authzStore) ReplaceTraining(context.Context, db.TrainingLog, []db.TrainingStepLog) error {
+	return nil
+}
+
+func (s *authzStore) DeleteTraining(context.Context, string) error { return nil }
+
+func (s *authzStore) CreateActiveTraining(context.Context, db.ActiveTraining) error { return nil }
+
+func (s *authzStore) GetActiveTraining(_ context.Context, id string) (*db.ActiveTraining, error) {
+	if id != "at1" {
+		return nil, db.ErrActiveTrainingNotFound
+	}
+	return &db.ActiveTraining{
+		ID:     id,
+		UserID: authzOwner,
+		State:  []byte(`{"trainingId":"at1","workoutId":"w1","userId":"` + authzOwner + `","steps":[{"id":"s1"}]}`),
+	}, nil
+}
+
+func (s *authzStore) ActiveTrainingForUser(context.Context, string) (*db.ActiveTraining, error) {
+	return nil, db.ErrActiveTrainingNotFound
+}
+
+func (s *authzStore) UpdateActiveTraining(context.Context, db.ActiveTraining, time.Time) error {
+	return nil
+}
+
+func (s *authzStore) DeleteActiveTraining(context.Context, string) error { return nil }
+
+func (s *authzStore) PersonalRecords(context.Context, string) ([]db.PersonalRecord, error) {
+	return nil, nil
+}
+
+func (s *authzStore) AddPersonalRecords(context.Context, []db.PersonalRecord) error { return nil }
+
+func (s *authzStore) CreateSummaryTemplate(context.Context, db.SummaryTemplate) error { return nil }
+
+func (s *authzStore) ListSummaryTemplates(context.Context, string) ([]db.SummaryTemplate, error) {
+	return nil, nil
+}
+
+func (s *authzStore) GetSummaryTemplate(_ context.Context, id string) (*db.SummaryTemplate, error) {
+	return &db.SummaryTemplate{ID: id, UserID: authzOwner, Name: "Short", Body: "{{.WorkoutName}}"}, nil
+}
+
+func (s *authzStore) UpdateSummaryTemplate(context.Context, db.SummaryTemplate) error { return nil }
+
+func (s *authzStore) DeleteSummaryTemplate(context.Context, string) error { return nil }
+
+func (s *authzStore) StatsBuckets(context.Context, db.StatsFilter) ([]db.StatsBucket, error) {
+	return nil, nil
+}
+
+func (s *authzStore) TrainingDays(context.Context, string, string) ([]time.Time, error) {
+	return nil, nil
+}
+
+func (s *authzStore) TopExercises(context.Context, db.StatsFilter, int) ([]db.ExerciseStats, error) {
+	return nil, nil
+}
+
+func (s *authzStore) CreateClass(context.Context, db.Class) error { return nil }
+
+func (s *authzStore) GetClass(_ context.Context, id string) (*db.Class, error) {
+	if id != "c1" {
+		return nil, db.ErrClassNotFound
+	}
+	return &db.Class{ID: id, CoachID: authzOwner, WorkoutID: "w1", TrainingID: "at1", JoinCode: "ABC234"}, nil
+}
+
+func (s *authzStore) OpenClassByCode(ctx context.Context, code string) (*db.Class, error) {
+	if code != "ABC234" {
+		return nil, db.ErrClassNotFound
+	}
+	return s.GetClass(ctx, "c1")
+}
+
+func (s *authzStore) EndClass(context.Context, string, time.Time) error { return nil }
+
+func (s *authzStore) AddClassParticipant(context.Context, db.ClassParticipant) error { return nil }
+
+func (s *authzStore) GetClassParticipant(_ context.Context, classID, userID string) (*db.ClassParticipant, error) {
+	if userID != authzOther {
+		return nil, db.ErrClassParticipantNotFound
+	}
+	return &db.ClassParticipant{ClassID: classID, UserID: userID}, nil
+}
+
+func (s *authzStore) ListClassParticipants(_ context.Context, classID string) ([]db.ClassParticipant, error) {
+	return []db.ClassParticipant{{ClassID: classID, UserID: authzOther}}, nil
+}
+
+func (s *authzStore) SeeClassParticipant(context.Context, string, string, time.Time, int) error {
+	return nil
+}
+
+func (s *authzStore) SaveClassResults(context.Context, string, string, json.RawMessage, time.Time, int) error {
+	return nil
+}
+
+func (s *authzStore) LeaveClass(context.Context, string, string, string, time.Time) error { return nil }
+
+func (s *authzStore) CreateSession(_ context.Context, session db.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[session.ID] = session
+	return nil
+}
+
+func (s *authzStore) GetSession(_ context.Context, id string) (*db.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, db.ErrSessionNotFound
+	}
+	return &session, nil
+}
+
+func (s *authzStore) RotateSession(ctx context.Context, oldID string, next db.Session) error {
+	if err := s.RevokeSession(ctx, oldID); err != nil {
+		return err
+	}
+	return s.CreateSession(ctx, next)
+}
+
+func (s *authzStore) RevokeSession(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
+
+func (s *authzStore) CreateAPIToken(context.Context, db.APIToken) error { return nil }
+
+func (s *authzStore) CreateCoachLink(context.Context, db.CoachLink) error { return nil }
+
+func (s *authzStore) ListCoachLinks(context.Context, string) ([]db.CoachLink, error) {
+	return nil, nil
+}
+
+func (s *authzStore) GetCoachLink(_ context.Context, id string) (*db.CoachLink, error) {
+	if id != "l1" {
+		return nil, db.ErrCoachLinkNotFound
+	}
+	return &db.CoachLink{ID: id, CoachID: authzOwner, AthleteID: authzOther, Status: db.CoachLinkPending}, nil
+}
+
+func (s *authzStore) AcceptCoachLink(context.Context, string, time.Time) error { return nil }
+
+func (s *authzStore) DeleteCoachLink(context.Context, string) error { return nil }
+
+func (s *authzStore) IsCoachOf(_ context.Context, coachID, athleteID string) (bool, error) {
+	return coachID == authzOwner && athleteID == authzOther, nil
+}
+
+func (s *authzStore) AssignWorkout(_ context.Context, _, athleteID, assignedBy, name string) (*db.Workout, error) {
+	return &db.Workout{ID: "w2", UserID: athleteID, AssignedBy: assignedBy, Name: name}, nil
+}
+
+func (s *authzStore) GetTraining(_ context.Context, id string) (*db.TrainingLog, error) {
+	return &db.TrainingLog{ID: id, WorkoutID: "w1", UserID: authzOther}, nil
+}
+
+func (s *authzStore) OrgRole(_ context.Context, orgID, userID string) (string, error) {
+	switch {
+	case orgID != "o1":
+		return "", nil
+	case userID == authzOwner:
+		return db.OrgRoleOwner, nil
+	case userID == "member@example.com":
+		return db.OrgRoleMember, nil
+	}
+	return "", nil
+}
+
+func (s *authzStore) ActiveOrganization(context.Context, string) (string, error) { return "", nil }
+
+func (s *authzStore) CreateOrganization(context.Context, db.Organization) error { return nil }
+
+func (s *authzStore) ListOrganizationsForUser(context.Context, string) ([]db.OrgMembership, error) {
+	return nil, nil
+}
+
+func (s *authzStore) GetOrganization(_ context.Context, id string) (*db.Organization, error) {
+	return &db.Organization{ID: id, Name: "Club", CreatedBy: authzOwner}, nil
+}
+
+func (s *authzStore) DeleteOrganization(context.Context, string) error { return nil }
+
+func (s *authzStore) ListOrgMembers(context.Context, string) ([]db.OrgMember, error) {
+	return []db.OrgMember{{OrgID: "o1", UserID: authzOwner, Role: db.OrgRoleOwner}}, nil
+}
+
+func (s *authzStore) CountOrgOwners(context.Context, string) (int, error) { return 2, nil }
+
+func (s *authzStore) AddOrgMember(context.Context, db.OrgMember) error { return nil }
+
+func (s *authzStore) UpdateOrgMemberRole(context.Context, string, string, string) error { return nil }
+
+func (s *authzStore) RemoveOrgMember(context.Context, string, string) error { return nil }
+
+func (s *authzStore) SetActiveOrganization(context.Context, string, string) error { return nil }
+
+func (s *authzStore) CreateInvitation(context.Context, db.Invitation) error { return nil }
+
+func (s *authzStore) ListInvitations(context.Context) ([]db.Invitation, error) {
+	return []db.Invitation{{ID: "i1", MaxUses: 1}}, nil
+}
+
+func (s *authzStore) RevokeInvitation(_ context.Context, id string) error {
+	if id != "i1" {
+		return db.ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (s *authzStore) ListAPITokens(_ context.Context, userID string) ([]db.APIToken, error) {
+	return []db.APIToken{{ID: "k1", UserID: userID, Name: "CLI"}}, nil
+}
+
+func (s *authzStore) RevokeAPIToken(_ context.Context, userID, id string) error {
+	if userID != authzOwner || id != "k1" {
+		return db.ErrAPITokenNotFound
+	}
+	return nil
+}
+
+func (s *authzStore) GetAPITokenByHash(context.Context, string) (*db.APIToken, error) {
+	return nil, db.ErrAPITokenNotFound
+}
+
+func (s *authzStore) TouchAPIToken(context.Context, string, time.Time) error { return nil }
+
+func (s *authzStore) CreateOneTimeToken(context.Context, db.OneTimeToken) error { return nil }
+
+func (s *authzStore) ResetPassword(context.Context, string, string, time.Time) (string, error) {
+	return "", db.ErrOneTimeTokenNotFound
+}
+
+func (s *authzStore) VerifyEmail(context.Context, string, time.Time) (string, error) {
+	return "", db.ErrOneTimeTokenNotFound
+}
+
+func (s *authzStore) ConsumeOneTimeToken(context.Context, string, string, time.Time) (string, error) {
+	return "", db.ErrOneTimeTokenNotFound
+}
+
+func (s *authzStore) GetTOTP(context.Context, string) (*db.TOTP, error) { return &db.TOTP{}, nil }
+
+func (s *authzStore) SetPendingTOTP(context.Context, string, string) error { return nil }
+
+func (s *authzStore) EnableTOTP(context.Context, string, int64, []string, time.Time) error {
+	return nil
+}
+
+func (s *authzStore) DisableTOTP(context.Context, string) error { return nil }
+
+func (s *authzStore) ClaimTOTPStep(context.Context, string, int64) (bool, error) { return true, nil }
+
+func (s *authzStore) ReplaceRecoveryCodes(context.Context, string, []string) error { return nil }
+
+func (s *authzStore) UseRecoveryCode(context.Context, string, string, time.Time) (bool, error) {
+	return false, nil
+}
+
+func (s *authzStore) RevokeUserSessions(_ context.Context, userID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var count int64
+	for id, session := range s.sessions {
+		if session.UserID == userID {
+			delete(s.sessions, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *authzStore) CreateAuditEvent(context.Context, db.AuditEvent) error { return nil }
+
+func (s *authzStore) ListAuditEvents(context.Context, db.AuditFilter) ([]db.AuditEvent, error) {
+	return nil, nil
+}
+
+func (s *authzStore) DeleteAuditEventsBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+const (
+	authzOwner = "owner@example.com"
+	authzOther = "other@example.com"
+	authzAdmin = "admin@example.com"
+)
+
+// authzStatus lists the expected status per caller; an empty caller is anonymous.
+type authzStatus struct {
+	anonymous int
+	owner     int
+	other     int
+	admin     int
+}
+
+func TestRouterAuthorization(t *testing.T) {
+	t.Parallel()
+
+	const workoutBody = `{"name":"Workout","steps":[{"type":"set","name":"Step","subsets":[{"name":"Main","exercises":[{"name":"Lift","reps":"5"}]}]}]}`
+	const completeBody = `{"trainingId":"tr1","workoutId":"w1","workoutName":"Workout","steps":[{"id":"s1","name":"Step","type":"set","elapsedMillis":1000}]}`
+	const replaceBody = `{"startedAt":"2024-03-01T18:00:00Z","completedAt":"2024-03-01T18:30:00Z","steps":[{"id":"s1","name":"Step","type":"set","elapsedMillis":1000}]}`
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		want   authzStatus
+	}{
+		{method: http.MethodGet, path: "/healthz", want: authzStatus{200, 200, 200, 200}},
+		{method: http.MethodPost, path: "/healthz", want: authzStatus{200, 200, 200, 200}},
+		{method: http.MethodGet, path: "/api/config", want: authzStatus{200, 200, 200, 200}},
+		{method: http.MethodGet, path: "/api/me", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/login", body: `{"email":"owner@example.com","password":"secret"}`, want: authzStatus{200, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/login/totp", body: `{"challenge":"unknown","code":"123456"}`, want: authzStatus{401, 401, 401, 401}},
+		{method: http.MethodGet, path: "/api/auth/oidc/login", want: authzStatus{404, 404, 404, 404}},
+		{method: http.MethodGet, path: "/api/auth/oidc/callback", want: authzStatus{404, 404, 404, 404}},
+		{method: http.MethodPost, path: "/api/logout", want: authzStatus{204, 204, 204, 204}},
+		{method: http.MethodPost, path: "/api/logout/all", want: authzStatus{401, 204, 204, 204}},
+		{method: http.MethodGet, path: "/api/me/export", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodDelete, path: "/api/me", body: `{"confirm":"owner@example.com","password":"secret"}`, want: authzStatus{401, 204, 400, 400}},
+		{method: http.MethodPut, path: "/api/me/password", body: `{"currentPassword":"secret","newPassword":"changed"}`, want: authzStatus{401, 204, 204, 204}},
+		{method: http.MethodPut, path: "/api/me/name", body: `{"name":"Name"}`, want: authzStatus{401, 204, 204, 204}},
+		{method: http.MethodPost, path: "/api/password/forgot", body: `{"email":"owner@example.com"}`, want: authzStatus{202, 202, 202, 202}},
+		{method: http.MethodPost, path: "/api/password/reset", body: `{"token":"unknown","newPassword":"changed"}`, want: authzStatus{400, 400, 400, 400}},
+		{method: http.MethodPost, path: "/api/email/verify", body: `{"token":"unknown"}`, want: authzStatus{400, 400, 400, 400}},
+		{method: http.MethodPost, path: "/api/email/verify/resend", body: `{"email":"owner@example.com"}`, want: authzStatus{202, 202, 202, 202}},
+		{method: http.MethodGet, path: "/api/me/tokens", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/me/tokens", body: `{"name":"CLI"}`, want: authzStatus{401, 201, 201, 201}},
+		{method: http.MethodDelete, path: "/api/me/tokens/k1", want: authzStatus{401, 204, 404, 404}},
+		{method: http.MethodPost, path: "/api/me/totp", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/me/totp/confirm", body: `{"code":"123456"}`, want: authzStatus{401, 400, 400, 400}},
+		{method: http.MethodPost, path: "/api/me/totp/disable", body: `{"password":"secret"}`, want: authzStatus{401, 204, 204, 204}},
+		{method: http.MethodPost, path: "/api/me/totp/recovery-codes", body: `{"code":"123456"}`, want: authzStatus{401, 400, 400, 400}},
+		{method: http.MethodGet, path: "/api/users", want: authzStatus{403, 403, 403, 200}},
+		{method: http.MethodPost, path: "/api/users", body: `{"email":"new@example.com","password":"secret"}`, want: authzStatus{201, 201, 201, 201}},
+		{method: http.MethodPost, path: "/api/users", body: `{"email":"new@example.com","password":"secret","inviteToken":"invite"}`, want: authzStatus{201, 201, 201, 201}},
+		{method: http.MethodPost, path: "/api/users/other@example.com/password-reset", want: authzStatus{403, 403, 403, 202}},
+		{method: http.MethodGet, path: "/api/users/owner@example.com/export", want: authzStatus{403, 403, 403, 200}},
+		{method: http.MethodDelete, path: "/api/users/owner@example.com", body: `{"confirm":"owner@example.com"}`, want: authzStatus{403, 403, 403, 204}},
+		{method: http.MethodPut, path: "/api/users/other@example.com/role", body: `{"role":"coach"}`, want: authzStatus{403, 403, 403, 204}},
+		{method: http.MethodGet, path: "/api/admin/audit", want: authzStatus{403, 403, 403, 200}},
+		{method: http.MethodPost, path: "/api/admin/users", body: `{"email":"new@example.com","password":"secret"}`, want: authzStatus{403, 403, 403, 201}},
+		{method: http.MethodGet, path: "/api/admin/invitations", want: authzStatus{403, 403, 403, 200}},
+		{method: http.MethodPost, path: "/api/admin/invitations", body: `{"maxUses":3}`, want: authzStatus{403, 403, 403, 201}},
+		{method: http.MethodDelete, path: "/api/admin/invitations/i1", want: authzStatus{403, 403, 403, 204}},
+		{method: http.MethodGet, path: "/api/users/owner@example.com/workouts", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodPost, path: "/api/users/owner@example.com/workouts", body: workoutBody, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodGet, path: "/api/workouts/w1", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodGet, path: "/api/workouts/w1/export", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodPost, path: "/api/workouts/import", body: `{"workout":` + workoutBody + `}`, want: authzStatus{401, 201, 201, 201}},
+		{method: http.MethodPut, path: "/api/workouts/w1", body: workoutBody, want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodDelete, path: "/api/workouts/w1", want: authzStatus{401, 204, 403, 204}},
+		{method: http.MethodGet, path: "/api/workouts/w1/pacing", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodPost, path: "/api/workouts/w1/pacing/apply", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodGet, path: "/api/templates", want: authzStatus{200, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/templates", body: `{"workoutId":"w1","name":"Template"}`, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodGet, path: "/api/templates/t1", want: authzStatus{200, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/templates/t1/apply", body: `{"name":"Copy"}`, want: authzStatus{401, 201, 201, 201}},
+		{method: http.MethodGet, path: "/api/exercises", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/exercises", body: `{"name":"Row"}`, want: authzStatus{401, 201, 201, 201}},
+		{method: http.MethodPut, path: "/api/exercises/e1", body: `{"name":"Row"}`, want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodDelete, path: "/api/exercises/e1", want: authzStatus{401, 204, 403, 204}},
+		{method: http.MethodPost, path: "/api/exercises/backfill", want: authzStatus{403, 403, 403, 200}},
+		{method: http.MethodGet, path: "/api/sounds", want: authzStatus{200, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/trainings", body: `{"workoutId":"w1"}`, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodGet, path: "/api/users/owner@example.com/trainings/history", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodPost, path: "/api/trainings/complete", body: completeBody, want: authzStatus{401, 201, 201, 201}},
+		{method: http.MethodPost, path: "/api/trainings/manual", body: `{"workoutId":"w1","startedAt":"2024-03-01T18:00:00Z"}`, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodPost, path: "/api/trainings/at1/start", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodPost, path: "/api/trainings/at1/pause", want: authzStatus{401, 400, 403, 400}},
+		{method: http.MethodPost, path: "/api/trainings/at1/abort", want: authzStatus{401, 204, 403, 204}},
+		{method: http.MethodGet, path: "/api/me/trainings/active", want: authzStatus{401, 404, 404, 404}},
+		{method: http.MethodGet, path: "/api/me/records", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodGet, path: "/api/me/stats?period=month", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodGet, path: "/api/trainings/tr1/summary?format=markdown", want: authzStatus{401, 403, 200, 200}},
+		{method: http.MethodGet, path: "/api/me/summary-templates", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/me/summary-templates", body: `{"name":"Short","body":"{{.WorkoutName}}"}`, want: authzStatus{401, 201, 201, 201}},
+		{method: http.MethodPut, path: "/api/me/summary-templates/st1", body: `{"name":"Short","body":"{{.WorkoutName}}"}`, want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodDelete, path: "/api/me/summary-templates/st1", want: authzStatus{401, 204, 403, 204}},
+
+		{method: http.MethodPost, path: "/api/classes", body: `{"workoutId":"w1"}`, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodPost, path: "/api/classes/join", body: `{"code":"abc234"}`, want: authzStatus{401, 400, 200, 200}},
+		{method: http.MethodGet, path: "/api/classes/c1", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodPut, path: "/api/classes/c1/results", body: `{"steps":[]}`, want: authzStatus{401, 404, 204, 404}},
+		{method: http.MethodPost, path: "/api/classes/c1/leave", body: `{}`, want: authzStatus{401, 404, 200, 404}},
+		{method: http.MethodPost, path: "/api/classes/c1/end", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodGet, path: "/api/coaching/links", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/coaching/links", body: `{"athleteId":"other@example.com"}`, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodPost, path: "/api/coaching/links/l1/accept", want: authzStatus{401, 403, 200, 403}},
+		{method: http.MethodDelete, path: "/api/coaching/links/l1", want: authzStatus{401, 204, 204, 204}},
+		{method: http.MethodPost, path: "/api/coaching/athletes/other@example.com/workouts", body: `{"workoutId":"w1"}`, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodGet, path: "/api/coaching/athletes/other@example.com/trainings/history", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodGet, path: "/api/coaching/athletes/other@example.com/trainings/tr1/steps", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodPatch, path: "/api/trainings/tr1", body: `{"rpe":7}`, want: authzStatus{401, 403, 200, 200}},
+		{method: http.MethodPut, path: "/api/trainings/tr1", body: replaceBody, want: authzStatus{401, 403, 200, 200}},
+		{method: http.MethodDelete, path: "/api/trainings/tr1", want: authzStatus{401, 403, 204, 204}},
+		{method: http.MethodGet, path: "/api/trainings/tr1/steps", want: authzStatus{401, 403, 200, 200}},
+		{method: http.MethodGet, path: "/api/templates?org=o1", want: authzStatus{403, 200, 403, 200}},
+		{method: http.MethodPost, path: "/api/templates", body: `{"workoutId":"w1","name":"Template","orgId":"o1"}`, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodPost, path: "/api/exercises", body: `{"name":"Row","orgId":"o1"}`, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodGet, path: "/api/organizations", want: authzStatus{401, 200, 200, 200}},
+		{method: http.MethodPost, path: "/api/organizations", body: `{"name":"Club"}`, want: authzStatus{401, 201, 201, 201}},
+		{method: http.MethodDelete, path: "/api/organizations/o1", want: authzStatus{401, 204, 403, 204}},
+		{method: http.MethodGet, path: "/api/organizations/o1/members", want: authzStatus{401, 200, 403, 200}},
+		{method: http.MethodPost, path: "/api/organizations/o1/members", body: `{"userId":"other@example.com"}`, want: authzStatus{401, 201, 403, 201}},
+		{method: http.MethodPut, path: "/api/organizations/o1/members/member@example.com", body: `{"role":"editor"}`, want: authzStatus{401, 204, 403, 204}},
+		{method: http.MethodDelete, path: "/api/organizations/o1/members/member@example.com", want: authzStatus{401, 204, 403, 204}},
+		{method: http.MethodDelete, path: "/api/organizations/o1/members/owner@example.com", want: authzStatus{401, 204, 403, 204}},
+		{method: http.MethodPut, path: "/api/me/organization", body: `{"orgId":"o1"}`, want: authzStatus{401, 204, 403, 403}},
+	}
+
+	callers := []struct {
+		name   string
+		userID string
+		want   func(authzStatus) int
+	}{
+		{name: "anonymous", want: func(s authzStatus) int { return s.anonymous }},
+		{name: "owner", userID: authzOwner, want: func(s authzStatus) int { return s.owner }},
+		{name: "other", userID: authzOther, want: func(s authzStatus) int { return s.other }},
+		{name: "admin", userID: authzAdmin, want: func(s authzStatus) int { return s.admin }},
+	}
+
+	webFS := fstest.MapFS{"web/dist/index.html": &fstest.MapFile{Data: []byte(`<!doctype html>`)}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, tc := range tests {
+		for _, caller := range callers {
+			t.Run(tc.method+" "+tc.path+" as "+caller.name, func(t *testing.T) {
+				t.Parallel()
+
+				store := newAuthzStore(t)
+				trainingsService := trainings.New(store, sounds.URLByKey)
+				api := &handler.API{
+					Logger:            logger,
+					HealthStore:       store,
+					AuthStore:         store,
+					Users:             users.New(store, "", true),
+					Sessions:          sessions.New(store, time.Hour),
+					Tokens:            tokens.New(store),
+					Accounts:          accounts.New(store, mailer.NewLog(logger), "http://localhost:8080"),
+					Invitations:       invitations.New(store, "http://localhost:8080"),
+					Exercises:         exercises.New(store),
+					Workouts:          workouts.New(store),
+					Templates:         templates.New(store),
+					Trainings:         trainingsService,
+					Stats:             stats.New(store),
+					Pacing:            pacing.New(store),
+					Summary:           summary.New(store),
+					Classes:           classes.New(store, trainingsService),
+					Audit:             audit.New(store),
+					Privacy:           privacy.New(store),
+					Coaching:          coaching.New(store),
+					Organizations:     organizations.New(store),
+					AllowRegistration: true,
+				}
+				router, err := NewRouter(webFS, "", logger, api, Limits{}, false)
+				require.NoError(t, err)
+
+				var body io.Reader
+				if tc.body != "" {
+					body = strings.NewReader(tc.body)
+				}
+				req := httptest.NewRequest(tc.method, tc.path, body)
+				if caller.userID != "" {
+					issued, err := api.Sessions.Create(context.Background(), caller.userID, "test", "127.0.0.1")
+					require.NoError(t, err)
+					req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: issued.Token})
+				}
+				rec := httptest.NewRecorder()
+
+				router.ServeHTTP(rec, req)
+
+				assert.Equal(t, caller.want(tc.want), rec.Code, rec.Body.String())
+			})
+		}
+	}
+}
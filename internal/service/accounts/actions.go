@@ -0,0 +1,140 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// RequestPasswordReset mails a reset link if the address belongs to a user.
+// Unknown addresses succeed silently so the endpoint cannot be used to enumerate accounts.
+func (s *Service) RequestPasswordReset(ctx context.Context, email string) error {
+	normalized, err := utils.NormalizeEmail(email)
+	if err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+	user, err := s.lookupUser(ctx, normalized)
+	if err != nil || user == nil {
+		return err
+	}
+	return s.sendPasswordReset(ctx, user.ID)
+}
+
+// SendPasswordReset lets an admin mail a reset link to any user.
+func (s *Service) SendPasswordReset(ctx context.Context, actor policy.Actor, userID string) error {
+	if err := policy.RequirePermission(actor, policy.PermUsersManage, errorScope); err != nil {
+		return err
+	}
+	cleanID := utils.NormalizeToken(userID)
+	if cleanID == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	user, err := s.lookupUser(ctx, cleanID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
+	}
+	return s.sendPasswordReset(ctx, user.ID)
+}
+
+// ResetPassword redeems a reset token, sets the new password and signs out all sessions.
+func (s *Service) ResetPassword(ctx context.Context, token, newPassword string) (string, error) {
+	token = strings.TrimSpace(token)
+	newPassword = strings.TrimSpace(newPassword)
+	if token == "" || newPassword == "" {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, "token and newPassword are required", errorScope)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	userID, err := s.store.ResetPassword(ctx, utils.HashToken(token), string(hash), time.Now().UTC())
+	if err != nil {
+		return "", storeError(err)
+	}
+	return userID, nil
+}
+
+// SendVerification mails an email verification link to a freshly registered user.
+func (s *Service) SendVerification(ctx context.Context, userID string) error {
+	cleanID := utils.NormalizeToken(userID)
+	if cleanID == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	return s.issue(ctx, cleanID, db.TokenPurposeVerifyEmail, VerifyTTL)
+}
+
+// ResendVerification mails a new verification link to an unverified user.
+// Unknown or already verified addresses succeed silently.
+func (s *Service) ResendVerification(ctx context.Context, email string) error {
+	normalized, err := utils.NormalizeEmail(email)
+	if err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+	user, err := s.lookupUser(ctx, normalized)
+	if err != nil || user == nil || user.EmailVerifiedAt != nil {
+		return err
+	}
+	return s.issue(ctx, user.ID, db.TokenPurposeVerifyEmail, VerifyTTL)
+}
+
+// VerifyEmail redeems a verification token and returns the verified user id.
+func (s *Service) VerifyEmail(ctx context.Context, token string) (string, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, "token is required", errorScope)
+	}
+	userID, err := s.store.VerifyEmail(ctx, utils.HashToken(token), time.Now().UTC())
+	if err != nil {
+		return "", storeError(err)
+	}
+	return userID, nil
+}
+
+// sendPasswordReset issues a reset token for an existing user.
+func (s *Service) sendPasswordReset(ctx context.Context, userID string) error {
+	return s.issue(ctx, userID, db.TokenPurposePasswordReset, ResetTTL)
+}
+
+// issue stores a new one-time token and mails the matching link.
+func (s *Service) issue(ctx context.Context, userID, purpose string, ttl time.Duration) error {
+	secret := utils.NewToken()
+	now := time.Now().UTC()
+	token := OneTimeToken{
+		ID:        utils.HashToken(secret),
+		UserID:    userID,
+		Purpose:   purpose,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.store.CreateOneTimeToken(ctx, token); err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if err := s.mailer.Send(ctx, s.message(userID, purpose, secret, ttl)); err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, "send mail: "+err.Error(), errorScope)
+	}
+	return nil
+}
+
+// lookupUser fetches a user and maps a missing row to nil.
+func (s *Service) lookupUser(ctx context.Context, id string) (*User, error) {
+	user, err := s.store.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return user, nil
+}
@@ -0,0 +1,224 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// linkToken extracts the secret from the link in a mail body.
+func linkToken(t *testing.T, body, param string) string {
+	t.Helper()
+	_, after, ok := strings.Cut(body, "?"+param+"=")
+	require.True(t, ok, "body has no %s link: %q", param, body)
+	token, _, _ := strings.Cut(after, "\n")
+	return token
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Mails reset link", func(t *testing.T) {
+		t.Parallel()
+		var stored OneTimeToken
+		store := &fakeStore{
+			getUserFn: func(context.Context, string) (*User, error) {
+				return &User{ID: "a@example.com"}, nil
+			},
+			createTokenFn: func(_ context.Context, token OneTimeToken) error {
+				stored = token
+				return nil
+			},
+		}
+		mail := &fakeMailer{}
+		svc := New(store, mail, "https://motus.example.com/app/")
+
+		require.NoError(t, svc.RequestPasswordReset(context.Background(), " A@Example.com "))
+		require.Len(t, mail.sent, 1)
+		assert.Equal(t, "a@example.com", mail.sent[0].To)
+		assert.Contains(t, mail.sent[0].Body, "https://motus.example.com/app/?resetToken=")
+		assert.Contains(t, mail.sent[0].Body, "within 1 hour")
+
+		secret := linkToken(t, mail.sent[0].Body, "resetToken")
+		assert.Equal(t, utils.HashToken(secret), stored.ID)
+		assert.Equal(t, db.TokenPurposePasswordReset, stored.Purpose)
+		assert.Equal(t, ResetTTL, stored.ExpiresAt.Sub(stored.CreatedAt))
+	})
+
+	t.Run("Unknown email succeeds silently", func(t *testing.T) {
+		t.Parallel()
+		mail := &fakeMailer{}
+		svc := New(&fakeStore{}, mail, "https://motus.example.com")
+
+		require.NoError(t, svc.RequestPasswordReset(context.Background(), "ghost@example.com"))
+		assert.Empty(t, mail.sent)
+	})
+
+	t.Run("Invalid email", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, &fakeMailer{}, "https://motus.example.com")
+
+		err := svc.RequestPasswordReset(context.Background(), "nope")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Mailer failure", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{getUserFn: func(context.Context, string) (*User, error) {
+			return &User{ID: "a@example.com"}, nil
+		}}
+		svc := New(store, &fakeMailer{err: errors.New("relay down")}, "https://motus.example.com")
+
+		err := svc.RequestPasswordReset(context.Background(), "a@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorInternal))
+		assert.EqualError(t, err, "send mail: relay down")
+	})
+}
+
+func TestSendPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Requires admin", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, &fakeMailer{}, "https://motus.example.com")
+
+		err := svc.SendPasswordReset(context.Background(), policy.Actor{UserID: "a@example.com"}, "b@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, &fakeMailer{}, "https://motus.example.com")
+
+		err := svc.SendPasswordReset(context.Background(), policy.Actor{UserID: "admin@example.com", IsAdmin: true}, "b@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Admin mails link", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{getUserFn: func(_ context.Context, id string) (*User, error) {
+			return &User{ID: id}, nil
+		}}
+		mail := &fakeMailer{}
+		svc := New(store, mail, "https://motus.example.com")
+
+		err := svc.SendPasswordReset(context.Background(), policy.Actor{UserID: "admin@example.com", IsAdmin: true}, "b@example.com")
+		require.NoError(t, err)
+		require.Len(t, mail.sent, 1)
+		assert.Equal(t, "b@example.com", mail.sent[0].To)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stores new hash", func(t *testing.T) {
+		t.Parallel()
+		var gotHash, gotPassword string
+		store := &fakeStore{resetFn: func(_ context.Context, tokenHash, passwordHash string, _ time.Time) (string, error) {
+			gotHash, gotPassword = tokenHash, passwordHash
+			return "a@example.com", nil
+		}}
+		svc := New(store, &fakeMailer{}, "https://motus.example.com")
+
+		userID, err := svc.ResetPassword(context.Background(), "secret", "new-pass")
+		require.NoError(t, err)
+		assert.Equal(t, "a@example.com", userID)
+		assert.Equal(t, utils.HashToken("secret"), gotHash)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(gotPassword), []byte("new-pass")))
+	})
+
+	t.Run("Missing fields", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, &fakeMailer{}, "https://motus.example.com")
+
+		_, err := svc.ResetPassword(context.Background(), "secret", " ")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{resetFn: func(context.Context, string, string, time.Time) (string, error) {
+			return "", db.ErrOneTimeTokenNotFound
+		}}
+		svc := New(store, &fakeMailer{}, "https://motus.example.com")
+
+		_, err := svc.ResetPassword(context.Background(), "secret", "new-pass")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+		assert.EqualError(t, err, "token is invalid or expired")
+	})
+}
+
+func TestResendVerification(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Mails unverified user", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{getUserFn: func(_ context.Context, id string) (*User, error) {
+			return &User{ID: id}, nil
+		}}
+		mail := &fakeMailer{}
+		svc := New(store, mail, "https://motus.example.com")
+
+		require.NoError(t, svc.ResendVerification(context.Background(), "a@example.com"))
+		require.Len(t, mail.sent, 1)
+		assert.Contains(t, mail.sent[0].Body, "?verifyToken=")
+		assert.Contains(t, mail.sent[0].Body, "within 48 hours")
+	})
+
+	t.Run("Skips verified user", func(t *testing.T) {
+		t.Parallel()
+		verified := time.Now()
+		store := &fakeStore{getUserFn: func(_ context.Context, id string) (*User, error) {
+			return &User{ID: id, EmailVerifiedAt: &verified}, nil
+		}}
+		mail := &fakeMailer{}
+		svc := New(store, mail, "https://motus.example.com")
+
+		require.NoError(t, svc.ResendVerification(context.Background(), "a@example.com"))
+		assert.Empty(t, mail.sent)
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Redeems token", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{verifyFn: func(_ context.Context, tokenHash string, _ time.Time) (string, error) {
+			assert.Equal(t, utils.HashToken("secret"), tokenHash)
+			return "a@example.com", nil
+		}}
+		svc := New(store, &fakeMailer{}, "https://motus.example.com")
+
+		userID, err := svc.VerifyEmail(context.Background(), "secret")
+		require.NoError(t, err)
+		assert.Equal(t, "a@example.com", userID)
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, &fakeMailer{}, "https://motus.example.com")
+
+		_, err := svc.VerifyEmail(context.Background(), "")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
@@ -0,0 +1,19 @@
+package accounts
+
+import (
+	"strings"
+
+	"github.com/gi8lino/motus/internal/mailer"
+)
+
+// Service issues and redeems password reset and email verification links.
+type Service struct {
+	store   Store
+	mailer  mailer.Mailer
+	siteURL string
+}
+
+// New creates a new accounts service. siteURL is the public root that links point to.
+func New(store Store, mail mailer.Mailer, siteURL string) *Service {
+	return &Service{store: store, mailer: mail, siteURL: strings.TrimRight(siteURL, "/")}
+}
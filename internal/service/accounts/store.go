@@ -0,0 +1,14 @@
+package accounts
+
+import (
+	"context"
+	"time"
+)
+
+// Store defines persistence operations required by the accounts domain.
+type Store interface {
+	GetUser(ctx context.Context, id string) (*User, error)
+	CreateOneTimeToken(ctx context.Context, token OneTimeToken) error
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string, at time.Time) (string, error)
+	VerifyEmail(ctx context.Context, tokenHash string, at time.Time) (string, error)
+}
@@ -0,0 +1,61 @@
+package accounts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/mailer"
+)
+
+type fakeStore struct {
+	getUserFn     func(context.Context, string) (*User, error)
+	createTokenFn func(context.Context, OneTimeToken) error
+	resetFn       func(context.Context, string, string, time.Time) (string, error)
+	verifyFn      func(context.Context, string, time.Time) (string, error)
+}
+
+func (f *fakeStore) GetUser(ctx context.Context, id string) (*User, error) {
+	if f.getUserFn == nil {
+		return nil, db.ErrUserNotFound
+	}
+	return f.getUserFn(ctx, id)
+}
+
+func (f *fakeStore) CreateOneTimeToken(ctx context.Context, token OneTimeToken) error {
+	if f.createTokenFn == nil {
+		return nil
+	}
+	return f.createTokenFn(ctx, token)
+}
+
+func (f *fakeStore) ResetPassword(ctx context.Context, tokenHash, passwordHash string, at time.Time) (string, error) {
+	if f.resetFn == nil {
+		return "", nil
+	}
+	return f.resetFn(ctx, tokenHash, passwordHash, at)
+}
+
+func (f *fakeStore) VerifyEmail(ctx context.Context, tokenHash string, at time.Time) (string, error) {
+	if f.verifyFn == nil {
+		return "", nil
+	}
+	return f.verifyFn(ctx, tokenHash, at)
+}
+
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+	err  error
+}
+
+func (f *fakeMailer) Send(_ context.Context, msg mailer.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
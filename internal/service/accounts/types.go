@@ -0,0 +1,24 @@
+// Package accounts provides password reset and email verification flows.
+package accounts
+
+import (
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+// User is the domain-level DTO for users.
+type User = db.User
+
+// OneTimeToken is the domain-level DTO for reset and verification tokens.
+type OneTimeToken = db.OneTimeToken
+
+// errorScope is the service error scope for accounts.
+const errorScope = "accounts"
+
+const (
+	// ResetTTL is how long a password reset link stays valid.
+	ResetTTL = time.Hour
+	// VerifyTTL is how long an email verification link stays valid.
+	VerifyTTL = 48 * time.Hour
+)
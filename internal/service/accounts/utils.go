@@ -0,0 +1,50 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/mailer"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+// message renders the email carrying a one-time link.
+func (s *Service) message(to, purpose, secret string, ttl time.Duration) mailer.Message {
+	if purpose == db.TokenPurposePasswordReset {
+		link := s.siteURL + "/?resetToken=" + url.QueryEscape(secret)
+		return mailer.Message{
+			To:      to,
+			Subject: "Reset your Motus password",
+			Body: fmt.Sprintf("Someone asked to reset the password of your Motus account.\n\n"+
+				"Open this link within %s to choose a new password:\n%s\n\n"+
+				"If this was not you, you can ignore this email.\n", hoursText(ttl), link),
+		}
+	}
+	link := s.siteURL + "/?verifyToken=" + url.QueryEscape(secret)
+	return mailer.Message{
+		To:      to,
+		Subject: "Confirm your Motus email address",
+		Body: fmt.Sprintf("Welcome to Motus!\n\n"+
+			"Open this link within %s to confirm your email address:\n%s\n", hoursText(ttl), link),
+	}
+}
+
+// storeError maps persistence errors of token redemption to service errors.
+func storeError(err error) error {
+	if errors.Is(err, db.ErrOneTimeTokenNotFound) {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+	return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+}
+
+// hoursText renders a link lifetime for humans, e.g. "1 hour" or "48 hours".
+func hoursText(ttl time.Duration) string {
+	hours := int(ttl.Round(time.Hour).Hours())
+	if hours == 1 {
+		return "1 hour"
+	}
+	return fmt.Sprintf("%d hours", hours)
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// List returns one page of audit events matching query. Only admins may read the audit log.
+func (s *Service) List(ctx context.Context, actor policy.Actor, query Query) (Page, error) {
+	if err := policy.RequirePermission(actor, policy.PermAuditRead, errorScope); err != nil {
+		return Page{}, err
+	}
+	filter, err := parseQuery(query)
+	if err != nil {
+		return Page{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+
+	// Fetch one extra row to learn whether another page follows.
+	limit := filter.Limit
+	filter.Limit++
+	events, err := s.store.ListAuditEvents(ctx, filter)
+	if err != nil {
+		return Page{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+
+	page := Page{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.NextCursor = strconv.FormatInt(page.Events[limit-1].ID, 10)
+	}
+	if page.Events == nil {
+		page.Events = []Event{}
+	}
+	return page, nil
+}
@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	admin := policy.Actor{UserID: "admin@example.com", IsAdmin: true}
+
+	t.Run("Requires admin", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		_, err := svc.List(context.Background(), policy.Actor{UserID: "u1"}, Query{})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Passes filters", func(t *testing.T) {
+		t.Parallel()
+		var got Filter
+		svc := New(&fakeStore{listFn: func(_ context.Context, filter Filter) ([]Event, error) {
+			got = filter
+			return nil, nil
+		}})
+
+		page, err := svc.List(context.Background(), admin, Query{
+			Actor:    " u1 ",
+			Action:   "workout_deleted",
+			Resource: "workout",
+			Since:    "2024-01-01T00:00:00Z",
+			Cursor:   "42",
+			Limit:    "10",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []Event{}, page.Events)
+		assert.Empty(t, page.NextCursor)
+		assert.Equal(t, "u1", got.ActorID)
+		assert.Equal(t, "workout_deleted", got.Action)
+		assert.Equal(t, "workout", got.Resource)
+		require.NotNil(t, got.Since)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), got.Since.UTC())
+		assert.Nil(t, got.Until)
+		assert.Equal(t, int64(42), got.BeforeID)
+		assert.Equal(t, 11, got.Limit, "fetches one extra row")
+	})
+
+	t.Run("Returns next cursor", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{listFn: func(context.Context, Filter) ([]Event, error) {
+			return []Event{{ID: 9}, {ID: 8}, {ID: 7}}, nil
+		}})
+
+		page, err := svc.List(context.Background(), admin, Query{Limit: "2"})
+		require.NoError(t, err)
+		require.Len(t, page.Events, 2)
+		assert.Equal(t, "8", page.NextCursor)
+	})
+
+	t.Run("Caps limit", func(t *testing.T) {
+		t.Parallel()
+		var got Filter
+		svc := New(&fakeStore{listFn: func(_ context.Context, filter Filter) ([]Event, error) {
+			got = filter
+			return nil, nil
+		}})
+
+		_, err := svc.List(context.Background(), admin, Query{Limit: "5000"})
+		require.NoError(t, err)
+		assert.Equal(t, MaxLimit+1, got.Limit)
+	})
+
+	t.Run("Rejects invalid parameters", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		for _, query := range []Query{{Since: "yesterday"}, {Until: "2024-13-01"}, {Cursor: "abc"}, {Limit: "-1"}} {
+			_, err := svc.List(context.Background(), admin, query)
+			require.Error(t, err)
+			assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+		}
+	})
+}
@@ -0,0 +1,11 @@
+package audit
+
+// Service records and lists audit events.
+type Service struct {
+	store Store
+}
+
+// New creates a new audit service.
+func New(store Store) *Service {
+	return &Service{store: store}
+}
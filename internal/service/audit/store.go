@@ -0,0 +1,13 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Store defines persistence operations required by the audit domain.
+type Store interface {
+	CreateAuditEvent(ctx context.Context, event Event) error
+	ListAuditEvents(ctx context.Context, filter Filter) ([]Event, error)
+	DeleteAuditEventsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
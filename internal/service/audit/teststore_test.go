@@ -0,0 +1,33 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+type fakeStore struct {
+	createFn func(context.Context, Event) error
+	listFn   func(context.Context, Filter) ([]Event, error)
+	deleteFn func(context.Context, time.Time) (int64, error)
+}
+
+func (f *fakeStore) CreateAuditEvent(ctx context.Context, event Event) error {
+	if f.createFn == nil {
+		return nil
+	}
+	return f.createFn(ctx, event)
+}
+
+func (f *fakeStore) ListAuditEvents(ctx context.Context, filter Filter) ([]Event, error) {
+	if f.listFn == nil {
+		return nil, nil
+	}
+	return f.listFn(ctx, filter)
+}
+
+func (f *fakeStore) DeleteAuditEventsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	if f.deleteFn == nil {
+		return 0, nil
+	}
+	return f.deleteFn(ctx, cutoff)
+}
@@ -0,0 +1,37 @@
+// Package audit provides domain logic for the persistent audit log.
+package audit
+
+import "github.com/gi8lino/motus/internal/db"
+
+// Event is the domain-level DTO for audit log entries.
+type Event = db.AuditEvent
+
+// Filter is the domain-level DTO for audit log queries.
+type Filter = db.AuditFilter
+
+// errorScope is the service error scope for the audit log.
+const errorScope = "audit"
+
+// Page sizes for listing the audit log.
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// Query holds the raw, unvalidated filter parameters of a listing request.
+type Query struct {
+	Actor      string // Actor matches the acting user id.
+	Action     string // Action matches the event name.
+	Resource   string // Resource matches the resource type.
+	ResourceID string // ResourceID matches the resource id.
+	Since      string // Since is an RFC 3339 lower bound on the event time.
+	Until      string // Until is an RFC 3339 upper bound on the event time.
+	Cursor     string // Cursor continues a previous listing.
+	Limit      string // Limit is the page size.
+}
+
+// Page is one page of audit events, newest first.
+type Page struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"nextCursor,omitempty"` // NextCursor is empty on the last page.
+}
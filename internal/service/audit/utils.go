@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseQuery validates raw query parameters into a store filter.
+func parseQuery(query Query) (Filter, error) {
+	filter := Filter{
+		ActorID:    strings.TrimSpace(query.Actor),
+		Action:     strings.TrimSpace(query.Action),
+		Resource:   strings.TrimSpace(query.Resource),
+		ResourceID: strings.TrimSpace(query.ResourceID),
+		Limit:      DefaultLimit,
+	}
+	var err error
+	if filter.Since, err = parseTime("since", query.Since); err != nil {
+		return Filter{}, err
+	}
+	if filter.Until, err = parseTime("until", query.Until); err != nil {
+		return Filter{}, err
+	}
+	if cursor := strings.TrimSpace(query.Cursor); cursor != "" {
+		id, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || id <= 0 {
+			return Filter{}, errInvalid("cursor")
+		}
+		filter.BeforeID = id
+	}
+	if limit := strings.TrimSpace(query.Limit); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return Filter{}, errInvalid("limit")
+		}
+		filter.Limit = min(n, MaxLimit)
+	}
+	return filter, nil
+}
+
+// parseTime parses an optional RFC 3339 timestamp.
+func parseTime(name, value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errInvalid(name)
+	}
+	return &t, nil
+}
+
+// errInvalid reports a malformed query parameter.
+func errInvalid(name string) error {
+	return fmt.Errorf("invalid %s", name)
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+// Record appends an event to the audit log, stamping it with the current time.
+func (s *Service) Record(ctx context.Context, event Event) error {
+	event.Action = strings.TrimSpace(event.Action)
+	if event.Action == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "action is required", errorScope)
+	}
+	event.CreatedAt = time.Now().UTC()
+	if err := s.store.CreateAuditEvent(ctx, event); err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
+
+// Prune deletes events older than retention and returns how many were removed.
+func (s *Service) Prune(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, nil
+	}
+	deleted, err := s.store.DeleteAuditEventsBefore(ctx, time.Now().UTC().Add(-retention))
+	if err != nil {
+		return 0, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return deleted, nil
+}
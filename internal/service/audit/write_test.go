@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stamps and stores event", func(t *testing.T) {
+		t.Parallel()
+		var stored Event
+		svc := New(&fakeStore{createFn: func(_ context.Context, event Event) error {
+			stored = event
+			return nil
+		}})
+
+		err := svc.Record(context.Background(), Event{ActorID: "u1", Action: " workout_deleted ", Resource: "workout", ResourceID: "w1"})
+		require.NoError(t, err)
+		assert.Equal(t, "workout_deleted", stored.Action)
+		assert.Equal(t, "w1", stored.ResourceID)
+		assert.WithinDuration(t, time.Now(), stored.CreatedAt, time.Minute)
+	})
+
+	t.Run("Requires action", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		err := svc.Record(context.Background(), Event{ActorID: "u1"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Store failure", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{createFn: func(context.Context, Event) error { return errors.New("boom") }})
+		err := svc.Record(context.Background(), Event{Action: "user_login"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorInternal))
+	})
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Deletes older events", func(t *testing.T) {
+		t.Parallel()
+		var cutoff time.Time
+		svc := New(&fakeStore{deleteFn: func(_ context.Context, c time.Time) (int64, error) {
+			cutoff = c
+			return 3, nil
+		}})
+
+		deleted, err := svc.Prune(context.Background(), 24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), cutoff, time.Minute)
+	})
+
+	t.Run("Disabled retention keeps everything", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{deleteFn: func(context.Context, time.Time) (int64, error) {
+			t.Fatal("unexpected delete")
+			return 0, nil
+		}})
+
+		deleted, err := svc.Prune(context.Background(), 0)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+}
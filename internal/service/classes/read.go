@@ -0,0 +1,87 @@
+package classes
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// Get returns a class for its coach or one of its participants; only the coach sees who takes part.
+func (s *Service) Get(ctx context.Context, actor policy.Actor, classID string) (ClassView, error) {
+	class, err := s.getClass(ctx, classID)
+	if err != nil {
+		return ClassView{}, err
+	}
+	if actor.CanAccess(class.CoachID) {
+		return s.view(ctx, class, true)
+	}
+	if _, err := s.participant(ctx, class, actor); err != nil {
+		return ClassView{}, err
+	}
+	return s.view(ctx, class, false)
+}
+
+// Follow subscribes the coach or a current participant to the timer of an open class.
+// Participants are marked as seen while they follow. The channel is closed once the class ends.
+func (s *Service) Follow(ctx context.Context, actor policy.Actor, classID string) (TrainingState, <-chan TrainingState, error) {
+	class, err := s.openClass(ctx, classID)
+	if err != nil {
+		return TrainingState{}, nil, err
+	}
+	if actor.CanAccess(class.CoachID) {
+		return s.timer.Follow(ctx, class.TrainingID)
+	}
+	participant, err := s.participant(ctx, class, actor)
+	if err != nil {
+		return TrainingState{}, nil, err
+	}
+	if participant.LeftAt != nil {
+		return TrainingState{}, nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "you left this class", errorScope)
+	}
+	state, events, err := s.timer.Follow(ctx, class.TrainingID)
+	if err != nil {
+		return TrainingState{}, nil, err
+	}
+	return state, s.watch(ctx, participant, state, events), nil
+}
+
+// view builds the API payload of a class, including its participants when requested.
+func (s *Service) view(ctx context.Context, class *Class, withParticipants bool) (ClassView, error) {
+	view := ClassView{Class: *class}
+	if class.EndedAt == nil {
+		state, found, err := s.snapshot(ctx, class)
+		if err != nil {
+			return ClassView{}, err
+		}
+		if found {
+			view.State = &state
+		}
+	}
+	if withParticipants {
+		participants, err := s.store.ListClassParticipants(ctx, class.ID)
+		if err != nil {
+			return ClassView{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		}
+		view.Participants = participants
+		if view.Participants == nil {
+			view.Participants = []ClassParticipant{}
+		}
+	}
+	return view, nil
+}
+
+// participant returns the actor's participation in a class.
+func (s *Service) participant(ctx context.Context, class *Class, actor policy.Actor) (*ClassParticipant, error) {
+	participant, err := s.store.GetClassParticipant(ctx, class.ID, strings.TrimSpace(actor.UserID))
+	if err != nil {
+		if errors.Is(err, db.ErrClassParticipantNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "you did not join this class", errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return participant, nil
+}
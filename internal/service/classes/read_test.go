@@ -0,0 +1,102 @@
+package classes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Coach sees participants", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		_, err := svc.Join(context.Background(), athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+
+		view, err := svc.Get(context.Background(), coach, class.ID)
+		require.NoError(t, err)
+		require.Len(t, view.Participants, 1)
+		assert.Equal(t, "athlete@example.com", view.Participants[0].UserID)
+		assert.NotNil(t, view.State)
+	})
+
+	t.Run("Participants see the class", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		_, err := svc.Join(context.Background(), athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+
+		view, err := svc.Get(context.Background(), athlete, class.ID)
+		require.NoError(t, err)
+		assert.Nil(t, view.Participants)
+	})
+
+	t.Run("Others are forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		_, err := svc.Get(context.Background(), athlete, class.ID)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
+
+func TestFollow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Participants follow the timer", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		_, err := svc.Join(context.Background(), athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+
+		state, events, err := svc.Follow(context.Background(), athlete, class.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "t1", state.TrainingID)
+		assert.NotNil(t, events)
+	})
+
+	t.Run("Disconnecting marks the participant as seen", func(t *testing.T) {
+		t.Parallel()
+		svc, store, timer, class := startClass(t)
+		_, err := svc.Join(context.Background(), athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		runTimer(timer, 2)
+		joined := store.participants[class.ID+"/athlete@example.com"].SeenAt
+
+		ctx, cancel := context.WithCancel(context.Background())
+		_, events, err := svc.Follow(ctx, athlete, class.ID)
+		require.NoError(t, err)
+		cancel()
+		for range events {
+		}
+
+		participant := store.participants[class.ID+"/athlete@example.com"]
+		assert.Equal(t, 2, participant.SeenIndex)
+		assert.False(t, participant.SeenAt.Before(joined))
+	})
+
+	t.Run("Dropouts stop following", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		_, err = svc.Leave(ctx, athlete, class.ID, LeaveRequest{})
+		require.NoError(t, err)
+
+		_, _, err = svc.Follow(ctx, athlete, class.ID)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Others are forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		_, _, err := svc.Follow(context.Background(), athlete, class.ID)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
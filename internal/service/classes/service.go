@@ -0,0 +1,12 @@
+package classes
+
+// Service runs classes on top of the training timer of the coach.
+type Service struct {
+	store Store
+	timer Timer
+}
+
+// New creates a new classes service.
+func New(store Store, timer Timer) *Service {
+	return &Service{store: store, timer: timer}
+}
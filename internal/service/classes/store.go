@@ -0,0 +1,34 @@
+package classes
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/trainings"
+)
+
+// Store defines persistence operations required by the classes domain.
+type Store interface {
+	CreateClass(ctx context.Context, class Class) error
+	GetClass(ctx context.Context, id string) (*Class, error)
+	OpenClassByCode(ctx context.Context, code string) (*Class, error)
+	EndClass(ctx context.Context, id string, at time.Time) error
+	AddClassParticipant(ctx context.Context, participant ClassParticipant) error
+	GetClassParticipant(ctx context.Context, classID, userID string) (*ClassParticipant, error)
+	ListClassParticipants(ctx context.Context, classID string) ([]ClassParticipant, error)
+	SeeClassParticipant(ctx context.Context, classID, userID string, at time.Time, index int) error
+	SaveClassResults(ctx context.Context, classID, userID string, results json.RawMessage, at time.Time, index int) error
+	LeaveClass(ctx context.Context, classID, userID, trainingID string, at time.Time) error
+	AssignWorkout(ctx context.Context, workoutID, athleteID, assignedBy, name string) (*Workout, error)
+}
+
+// Timer is the part of the trainings service that keeps the clock of a class and logs its participants.
+type Timer interface {
+	CreateState(ctx context.Context, actor policy.Actor, workoutID string) (TrainingState, error)
+	Snapshot(ctx context.Context, trainingID string) (TrainingState, error)
+	Follow(ctx context.Context, trainingID string) (TrainingState, <-chan TrainingState, error)
+	Abort(ctx context.Context, actor policy.Actor, trainingID string) (TrainingState, error)
+	Record(ctx context.Context, log TrainingLog, steps []TrainingStepLog) (TrainingLog, []trainings.PersonalRecord, error)
+}
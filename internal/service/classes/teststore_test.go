@@ -0,0 +1,194 @@
+package classes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/trainings"
+)
+
+// fakeStore keeps classes, participants and written logs in memory.
+type fakeStore struct {
+	classes      map[string]Class
+	participants map[string]ClassParticipant
+	workouts     []Workout
+	takenCodes   int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		classes:      map[string]Class{},
+		participants: map[string]ClassParticipant{},
+	}
+}
+
+func (f *fakeStore) CreateClass(_ context.Context, class Class) error {
+	if f.takenCodes > 0 {
+		f.takenCodes--
+		return db.ErrJoinCodeTaken
+	}
+	f.classes[class.ID] = class
+	return nil
+}
+
+func (f *fakeStore) GetClass(_ context.Context, id string) (*Class, error) {
+	class, ok := f.classes[id]
+	if !ok {
+		return nil, db.ErrClassNotFound
+	}
+	return &class, nil
+}
+
+func (f *fakeStore) OpenClassByCode(_ context.Context, code string) (*Class, error) {
+	for _, class := range f.classes {
+		if class.JoinCode == code && class.EndedAt == nil {
+			return &class, nil
+		}
+	}
+	return nil, db.ErrClassNotFound
+}
+
+func (f *fakeStore) EndClass(_ context.Context, id string, at time.Time) error {
+	class, ok := f.classes[id]
+	if !ok || class.EndedAt != nil {
+		return db.ErrClassNotFound
+	}
+	class.EndedAt = &at
+	f.classes[id] = class
+	return nil
+}
+
+func (f *fakeStore) AddClassParticipant(_ context.Context, participant ClassParticipant) error {
+	key := participant.ClassID + "/" + participant.UserID
+	if _, ok := f.participants[key]; !ok {
+		f.participants[key] = participant
+	}
+	return nil
+}
+
+func (f *fakeStore) GetClassParticipant(_ context.Context, classID, userID string) (*ClassParticipant, error) {
+	participant, ok := f.participants[classID+"/"+userID]
+	if !ok {
+		return nil, db.ErrClassParticipantNotFound
+	}
+	return &participant, nil
+}
+
+func (f *fakeStore) ListClassParticipants(_ context.Context, classID string) ([]ClassParticipant, error) {
+	var participants []ClassParticipant
+	for _, participant := range f.participants {
+		if participant.ClassID == classID {
+			participants = append(participants, participant)
+		}
+	}
+	sort.Slice(participants, func(i, j int) bool { return participants[i].UserID < participants[j].UserID })
+	return participants, nil
+}
+
+func (f *fakeStore) SeeClassParticipant(_ context.Context, classID, userID string, at time.Time, index int) error {
+	key := classID + "/" + userID
+	participant, ok := f.participants[key]
+	if !ok || participant.LeftAt != nil {
+		return db.ErrClassParticipantNotFound
+	}
+	participant.SeenAt = at
+	participant.SeenIndex = index
+	f.participants[key] = participant
+	return nil
+}
+
+func (f *fakeStore) SaveClassResults(_ context.Context, classID, userID string, results json.RawMessage, at time.Time, index int) error {
+	key := classID + "/" + userID
+	participant, ok := f.participants[key]
+	if !ok || participant.LeftAt != nil {
+		return db.ErrClassParticipantNotFound
+	}
+	participant.Results = results
+	participant.SeenAt = at
+	participant.SeenIndex = index
+	f.participants[key] = participant
+	return nil
+}
+
+func (f *fakeStore) LeaveClass(_ context.Context, classID, userID, trainingID string, at time.Time) error {
+	key := classID + "/" + userID
+	participant, ok := f.participants[key]
+	if !ok || participant.LeftAt != nil {
+		return db.ErrClassParticipantNotFound
+	}
+	participant.LeftAt = &at
+	participant.TrainingID = trainingID
+	f.participants[key] = participant
+	return nil
+}
+
+func (f *fakeStore) AssignWorkout(_ context.Context, workoutID, athleteID, assignedBy, name string) (*Workout, error) {
+	if workoutID == "" {
+		return nil, db.ErrWorkoutNotFound
+	}
+	workout := Workout{ID: fmt.Sprintf("copy-%d", len(f.workouts)+1), UserID: athleteID, Name: name, AssignedBy: assignedBy}
+	f.workouts = append(f.workouts, workout)
+	return &workout, nil
+}
+
+// fakeTimer serves a single coach training whose state tests change directly.
+type fakeTimer struct {
+	state   *TrainingState
+	aborted bool
+	logs    []TrainingLog
+	steps   map[string][]TrainingStepLog
+}
+
+func (f *fakeTimer) CreateState(_ context.Context, actor policy.Actor, workoutID string) (TrainingState, error) {
+	if workoutID == "" {
+		return TrainingState{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "workoutId is required", "trainings")
+	}
+	f.state = &TrainingState{
+		TrainingID:  "t1",
+		WorkoutID:   workoutID,
+		WorkoutName: "Class workout",
+		UserID:      actor.UserID,
+		Steps:       []trainings.TrainingStepState{{ID: "s1", Name: "Squats"}, {ID: "s2", Name: "Rest"}, {ID: "s3", Name: "Lunges"}},
+	}
+	return *f.state, nil
+}
+
+func (f *fakeTimer) Snapshot(context.Context, string) (TrainingState, error) {
+	if f.state == nil {
+		return TrainingState{}, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "no active training", "trainings")
+	}
+	return *f.state, nil
+}
+
+func (f *fakeTimer) Follow(ctx context.Context, trainingID string) (TrainingState, <-chan TrainingState, error) {
+	state, err := f.Snapshot(ctx, trainingID)
+	if err != nil {
+		return TrainingState{}, nil, err
+	}
+	return state, make(chan TrainingState), nil
+}
+
+func (f *fakeTimer) Abort(context.Context, policy.Actor, string) (TrainingState, error) {
+	if f.state == nil {
+		return TrainingState{}, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "no active training", "trainings")
+	}
+	f.aborted = true
+	state := *f.state
+	f.state = nil
+	return state, nil
+}
+
+func (f *fakeTimer) Record(_ context.Context, log TrainingLog, steps []TrainingStepLog) (TrainingLog, []trainings.PersonalRecord, error) {
+	f.logs = append(f.logs, log)
+	if f.steps == nil {
+		f.steps = map[string][]TrainingStepLog{}
+	}
+	f.steps[log.UserID] = steps
+	return log, nil, nil
+}
@@ -0,0 +1,73 @@
+// Package classes runs group trainings in which participants follow the timer of a coach.
+package classes
+
+import (
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/trainings"
+)
+
+// Class is the domain-level DTO for group classes.
+type Class = db.Class
+
+// ClassParticipant is the domain-level DTO for class participants.
+type ClassParticipant = db.ClassParticipant
+
+// Workout is the domain-level DTO for the workout copies participants log against.
+type Workout = db.Workout
+
+// TrainingLog is the domain-level DTO for completed training logs.
+type TrainingLog = db.TrainingLog
+
+// TrainingStepLog is the domain-level DTO for training step timing logs.
+type TrainingStepLog = db.TrainingStepLog
+
+// TrainingState is the runtime state of the coach's timer.
+type TrainingState = trainings.TrainingState
+
+// TrainingStepState is a step of the coach's timer.
+type TrainingStepState = trainings.TrainingStepState
+
+// errorScope is the service error scope for classes.
+const errorScope = "classes"
+
+// joinCodeAlphabet leaves out characters that are easily confused when read from a screen.
+const joinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// joinCodeLength is the number of characters in a join code.
+const joinCodeLength = 6
+
+// followHeartbeat is how often a participant following the class timer is marked as seen.
+const followHeartbeat = 30 * time.Second
+
+// staleAfter is how long a participant may go unseen before End logs them only up to where they were last seen.
+const staleAfter = 2 * time.Minute
+
+// StartRequest names the workout a coach runs as a class.
+type StartRequest struct {
+	WorkoutID string `json:"workoutId"`
+}
+
+// JoinRequest carries the code a participant entered.
+type JoinRequest struct {
+	Code string `json:"code"`
+}
+
+// LeaveRequest carries the exercise results a participant recorded; steps are matched by id.
+type LeaveRequest struct {
+	Steps []TrainingStepState `json:"steps"`
+}
+
+// ResultsRequest carries the exercise results a participant recorded so far; steps are matched by id.
+type ResultsRequest struct {
+	Steps []TrainingStepState `json:"steps"`
+}
+
+// ClassView is the API payload for a class with the current state of its timer.
+// Participants are only listed for the coach.
+type ClassView struct {
+	Class
+	State        *TrainingState     `json:"state,omitempty"`
+	Participants []ClassParticipant `json:"participants,omitempty"`
+}
@@ -0,0 +1,228 @@
+package classes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/trainings"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// getClass fetches a class by id.
+func (s *Service) getClass(ctx context.Context, classID string) (*Class, error) {
+	classID = strings.TrimSpace(classID)
+	if classID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "class id is required", errorScope)
+	}
+	class, err := s.store.GetClass(ctx, classID)
+	if err != nil {
+		return nil, mapClassError(err)
+	}
+	return class, nil
+}
+
+// openClass fetches a class that has not ended yet.
+func (s *Service) openClass(ctx context.Context, classID string) (*Class, error) {
+	class, err := s.getClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	if class.EndedAt != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "class has ended", errorScope)
+	}
+	return class, nil
+}
+
+// snapshot returns the state of the coach's timer and whether it still exists.
+func (s *Service) snapshot(ctx context.Context, class *Class) (TrainingState, bool, error) {
+	state, err := s.timer.Snapshot(ctx, class.TrainingID)
+	if errpkg.IsKind(err, errpkg.ErrorNotFound) {
+		return TrainingState{}, false, nil
+	}
+	if err != nil {
+		return TrainingState{}, false, err
+	}
+	return state, true, nil
+}
+
+// activeParticipant returns the actor's participation in a class they have not left yet.
+func (s *Service) activeParticipant(ctx context.Context, class *Class, actor policy.Actor) (*ClassParticipant, error) {
+	participant, err := s.store.GetClassParticipant(ctx, class.ID, strings.TrimSpace(actor.UserID))
+	if err != nil {
+		if errors.Is(err, db.ErrClassParticipantNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "you did not join this class", errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if participant.LeftAt != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "you already left this class", errorScope)
+	}
+	return participant, nil
+}
+
+// watch forwards the timer of a class to a participant and marks them as seen while they stay connected.
+// The last mark is written when the participant disconnects.
+func (s *Service) watch(ctx context.Context, participant *ClassParticipant, state TrainingState, events <-chan TrainingState) <-chan TrainingState {
+	out := make(chan TrainingState)
+	index := state.CurrentIndex
+	s.see(ctx, participant, index)
+	go func() {
+		defer close(out)
+		ticker := time.NewTicker(followHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case state, ok := <-events:
+				if !ok {
+					return
+				}
+				index = state.CurrentIndex
+				s.see(ctx, participant, index)
+				select {
+				case out <- state:
+				case <-ctx.Done():
+					s.see(context.WithoutCancel(ctx), participant, index)
+					return
+				}
+			case <-ticker.C:
+				s.see(ctx, participant, index)
+			case <-ctx.Done():
+				s.see(context.WithoutCancel(ctx), participant, index)
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// see records that a participant follows the class at the given step.
+// Marks are best effort: a failed write only makes End treat the participant as gone earlier.
+func (s *Service) see(ctx context.Context, participant *ClassParticipant, index int) {
+	_ = s.store.SeeClassParticipant(ctx, participant.ClassID, participant.UserID, time.Now().UTC(), index)
+}
+
+// recordParticipant writes the training log of a participant from the class state and returns its id.
+// The log belongs to a copy of the class workout in the participant's library, so it survives
+// the coach deleting their workout. Nothing is logged for a class that has not started yet.
+func (s *Service) recordParticipant(ctx context.Context, class *Class, userID string, state TrainingState, at time.Time) (string, error) {
+	steps := trainedSteps(state)
+	if len(steps) == 0 {
+		return "", nil
+	}
+	workout, err := s.store.AssignWorkout(ctx, class.WorkoutID, userID, class.CoachID, class.WorkoutName)
+	if err != nil {
+		if errors.Is(err, db.ErrWorkoutNotFound) {
+			return "", errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "class workout not found", errorScope)
+		}
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	completedAt := at
+	if state.Done {
+		completedAt = state.CompletedAt
+	}
+	log, stepLogs, err := trainings.BuildTrainingLog(trainings.CompleteRequest{
+		TrainingID:  utils.NewID(),
+		WorkoutID:   workout.ID,
+		WorkoutName: workout.Name,
+		UserID:      userID,
+		StartedAt:   state.StartedAt,
+		CompletedAt: completedAt,
+		Steps:       steps,
+	})
+	if err != nil {
+		return "", err
+	}
+	// Logging through the trainings service detects the personal records set in class.
+	if _, _, err := s.timer.Record(ctx, log, stepLogs); err != nil {
+		return "", err
+	}
+	return log.ID, nil
+}
+
+// participantState returns the class state to log for a participant who stayed until End, with their submitted results merged in.
+// Participants unseen for longer than staleAfter are logged up to the step and time they were last seen.
+func participantState(state TrainingState, participant ClassParticipant, at time.Time) (TrainingState, time.Time, error) {
+	submitted, err := participantResults(&participant)
+	if err != nil {
+		return TrainingState{}, time.Time{}, err
+	}
+	end := at
+	if state.Done {
+		end = state.CompletedAt
+	}
+	if end.Sub(participant.SeenAt) > staleAfter {
+		at = participant.SeenAt
+		if at.Before(state.StartedAt) {
+			// Gone before the class started: there is nothing to log.
+			state.StartedAt = time.Time{}
+		}
+		state.Done = false
+		state.CurrentIndex = min(participant.SeenIndex, state.CurrentIndex)
+	}
+	state.Steps = slices.Clone(state.Steps)
+	trainings.MergeResults(state.Steps, submitted)
+	return state, at, nil
+}
+
+// participantResults decodes the exercise results a participant submitted.
+func participantResults(participant *ClassParticipant) ([]TrainingStepState, error) {
+	if len(participant.Results) == 0 {
+		return nil, nil
+	}
+	var steps []TrainingStepState
+	if err := json.Unmarshal(participant.Results, &steps); err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, "decode participant results: "+err.Error(), errorScope)
+	}
+	return steps, nil
+}
+
+// submittedResults keeps the step ids, results and ratings of a submission.
+func submittedResults(steps []TrainingStepState) []TrainingStepState {
+	results := make([]TrainingStepState, 0, len(steps))
+	for _, step := range steps {
+		if len(step.Results) == 0 && step.RPE == 0 {
+			continue
+		}
+		results = append(results, TrainingStepState{ID: step.ID, Results: step.Results, RPE: step.RPE})
+	}
+	return results
+}
+
+// trainedSteps returns every step of a finished class, otherwise the completed steps and the one in progress.
+func trainedSteps(state TrainingState) []trainings.TrainingStepState {
+	if state.StartedAt.IsZero() || len(state.Steps) == 0 {
+		return nil
+	}
+	if state.Done {
+		return state.Steps
+	}
+	return state.Steps[:min(state.CurrentIndex+1, len(state.Steps))]
+}
+
+// newJoinCode returns a random code participants type to join a class.
+func newJoinCode() string {
+	var b [joinCodeLength]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	for i := range b {
+		b[i] = joinCodeAlphabet[int(b[i])%len(joinCodeAlphabet)]
+	}
+	return string(b[:])
+}
+
+// mapClassError maps store errors for classes to service errors.
+func mapClassError(err error) error {
+	if errors.Is(err, db.ErrClassNotFound) || errors.Is(err, db.ErrClassParticipantNotFound) {
+		return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+	}
+	return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+}
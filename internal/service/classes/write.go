@@ -0,0 +1,209 @@
+package classes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/trainings"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// joinCodeAttempts bounds how often Start draws a new code when one is taken.
+const joinCodeAttempts = 5
+
+// Start creates the coach's training for a workout and opens a class for it.
+// The coach drives the timer through the regular training endpoints.
+func (s *Service) Start(ctx context.Context, actor policy.Actor, req StartRequest) (ClassView, error) {
+	if err := policy.RequirePermission(actor, policy.PermAthletesManage, errorScope); err != nil {
+		return ClassView{}, err
+	}
+	state, err := s.timer.CreateState(ctx, actor, req.WorkoutID)
+	if err != nil {
+		return ClassView{}, err
+	}
+
+	class := Class{
+		ID:          utils.NewID(),
+		CoachID:     strings.TrimSpace(actor.UserID),
+		WorkoutID:   state.WorkoutID,
+		WorkoutName: state.WorkoutName,
+		TrainingID:  state.TrainingID,
+		CreatedAt:   time.Now().UTC(),
+	}
+	for attempt := 0; ; attempt++ {
+		class.JoinCode = newJoinCode()
+		err = s.store.CreateClass(ctx, class)
+		if !errors.Is(err, db.ErrJoinCodeTaken) || attempt == joinCodeAttempts-1 {
+			break
+		}
+	}
+	if err != nil {
+		return ClassView{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return ClassView{Class: class, State: &state}, nil
+}
+
+// Join adds the actor to the open class with the given code.
+func (s *Service) Join(ctx context.Context, actor policy.Actor, req JoinRequest) (ClassView, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code == "" {
+		return ClassView{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "code is required", errorScope)
+	}
+	class, err := s.store.OpenClassByCode(ctx, code)
+	if err != nil {
+		return ClassView{}, mapClassError(err)
+	}
+	userID := strings.TrimSpace(actor.UserID)
+	if userID == class.CoachID {
+		return ClassView{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "coaches cannot join their own class", errorScope)
+	}
+
+	participant, err := s.store.GetClassParticipant(ctx, class.ID, userID)
+	switch {
+	case errors.Is(err, db.ErrClassParticipantNotFound):
+		now := time.Now().UTC()
+		if err := s.store.AddClassParticipant(ctx, ClassParticipant{
+			ClassID:  class.ID,
+			UserID:   userID,
+			JoinedAt: now,
+			SeenAt:   now,
+		}); err != nil {
+			return ClassView{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		}
+	case err != nil:
+		return ClassView{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	case participant.LeftAt != nil:
+		return ClassView{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "you already left this class", errorScope)
+	}
+	return s.view(ctx, class, false)
+}
+
+// Leave drops the actor out of a class and logs the steps trained so far with the results in req.
+func (s *Service) Leave(ctx context.Context, actor policy.Actor, classID string, req LeaveRequest) (*ClassParticipant, error) {
+	class, err := s.openClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	participant, err := s.activeParticipant(ctx, class, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	state, found, err := s.snapshot(ctx, class)
+	if err != nil {
+		return nil, err
+	}
+	at := time.Now().UTC()
+	if found {
+		submitted, err := participantResults(participant)
+		if err != nil {
+			return nil, err
+		}
+		trainings.MergeResults(state.Steps, submitted)
+		trainings.MergeResults(state.Steps, req.Steps)
+		participant.TrainingID, err = s.recordParticipant(ctx, class, participant.UserID, state, at)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.store.LeaveClass(ctx, class.ID, participant.UserID, participant.TrainingID, at); err != nil {
+		return nil, mapClassError(err)
+	}
+	participant.LeftAt = &at
+	return participant, nil
+}
+
+// SubmitResults stores the exercise results the actor recorded so far and marks them as seen.
+// End merges the latest submission into the participant's log.
+func (s *Service) SubmitResults(ctx context.Context, actor policy.Actor, classID string, req ResultsRequest) error {
+	class, err := s.openClass(ctx, classID)
+	if err != nil {
+		return err
+	}
+	participant, err := s.activeParticipant(ctx, class, actor)
+	if err != nil {
+		return err
+	}
+	state, found, err := s.snapshot(ctx, class)
+	if err != nil {
+		return err
+	}
+	index := participant.SeenIndex
+	if found {
+		index = state.CurrentIndex
+	}
+
+	results, err := json.Marshal(submittedResults(req.Steps))
+	if err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if err := s.store.SaveClassResults(ctx, class.ID, participant.UserID, results, time.Now().UTC(), index); err != nil {
+		return mapClassError(err)
+	}
+	return nil
+}
+
+// End closes a class, logs the training of every remaining participant and discards the coach's timer.
+// Participants who left earlier keep their partial logs; those who went unseen are logged up to where they dropped out.
+func (s *Service) End(ctx context.Context, actor policy.Actor, classID string) (ClassView, error) {
+	class, err := s.openClass(ctx, classID)
+	if err != nil {
+		return ClassView{}, err
+	}
+	if !actor.CanAccess(class.CoachID) {
+		return ClassView{}, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "only the coach can end a class", errorScope)
+	}
+	participants, err := s.store.ListClassParticipants(ctx, class.ID)
+	if err != nil {
+		return ClassView{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	state, found, err := s.snapshot(ctx, class)
+	if err != nil {
+		return ClassView{}, err
+	}
+
+	at := time.Now().UTC()
+	for i, participant := range participants {
+		if participant.LeftAt != nil {
+			continue
+		}
+		if found {
+			trained, trainedUntil, err := participantState(state, participant, at)
+			if err != nil {
+				return ClassView{}, err
+			}
+			participant.TrainingID, err = s.recordParticipant(ctx, class, participant.UserID, trained, trainedUntil)
+			if err != nil {
+				return ClassView{}, err
+			}
+		}
+		if err := s.store.LeaveClass(ctx, class.ID, participant.UserID, participant.TrainingID, at); err != nil {
+			return ClassView{}, mapClassError(err)
+		}
+		participant.LeftAt = &at
+		participants[i] = participant
+	}
+
+	if found {
+		// Aborting the timer ends the event streams of all followers.
+		if _, err := s.timer.Abort(ctx, actor, class.TrainingID); err != nil && !errpkg.IsKind(err, errpkg.ErrorNotFound) {
+			return ClassView{}, err
+		}
+	}
+	if err := s.store.EndClass(ctx, class.ID, at); err != nil {
+		return ClassView{}, mapClassError(err)
+	}
+	class.EndedAt = &at
+
+	view := ClassView{Class: *class, Participants: participants}
+	if found {
+		view.State = &state
+	}
+	return view, nil
+}
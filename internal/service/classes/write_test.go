@@ -0,0 +1,343 @@
+package classes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/trainings"
+)
+
+var (
+	coach     = policy.NewActor("coach@example.com", db.RoleCoach)
+	athlete   = policy.NewActor("athlete@example.com", db.RoleMember)
+	classmate = policy.NewActor("classmate@example.com", db.RoleMember)
+)
+
+// startClass opens a class for coach and returns the service, its fakes and the class.
+func startClass(t *testing.T) (*Service, *fakeStore, *fakeTimer, ClassView) {
+	t.Helper()
+	store, timer := newFakeStore(), &fakeTimer{}
+	svc := New(store, timer)
+	view, err := svc.Start(context.Background(), coach, StartRequest{WorkoutID: "w1"})
+	require.NoError(t, err)
+	return svc, store, timer, view
+}
+
+// runTimer starts the fake coach timer at the given step.
+func runTimer(timer *fakeTimer, index int) {
+	timer.state.StartedAt = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	timer.state.Running = true
+	timer.state.CurrentIndex = index
+	for i := range timer.state.Steps {
+		timer.state.Steps[i].ElapsedMillis = 30_000
+	}
+}
+
+func TestStart(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Opens a class with a join code", func(t *testing.T) {
+		t.Parallel()
+		_, store, _, view := startClass(t)
+		assert.Len(t, view.JoinCode, joinCodeLength)
+		assert.Equal(t, "coach@example.com", view.CoachID)
+		assert.Equal(t, "t1", view.TrainingID)
+		require.NotNil(t, view.State)
+		assert.Contains(t, store.classes, view.ID)
+	})
+
+	t.Run("Retries taken join codes", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		store.takenCodes = 2
+		svc := New(store, &fakeTimer{})
+		_, err := svc.Start(context.Background(), coach, StartRequest{WorkoutID: "w1"})
+		require.NoError(t, err)
+		assert.Len(t, store.classes, 1)
+	})
+
+	t.Run("Requires coach role", func(t *testing.T) {
+		t.Parallel()
+		svc := New(newFakeStore(), &fakeTimer{})
+		_, err := svc.Start(context.Background(), athlete, StartRequest{WorkoutID: "w1"})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Adds the participant", func(t *testing.T) {
+		t.Parallel()
+		svc, store, _, class := startClass(t)
+
+		view, err := svc.Join(context.Background(), athlete, JoinRequest{Code: " " + class.JoinCode + " "})
+		require.NoError(t, err)
+		assert.Equal(t, class.ID, view.ID)
+		assert.Nil(t, view.Participants)
+		assert.Contains(t, store.participants, class.ID+"/athlete@example.com")
+	})
+
+	t.Run("Unknown code", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, _ := startClass(t)
+		_, err := svc.Join(context.Background(), athlete, JoinRequest{Code: "NOPE00"})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Coach cannot join", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		_, err := svc.Join(context.Background(), coach, JoinRequest{Code: class.JoinCode})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Dropouts cannot rejoin", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		_, err = svc.Leave(ctx, athlete, class.ID, LeaveRequest{})
+		require.NoError(t, err)
+
+		_, err = svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
+
+func TestLeave(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Logs the steps trained so far", func(t *testing.T) {
+		t.Parallel()
+		svc, store, timer, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		runTimer(timer, 1)
+
+		participant, err := svc.Leave(ctx, athlete, class.ID, LeaveRequest{})
+		require.NoError(t, err)
+		require.NotNil(t, participant.LeftAt)
+		require.Len(t, timer.logs, 1)
+		assert.Equal(t, participant.TrainingID, timer.logs[0].ID)
+		assert.Equal(t, "athlete@example.com", timer.logs[0].UserID)
+		assert.Equal(t, "Class workout", timer.logs[0].WorkoutName)
+		assert.Len(t, timer.steps["athlete@example.com"], 2)
+		require.Len(t, store.workouts, 1)
+		assert.Equal(t, store.workouts[0].ID, timer.logs[0].WorkoutID)
+		assert.Equal(t, "athlete@example.com", store.workouts[0].UserID)
+		assert.Equal(t, "coach@example.com", store.workouts[0].AssignedBy)
+	})
+
+	t.Run("Logs the recorded results", func(t *testing.T) {
+		t.Parallel()
+		svc, _, timer, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		runTimer(timer, 1)
+
+		_, err = svc.Leave(ctx, athlete, class.ID, LeaveRequest{Steps: []TrainingStepState{
+			{ID: "s1", Results: []trainings.ExerciseResult{{Name: "Squat", Reps: 8, Weight: 60}}},
+			{ID: "unknown", Results: []trainings.ExerciseResult{{Name: "Ignored"}}},
+		}})
+		require.NoError(t, err)
+		steps := timer.steps["athlete@example.com"]
+		require.Len(t, steps, 2)
+		require.Len(t, steps[0].Exercises, 1)
+		assert.Equal(t, 8, steps[0].Exercises[0].Reps)
+		assert.Equal(t, "kg", steps[0].Exercises[0].WeightUnit)
+		assert.Empty(t, steps[1].Exercises)
+	})
+
+	t.Run("Nothing is logged before the class starts", func(t *testing.T) {
+		t.Parallel()
+		svc, _, timer, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+
+		participant, err := svc.Leave(ctx, athlete, class.ID, LeaveRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, participant.TrainingID)
+		assert.Empty(t, timer.logs)
+	})
+
+	t.Run("Requires participation", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		_, err := svc.Leave(context.Background(), athlete, class.ID, LeaveRequest{})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+}
+
+func TestEnd(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Logs remaining participants and keeps partial logs", func(t *testing.T) {
+		t.Parallel()
+		svc, _, timer, class := startClass(t)
+		ctx := context.Background()
+		for _, actor := range []policy.Actor{athlete, classmate} {
+			_, err := svc.Join(ctx, actor, JoinRequest{Code: class.JoinCode})
+			require.NoError(t, err)
+		}
+		runTimer(timer, 0)
+		_, err := svc.Leave(ctx, classmate, class.ID, LeaveRequest{})
+		require.NoError(t, err)
+
+		timer.state.CurrentIndex = 2
+		timer.state.Done = true
+		timer.state.CompletedAt = timer.state.StartedAt.Add(90 * time.Second)
+		view, err := svc.End(ctx, coach, class.ID)
+		require.NoError(t, err)
+
+		require.NotNil(t, view.EndedAt)
+		assert.True(t, timer.aborted)
+		require.Len(t, view.Participants, 2)
+		for _, participant := range view.Participants {
+			assert.NotNil(t, participant.LeftAt)
+			assert.NotEmpty(t, participant.TrainingID)
+		}
+		require.Len(t, timer.logs, 2)
+		assert.Len(t, timer.steps["classmate@example.com"], 1)
+		assert.Len(t, timer.steps["athlete@example.com"], 3)
+		assert.Nil(t, timer.state)
+
+		_, err = svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Merges submitted results", func(t *testing.T) {
+		t.Parallel()
+		svc, _, timer, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		runTimer(timer, 2)
+		err = svc.SubmitResults(ctx, athlete, class.ID, ResultsRequest{Steps: []TrainingStepState{
+			{ID: "s1", Results: []trainings.ExerciseResult{{Name: "Squat", Reps: 8, Weight: 60}}},
+		}})
+		require.NoError(t, err)
+
+		timer.state.Done = true
+		timer.state.CompletedAt = time.Now().UTC()
+		_, err = svc.End(ctx, coach, class.ID)
+		require.NoError(t, err)
+
+		steps := timer.steps["athlete@example.com"]
+		require.Len(t, steps, 3)
+		require.Len(t, steps[0].Exercises, 1)
+		assert.Equal(t, 8, steps[0].Exercises[0].Reps)
+	})
+
+	t.Run("Logs unseen participants up to where they dropped out", func(t *testing.T) {
+		t.Parallel()
+		svc, store, timer, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		runTimer(timer, 2)
+		seenAt := timer.state.StartedAt.Add(45 * time.Second)
+		require.NoError(t, store.SeeClassParticipant(ctx, class.ID, athlete.UserID, seenAt, 1))
+
+		timer.state.Done = true
+		timer.state.CompletedAt = timer.state.StartedAt.Add(10 * time.Minute)
+		_, err = svc.End(ctx, coach, class.ID)
+		require.NoError(t, err)
+
+		require.Len(t, timer.logs, 1)
+		assert.Equal(t, seenAt, timer.logs[0].CompletedAt)
+		assert.Len(t, timer.steps["athlete@example.com"], 2)
+	})
+
+	t.Run("Nothing is logged for participants gone before the start", func(t *testing.T) {
+		t.Parallel()
+		svc, store, timer, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		runTimer(timer, 2)
+		require.NoError(t, store.SeeClassParticipant(ctx, class.ID, athlete.UserID, timer.state.StartedAt.Add(-time.Minute), 0))
+
+		view, err := svc.End(ctx, coach, class.ID)
+		require.NoError(t, err)
+
+		assert.Empty(t, timer.logs)
+		require.Len(t, view.Participants, 1)
+		assert.Empty(t, view.Participants[0].TrainingID)
+	})
+
+	t.Run("Only the coach can end a class", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		_, err := svc.End(context.Background(), athlete, class.ID)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Ended classes cannot end again", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		_, err := svc.End(context.Background(), coach, class.ID)
+		require.NoError(t, err)
+		_, err = svc.End(context.Background(), coach, class.ID)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
+
+func TestSubmitResults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stores the results and marks the participant as seen", func(t *testing.T) {
+		t.Parallel()
+		svc, store, timer, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		runTimer(timer, 1)
+
+		err = svc.SubmitResults(ctx, athlete, class.ID, ResultsRequest{Steps: []TrainingStepState{
+			{ID: "s1", Name: "Squats", Results: []trainings.ExerciseResult{{Name: "Squat", Reps: 8}}},
+			{ID: "s2", Name: "Rest"},
+		}})
+		require.NoError(t, err)
+
+		participant := store.participants[class.ID+"/athlete@example.com"]
+		assert.Equal(t, 1, participant.SeenIndex)
+		submitted, err := participantResults(&participant)
+		require.NoError(t, err)
+		require.Len(t, submitted, 1)
+		assert.Equal(t, "s1", submitted[0].ID)
+		assert.Empty(t, submitted[0].Name)
+		assert.Equal(t, 8, submitted[0].Results[0].Reps)
+	})
+
+	t.Run("Requires participation", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		err := svc.SubmitResults(context.Background(), athlete, class.ID, ResultsRequest{})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Dropouts cannot submit", func(t *testing.T) {
+		t.Parallel()
+		svc, _, _, class := startClass(t)
+		ctx := context.Background()
+		_, err := svc.Join(ctx, athlete, JoinRequest{Code: class.JoinCode})
+		require.NoError(t, err)
+		_, err = svc.Leave(ctx, athlete, class.ID, LeaveRequest{})
+		require.NoError(t, err)
+
+		err = svc.SubmitResults(ctx, athlete, class.ID, ResultsRequest{})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
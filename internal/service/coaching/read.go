@@ -0,0 +1,80 @@
+package coaching
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/trainings"
+)
+
+// List returns the links where the actor is coach or athlete, including pending invitations.
+func (s *Service) List(ctx context.Context, actor policy.Actor) ([]CoachLink, error) {
+	userID := strings.TrimSpace(actor.UserID)
+	if userID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	links, err := s.store.ListCoachLinks(ctx, userID)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if links == nil {
+		links = []CoachLink{}
+	}
+	return links, nil
+}
+
+// History returns the recent trainings of an athlete the actor coaches.
+func (s *Service) History(ctx context.Context, actor policy.Actor, athleteID string) ([]TrainingHistoryItem, error) {
+	athleteID, err := s.requireCoach(ctx, actor, athleteID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.store.TrainingHistory(ctx, TrainingHistoryFilter{UserID: athleteID, Limit: historyLimit})
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	ids := make([]string, 0, len(history))
+	for _, entry := range history {
+		ids = append(ids, entry.ID)
+	}
+	stepMap, err := s.store.StepTimingsForTrainings(ctx, ids)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return trainings.BuildTrainingHistoryItems(history, stepMap), nil
+}
+
+// StepTimings returns the step timings of one training of an athlete the actor coaches.
+func (s *Service) StepTimings(ctx context.Context, actor policy.Actor, athleteID, trainingID string) ([]TrainingStepLog, error) {
+	athleteID, err := s.requireCoach(ctx, actor, athleteID)
+	if err != nil {
+		return nil, err
+	}
+	trainingID = strings.TrimSpace(trainingID)
+	if trainingID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "training id is required", errorScope)
+	}
+	training, err := s.store.GetTraining(ctx, trainingID)
+	if err != nil {
+		if errors.Is(err, db.ErrTrainingNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	// Report foreign trainings as missing so ids of other users cannot be probed.
+	if training.UserID != athleteID {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, db.ErrTrainingNotFound.Error(), errorScope)
+	}
+	steps, err := s.store.TrainingStepTimings(ctx, training.ID)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if steps == nil {
+		steps = []TrainingStepLog{}
+	}
+	return steps, nil
+}
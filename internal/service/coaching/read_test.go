@@ -0,0 +1,87 @@
+package coaching
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Returns empty slice", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		links, err := svc.List(context.Background(), athlete)
+		require.NoError(t, err)
+		assert.NotNil(t, links)
+		assert.Empty(t, links)
+	})
+}
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Coach reads athlete history", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		svc := New(&fakeStore{
+			isCoachOfFn: coachOf("coach@example.com", "athlete@example.com"),
+			historyFn: func(_ context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error) {
+				return []TrainingLog{{ID: "t1", UserID: filter.UserID, StartedAt: now, CompletedAt: now}}, nil
+			},
+			stepTimingsFn: func(context.Context, string) ([]TrainingStepLog, error) {
+				return []TrainingStepLog{{ID: "s1", TrainingID: "t1"}}, nil
+			},
+		})
+
+		items, err := svc.History(context.Background(), coach, "athlete@example.com")
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "athlete@example.com", items[0].UserID)
+		assert.Len(t, items[0].Steps, 1)
+	})
+
+	t.Run("Requires an active link", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{isCoachOfFn: coachOf("coach@example.com", "someone@example.com")})
+		_, err := svc.History(context.Background(), coach, "athlete@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
+
+func TestStepTimings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Hides trainings of other users", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			isCoachOfFn: coachOf("coach@example.com", "athlete@example.com"),
+			getTrainingFn: func(_ context.Context, id string) (*TrainingLog, error) {
+				return &TrainingLog{ID: id, UserID: "other@example.com"}, nil
+			},
+		})
+		_, err := svc.StepTimings(context.Background(), coach, "athlete@example.com", "t1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Returns timings", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			isCoachOfFn: coachOf("coach@example.com", "athlete@example.com"),
+			getTrainingFn: func(_ context.Context, id string) (*TrainingLog, error) {
+				return &TrainingLog{ID: id, UserID: "athlete@example.com"}, nil
+			},
+		})
+		steps, err := svc.StepTimings(context.Background(), coach, "athlete@example.com", "t1")
+		require.NoError(t, err)
+		assert.NotNil(t, steps)
+	})
+}
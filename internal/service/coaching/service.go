@@ -0,0 +1,11 @@
+package coaching
+
+// Service links coaches with athletes and lets coaches assign workouts and read results.
+type Service struct {
+	store Store
+}
+
+// New creates a new coaching service.
+func New(store Store) *Service {
+	return &Service{store: store}
+}
@@ -0,0 +1,26 @@
+package coaching
+
+import (
+	"context"
+	"time"
+
+	"github.com/gi8lino/motus/internal/service/organizations"
+)
+
+// Store defines persistence operations required by the coaching domain.
+type Store interface {
+	organizations.MembershipStore
+	GetUser(ctx context.Context, id string) (*User, error)
+	CreateCoachLink(ctx context.Context, link CoachLink) error
+	ListCoachLinks(ctx context.Context, userID string) ([]CoachLink, error)
+	GetCoachLink(ctx context.Context, id string) (*CoachLink, error)
+	AcceptCoachLink(ctx context.Context, id string, at time.Time) error
+	DeleteCoachLink(ctx context.Context, id string) error
+	IsCoachOf(ctx context.Context, coachID, athleteID string) (bool, error)
+	WorkoutWithSteps(ctx context.Context, id string) (*Workout, error)
+	AssignWorkout(ctx context.Context, workoutID, athleteID, assignedBy, name string) (*Workout, error)
+	GetTraining(ctx context.Context, id string) (*TrainingLog, error)
+	TrainingHistory(ctx context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error)
+	TrainingStepTimings(ctx context.Context, trainingID string) ([]TrainingStepLog, error)
+	StepTimingsForTrainings(ctx context.Context, trainingIDs []string) (map[string][]TrainingStepLog, error)
+}
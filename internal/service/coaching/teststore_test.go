@@ -0,0 +1,143 @@
+package coaching
+
+import (
+	"context"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+type fakeStore struct {
+	getUserFn          func(context.Context, string) (*User, error)
+	createLinkFn       func(context.Context, CoachLink) error
+	listLinksFn        func(context.Context, string) ([]CoachLink, error)
+	getLinkFn          func(context.Context, string) (*CoachLink, error)
+	acceptLinkFn       func(context.Context, string, time.Time) error
+	deleteLinkFn       func(context.Context, string) error
+	isCoachOfFn        func(context.Context, string, string) (bool, error)
+	workoutWithStepsFn func(context.Context, string) (*Workout, error)
+	assignWorkoutFn    func(context.Context, string, string, string, string) (*Workout, error)
+	getTrainingFn      func(context.Context, string) (*TrainingLog, error)
+	historyFn          func(context.Context, TrainingHistoryFilter) ([]TrainingLog, error)
+	stepTimingsFn      func(context.Context, string) ([]TrainingStepLog, error)
+	orgRoleFn          func(context.Context, string, string) (string, error)
+}
+
+func (f *fakeStore) OrgRole(ctx context.Context, orgID, userID string) (string, error) {
+	if f.orgRoleFn == nil {
+		return "", nil
+	}
+	return f.orgRoleFn(ctx, orgID, userID)
+}
+
+func (f *fakeStore) ActiveOrganization(context.Context, string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeStore) GetUser(ctx context.Context, id string) (*User, error) {
+	if f.getUserFn == nil {
+		return &User{ID: id}, nil
+	}
+	return f.getUserFn(ctx, id)
+}
+
+func (f *fakeStore) CreateCoachLink(ctx context.Context, link CoachLink) error {
+	if f.createLinkFn == nil {
+		return nil
+	}
+	return f.createLinkFn(ctx, link)
+}
+
+func (f *fakeStore) ListCoachLinks(ctx context.Context, userID string) ([]CoachLink, error) {
+	if f.listLinksFn == nil {
+		return nil, nil
+	}
+	return f.listLinksFn(ctx, userID)
+}
+
+func (f *fakeStore) GetCoachLink(ctx context.Context, id string) (*CoachLink, error) {
+	if f.getLinkFn == nil {
+		return nil, db.ErrCoachLinkNotFound
+	}
+	return f.getLinkFn(ctx, id)
+}
+
+func (f *fakeStore) AcceptCoachLink(ctx context.Context, id string, at time.Time) error {
+	if f.acceptLinkFn == nil {
+		return nil
+	}
+	return f.acceptLinkFn(ctx, id, at)
+}
+
+func (f *fakeStore) DeleteCoachLink(ctx context.Context, id string) error {
+	if f.deleteLinkFn == nil {
+		return nil
+	}
+	return f.deleteLinkFn(ctx, id)
+}
+
+func (f *fakeStore) IsCoachOf(ctx context.Context, coachID, athleteID string) (bool, error) {
+	if f.isCoachOfFn == nil {
+		return false, nil
+	}
+	return f.isCoachOfFn(ctx, coachID, athleteID)
+}
+
+func (f *fakeStore) WorkoutWithSteps(ctx context.Context, id string) (*Workout, error) {
+	if f.workoutWithStepsFn == nil {
+		return nil, db.ErrWorkoutNotFound
+	}
+	return f.workoutWithStepsFn(ctx, id)
+}
+
+func (f *fakeStore) AssignWorkout(ctx context.Context, workoutID, athleteID, assignedBy, name string) (*Workout, error) {
+	if f.assignWorkoutFn == nil {
+		return &Workout{ID: "copy", UserID: athleteID, AssignedBy: assignedBy, Name: name}, nil
+	}
+	return f.assignWorkoutFn(ctx, workoutID, athleteID, assignedBy, name)
+}
+
+func (f *fakeStore) GetTraining(ctx context.Context, id string) (*TrainingLog, error) {
+	if f.getTrainingFn == nil {
+		return nil, db.ErrTrainingNotFound
+	}
+	return f.getTrainingFn(ctx, id)
+}
+
+func (f *fakeStore) TrainingHistory(ctx context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error) {
+	if f.historyFn == nil {
+		return nil, nil
+	}
+	return f.historyFn(ctx, filter)
+}
+
+func (f *fakeStore) StepTimingsForTrainings(ctx context.Context, trainingIDs []string) (map[string][]TrainingStepLog, error) {
+	timings := make(map[string][]TrainingStepLog, len(trainingIDs))
+	for _, id := range trainingIDs {
+		steps, err := f.TrainingStepTimings(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		timings[id] = steps
+	}
+	return timings, nil
+}
+
+func (f *fakeStore) TrainingStepTimings(ctx context.Context, trainingID string) ([]TrainingStepLog, error) {
+	if f.stepTimingsFn == nil {
+		return nil, nil
+	}
+	return f.stepTimingsFn(ctx, trainingID)
+}
+
+// missingUser mimics the store for unknown users.
+func missingUser(context.Context, string) (*User, error) {
+	return nil, db.ErrUserNotFound
+}
+
+// coachOf returns an IsCoachOf fake that links coach to athlete only.
+func coachOf(coach, athlete string) func(context.Context, string, string) (bool, error) {
+	return func(_ context.Context, coachID, athleteID string) (bool, error) {
+		return coachID == coach && athleteID == athlete, nil
+	}
+}
@@ -0,0 +1,41 @@
+// Package coaching provides domain logic for coach/athlete links and workout assignment.
+package coaching
+
+import (
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/trainings"
+)
+
+// CoachLink is the domain-level DTO for coach/athlete links.
+type CoachLink = db.CoachLink
+
+// User is the domain-level DTO for users.
+type User = db.User
+
+// Workout is the domain-level DTO for workouts.
+type Workout = db.Workout
+
+// TrainingLog is the domain-level DTO for completed training logs.
+type TrainingLog = db.TrainingLog
+
+// TrainingStepLog is the domain-level DTO for training step timing logs.
+type TrainingStepLog = db.TrainingStepLog
+
+// TrainingHistoryFilter is the domain-level DTO for training history queries.
+type TrainingHistoryFilter = db.TrainingHistoryFilter
+
+// TrainingHistoryItem is the API payload for a completed training.
+type TrainingHistoryItem = trainings.TrainingHistoryItem
+
+// errorScope is the service error scope for coaching.
+const errorScope = "coaching"
+
+// historyLimit caps how many trainings a coach sees per request.
+const historyLimit = 25
+
+// AssignRequest describes the workout copy a coach pushes to an athlete.
+// Name defaults to the name of the source workout.
+type AssignRequest struct {
+	WorkoutID string `json:"workoutId"`
+	Name      string `json:"name"`
+}
@@ -0,0 +1,37 @@
+package coaching
+
+import (
+	"context"
+	"strings"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// requireCoach returns the trimmed athlete id when the actor coaches that athlete.
+// Admins may act for any athlete without a link.
+func (s *Service) requireCoach(ctx context.Context, actor policy.Actor, athleteID string) (string, error) {
+	athleteID = strings.TrimSpace(athleteID)
+	if athleteID == "" {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, "athlete id is required", errorScope)
+	}
+	if err := policy.RequirePermission(actor, policy.PermAthletesManage, errorScope); err != nil {
+		return "", err
+	}
+	if actor.IsAdmin {
+		return athleteID, nil
+	}
+	active, err := s.store.IsCoachOf(ctx, actor.UserID, athleteID)
+	if err != nil {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if !active {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "you do not coach this athlete", errorScope)
+	}
+	return athleteID, nil
+}
+
+// isParticipant reports whether the actor is the coach or the athlete of link.
+func isParticipant(actor policy.Actor, link *CoachLink) bool {
+	return actor.CanAccess(link.CoachID) || actor.CanAccess(link.AthleteID)
+}
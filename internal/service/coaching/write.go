@@ -0,0 +1,148 @@
+package coaching
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/organizations"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// Invite creates a pending link from the actor to an athlete, who has to accept it.
+func (s *Service) Invite(ctx context.Context, actor policy.Actor, athleteID string) (*CoachLink, error) {
+	if err := policy.RequirePermission(actor, policy.PermAthletesManage, errorScope); err != nil {
+		return nil, err
+	}
+	coachID := strings.TrimSpace(actor.UserID)
+	athleteID = utils.NormalizeToken(athleteID)
+	if athleteID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "athlete id is required", errorScope)
+	}
+	if athleteID == coachID {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "you cannot coach yourself", errorScope)
+	}
+	if _, err := s.store.GetUser(ctx, athleteID); err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "athlete not found", errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+
+	link := CoachLink{
+		ID:        utils.NewID(),
+		CoachID:   coachID,
+		AthleteID: athleteID,
+		Status:    db.CoachLinkPending,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.store.CreateCoachLink(ctx, link); err != nil {
+		if errors.Is(err, db.ErrCoachLinkExists) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return &link, nil
+}
+
+// Accept activates a pending link; only the invited athlete may accept it.
+func (s *Service) Accept(ctx context.Context, actor policy.Actor, linkID string) (*CoachLink, error) {
+	link, err := s.getLink(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(actor.UserID) != link.AthleteID {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "only the athlete can accept a coach link", errorScope)
+	}
+	if link.Status != db.CoachLinkPending {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "coach link is already active", errorScope)
+	}
+
+	now := time.Now().UTC()
+	if err := s.store.AcceptCoachLink(ctx, link.ID, now); err != nil {
+		if errors.Is(err, db.ErrCoachLinkNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	link.Status = db.CoachLinkActive
+	link.AcceptedAt = &now
+	return link, nil
+}
+
+// Remove deletes a link; coach and athlete may both end it or decline an invitation.
+func (s *Service) Remove(ctx context.Context, actor policy.Actor, linkID string) (*CoachLink, error) {
+	link, err := s.getLink(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if !isParticipant(actor, link) {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "access to another user's resource is forbidden", errorScope)
+	}
+	if err := s.store.DeleteCoachLink(ctx, link.ID); err != nil {
+		if errors.Is(err, db.ErrCoachLinkNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return link, nil
+}
+
+// Assign copies one of the actor's workouts or a template into the athlete's library.
+func (s *Service) Assign(ctx context.Context, actor policy.Actor, athleteID string, req AssignRequest) (*Workout, error) {
+	athleteID, err := s.requireCoach(ctx, actor, athleteID)
+	if err != nil {
+		return nil, err
+	}
+	workoutID := strings.TrimSpace(req.WorkoutID)
+	if workoutID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "workoutId is required", errorScope)
+	}
+	src, err := s.store.WorkoutWithSteps(ctx, workoutID)
+	if err != nil {
+		if errors.Is(err, db.ErrWorkoutNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	switch {
+	case !src.IsTemplate:
+		if err := policy.RequireOwner(actor, src.UserID, errorScope); err != nil {
+			return nil, err
+		}
+	case src.OrgID != "":
+		// Organization templates are hidden from non-members, as in the template catalog.
+		if err := organizations.RequireMember(ctx, s.store, actor, src.OrgID, errorScope); err != nil {
+			if errpkg.IsKind(err, errpkg.ErrorForbidden) {
+				return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "template not found", errorScope)
+			}
+			return nil, err
+		}
+	}
+
+	workout, err := s.store.AssignWorkout(ctx, src.ID, athleteID, actor.UserID, req.Name)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return workout, nil
+}
+
+// getLink loads a link by id and maps missing links to not found.
+func (s *Service) getLink(ctx context.Context, linkID string) (*CoachLink, error) {
+	linkID = strings.TrimSpace(linkID)
+	if linkID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "link id is required", errorScope)
+	}
+	link, err := s.store.GetCoachLink(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, db.ErrCoachLinkNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return link, nil
+}
@@ -0,0 +1,224 @@
+package coaching
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+var (
+	coach   = policy.NewActor("coach@example.com", db.RoleCoach)
+	athlete = policy.NewActor("athlete@example.com", db.RoleMember)
+)
+
+func TestInvite(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Creates pending link", func(t *testing.T) {
+		t.Parallel()
+		var stored CoachLink
+		svc := New(&fakeStore{createLinkFn: func(_ context.Context, link CoachLink) error {
+			stored = link
+			return nil
+		}})
+
+		link, err := svc.Invite(context.Background(), coach, " Athlete@Example.com ")
+		require.NoError(t, err)
+		assert.Equal(t, "athlete@example.com", stored.AthleteID)
+		assert.Equal(t, "coach@example.com", stored.CoachID)
+		assert.Equal(t, db.CoachLinkPending, link.Status)
+	})
+
+	t.Run("Requires coach role", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		_, err := svc.Invite(context.Background(), athlete, "coach@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Rejects self", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		_, err := svc.Invite(context.Background(), coach, "coach@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Unknown athlete", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{getUserFn: missingUser})
+		_, err := svc.Invite(context.Background(), coach, "ghost@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Duplicate link", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{createLinkFn: func(context.Context, CoachLink) error {
+			return db.ErrCoachLinkExists
+		}})
+		_, err := svc.Invite(context.Background(), coach, "athlete@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
+
+func TestAccept(t *testing.T) {
+	t.Parallel()
+
+	pending := func(context.Context, string) (*CoachLink, error) {
+		return &CoachLink{ID: "l1", CoachID: "coach@example.com", AthleteID: "athlete@example.com", Status: db.CoachLinkPending}, nil
+	}
+
+	t.Run("Athlete accepts", func(t *testing.T) {
+		t.Parallel()
+		var accepted string
+		svc := New(&fakeStore{getLinkFn: pending, acceptLinkFn: func(_ context.Context, id string, _ time.Time) error {
+			accepted = id
+			return nil
+		}})
+
+		link, err := svc.Accept(context.Background(), athlete, "l1")
+		require.NoError(t, err)
+		assert.Equal(t, "l1", accepted)
+		assert.Equal(t, db.CoachLinkActive, link.Status)
+		assert.NotNil(t, link.AcceptedAt)
+	})
+
+	t.Run("Coach cannot accept", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{getLinkFn: pending})
+		_, err := svc.Accept(context.Background(), coach, "l1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Unknown link", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		_, err := svc.Accept(context.Background(), athlete, "missing")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	active := func(context.Context, string) (*CoachLink, error) {
+		return &CoachLink{ID: "l1", CoachID: "coach@example.com", AthleteID: "athlete@example.com", Status: db.CoachLinkActive}, nil
+	}
+
+	t.Run("Either side may remove", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{getLinkFn: active})
+		_, err := svc.Remove(context.Background(), coach, "l1")
+		require.NoError(t, err)
+		_, err = svc.Remove(context.Background(), athlete, "l1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Others are forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{getLinkFn: active})
+		_, err := svc.Remove(context.Background(), policy.Actor{UserID: "other@example.com"}, "l1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
+
+func TestAssign(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Copies own workout tagged with the coach", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			isCoachOfFn: coachOf("coach@example.com", "athlete@example.com"),
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "w1", UserID: "coach@example.com", Name: "Intervals"}, nil
+			},
+		})
+
+		workout, err := svc.Assign(context.Background(), coach, "athlete@example.com", AssignRequest{WorkoutID: "w1", Name: "Tuesday"})
+		require.NoError(t, err)
+		assert.Equal(t, "athlete@example.com", workout.UserID)
+		assert.Equal(t, "coach@example.com", workout.AssignedBy)
+		assert.Equal(t, "Tuesday", workout.Name)
+	})
+
+	t.Run("Requires an active link", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		_, err := svc.Assign(context.Background(), coach, "athlete@example.com", AssignRequest{WorkoutID: "w1"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Rejects foreign workouts", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			isCoachOfFn: coachOf("coach@example.com", "athlete@example.com"),
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "w1", UserID: "other@example.com"}, nil
+			},
+		})
+		_, err := svc.Assign(context.Background(), coach, "athlete@example.com", AssignRequest{WorkoutID: "w1"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Allows templates", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			isCoachOfFn: coachOf("coach@example.com", "athlete@example.com"),
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "t1", UserID: "other@example.com", IsTemplate: true}, nil
+			},
+		})
+		_, err := svc.Assign(context.Background(), coach, "athlete@example.com", AssignRequest{WorkoutID: "t1"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Allows templates of the coach's organization", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			isCoachOfFn: coachOf("coach@example.com", "athlete@example.com"),
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "t1", UserID: "other@example.com", IsTemplate: true, OrgID: "org1"}, nil
+			},
+			orgRoleFn: func(_ context.Context, orgID, userID string) (string, error) {
+				if orgID == "org1" && userID == "coach@example.com" {
+					return db.OrgRoleMember, nil
+				}
+				return "", nil
+			},
+		})
+		_, err := svc.Assign(context.Background(), coach, "athlete@example.com", AssignRequest{WorkoutID: "t1"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Hides templates of other organizations", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			isCoachOfFn: coachOf("coach@example.com", "athlete@example.com"),
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "t1", UserID: "other@example.com", IsTemplate: true, OrgID: "org2"}, nil
+			},
+			assignWorkoutFn: func(context.Context, string, string, string, string) (*Workout, error) {
+				t.Fatal("template of another organization was copied")
+				return nil, nil
+			},
+		})
+		_, err := svc.Assign(context.Background(), coach, "athlete@example.com", AssignRequest{WorkoutID: "t1"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+}
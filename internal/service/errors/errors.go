@@ -10,6 +10,7 @@ const (
 	ErrorValidation   ErrorKind = "validation"
 	ErrorForbidden    ErrorKind = "forbidden"
 	ErrorNotFound     ErrorKind = "not_found"
+	ErrorConflict     ErrorKind = "conflict"
 	ErrorUnauthorized ErrorKind = "unauthorized"
 	ErrorInternal     ErrorKind = "internal"
 	ErrorNoRows       ErrorKind = "no_rows"
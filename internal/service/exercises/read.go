@@ -4,15 +4,22 @@ import (
 	"context"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/organizations"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
-// List returns the exercise catalog for a user.
-func (s *Service) List(ctx context.Context, userID string) ([]Exercise, error) {
-	uid, err := requireUserID(userID)
+// List returns the exercise catalog for the actor, including the shared exercises
+// of the requested or active organization.
+func (s *Service) List(ctx context.Context, actor policy.Actor, orgID string) ([]Exercise, error) {
+	uid, err := requireUserID(actor.UserID)
 	if err != nil {
 		return nil, err
 	}
-	exercises, err := s.store.ListExercises(ctx, uid)
+	orgID, err = organizations.ResolveContext(ctx, s.store, actor, orgID, errorScope)
+	if err != nil {
+		return nil, err
+	}
+	exercises, err := s.store.ListExercises(ctx, uid, orgID)
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
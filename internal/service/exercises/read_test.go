@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
 func TestList(t *testing.T) {
@@ -17,7 +18,7 @@ func TestList(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeStore{})
-		_, err := svc.List(context.Background(), " ")
+		_, err := svc.List(context.Background(), policy.Actor{UserID: " "}, "")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
 	})
@@ -1,12 +1,17 @@
 package exercises
 
-import "context"
+import (
+	"context"
+
+	"github.com/gi8lino/motus/internal/service/organizations"
+)
 
 // Store defines persistence operations required by the exercises domain.
 type Store interface {
-	ListExercises(ctx context.Context, userID string) ([]Exercise, error)
-	GetUser(ctx context.Context, userID string) (*User, error)
+	organizations.MembershipStore
+	ListExercises(ctx context.Context, userID, orgID string) ([]Exercise, error)
 	CreateExercise(ctx context.Context, name, userID string, isCore bool) (*Exercise, error)
+	CreateOrgExercise(ctx context.Context, name, orgID string) (*Exercise, error)
 	GetExercise(ctx context.Context, id string) (*Exercise, error)
 	RenameExercise(ctx context.Context, id, name string) (*Exercise, error)
 	DeleteExercise(ctx context.Context, id string) error
@@ -6,8 +6,5 @@ import "github.com/gi8lino/motus/internal/db"
 // Exercise is the domain-level DTO for catalog exercises.
 type Exercise = db.Exercise
 
-// User is the domain-level DTO for users used by the exercise domain.
-type User = db.User
-
 // errorScope is the service error scope for exercises.
 const errorScope = "exercises"
@@ -1,9 +1,12 @@
 package exercises
 
 import (
+	"context"
 	"strings"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/organizations"
+	"github.com/gi8lino/motus/internal/service/policy"
 	"github.com/gi8lino/motus/internal/utils"
 )
 
@@ -30,3 +33,15 @@ func requireEntityID(value, msg string) (string, error) {
 	}
 	return "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, msg, errorScope)
 }
+
+// requireSharedEditor ensures the actor may change a shared exercise: organization
+// exercises need an organization editor, global core exercises the catalog permission.
+func (s *Service) requireSharedEditor(ctx context.Context, actor policy.Actor, exercise *Exercise) error {
+	if exercise.OrgID != "" {
+		return organizations.RequireEditor(ctx, s.store, actor, exercise.OrgID, errorScope)
+	}
+	if exercise.IsCore {
+		return policy.RequirePermission(actor, policy.PermCatalogWrite, errorScope)
+	}
+	return nil
+}
@@ -2,13 +2,17 @@ package exercises
 
 import (
 	"context"
+	"strings"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/organizations"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
-// Create adds a new exercise to the catalog.
-func (s *Service) Create(ctx context.Context, userID, name string, isCore bool) (*Exercise, error) {
-	uid, err := requireUserID(userID)
+// Create adds a new exercise to the catalog. With orgID the exercise is shared
+// within that organization, which needs an editor role; isCore is then implied.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, name string, isCore bool, orgID string) (*Exercise, error) {
+	uid, err := requireUserID(actor.UserID)
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
@@ -17,15 +21,20 @@ func (s *Service) Create(ctx context.Context, userID, name string, isCore bool)
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
 
-	user, err := s.store.GetUser(ctx, uid)
-	if err != nil {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
-	}
-	if user == nil {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
+	if orgID = strings.TrimSpace(orgID); orgID != "" {
+		if err := organizations.RequireEditor(ctx, s.store, actor, orgID, errorScope); err != nil {
+			return nil, err
+		}
+		exercise, err := s.store.CreateOrgExercise(ctx, clean, orgID)
+		if err != nil {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		}
+		return exercise, nil
 	}
-	if isCore && !user.IsAdmin {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "core exercises require admin permissions", errorScope)
+	if isCore {
+		if err := policy.RequirePermission(actor, policy.PermCatalogWrite, errorScope); err != nil {
+			return nil, err
+		}
 	}
 
 	exercise, err := s.store.CreateExercise(ctx, clean, uid, isCore)
@@ -36,9 +45,8 @@ func (s *Service) Create(ctx context.Context, userID, name string, isCore bool)
 }
 
 // Update renames an exercise entry.
-func (s *Service) Update(ctx context.Context, userID, exerciseID, name string) (*Exercise, error) {
-	uid, err := requireUserID(userID)
-	if err != nil {
+func (s *Service) Update(ctx context.Context, actor policy.Actor, exerciseID, name string) (*Exercise, error) {
+	if _, err := requireUserID(actor.UserID); err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
 	clean, err := requireName(name)
@@ -50,14 +58,6 @@ func (s *Service) Update(ctx context.Context, userID, exerciseID, name string) (
 		return nil, err
 	}
 
-	user, err := s.store.GetUser(ctx, uid)
-	if err != nil {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
-	}
-	if user == nil {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
-	}
-
 	exercise, err := s.store.GetExercise(ctx, id)
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
@@ -66,11 +66,10 @@ func (s *Service) Update(ctx context.Context, userID, exerciseID, name string) (
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "exercise not found", errorScope)
 	}
 
-	if exercise.IsCore && !user.IsAdmin {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "core exercises require admin permissions", errorScope)
+	if err := s.requireSharedEditor(ctx, actor, exercise); err != nil {
+		return nil, err
 	}
-
-	if exercise.OwnerUserID != "" && exercise.OwnerUserID != uid && !user.IsAdmin {
+	if exercise.OwnerUserID != "" && !actor.CanAccess(exercise.OwnerUserID) {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "exercise belongs to another user", errorScope)
 	}
 
@@ -82,9 +81,8 @@ func (s *Service) Update(ctx context.Context, userID, exerciseID, name string) (
 }
 
 // Delete removes an exercise from the catalog.
-func (s *Service) Delete(ctx context.Context, userID, exerciseID string) error {
-	uid, err := requireUserID(userID)
-	if err != nil {
+func (s *Service) Delete(ctx context.Context, actor policy.Actor, exerciseID string) error {
+	if _, err := requireUserID(actor.UserID); err != nil {
 		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
 	id, err := requireEntityID(exerciseID, "exercise id is required")
@@ -92,14 +90,6 @@ func (s *Service) Delete(ctx context.Context, userID, exerciseID string) error {
 		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
 
-	user, err := s.store.GetUser(ctx, uid)
-	if err != nil {
-		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
-	}
-	if user == nil {
-		return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
-	}
-
 	exercise, err := s.store.GetExercise(ctx, id)
 	if err != nil {
 		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
@@ -108,11 +98,10 @@ func (s *Service) Delete(ctx context.Context, userID, exerciseID string) error {
 		return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "exercise not found", errorScope)
 	}
 
-	if exercise.IsCore && !user.IsAdmin {
-		return errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "core exercises require admin permissions", errorScope)
+	if err := s.requireSharedEditor(ctx, actor, exercise); err != nil {
+		return err
 	}
-
-	if exercise.OwnerUserID != "" && exercise.OwnerUserID != uid && !user.IsAdmin {
+	if exercise.OwnerUserID != "" && !actor.CanAccess(exercise.OwnerUserID) {
 		return errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "exercise belongs to another user", errorScope)
 	}
 
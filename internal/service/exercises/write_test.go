@@ -7,32 +7,34 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
 type fakeStore struct {
-	listExercisesFn     func(context.Context, string) ([]Exercise, error)
-	getUserFn           func(context.Context, string) (*User, error)
+	listExercisesFn     func(context.Context, string, string) ([]Exercise, error)
 	createExerciseFn    func(context.Context, string, string, bool) (*Exercise, error)
+	createOrgExerciseFn func(context.Context, string, string) (*Exercise, error)
 	getExerciseFn       func(context.Context, string) (*Exercise, error)
 	replaceExerciseFn   func(context.Context, string, string, string, string) error
 	renameExerciseFn    func(context.Context, string, string) (*Exercise, error)
 	deleteExerciseFn    func(context.Context, string) error
 	backfillExercisesFn func(context.Context) error
+	orgRoles            map[string]string // orgRoles maps "org/user" to an organization role.
 }
 
-func (f *fakeStore) ListExercises(ctx context.Context, userID string) ([]Exercise, error) {
-	if f.listExercisesFn == nil {
-		return nil, nil
-	}
-	return f.listExercisesFn(ctx, userID)
+func (f *fakeStore) OrgRole(_ context.Context, orgID, userID string) (string, error) {
+	return f.orgRoles[orgID+"/"+userID], nil
 }
 
-func (f *fakeStore) GetUser(ctx context.Context, userID string) (*User, error) {
-	if f.getUserFn == nil {
+func (f *fakeStore) ActiveOrganization(context.Context, string) (string, error) { return "", nil }
+
+func (f *fakeStore) ListExercises(ctx context.Context, userID, orgID string) ([]Exercise, error) {
+	if f.listExercisesFn == nil {
 		return nil, nil
 	}
-	return f.getUserFn(ctx, userID)
+	return f.listExercisesFn(ctx, userID, orgID)
 }
 
 func (f *fakeStore) CreateExercise(ctx context.Context, name, userID string, isCore bool) (*Exercise, error) {
@@ -42,6 +44,13 @@ func (f *fakeStore) CreateExercise(ctx context.Context, name, userID string, isC
 	return f.createExerciseFn(ctx, name, userID, isCore)
 }
 
+func (f *fakeStore) CreateOrgExercise(ctx context.Context, name, orgID string) (*Exercise, error) {
+	if f.createOrgExerciseFn == nil {
+		return nil, nil
+	}
+	return f.createOrgExerciseFn(ctx, name, orgID)
+}
+
 func (f *fakeStore) GetExercise(ctx context.Context, id string) (*Exercise, error) {
 	if f.getExerciseFn == nil {
 		return nil, nil
@@ -80,17 +89,13 @@ func (f *fakeStore) BackfillCoreExercises(ctx context.Context) error {
 func TestCreate(t *testing.T) {
 	t.Parallel()
 
-	t.Run("User not found", func(t *testing.T) {
+	t.Run("Requires user id", func(t *testing.T) {
 		t.Parallel()
 
-		svc := New(&fakeStore{
-			getUserFn: func(context.Context, string) (*User, error) {
-				return nil, nil
-			},
-		})
-		_, err := svc.Create(context.Background(), "user", "Burpee", false)
+		svc := New(&fakeStore{})
+		_, err := svc.Create(context.Background(), policy.Actor{}, "Burpee", false, "")
 		require.Error(t, err)
-		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
 	})
 
 	t.Run("Core requires admin", func(t *testing.T) {
@@ -98,36 +103,85 @@ func TestCreate(t *testing.T) {
 
 		called := false
 		svc := New(&fakeStore{
-			getUserFn: func(context.Context, string) (*User, error) {
-				return &User{ID: "user", IsAdmin: false}, nil
-			},
 			createExerciseFn: func(context.Context, string, string, bool) (*Exercise, error) {
 				called = true
 				return nil, nil
 			},
 		})
-		_, err := svc.Create(context.Background(), "user", "Burpee", true)
+		_, err := svc.Create(context.Background(), policy.NewActor("user", db.RoleMember), "Burpee", true, "")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
 		assert.False(t, called, "expected CreateExercise not to be called")
 	})
+
+	t.Run("Catalog editor creates core", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			createExerciseFn: func(_ context.Context, name, _ string, isCore bool) (*Exercise, error) {
+				return &Exercise{ID: "ex", Name: name, IsCore: isCore}, nil
+			},
+		})
+		exercise, err := svc.Create(context.Background(), policy.NewActor("editor", db.RoleCatalogEditor), "Burpee", true, "")
+		require.NoError(t, err)
+		assert.True(t, exercise.IsCore)
+	})
+
+	t.Run("Organization editor shares exercise", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			createOrgExerciseFn: func(_ context.Context, name, orgID string) (*Exercise, error) {
+				return &Exercise{ID: "ex", Name: name, IsCore: true, OrgID: orgID}, nil
+			},
+			orgRoles: map[string]string{"o1/coach": db.OrgRoleEditor},
+		})
+		exercise, err := svc.Create(context.Background(), policy.NewActor("coach", db.RoleMember), "Burpee", false, "o1")
+		require.NoError(t, err)
+		assert.Equal(t, "o1", exercise.OrgID)
+	})
+
+	t.Run("Organization member cannot share exercise", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			orgRoles: map[string]string{"o1/user": db.OrgRoleMember},
+		})
+		_, err := svc.Create(context.Background(), policy.NewActor("user", db.RoleCatalogEditor), "Burpee", true, "o1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
 }
 
 func TestUpdate(t *testing.T) {
 	t.Parallel()
 
-	t.Run("Requires admin", func(t *testing.T) {
+	t.Run("Organization editor renames shared exercise", func(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeStore{
-			getUserFn: func(context.Context, string) (*User, error) {
-				return &User{ID: "user", IsAdmin: false}, nil
+			getExerciseFn: func(context.Context, string) (*Exercise, error) {
+				return &Exercise{ID: "ex", IsCore: true, OrgID: "o1"}, nil
 			},
+			renameExerciseFn: func(_ context.Context, id, name string) (*Exercise, error) {
+				return &Exercise{ID: id, Name: name, IsCore: true, OrgID: "o1"}, nil
+			},
+			orgRoles: map[string]string{"o1/coach": db.OrgRoleOwner},
+		})
+		updated, err := svc.Update(context.Background(), policy.NewActor("coach", db.RoleMember), "ex", "Burpee 2")
+		require.NoError(t, err)
+		assert.Equal(t, "Burpee 2", updated.Name)
+	})
+
+	t.Run("Requires admin", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
 			getExerciseFn: func(context.Context, string) (*Exercise, error) {
 				return &Exercise{ID: "core", IsCore: true}, nil
 			},
 		})
-		_, err := svc.Update(context.Background(), "user", "core", "Burpee")
+		_, err := svc.Update(context.Background(), policy.NewActor("user", db.RoleMember), "core", "Burpee")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
 	})
@@ -136,9 +190,6 @@ func TestUpdate(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeStore{
-			getUserFn: func(context.Context, string) (*User, error) {
-				return &User{ID: "admin", IsAdmin: true}, nil
-			},
 			getExerciseFn: func(context.Context, string) (*Exercise, error) {
 				return &Exercise{ID: "ex", Name: "Burpee", OwnerUserID: "other", IsCore: false}, nil
 			},
@@ -146,7 +197,7 @@ func TestUpdate(t *testing.T) {
 				return &Exercise{ID: "ex", Name: "Burpee 2"}, nil
 			},
 		})
-		updated, err := svc.Update(context.Background(), "admin", "ex", "Burpee 2")
+		updated, err := svc.Update(context.Background(), policy.NewActor("admin", db.RoleAdmin), "ex", "Burpee 2")
 		require.NoError(t, err)
 		assert.Equal(t, "Burpee 2", updated.Name)
 	})
@@ -159,14 +210,11 @@ func TestDelete(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeStore{
-			getUserFn: func(context.Context, string) (*User, error) {
-				return &User{ID: "user", IsAdmin: false}, nil
-			},
 			getExerciseFn: func(context.Context, string) (*Exercise, error) {
 				return &Exercise{ID: "core", IsCore: true}, nil
 			},
 		})
-		err := svc.Delete(context.Background(), "user", "core")
+		err := svc.Delete(context.Background(), policy.NewActor("user", db.RoleMember), "core")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
 	})
@@ -175,9 +223,6 @@ func TestDelete(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeStore{
-			getUserFn: func(context.Context, string) (*User, error) {
-				return &User{ID: "admin", IsAdmin: true}, nil
-			},
 			getExerciseFn: func(context.Context, string) (*Exercise, error) {
 				return &Exercise{ID: "ex", Name: "Burpee", OwnerUserID: "other"}, nil
 			},
@@ -185,7 +230,7 @@ func TestDelete(t *testing.T) {
 				return nil
 			},
 		})
-		err := svc.Delete(context.Background(), "admin", "ex")
+		err := svc.Delete(context.Background(), policy.NewActor("admin", db.RoleAdmin), "ex")
 		require.NoError(t, err)
 	})
 }
@@ -0,0 +1,23 @@
+package invitations
+
+import (
+	"context"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// List returns all invitations that were not revoked, including expired and used up ones.
+func (s *Service) List(ctx context.Context, actor policy.Actor) ([]Invitation, error) {
+	if err := policy.RequirePermission(actor, policy.PermUsersManage, errorScope); err != nil {
+		return nil, err
+	}
+	invitations, err := s.store.ListInvitations(ctx)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if invitations == nil {
+		invitations = []Invitation{}
+	}
+	return invitations, nil
+}
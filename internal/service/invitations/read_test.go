@@ -0,0 +1,33 @@
+package invitations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Returns empty slice", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, "")
+		invitations, err := svc.List(context.Background(), policy.Actor{UserID: "admin", IsAdmin: true})
+		require.NoError(t, err)
+		assert.NotNil(t, invitations)
+		assert.Empty(t, invitations)
+	})
+
+	t.Run("Requires admin", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, "")
+		_, err := svc.List(context.Background(), policy.Actor{UserID: "u1"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
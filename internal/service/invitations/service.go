@@ -0,0 +1,14 @@
+package invitations
+
+import "strings"
+
+// Service issues, lists and revokes invitations.
+type Service struct {
+	store   Store
+	siteURL string
+}
+
+// New creates a new invitations service. siteURL is the public root that invite links point to.
+func New(store Store, siteURL string) *Service {
+	return &Service{store: store, siteURL: strings.TrimRight(siteURL, "/")}
+}
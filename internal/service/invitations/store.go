@@ -0,0 +1,10 @@
+package invitations
+
+import "context"
+
+// Store defines persistence operations required by the invitations domain.
+type Store interface {
+	CreateInvitation(ctx context.Context, invitation Invitation) error
+	ListInvitations(ctx context.Context) ([]Invitation, error)
+	RevokeInvitation(ctx context.Context, id string) error
+}
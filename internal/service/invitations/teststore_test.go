@@ -0,0 +1,30 @@
+package invitations
+
+import "context"
+
+type fakeStore struct {
+	createFn func(context.Context, Invitation) error
+	listFn   func(context.Context) ([]Invitation, error)
+	revokeFn func(context.Context, string) error
+}
+
+func (f *fakeStore) CreateInvitation(ctx context.Context, invitation Invitation) error {
+	if f.createFn == nil {
+		return nil
+	}
+	return f.createFn(ctx, invitation)
+}
+
+func (f *fakeStore) ListInvitations(ctx context.Context) ([]Invitation, error) {
+	if f.listFn == nil {
+		return nil, nil
+	}
+	return f.listFn(ctx)
+}
+
+func (f *fakeStore) RevokeInvitation(ctx context.Context, id string) error {
+	if f.revokeFn == nil {
+		return nil
+	}
+	return f.revokeFn(ctx, id)
+}
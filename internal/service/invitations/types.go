@@ -0,0 +1,32 @@
+// Package invitations provides domain logic for admin-issued registration invitations.
+package invitations
+
+import (
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+// Invitation is the domain-level DTO for invitations.
+type Invitation = db.Invitation
+
+// errorScope is the service error scope for invitations.
+const errorScope = "invitations"
+
+// DefaultTTL is how long an invitation stays valid when no expiry is given.
+const DefaultTTL = 7 * 24 * time.Hour
+
+// CreateRequest describes the payload for issuing an invitation.
+// MaxUses defaults to a single use and Role to the member role.
+type CreateRequest struct {
+	MaxUses   int        `json:"maxUses"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	Role      string     `json:"role"`
+}
+
+// Created pairs a stored invitation with its token and link, which are only returned once.
+type Created struct {
+	Invitation
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,74 @@
+package invitations
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// Create issues a new invitation and returns its token and link once.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, req CreateRequest) (Created, error) {
+	if err := policy.RequirePermission(actor, policy.PermUsersManage, errorScope); err != nil {
+		return Created{}, err
+	}
+	if req.MaxUses < 0 {
+		return Created{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "maxUses must not be negative", errorScope)
+	}
+	role, ok := policy.NormalizeRole(utils.DefaultIfZero(strings.TrimSpace(req.Role), db.RoleMember))
+	if !ok {
+		return Created{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "role must be one of "+strings.Join(policy.Roles, ", "), errorScope)
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(DefaultTTL)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return Created{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "expiresAt must be in the future", errorScope)
+		}
+		expiresAt = req.ExpiresAt.UTC()
+	}
+
+	secret := utils.NewToken()
+	invitation := Invitation{
+		ID:        utils.NewID(),
+		TokenHash: utils.HashToken(secret),
+		CreatedBy: strings.TrimSpace(actor.UserID),
+		Role:      role,
+		MaxUses:   utils.DefaultIfZero(req.MaxUses, 1),
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.store.CreateInvitation(ctx, invitation); err != nil {
+		return Created{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return Created{
+		Invitation: invitation,
+		Token:      secret,
+		URL:        s.siteURL + "/?invite=" + url.QueryEscape(secret),
+	}, nil
+}
+
+// Revoke invalidates an invitation so it cannot create further accounts.
+func (s *Service) Revoke(ctx context.Context, actor policy.Actor, id string) error {
+	if err := policy.RequirePermission(actor, policy.PermUsersManage, errorScope); err != nil {
+		return err
+	}
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "invitation id is required", errorScope)
+	}
+	if err := s.store.RevokeInvitation(ctx, id); err != nil {
+		if errors.Is(err, db.ErrInvitationNotFound) {
+			return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package invitations
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	admin := policy.Actor{UserID: "admin@example.com", IsAdmin: true}
+
+	t.Run("Stores hashed token with defaults", func(t *testing.T) {
+		t.Parallel()
+		var stored Invitation
+		svc := New(&fakeStore{createFn: func(_ context.Context, invitation Invitation) error {
+			stored = invitation
+			return nil
+		}}, "https://motus.example.com/")
+
+		created, err := svc.Create(context.Background(), admin, CreateRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, utils.HashToken(created.Token), stored.TokenHash)
+		assert.Equal(t, "https://motus.example.com/?invite="+created.Token, created.URL)
+		assert.Equal(t, 1, stored.MaxUses)
+		assert.Equal(t, "admin@example.com", stored.CreatedBy)
+		assert.Equal(t, db.RoleMember, stored.Role)
+		assert.WithinDuration(t, time.Now().Add(DefaultTTL), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Multi-use admin invitation", func(t *testing.T) {
+		t.Parallel()
+		expiresAt := time.Now().Add(time.Hour)
+		svc := New(&fakeStore{}, "")
+
+		created, err := svc.Create(context.Background(), admin, CreateRequest{MaxUses: 5, ExpiresAt: &expiresAt, Role: "Admin"})
+		require.NoError(t, err)
+		assert.Equal(t, 5, created.MaxUses)
+		assert.Equal(t, db.RoleAdmin, created.Role)
+		assert.True(t, expiresAt.Equal(created.ExpiresAt))
+		assert.True(t, strings.HasPrefix(created.URL, "/?invite="))
+	})
+
+	t.Run("Rejects unknown roles", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, "")
+		_, err := svc.Create(context.Background(), admin, CreateRequest{Role: "owner"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Requires admin", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, "")
+		_, err := svc.Create(context.Background(), policy.Actor{UserID: "u1"}, CreateRequest{})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Rejects invalid limits", func(t *testing.T) {
+		t.Parallel()
+		past := time.Now().Add(-time.Hour)
+		svc := New(&fakeStore{}, "")
+
+		_, err := svc.Create(context.Background(), admin, CreateRequest{MaxUses: -1})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+
+		_, err = svc.Create(context.Background(), admin, CreateRequest{ExpiresAt: &past})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	t.Parallel()
+
+	admin := policy.Actor{UserID: "admin@example.com", IsAdmin: true}
+
+	t.Run("Revokes invitation", func(t *testing.T) {
+		t.Parallel()
+		var revoked string
+		svc := New(&fakeStore{revokeFn: func(_ context.Context, id string) error {
+			revoked = id
+			return nil
+		}}, "")
+		require.NoError(t, svc.Revoke(context.Background(), admin, " inv1 "))
+		assert.Equal(t, "inv1", revoked)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{revokeFn: func(context.Context, string) error {
+			return db.ErrInvitationNotFound
+		}}, "")
+		err := svc.Revoke(context.Background(), admin, "inv1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Requires admin", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, "")
+		err := svc.Revoke(context.Background(), policy.Actor{UserID: "u1"}, "inv1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
@@ -0,0 +1,33 @@
+package organizations
+
+import (
+	"context"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// List returns the organizations of the actor and marks the active one.
+func (s *Service) List(ctx context.Context, actor policy.Actor) ([]Membership, error) {
+	memberships, err := s.store.ListOrganizationsForUser(ctx, actor.UserID)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return memberships, nil
+}
+
+// Members lists the members of an organization the actor belongs to.
+func (s *Service) Members(ctx context.Context, actor policy.Actor, orgID string) ([]Member, error) {
+	org, err := s.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := RequireMember(ctx, s.store, actor, org.ID, errorScope); err != nil {
+		return nil, err
+	}
+	members, err := s.store.ListOrgMembers(ctx, org.ID)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return members, nil
+}
@@ -0,0 +1,85 @@
+package organizations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeStore()
+	store.active["owner@example.com"] = "o1"
+	list, err := New(store).List(context.Background(), owner)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.True(t, list[0].Active)
+	assert.Equal(t, db.OrgRoleOwner, list[0].Role)
+}
+
+func TestMembers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Member lists", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore().withRole("member@example.com", db.OrgRoleMember)
+		members, err := New(store).Members(context.Background(), member, "o1")
+		require.NoError(t, err)
+		assert.Len(t, members, 1)
+	})
+
+	t.Run("Outsider is forbidden", func(t *testing.T) {
+		t.Parallel()
+		_, err := New(newFakeStore()).Members(context.Background(), member, "o1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Admin lists", func(t *testing.T) {
+		t.Parallel()
+		_, err := New(newFakeStore()).Members(context.Background(), admin, "o1")
+		require.NoError(t, err)
+	})
+}
+
+func TestResolveContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Uses active organization", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		store.active["owner@example.com"] = "o1"
+		orgID, err := ResolveContext(context.Background(), store, owner, "", errorScope)
+		require.NoError(t, err)
+		assert.Equal(t, "o1", orgID)
+	})
+
+	t.Run("Drops active organization after leaving", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		store.active["member@example.com"] = "o1"
+		orgID, err := ResolveContext(context.Background(), store, member, "", errorScope)
+		require.NoError(t, err)
+		assert.Empty(t, orgID)
+	})
+
+	t.Run("Rejects foreign organization", func(t *testing.T) {
+		t.Parallel()
+		_, err := ResolveContext(context.Background(), newFakeStore(), member, "o1", errorScope)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Admin picks any organization", func(t *testing.T) {
+		t.Parallel()
+		orgID, err := ResolveContext(context.Background(), newFakeStore(), admin, "o1", errorScope)
+		require.NoError(t, err)
+		assert.Equal(t, "o1", orgID)
+	})
+}
@@ -0,0 +1,78 @@
+package organizations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// ResolveContext returns the organization whose shared content the actor works with.
+// An explicit orgID requires membership; otherwise the active organization of the actor
+// is used, or none once the actor has left it or is anonymous. Admins may pick any organization.
+func ResolveContext(ctx context.Context, store MembershipStore, actor policy.Actor, orgID, scope string) (string, error) {
+	orgID = strings.TrimSpace(orgID)
+	explicit := orgID != ""
+	if !explicit {
+		if actor.UserID == "" {
+			return "", nil
+		}
+		active, err := store.ActiveOrganization(ctx, actor.UserID)
+		if err != nil {
+			return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), scope)
+		}
+		if orgID = active; orgID == "" {
+			return "", nil
+		}
+	}
+	if actor.IsAdmin {
+		return orgID, nil
+	}
+
+	role, err := store.OrgRole(ctx, orgID, actor.UserID)
+	if err != nil {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), scope)
+	}
+	if role != "" {
+		return orgID, nil
+	}
+	if explicit {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "you are not a member of this organization", scope)
+	}
+	return "", nil
+}
+
+// RequireMember ensures the actor belongs to orgID; admins belong everywhere.
+func RequireMember(ctx context.Context, store MembershipStore, actor policy.Actor, orgID, scope string) error {
+	_, err := requireRole(ctx, store, actor, orgID, scope, db.OrgRoleOwner, db.OrgRoleEditor, db.OrgRoleMember)
+	return err
+}
+
+// RequireEditor ensures the actor may publish templates and exercises in orgID.
+func RequireEditor(ctx context.Context, store MembershipStore, actor policy.Actor, orgID, scope string) error {
+	_, err := requireRole(ctx, store, actor, orgID, scope, db.OrgRoleOwner, db.OrgRoleEditor)
+	return err
+}
+
+// requireRole returns the actor's role in orgID when it is one of allowed.
+// Admins pass with the owner role.
+func requireRole(ctx context.Context, store MembershipStore, actor policy.Actor, orgID, scope string, allowed ...string) (string, error) {
+	if actor.IsAdmin {
+		return db.OrgRoleOwner, nil
+	}
+	role, err := store.OrgRole(ctx, strings.TrimSpace(orgID), actor.UserID)
+	if err != nil {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), scope)
+	}
+	if role == "" {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "you are not a member of this organization", scope)
+	}
+	for _, candidate := range allowed {
+		if role == candidate {
+			return role, nil
+		}
+	}
+	return "", errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "your organization role does not allow this", scope)
+}
@@ -0,0 +1,11 @@
+package organizations
+
+// Service provides access to organization operations.
+type Service struct {
+	store Store
+}
+
+// New builds an organizations service.
+func New(store Store) *Service {
+	return &Service{store: store}
+}
@@ -0,0 +1,25 @@
+package organizations
+
+import "context"
+
+// MembershipStore resolves organization roles; other domains use it to scope shared content.
+type MembershipStore interface {
+	OrgRole(ctx context.Context, orgID, userID string) (string, error)
+	ActiveOrganization(ctx context.Context, userID string) (string, error)
+}
+
+// Store defines persistence operations required by the organizations domain.
+type Store interface {
+	MembershipStore
+	GetUser(ctx context.Context, id string) (*User, error)
+	CreateOrganization(ctx context.Context, org Organization) error
+	ListOrganizationsForUser(ctx context.Context, userID string) ([]Membership, error)
+	GetOrganization(ctx context.Context, id string) (*Organization, error)
+	DeleteOrganization(ctx context.Context, id string) error
+	ListOrgMembers(ctx context.Context, orgID string) ([]Member, error)
+	CountOrgOwners(ctx context.Context, orgID string) (int, error)
+	AddOrgMember(ctx context.Context, member Member) error
+	UpdateOrgMemberRole(ctx context.Context, orgID, userID, role string) error
+	RemoveOrgMember(ctx context.Context, orgID, userID string) error
+	SetActiveOrganization(ctx context.Context, userID, orgID string) error
+}
@@ -0,0 +1,149 @@
+package organizations
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+// fakeStore keeps organization roles and active contexts in memory.
+type fakeStore struct {
+	mu      sync.Mutex
+	roles   map[string]string // roles maps "org/user" to an organization role.
+	active  map[string]string
+	created []Organization
+	deleted []string
+	added   []Member
+	removed []string
+
+	getUserFn func(context.Context, string) (*User, error)
+}
+
+// newFakeStore returns a store where org "o1" is owned by owner@example.com.
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		roles:  map[string]string{"o1/owner@example.com": db.OrgRoleOwner},
+		active: map[string]string{},
+	}
+}
+
+func (f *fakeStore) OrgRole(_ context.Context, orgID, userID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.roles[orgID+"/"+userID], nil
+}
+
+func (f *fakeStore) ActiveOrganization(_ context.Context, userID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.active[userID], nil
+}
+
+func (f *fakeStore) GetUser(ctx context.Context, id string) (*User, error) {
+	if f.getUserFn == nil {
+		return &User{ID: id, Name: "User"}, nil
+	}
+	return f.getUserFn(ctx, id)
+}
+
+func (f *fakeStore) CreateOrganization(_ context.Context, org Organization) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created = append(f.created, org)
+	f.roles[org.ID+"/"+org.CreatedBy] = db.OrgRoleOwner
+	return nil
+}
+
+func (f *fakeStore) ListOrganizationsForUser(_ context.Context, userID string) ([]Membership, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if role := f.roles["o1/"+userID]; role != "" {
+		return []Membership{{Organization: Organization{ID: "o1", Name: "Club"}, Role: role, Active: f.active[userID] == "o1"}}, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeStore) GetOrganization(_ context.Context, id string) (*Organization, error) {
+	if id != "o1" {
+		return nil, db.ErrOrganizationNotFound
+	}
+	return &Organization{ID: id, Name: "Club", CreatedBy: "owner@example.com"}, nil
+}
+
+func (f *fakeStore) DeleteOrganization(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeStore) ListOrgMembers(_ context.Context, orgID string) ([]Member, error) {
+	return []Member{{OrgID: orgID, UserID: "owner@example.com", Role: db.OrgRoleOwner}}, nil
+}
+
+func (f *fakeStore) CountOrgOwners(_ context.Context, orgID string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for key, role := range f.roles {
+		if role == db.OrgRoleOwner && strings.HasPrefix(key, orgID+"/") {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeStore) AddOrgMember(_ context.Context, member Member) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := member.OrgID + "/" + member.UserID
+	if f.roles[key] != "" {
+		return db.ErrOrgMemberExists
+	}
+	f.roles[key] = member.Role
+	f.added = append(f.added, member)
+	return nil
+}
+
+func (f *fakeStore) UpdateOrgMemberRole(_ context.Context, orgID, userID, role string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := orgID + "/" + userID
+	if f.roles[key] == "" {
+		return db.ErrOrgMemberNotFound
+	}
+	f.roles[key] = role
+	return nil
+}
+
+func (f *fakeStore) RemoveOrgMember(_ context.Context, orgID, userID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := orgID + "/" + userID
+	if f.roles[key] == "" {
+		return db.ErrOrgMemberNotFound
+	}
+	delete(f.roles, key)
+	f.removed = append(f.removed, userID)
+	return nil
+}
+
+func (f *fakeStore) SetActiveOrganization(_ context.Context, userID, orgID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.active[userID] = orgID
+	return nil
+}
+
+// withRole adds a member to org "o1".
+func (f *fakeStore) withRole(userID, role string) *fakeStore {
+	f.roles["o1/"+userID] = role
+	return f
+}
+
+// missingUser mimics the store for unknown users.
+func missingUser(context.Context, string) (*User, error) {
+	return nil, db.ErrUserNotFound
+}
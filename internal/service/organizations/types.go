@@ -0,0 +1,28 @@
+// Package organizations provides domain logic for organizations and their shared libraries.
+package organizations
+
+import "github.com/gi8lino/motus/internal/db"
+
+// Organization is the domain-level DTO for organizations.
+type Organization = db.Organization
+
+// Membership is the domain-level DTO for an organization seen by a member.
+type Membership = db.OrgMembership
+
+// Member is the domain-level DTO for organization members.
+type Member = db.OrgMember
+
+// User is the domain-level DTO for users.
+type User = db.User
+
+// errorScope is the service error scope for organizations.
+const errorScope = "organizations"
+
+// Roles lists the organization roles from most to least privileged.
+var Roles = []string{db.OrgRoleOwner, db.OrgRoleEditor, db.OrgRoleMember}
+
+// MemberRequest adds a user to an organization. Role defaults to member.
+type MemberRequest struct {
+	UserID string `json:"userId"`
+	Role   string `json:"role"`
+}
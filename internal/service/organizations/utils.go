@@ -0,0 +1,59 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// normalizeRole lowercases role and defaults it to member.
+func normalizeRole(role string) (string, error) {
+	role = utils.DefaultIfZero(utils.NormalizeToken(role), db.OrgRoleMember)
+	if !slices.Contains(Roles, role) {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, "role must be one of owner, editor, member", errorScope)
+	}
+	return role, nil
+}
+
+// getOrganization loads an organization and maps a missing one to a not-found error.
+func (s *Service) getOrganization(ctx context.Context, orgID string) (*Organization, error) {
+	orgID = strings.TrimSpace(orgID)
+	if orgID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "organization id is required", errorScope)
+	}
+	org, err := s.store.GetOrganization(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, db.ErrOrganizationNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return org, nil
+}
+
+// keepOwner rejects changes that would leave the organization without an owner.
+func (s *Service) keepOwner(ctx context.Context, orgID, userID string) error {
+	role, err := s.store.OrgRole(ctx, orgID, userID)
+	if err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if role == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, db.ErrOrgMemberNotFound.Error(), errorScope)
+	}
+	if role != db.OrgRoleOwner {
+		return nil
+	}
+	owners, err := s.store.CountOrgOwners(ctx, orgID)
+	if err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if owners <= 1 {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "an organization needs at least one owner", errorScope)
+	}
+	return nil
+}
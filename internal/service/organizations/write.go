@@ -0,0 +1,170 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// Create founds an organization with the actor as its first owner.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, name string) (*Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "name is required", errorScope)
+	}
+
+	org := Organization{
+		ID:        utils.NewID(),
+		Name:      name,
+		CreatedBy: strings.TrimSpace(actor.UserID),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.store.CreateOrganization(ctx, org); err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return &org, nil
+}
+
+// Delete removes an organization together with its templates and shared exercises; owners only.
+func (s *Service) Delete(ctx context.Context, actor policy.Actor, orgID string) (*Organization, error) {
+	org, err := s.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := requireRole(ctx, s.store, actor, org.ID, errorScope, db.OrgRoleOwner); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.DeleteOrganization(ctx, org.ID); err != nil {
+		if errors.Is(err, db.ErrOrganizationNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return org, nil
+}
+
+// AddMember adds an existing user to an organization; owners only.
+func (s *Service) AddMember(ctx context.Context, actor policy.Actor, orgID string, req MemberRequest) (*Member, error) {
+	org, err := s.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := requireRole(ctx, s.store, actor, org.ID, errorScope, db.OrgRoleOwner); err != nil {
+		return nil, err
+	}
+	userID := utils.NormalizeToken(req.UserID)
+	if userID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "userId is required", errorScope)
+	}
+	role, err := normalizeRole(req.Role)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+
+	member := Member{
+		OrgID:    org.ID,
+		UserID:   user.ID,
+		Name:     user.Name,
+		Role:     role,
+		JoinedAt: time.Now().UTC(),
+	}
+	if err := s.store.AddOrgMember(ctx, member); err != nil {
+		if errors.Is(err, db.ErrOrgMemberExists) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return &member, nil
+}
+
+// UpdateMember changes the organization role of a member; owners only.
+func (s *Service) UpdateMember(ctx context.Context, actor policy.Actor, orgID, userID, role string) error {
+	org, err := s.getOrganization(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if _, err := requireRole(ctx, s.store, actor, org.ID, errorScope, db.OrgRoleOwner); err != nil {
+		return err
+	}
+	role, err = normalizeRole(role)
+	if err != nil {
+		return err
+	}
+	userID = utils.NormalizeToken(userID)
+	if role != db.OrgRoleOwner {
+		if err := s.keepOwner(ctx, org.ID, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.UpdateOrgMemberRole(ctx, org.ID, userID, role); err != nil {
+		if errors.Is(err, db.ErrOrgMemberNotFound) {
+			return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
+
+// RemoveMember removes a user from an organization. Owners remove anyone; members may leave.
+func (s *Service) RemoveMember(ctx context.Context, actor policy.Actor, orgID, userID string) error {
+	org, err := s.getOrganization(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	userID = utils.NormalizeToken(userID)
+	if !actor.CanAccess(userID) {
+		if _, err := requireRole(ctx, s.store, actor, org.ID, errorScope, db.OrgRoleOwner); err != nil {
+			return err
+		}
+	}
+	if err := s.keepOwner(ctx, org.ID, userID); err != nil {
+		return err
+	}
+
+	if err := s.store.RemoveOrgMember(ctx, org.ID, userID); err != nil {
+		if errors.Is(err, db.ErrOrgMemberNotFound) {
+			return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
+
+// Switch makes orgID the active organization of the actor; an empty id leaves all organizations.
+func (s *Service) Switch(ctx context.Context, actor policy.Actor, orgID string) error {
+	orgID = strings.TrimSpace(orgID)
+	if orgID != "" {
+		org, err := s.getOrganization(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		// The context must be one of the actor's own organizations, even for admins.
+		role, err := s.store.OrgRole(ctx, org.ID, actor.UserID)
+		if err != nil {
+			return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		}
+		if role == "" {
+			return errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "you are not a member of this organization", errorScope)
+		}
+	}
+
+	if err := s.store.SetActiveOrganization(ctx, actor.UserID, orgID); err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
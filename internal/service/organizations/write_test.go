@@ -0,0 +1,192 @@
+package organizations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+var (
+	owner  = policy.NewActor("owner@example.com", db.RoleMember)
+	member = policy.NewActor("member@example.com", db.RoleMember)
+	admin  = policy.NewActor("admin@example.com", db.RoleAdmin)
+)
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Makes creator owner", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		svc := New(store)
+
+		org, err := svc.Create(context.Background(), member, " Club ")
+		require.NoError(t, err)
+		assert.Equal(t, "Club", org.Name)
+		assert.Equal(t, db.OrgRoleOwner, store.roles[org.ID+"/member@example.com"])
+	})
+
+	t.Run("Requires name", func(t *testing.T) {
+		t.Parallel()
+		_, err := New(newFakeStore()).Create(context.Background(), member, " ")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Owner deletes", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		_, err := New(store).Delete(context.Background(), owner, "o1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"o1"}, store.deleted)
+	})
+
+	t.Run("Editor is forbidden", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore().withRole("member@example.com", db.OrgRoleEditor)
+		_, err := New(store).Delete(context.Background(), member, "o1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+		assert.Empty(t, store.deleted)
+	})
+
+	t.Run("Unknown organization", func(t *testing.T) {
+		t.Parallel()
+		_, err := New(newFakeStore()).Delete(context.Background(), admin, "missing")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+}
+
+func TestAddMember(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Defaults to member role", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		added, err := New(store).AddMember(context.Background(), owner, "o1", MemberRequest{UserID: " Member@Example.com "})
+		require.NoError(t, err)
+		assert.Equal(t, "member@example.com", added.UserID)
+		assert.Equal(t, db.OrgRoleMember, added.Role)
+	})
+
+	t.Run("Rejects unknown role", func(t *testing.T) {
+		t.Parallel()
+		_, err := New(newFakeStore()).AddMember(context.Background(), owner, "o1", MemberRequest{UserID: "member@example.com", Role: "coach"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		store.getUserFn = missingUser
+		_, err := New(store).AddMember(context.Background(), owner, "o1", MemberRequest{UserID: "ghost@example.com"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Rejects existing member", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore().withRole("member@example.com", db.OrgRoleMember)
+		_, err := New(store).AddMember(context.Background(), owner, "o1", MemberRequest{UserID: "member@example.com"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Members cannot add", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore().withRole("member@example.com", db.OrgRoleMember)
+		_, err := New(store).AddMember(context.Background(), member, "o1", MemberRequest{UserID: "third@example.com"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
+
+func TestUpdateMember(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Promotes member", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore().withRole("member@example.com", db.OrgRoleMember)
+		require.NoError(t, New(store).UpdateMember(context.Background(), owner, "o1", "member@example.com", "editor"))
+		assert.Equal(t, db.OrgRoleEditor, store.roles["o1/member@example.com"])
+	})
+
+	t.Run("Keeps last owner", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		err := New(store).UpdateMember(context.Background(), owner, "o1", "owner@example.com", "member")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+		assert.Equal(t, db.OrgRoleOwner, store.roles["o1/owner@example.com"])
+	})
+}
+
+func TestRemoveMember(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Member leaves", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore().withRole("member@example.com", db.OrgRoleMember)
+		require.NoError(t, New(store).RemoveMember(context.Background(), member, "o1", "member@example.com"))
+		assert.Equal(t, []string{"member@example.com"}, store.removed)
+	})
+
+	t.Run("Member cannot remove others", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore().withRole("member@example.com", db.OrgRoleMember)
+		err := New(store).RemoveMember(context.Background(), member, "o1", "owner@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Last owner cannot leave", func(t *testing.T) {
+		t.Parallel()
+		err := New(newFakeStore()).RemoveMember(context.Background(), owner, "o1", "owner@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Owner leaves after handing over", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore().withRole("member@example.com", db.OrgRoleOwner)
+		require.NoError(t, New(store).RemoveMember(context.Background(), owner, "o1", "owner@example.com"))
+	})
+}
+
+func TestSwitch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Member switches", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore().withRole("member@example.com", db.OrgRoleMember)
+		require.NoError(t, New(store).Switch(context.Background(), member, "o1"))
+		assert.Equal(t, "o1", store.active["member@example.com"])
+	})
+
+	t.Run("Empty id leaves", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		store.active["owner@example.com"] = "o1"
+		require.NoError(t, New(store).Switch(context.Background(), owner, ""))
+		assert.Empty(t, store.active["owner@example.com"])
+	})
+
+	t.Run("Admins must be members", func(t *testing.T) {
+		t.Parallel()
+		err := New(newFakeStore()).Switch(context.Background(), admin, "o1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
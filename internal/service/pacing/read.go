@@ -0,0 +1,89 @@
+package pacing
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// Analyze aggregates the recent trainings of a workout step by step.
+func (s *Service) Analyze(ctx context.Context, actor policy.Actor, workoutID string) (Analysis, error) {
+	workout, err := s.loadWorkout(ctx, actor, workoutID)
+	if err != nil {
+		return Analysis{}, err
+	}
+	return s.analyze(ctx, workout)
+}
+
+// analyze builds the analysis of a loaded workout.
+func (s *Service) analyze(ctx context.Context, workout *Workout) (Analysis, error) {
+	history, err := s.store.TrainingHistory(ctx, TrainingHistoryFilter{
+		UserID:    workout.UserID,
+		WorkoutID: workout.ID,
+		Limit:     trainingLimit,
+	})
+	if err != nil {
+		return Analysis{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	// History is newest first; samples are collected oldest first so trends read forward.
+	slices.Reverse(history)
+	ids := make([]string, 0, len(history))
+	for _, entry := range history {
+		ids = append(ids, entry.ID)
+	}
+	stepMap, err := s.store.StepTimingsForTrainings(ctx, ids)
+	if err != nil {
+		return Analysis{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+
+	targets, slots := buildTargets(workout)
+	samples := make([][]float64, len(targets))
+	analysis := Analysis{WorkoutID: workout.ID}
+	for _, id := range ids {
+		steps := stepMap[id]
+		if len(steps) == 0 {
+			continue
+		}
+		runs, ok := trainingRuns(steps, slots, len(targets))
+		if !ok {
+			analysis.SkippedTrainings++
+			continue
+		}
+		analysis.Trainings++
+		for idx, loops := range runs {
+			samples[idx] = append(samples[idx], loops...)
+		}
+	}
+	for idx := range targets {
+		summarize(&targets[idx], samples[idx])
+	}
+	analysis.Targets = targets
+	return analysis, nil
+}
+
+// loadWorkout fetches a workout the actor owns.
+func (s *Service) loadWorkout(ctx context.Context, actor policy.Actor, workoutID string) (*Workout, error) {
+	workoutID = strings.TrimSpace(workoutID)
+	if workoutID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "workout id is required", errorScope)
+	}
+	workout, err := s.store.WorkoutWithSteps(ctx, workoutID)
+	if err != nil {
+		if errors.Is(err, db.ErrWorkoutNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if workout == nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "workout not found", errorScope)
+	}
+	if err := policy.RequireOwner(actor, workout.UserID, errorScope); err != nil {
+		return nil, err
+	}
+	return workout, nil
+}
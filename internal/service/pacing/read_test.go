@@ -0,0 +1,206 @@
+package pacing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// pacingWorkout returns a workout with a repeated set of two subsets, a rest between the
+// repeats and a closing pause. It expands to the training steps listed by pacingSteps.
+func pacingWorkout() *Workout {
+	return &Workout{
+		ID:     "w1",
+		UserID: "u1",
+		Name:   "Legs",
+		Steps: []db.WorkoutStep{
+			{
+				ID:                "legs",
+				Type:              "set",
+				Name:              "Legs",
+				RepeatCount:       2,
+				RepeatRestSeconds: 20,
+				Subsets: []db.WorkoutSubset{
+					{ID: "main", Name: "Main", EstimatedSeconds: 60, Exercises: []db.SubsetExercise{
+						{Name: "Squat", Type: "rep", Reps: "10"},
+						{Name: "Lunge", Type: "rep", Reps: "10"},
+					}},
+					{ID: "finisher", Name: "Finisher", EstimatedSeconds: 30, Superset: true, Exercises: []db.SubsetExercise{
+						{Name: "Jumps", Type: "rep", Reps: "20"},
+					}},
+				},
+			},
+			{ID: "cooldown", Type: "pause", Name: "Cooldown", EstimatedSeconds: 90},
+		},
+	}
+}
+
+// pacingSteps are the names and types of the training steps of pacingWorkout.
+var pacingSteps = []struct{ name, typ string }{
+	{"Squat", "set"}, {"Lunge", "set"}, {"Finisher", "set"}, {"Pause", "pause"},
+	{"Squat", "set"}, {"Lunge", "set"}, {"Finisher", "set"},
+	{"Cooldown", "pause"},
+}
+
+// loggedSteps returns step logs of a training with the given seconds per training step.
+func loggedSteps(trainingID string, seconds ...int) []TrainingStepLog {
+	steps := make([]TrainingStepLog, 0, len(seconds))
+	for idx, sec := range seconds {
+		steps = append(steps, TrainingStepLog{
+			ID:            fmt.Sprintf("%s-%d", trainingID, idx),
+			TrainingID:    trainingID,
+			StepOrder:     idx,
+			Name:          pacingSteps[idx].name,
+			Type:          pacingSteps[idx].typ,
+			ElapsedMillis: int64(sec) * 1000,
+		})
+	}
+	return steps
+}
+
+// pacingStore returns a store with one training per entry of runs, newest first.
+func pacingStore(runs ...[]int) *fakeStore {
+	store := &fakeStore{workout: pacingWorkout(), steps: map[string][]TrainingStepLog{}}
+	for idx, seconds := range runs {
+		id := fmt.Sprintf("t%d", len(runs)-idx)
+		store.history = append(store.history, TrainingLog{ID: id, WorkoutID: "w1", UserID: "u1"})
+		store.steps[id] = loggedSteps(id, seconds...)
+	}
+	return store
+}
+
+// targetByName returns the target of kind named name.
+func targetByName(t *testing.T, targets []Target, kind, name string) Target {
+	t.Helper()
+	for _, target := range targets {
+		if target.Kind == kind && target.Name == name {
+			return target
+		}
+	}
+	require.Failf(t, "target not found", "%s %s", kind, name)
+	return Target{}
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Aggregates runs per target", func(t *testing.T) {
+		t.Parallel()
+		store := pacingStore(
+			[]int{40, 40, 25, 20, 40, 45, 30, 80},
+			[]int{35, 40, 30, 20, 40, 40, 35, 100},
+			[]int{30, 30, 35, 20, 35, 35, 40, 120},
+		)
+		svc := New(store)
+
+		analysis, err := svc.Analyze(context.Background(), policy.Actor{UserID: "u1"}, "w1")
+		require.NoError(t, err)
+		assert.Equal(t, 3, analysis.Trainings)
+		assert.Zero(t, analysis.SkippedTrainings)
+		require.Len(t, analysis.Targets, 4)
+
+		main := targetByName(t, analysis.Targets, KindSubset, "Main")
+		assert.Equal(t, 6, main.Samples)
+		assert.Equal(t, 77.5, main.MedianSeconds)
+		assert.Equal(t, 1.29, main.OverrunRatio)
+		assert.Equal(t, 78, main.SuggestedSeconds)
+		assert.Equal(t, TrendSlower, main.Trend)
+		assert.Equal(t, 10.0, main.TrendSeconds)
+
+		finisher := targetByName(t, analysis.Targets, KindSubset, "Finisher")
+		assert.Equal(t, 32.5, finisher.MedianSeconds)
+		assert.Equal(t, 33, finisher.SuggestedSeconds)
+		assert.Equal(t, TrendFaster, finisher.Trend)
+
+		cooldown := targetByName(t, analysis.Targets, KindStep, "Cooldown")
+		assert.Equal(t, 3, cooldown.Samples)
+		assert.Equal(t, 100.0, cooldown.MedianSeconds)
+		assert.Equal(t, 116.0, cooldown.P90Seconds)
+		assert.Equal(t, 100, cooldown.SuggestedSeconds)
+
+		legs := targetByName(t, analysis.Targets, KindStep, "Legs")
+		assert.Equal(t, 6, legs.Samples)
+		assert.Zero(t, legs.SuggestedSeconds)
+	})
+
+	t.Run("Skips trainings of older workout versions", func(t *testing.T) {
+		t.Parallel()
+		store := pacingStore([]int{40, 40, 25, 20, 40, 45, 30, 80})
+		store.steps["t1"][1].Name = "Deadlift"
+		svc := New(store)
+
+		analysis, err := svc.Analyze(context.Background(), policy.Actor{UserID: "u1"}, "w1")
+		require.NoError(t, err)
+		assert.Zero(t, analysis.Trainings)
+		assert.Equal(t, 1, analysis.SkippedTrainings)
+		assert.Zero(t, targetByName(t, analysis.Targets, KindSubset, "Main").Samples)
+	})
+
+	t.Run("Needs enough samples for suggestions", func(t *testing.T) {
+		t.Parallel()
+		store := pacingStore([]int{40, 40, 25, 20, 0, 0, 0, 0})
+		svc := New(store)
+
+		analysis, err := svc.Analyze(context.Background(), policy.Actor{UserID: "u1"}, "w1")
+		require.NoError(t, err)
+		main := targetByName(t, analysis.Targets, KindSubset, "Main")
+		assert.Equal(t, 1, main.Samples)
+		assert.Zero(t, main.SuggestedSeconds)
+		assert.Empty(t, main.Trend)
+		assert.Zero(t, targetByName(t, analysis.Targets, KindStep, "Cooldown").Samples)
+	})
+
+	t.Run("Queries the owner's trainings of the workout", func(t *testing.T) {
+		t.Parallel()
+		store := pacingStore()
+		var got TrainingHistoryFilter
+		store.historyFn = func(_ context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error) {
+			got = filter
+			return nil, nil
+		}
+		svc := New(store)
+
+		_, err := svc.Analyze(context.Background(), policy.Actor{UserID: "admin", IsAdmin: true}, "w1")
+		require.NoError(t, err)
+		assert.Equal(t, TrainingHistoryFilter{UserID: "u1", WorkoutID: "w1", Limit: trainingLimit}, got)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		store := pacingStore()
+		svc := New(store)
+
+		_, err := svc.Analyze(context.Background(), policy.Actor{UserID: "u2"}, "w1")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+
+		_, err = svc.Analyze(context.Background(), policy.Actor{UserID: "u1"}, "missing")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+
+		_, err = svc.Analyze(context.Background(), policy.Actor{UserID: "u1"}, " ")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+
+		store.historyFn = func(context.Context, TrainingHistoryFilter) ([]TrainingLog, error) {
+			return nil, errors.New("boom")
+		}
+		_, err = svc.Analyze(context.Background(), policy.Actor{UserID: "u1"}, "w1")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorInternal))
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	sorted := []float64{10, 20, 30, 40}
+	assert.Equal(t, 25.0, percentile(sorted, 0.5))
+	assert.Equal(t, 10.0, percentile(sorted, 0))
+	assert.Equal(t, 37.0, percentile(sorted, 0.9))
+	assert.Zero(t, percentile(nil, 0.5))
+}
@@ -0,0 +1,11 @@
+package pacing
+
+// Service compares workout targets with the durations of logged trainings.
+type Service struct {
+	store Store
+}
+
+// New creates a new pacing service.
+func New(store Store) *Service {
+	return &Service{store: store}
+}
@@ -0,0 +1,11 @@
+package pacing
+
+import "context"
+
+// Store defines persistence operations required by the pacing domain.
+type Store interface {
+	WorkoutWithSteps(ctx context.Context, id string) (*Workout, error)
+	TrainingHistory(ctx context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error)
+	StepTimingsForTrainings(ctx context.Context, trainingIDs []string) (map[string][]TrainingStepLog, error)
+	UpdateEstimatedSeconds(ctx context.Context, workoutID string, steps, subsets map[string]int) error
+}
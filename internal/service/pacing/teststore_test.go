@@ -0,0 +1,57 @@
+package pacing
+
+import (
+	"context"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+type fakeStore struct {
+	workout     *Workout
+	history     []TrainingLog
+	steps       map[string][]TrainingStepLog
+	historyFn   func(context.Context, TrainingHistoryFilter) ([]TrainingLog, error)
+	updateFn    func(context.Context, string, map[string]int, map[string]int) error
+	updateCalls int
+}
+
+func (f *fakeStore) WorkoutWithSteps(_ context.Context, id string) (*Workout, error) {
+	if f.workout == nil || f.workout.ID != id {
+		return nil, db.ErrWorkoutNotFound
+	}
+	return f.workout, nil
+}
+
+func (f *fakeStore) TrainingHistory(ctx context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error) {
+	if f.historyFn != nil {
+		return f.historyFn(ctx, filter)
+	}
+	return f.history, nil
+}
+
+func (f *fakeStore) StepTimingsForTrainings(_ context.Context, ids []string) (map[string][]TrainingStepLog, error) {
+	result := make(map[string][]TrainingStepLog, len(ids))
+	for _, id := range ids {
+		result[id] = f.steps[id]
+	}
+	return result, nil
+}
+
+func (f *fakeStore) UpdateEstimatedSeconds(ctx context.Context, workoutID string, steps, subsets map[string]int) error {
+	f.updateCalls++
+	if f.updateFn != nil {
+		return f.updateFn(ctx, workoutID, steps, subsets)
+	}
+	for i := range f.workout.Steps {
+		step := &f.workout.Steps[i]
+		if seconds, ok := steps[step.ID]; ok {
+			step.EstimatedSeconds = seconds
+		}
+		for j := range step.Subsets {
+			if seconds, ok := subsets[step.Subsets[j].ID]; ok {
+				step.Subsets[j].EstimatedSeconds = seconds
+			}
+		}
+	}
+	return nil
+}
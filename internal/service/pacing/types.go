@@ -0,0 +1,73 @@
+// Package pacing analyses how long the steps of a workout take compared to their targets.
+package pacing
+
+import "github.com/gi8lino/motus/internal/db"
+
+// Workout is the domain-level DTO for workouts.
+type Workout = db.Workout
+
+// TrainingLog is the domain-level DTO for completed training logs.
+type TrainingLog = db.TrainingLog
+
+// TrainingStepLog is the domain-level DTO for training step timing logs.
+type TrainingStepLog = db.TrainingStepLog
+
+// TrainingHistoryFilter is the domain-level DTO for training history queries.
+type TrainingHistoryFilter = db.TrainingHistoryFilter
+
+// errorScope is the service error scope for pacing.
+const errorScope = "pacing"
+
+// Analysis tuning.
+const (
+	trainingLimit  = 50   // trainingLimit caps how many recent trainings are analysed.
+	minSamples     = 3    // minSamples is the number of samples a suggestion needs.
+	toleranceRatio = 0.05 // toleranceRatio is the share of the target within which pacing counts as on target.
+)
+
+// Target kinds.
+const (
+	KindStep   = "step"
+	KindSubset = "subset"
+)
+
+// Trend directions of the actual durations.
+const (
+	TrendFaster = "faster"
+	TrendSlower = "slower"
+	TrendSteady = "steady"
+)
+
+// Target is the pacing of one workout step or subset.
+// Set steps take as long as their subsets, so only pause steps and subsets get suggestions.
+type Target struct {
+	Kind             string  `json:"kind"`                       // Kind is step or subset.
+	StepID           string  `json:"stepId"`                     // StepID is the workout step.
+	SubsetID         string  `json:"subsetId,omitempty"`         // SubsetID is the subset for subset targets.
+	Name             string  `json:"name"`                       // Name is the step or subset label.
+	Type             string  `json:"type"`                       // Type is set or pause.
+	EstimatedSeconds int     `json:"estimatedSeconds"`           // EstimatedSeconds is the current target.
+	Samples          int     `json:"samples"`                    // Samples counts the analysed runs, one per training and repeat.
+	MedianSeconds    float64 `json:"medianSeconds"`              // MedianSeconds is the median actual duration.
+	P25Seconds       float64 `json:"p25Seconds"`                 // P25Seconds is the 25th percentile.
+	P75Seconds       float64 `json:"p75Seconds"`                 // P75Seconds is the 75th percentile.
+	P90Seconds       float64 `json:"p90Seconds"`                 // P90Seconds is the 90th percentile.
+	OverrunRatio     float64 `json:"overrunRatio,omitempty"`     // OverrunRatio is the median divided by the target; empty without a target.
+	Trend            string  `json:"trend,omitempty"`            // Trend compares the newer half of the samples with the older half.
+	TrendSeconds     float64 `json:"trendSeconds,omitempty"`     // TrendSeconds is the median change between the halves.
+	SuggestedSeconds int     `json:"suggestedSeconds,omitempty"` // SuggestedSeconds is the proposed target; empty when the target fits.
+}
+
+// Analysis is the pacing of a workout across its recent trainings.
+type Analysis struct {
+	WorkoutID        string   `json:"workoutId"`
+	Trainings        int      `json:"trainings"`        // Trainings counts the analysed trainings.
+	SkippedTrainings int      `json:"skippedTrainings"` // SkippedTrainings counts trainings that no longer match the workout steps.
+	Targets          []Target `json:"targets"`          // Targets lists every step followed by its subsets.
+}
+
+// ApplyResult reports the targets changed by applying suggestions.
+type ApplyResult struct {
+	Workout *Workout `json:"workout"` // Workout is the updated workout.
+	Applied int      `json:"applied"` // Applied counts the changed targets.
+}
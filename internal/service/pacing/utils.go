@@ -0,0 +1,163 @@
+package pacing
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/service/trainings"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// slot describes one step of a training built from the workout, in training order.
+type slot struct {
+	name    string
+	typ     string
+	loop    int   // loop is the repeat, starting at 1.
+	targets []int // targets are the indexes of the targets the step counts towards; empty for repeat rests.
+}
+
+// buildTargets lists the targets of a workout and the training steps it expands to.
+// Training step ids start with the id of their workout step, which ties both together.
+func buildTargets(workout *Workout) ([]Target, []slot) {
+	var targets []Target
+	stepTarget := make(map[string]int, len(workout.Steps))
+	subsetTarget := make(map[string]int)
+	for _, st := range workout.Steps {
+		stepTarget[st.ID] = len(targets)
+		targets = append(targets, Target{
+			Kind:             KindStep,
+			StepID:           st.ID,
+			Name:             st.Name,
+			Type:             st.Type,
+			EstimatedSeconds: st.EstimatedSeconds,
+		})
+		for _, sub := range st.Subsets {
+			subsetTarget[sub.ID] = len(targets)
+			targets = append(targets, Target{
+				Kind:             KindSubset,
+				StepID:           st.ID,
+				SubsetID:         sub.ID,
+				Name:             utils.DefaultIfZero(strings.TrimSpace(sub.Name), st.Name),
+				Type:             st.Type,
+				EstimatedSeconds: sub.EstimatedSeconds,
+			})
+		}
+	}
+
+	state := trainings.NewStateFromWorkout(workout, func(string) string { return "" })
+	slots := make([]slot, 0, len(state.Steps))
+	for _, step := range state.Steps {
+		sl := slot{name: strings.TrimSpace(step.Name), typ: step.Type, loop: max(step.LoopIndex, 1)}
+		for _, st := range workout.Steps {
+			if step.ID != st.ID && !strings.HasPrefix(step.ID, st.ID+"-") {
+				continue
+			}
+			if !strings.HasPrefix(step.ID, st.ID+"-rest-") {
+				sl.targets = append(sl.targets, stepTarget[st.ID])
+				if idx, ok := subsetTarget[step.SubsetID]; ok && step.SubsetID != "" {
+					sl.targets = append(sl.targets, idx)
+				}
+			}
+			break
+		}
+		slots = append(slots, sl)
+	}
+	return targets, slots
+}
+
+// trainingRuns sums the seconds of one training per target and repeat. It reports false when
+// the logged steps no longer line up with the workout, for example after steps were edited.
+func trainingRuns(steps []TrainingStepLog, slots []slot, targetCount int) ([][]float64, bool) {
+	type run struct{ target, loop int }
+	sums := make(map[run]float64)
+	loops := 1
+	for _, step := range steps {
+		if step.StepOrder < 0 || step.StepOrder >= len(slots) {
+			return nil, false
+		}
+		sl := slots[step.StepOrder]
+		if sl.name != strings.TrimSpace(step.Name) || sl.typ != step.Type {
+			return nil, false
+		}
+		for _, idx := range sl.targets {
+			sums[run{target: idx, loop: sl.loop}] += float64(step.ElapsedMillis) / 1000
+		}
+		loops = max(loops, sl.loop)
+	}
+
+	runs := make([][]float64, targetCount)
+	for idx := range runs {
+		for loop := 1; loop <= loops; loop++ {
+			// Steps that were never reached or skipped right away say nothing about pacing.
+			if seconds := sums[run{target: idx, loop: loop}]; seconds > 0 {
+				runs[idx] = append(runs[idx], seconds)
+			}
+		}
+	}
+	return runs, true
+}
+
+// summarize fills the statistics of target from samples ordered oldest first.
+func summarize(target *Target, samples []float64) {
+	target.Samples = len(samples)
+	if len(samples) == 0 {
+		return
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	target.MedianSeconds = round1(percentile(sorted, 0.5))
+	target.P25Seconds = round1(percentile(sorted, 0.25))
+	target.P75Seconds = round1(percentile(sorted, 0.75))
+	target.P90Seconds = round1(percentile(sorted, 0.9))
+	if target.EstimatedSeconds > 0 {
+		target.OverrunRatio = math.Round(target.MedianSeconds/float64(target.EstimatedSeconds)*100) / 100
+	}
+
+	if half := len(samples) / 2; half >= 2 {
+		older := slices.Clone(samples[:len(samples)-half])
+		newer := slices.Clone(samples[len(samples)-half:])
+		slices.Sort(older)
+		slices.Sort(newer)
+		olderMedian := percentile(older, 0.5)
+		delta := percentile(newer, 0.5) - olderMedian
+		target.TrendSeconds = round1(delta)
+		switch {
+		case math.Abs(delta) <= max(1, olderMedian*toleranceRatio):
+			target.Trend = TrendSteady
+		case delta < 0:
+			target.Trend = TrendFaster
+		default:
+			target.Trend = TrendSlower
+		}
+	}
+
+	// Set steps last as long as their subsets and keep no target of their own.
+	if target.Kind == KindStep && target.Type != utils.StepTypePause.String() {
+		return
+	}
+	if target.Samples < minSamples {
+		return
+	}
+	suggested := int(math.Round(target.MedianSeconds))
+	diff := math.Abs(float64(suggested - target.EstimatedSeconds))
+	if suggested > 0 && diff >= max(1, float64(target.EstimatedSeconds)*toleranceRatio) {
+		target.SuggestedSeconds = suggested
+	}
+}
+
+// percentile interpolates the p-th percentile of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := p * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+// round1 rounds seconds to one decimal.
+func round1(seconds float64) float64 {
+	return math.Round(seconds*10) / 10
+}
@@ -0,0 +1,46 @@
+package pacing
+
+import (
+	"context"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// Apply replaces the targets of a workout with the suggested ones.
+func (s *Service) Apply(ctx context.Context, actor policy.Actor, workoutID string) (ApplyResult, error) {
+	workout, err := s.loadWorkout(ctx, actor, workoutID)
+	if err != nil {
+		return ApplyResult{}, err
+	}
+	analysis, err := s.analyze(ctx, workout)
+	if err != nil {
+		return ApplyResult{}, err
+	}
+
+	steps := make(map[string]int)
+	subsets := make(map[string]int)
+	for _, target := range analysis.Targets {
+		if target.SuggestedSeconds == 0 {
+			continue
+		}
+		if target.Kind == KindSubset {
+			subsets[target.SubsetID] = target.SuggestedSeconds
+		} else {
+			steps[target.StepID] = target.SuggestedSeconds
+		}
+	}
+	applied := len(steps) + len(subsets)
+	if applied == 0 {
+		return ApplyResult{Workout: workout}, nil
+	}
+
+	if err := s.store.UpdateEstimatedSeconds(ctx, workout.ID, steps, subsets); err != nil {
+		return ApplyResult{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	updated, err := s.loadWorkout(ctx, actor, workout.ID)
+	if err != nil {
+		return ApplyResult{}, err
+	}
+	return ApplyResult{Workout: updated, Applied: applied}, nil
+}
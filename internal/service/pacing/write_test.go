@@ -0,0 +1,66 @@
+package pacing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	runs := [][]int{
+		{40, 40, 25, 20, 40, 45, 30, 80},
+		{35, 40, 30, 20, 40, 40, 35, 100},
+		{30, 30, 35, 20, 35, 35, 40, 120},
+	}
+
+	t.Run("Applies suggestions and keeps ids", func(t *testing.T) {
+		t.Parallel()
+		store := pacingStore(runs...)
+		svc := New(store)
+
+		result, err := svc.Apply(context.Background(), policy.Actor{UserID: "u1"}, "w1")
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Applied)
+		require.NotNil(t, result.Workout)
+		assert.Equal(t, "main", result.Workout.Steps[0].Subsets[0].ID)
+		assert.Equal(t, 78, result.Workout.Steps[0].Subsets[0].EstimatedSeconds)
+		assert.Equal(t, 33, result.Workout.Steps[0].Subsets[1].EstimatedSeconds)
+		assert.Equal(t, 100, result.Workout.Steps[1].EstimatedSeconds)
+
+		// Applying again finds nothing left to change.
+		again, err := svc.Apply(context.Background(), policy.Actor{UserID: "u1"}, "w1")
+		require.NoError(t, err)
+		assert.Zero(t, again.Applied)
+		assert.Equal(t, 1, store.updateCalls)
+	})
+
+	t.Run("Requires owner", func(t *testing.T) {
+		t.Parallel()
+		store := pacingStore(runs...)
+		svc := New(store)
+
+		_, err := svc.Apply(context.Background(), policy.Actor{UserID: "u2"}, "w1")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+		assert.Zero(t, store.updateCalls)
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		t.Parallel()
+		store := pacingStore(runs...)
+		store.updateFn = func(context.Context, string, map[string]int, map[string]int) error {
+			return errors.New("boom")
+		}
+		svc := New(store)
+
+		_, err := svc.Apply(context.Background(), policy.Actor{UserID: "u1"}, "w1")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorInternal))
+	})
+}
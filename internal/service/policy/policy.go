@@ -0,0 +1,40 @@
+// Package policy centralizes authorization decisions shared by the services.
+package policy
+
+import (
+	"strings"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+// Actor identifies the authenticated caller of a service operation.
+type Actor struct {
+	UserID  string // UserID is the authenticated user id.
+	Role    string // Role selects the permissions of the actor.
+	IsAdmin bool   // IsAdmin grants an explicit override for cross-user access and every permission.
+}
+
+// CanAccess reports whether the actor may act on resources owned by ownerID.
+func (a Actor) CanAccess(ownerID string) bool {
+	if a.IsAdmin {
+		return true
+	}
+	uid := strings.TrimSpace(a.UserID)
+	return uid != "" && uid == strings.TrimSpace(ownerID)
+}
+
+// RequireOwner returns a forbidden error unless the actor owns the resource or is an admin.
+func RequireOwner(actor Actor, ownerID, scope string) error {
+	if actor.CanAccess(ownerID) {
+		return nil
+	}
+	return errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "access to another user's resource is forbidden", scope)
+}
+
+// RequireAdmin returns a forbidden error unless the actor is an admin.
+func RequireAdmin(actor Actor, scope string) error {
+	if actor.IsAdmin {
+		return nil
+	}
+	return errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "admin permissions required", scope)
+}
@@ -0,0 +1,109 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+func TestCanAccess(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		actor   Actor
+		ownerID string
+		want    bool
+	}{
+		{name: "owner", actor: Actor{UserID: "a@example.com"}, ownerID: "a@example.com", want: true},
+		{name: "other user", actor: Actor{UserID: "b@example.com"}, ownerID: "a@example.com", want: false},
+		{name: "admin override", actor: Actor{UserID: "admin@example.com", IsAdmin: true}, ownerID: "a@example.com", want: true},
+		{name: "anonymous", actor: Actor{}, ownerID: "", want: false},
+		{name: "trims ids", actor: Actor{UserID: " a@example.com "}, ownerID: "a@example.com", want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, tc.actor.CanAccess(tc.ownerID))
+		})
+	}
+}
+
+func TestRequireOwner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Allows owner", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, RequireOwner(Actor{UserID: "a@example.com"}, "a@example.com", "workouts"))
+	})
+
+	t.Run("Forbids other user", func(t *testing.T) {
+		t.Parallel()
+		err := RequireOwner(Actor{UserID: "b@example.com"}, "a@example.com", "workouts")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
+
+func TestRequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Allows admin", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, RequireAdmin(Actor{UserID: "a@example.com", IsAdmin: true}, "users"))
+	})
+
+	t.Run("Forbids member", func(t *testing.T) {
+		t.Parallel()
+		err := RequireAdmin(Actor{UserID: "a@example.com"}, "users")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
+
+func TestRequirePermission(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		actor      Actor
+		permission Permission
+		allowed    bool
+	}{
+		{name: "admin holds every permission", actor: NewActor("a@example.com", db.RoleAdmin), permission: PermCatalogWrite, allowed: true},
+		{name: "catalog editor writes catalog", actor: NewActor("a@example.com", db.RoleCatalogEditor), permission: PermCatalogWrite, allowed: true},
+		{name: "catalog editor cannot manage users", actor: NewActor("a@example.com", db.RoleCatalogEditor), permission: PermUsersManage, allowed: false},
+		{name: "coach manages athletes", actor: NewActor("a@example.com", db.RoleCoach), permission: PermAthletesManage, allowed: true},
+		{name: "member has no permissions", actor: NewActor("a@example.com", db.RoleMember), permission: PermAuditRead, allowed: false},
+		{name: "unknown role has no permissions", actor: NewActor("a@example.com", "owner"), permission: PermAuditRead, allowed: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := RequirePermission(tc.actor, tc.permission, "users")
+			if tc.allowed {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+		})
+	}
+}
+
+func TestNormalizeRole(t *testing.T) {
+	t.Parallel()
+
+	role, ok := NormalizeRole(" Catalog-Editor ")
+	assert.True(t, ok)
+	assert.Equal(t, db.RoleCatalogEditor, role)
+
+	_, ok = NormalizeRole("owner")
+	assert.False(t, ok)
+}
@@ -0,0 +1,60 @@
+package policy
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+// Permission names a privileged capability granted through roles.
+type Permission string
+
+const (
+	PermUsersManage    Permission = "users:manage"    // PermUsersManage covers user administration and invitations.
+	PermAuditRead      Permission = "audit:read"      // PermAuditRead allows reading the audit log.
+	PermCatalogWrite   Permission = "catalog:write"   // PermCatalogWrite allows curating core exercises.
+	PermAthletesManage Permission = "athletes:manage" // PermAthletesManage allows coaching other users.
+)
+
+// Roles lists the assignable roles from most to least privileged.
+var Roles = []string{db.RoleAdmin, db.RoleCoach, db.RoleCatalogEditor, db.RoleMember}
+
+// rolePermissions is the permission matrix; admins are granted everything.
+var rolePermissions = map[string][]Permission{
+	db.RoleAdmin:         {PermUsersManage, PermAuditRead, PermCatalogWrite, PermAthletesManage},
+	db.RoleCoach:         {PermAthletesManage},
+	db.RoleCatalogEditor: {PermCatalogWrite},
+	db.RoleMember:        {},
+}
+
+// NormalizeRole trims and lowercases role and reports whether it is known.
+func NormalizeRole(role string) (string, bool) {
+	role = strings.ToLower(strings.TrimSpace(role))
+	_, ok := rolePermissions[role]
+	return role, ok
+}
+
+// RoleAllows reports whether role grants permission.
+func RoleAllows(role string, permission Permission) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+// NewActor builds an actor for userID with the given role.
+func NewActor(userID, role string) Actor {
+	return Actor{UserID: userID, Role: role, IsAdmin: role == db.RoleAdmin}
+}
+
+// Can reports whether the actor holds permission.
+func (a Actor) Can(permission Permission) bool {
+	return a.IsAdmin || RoleAllows(a.Role, permission)
+}
+
+// RequirePermission returns a forbidden error unless the actor holds permission.
+func RequirePermission(actor Actor, permission Permission, scope string) error {
+	if actor.Can(permission) {
+		return nil
+	}
+	return errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "permission "+string(permission)+" required", scope)
+}
@@ -0,0 +1,40 @@
+package privacy
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+)
+
+// WriteArchive writes export as a zip archive with one JSON document per data set.
+func WriteArchive(w io.Writer, export Export) error {
+	files := []struct {
+		name string
+		data any
+	}{
+		{name: "profile.json", data: export.Profile},
+		{name: "workouts.json", data: export.Workouts},
+		{name: "templates.json", data: export.Templates},
+		{name: "exercises.json", data: export.Exercises},
+		{name: "trainings.json", data: export.Trainings},
+		{name: "api-tokens.json", data: export.APITokens},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, file := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
@@ -0,0 +1,59 @@
+package privacy
+
+import (
+	"context"
+	"time"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// Export collects everything stored about userID. Users export their own data; admins any user's.
+func (s *Service) Export(ctx context.Context, actor policy.Actor, userID string) (Export, error) {
+	user, _, err := s.loadUser(ctx, actor, userID)
+	if err != nil {
+		return Export{}, err
+	}
+
+	export := Export{ExportedAt: time.Now().UTC(), Profile: *user}
+	internal := func(err error) (Export, error) {
+		return Export{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+
+	if export.Workouts, err = s.store.WorkoutsByUser(ctx, user.ID); err != nil {
+		return internal(err)
+	}
+	if export.Templates, err = s.store.TemplatesByUser(ctx, user.ID); err != nil {
+		return internal(err)
+	}
+	exercises, err := s.store.ListExercises(ctx, user.ID, "")
+	if err != nil {
+		return internal(err)
+	}
+	for _, exercise := range exercises {
+		if !exercise.IsCore && exercise.OwnerUserID == user.ID {
+			export.Exercises = append(export.Exercises, exercise)
+		}
+	}
+	logs, err := s.store.TrainingsByUser(ctx, user.ID)
+	if err != nil {
+		return internal(err)
+	}
+	for _, log := range logs {
+		steps, err := s.store.TrainingStepTimings(ctx, log.ID)
+		if err != nil {
+			return internal(err)
+		}
+		export.Trainings = append(export.Trainings, Training{TrainingLog: log, Steps: nonNil(steps)})
+	}
+	if export.APITokens, err = s.store.ListAPITokens(ctx, user.ID); err != nil {
+		return internal(err)
+	}
+
+	export.Workouts = nonNil(export.Workouts)
+	export.Templates = nonNil(export.Templates)
+	export.Exercises = nonNil(export.Exercises)
+	export.Trainings = nonNil(export.Trainings)
+	export.APITokens = nonNil(export.APITokens)
+	return export, nil
+}
@@ -0,0 +1,99 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeStore{
+		workoutsByUserFn: func(_ context.Context, userID string) ([]Workout, error) {
+			return []Workout{{ID: "w1", UserID: userID}}, nil
+		},
+		templatesByUserFn: func(_ context.Context, userID string) ([]Workout, error) {
+			return []Workout{{ID: "t1", UserID: userID}}, nil
+		},
+		listExercisesFn: func(context.Context, string, string) ([]Exercise, error) {
+			return []Exercise{{ID: "core", IsCore: true}, {ID: "e1", OwnerUserID: "u1"}}, nil
+		},
+		trainingsByUserFn: func(context.Context, string) ([]TrainingLog, error) {
+			return []TrainingLog{{ID: "tr1"}}, nil
+		},
+		trainingStepsFn: func(_ context.Context, trainingID string) ([]TrainingStepLog, error) {
+			return []TrainingStepLog{{TrainingID: trainingID, ElapsedMillis: 1500}}, nil
+		},
+	}
+
+	t.Run("Collects owned data", func(t *testing.T) {
+		t.Parallel()
+		svc := New(store)
+
+		export, err := svc.Export(context.Background(), policy.Actor{UserID: "u1"}, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "u1", export.Profile.ID)
+		assert.Len(t, export.Workouts, 1)
+		require.Len(t, export.Templates, 1)
+		assert.Equal(t, "t1", export.Templates[0].ID)
+		require.Len(t, export.Exercises, 1)
+		assert.Equal(t, "e1", export.Exercises[0].ID)
+		require.Len(t, export.Trainings, 1)
+		assert.Equal(t, int64(1500), export.Trainings[0].Steps[0].ElapsedMillis)
+		assert.Equal(t, []APIToken{}, export.APITokens)
+	})
+
+	t.Run("Other users are forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc := New(store)
+		_, err := svc.Export(context.Background(), policy.Actor{UserID: "u2"}, "u1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Admins export any user", func(t *testing.T) {
+		t.Parallel()
+		svc := New(store)
+		export, err := svc.Export(context.Background(), policy.Actor{UserID: "admin", IsAdmin: true}, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "u1", export.Profile.ID)
+	})
+
+	t.Run("Archive holds one document per data set", func(t *testing.T) {
+		t.Parallel()
+		svc := New(store)
+		export, err := svc.Export(context.Background(), policy.Actor{UserID: "u1"}, "u1")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteArchive(&buf, export))
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(zr.File))
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"profile.json", "workouts.json", "templates.json", "exercises.json", "trainings.json", "api-tokens.json"}, names)
+
+		rc, err := zr.File[4].Open()
+		require.NoError(t, err)
+		defer rc.Close() // nolint:errcheck
+		raw, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		var trainings []Training
+		require.NoError(t, json.Unmarshal(raw, &trainings))
+		require.Len(t, trainings, 1)
+		assert.Len(t, trainings[0].Steps, 1)
+	})
+}
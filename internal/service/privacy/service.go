@@ -0,0 +1,11 @@
+package privacy
+
+// Service exports and deletes personal data.
+type Service struct {
+	store Store
+}
+
+// New creates a new privacy service.
+func New(store Store) *Service {
+	return &Service{store: store}
+}
@@ -0,0 +1,16 @@
+package privacy
+
+import "context"
+
+// Store defines persistence operations required by the privacy domain.
+type Store interface {
+	GetUserWithPassword(ctx context.Context, id string) (*User, string, error)
+	ListUsers(ctx context.Context) ([]User, error)
+	WorkoutsByUser(ctx context.Context, userID string) ([]Workout, error)
+	TemplatesByUser(ctx context.Context, userID string) ([]Workout, error)
+	ListExercises(ctx context.Context, userID, orgID string) ([]Exercise, error)
+	TrainingsByUser(ctx context.Context, userID string) ([]TrainingLog, error)
+	TrainingStepTimings(ctx context.Context, trainingID string) ([]TrainingStepLog, error)
+	ListAPITokens(ctx context.Context, userID string) ([]APIToken, error)
+	DeleteUser(ctx context.Context, userID, templateOwnerID string) error
+}
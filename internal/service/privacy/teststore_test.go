@@ -0,0 +1,78 @@
+package privacy
+
+import "context"
+
+type fakeStore struct {
+	getUserWithPasswordFn func(context.Context, string) (*User, string, error)
+	listUsersFn           func(context.Context) ([]User, error)
+	workoutsByUserFn      func(context.Context, string) ([]Workout, error)
+	templatesByUserFn     func(context.Context, string) ([]Workout, error)
+	listExercisesFn       func(context.Context, string, string) ([]Exercise, error)
+	trainingsByUserFn     func(context.Context, string) ([]TrainingLog, error)
+	trainingStepsFn       func(context.Context, string) ([]TrainingStepLog, error)
+	listAPITokensFn       func(context.Context, string) ([]APIToken, error)
+	deleteUserFn          func(context.Context, string, string) error
+}
+
+func (f *fakeStore) GetUserWithPassword(ctx context.Context, id string) (*User, string, error) {
+	if f.getUserWithPasswordFn == nil {
+		return &User{ID: id}, "", nil
+	}
+	return f.getUserWithPasswordFn(ctx, id)
+}
+
+func (f *fakeStore) ListUsers(ctx context.Context) ([]User, error) {
+	if f.listUsersFn == nil {
+		return nil, nil
+	}
+	return f.listUsersFn(ctx)
+}
+
+func (f *fakeStore) WorkoutsByUser(ctx context.Context, userID string) ([]Workout, error) {
+	if f.workoutsByUserFn == nil {
+		return nil, nil
+	}
+	return f.workoutsByUserFn(ctx, userID)
+}
+
+func (f *fakeStore) TemplatesByUser(ctx context.Context, userID string) ([]Workout, error) {
+	if f.templatesByUserFn == nil {
+		return nil, nil
+	}
+	return f.templatesByUserFn(ctx, userID)
+}
+
+func (f *fakeStore) ListExercises(ctx context.Context, userID, orgID string) ([]Exercise, error) {
+	if f.listExercisesFn == nil {
+		return nil, nil
+	}
+	return f.listExercisesFn(ctx, userID, orgID)
+}
+
+func (f *fakeStore) TrainingsByUser(ctx context.Context, userID string) ([]TrainingLog, error) {
+	if f.trainingsByUserFn == nil {
+		return nil, nil
+	}
+	return f.trainingsByUserFn(ctx, userID)
+}
+
+func (f *fakeStore) TrainingStepTimings(ctx context.Context, trainingID string) ([]TrainingStepLog, error) {
+	if f.trainingStepsFn == nil {
+		return nil, nil
+	}
+	return f.trainingStepsFn(ctx, trainingID)
+}
+
+func (f *fakeStore) ListAPITokens(ctx context.Context, userID string) ([]APIToken, error) {
+	if f.listAPITokensFn == nil {
+		return nil, nil
+	}
+	return f.listAPITokensFn(ctx, userID)
+}
+
+func (f *fakeStore) DeleteUser(ctx context.Context, userID, templateOwnerID string) error {
+	if f.deleteUserFn == nil {
+		return nil
+	}
+	return f.deleteUserFn(ctx, userID, templateOwnerID)
+}
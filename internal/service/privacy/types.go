@@ -0,0 +1,52 @@
+// Package privacy provides personal data export and account deletion.
+package privacy
+
+import (
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+// User is the domain-level DTO for users.
+type User = db.User
+
+// Workout is the domain-level DTO for workouts and templates.
+type Workout = db.Workout
+
+// Exercise is the domain-level DTO for catalog exercises.
+type Exercise = db.Exercise
+
+// TrainingLog is the domain-level DTO for completed trainings.
+type TrainingLog = db.TrainingLog
+
+// TrainingStepLog is the domain-level DTO for recorded step timings.
+type TrainingStepLog = db.TrainingStepLog
+
+// APIToken is the domain-level DTO for personal access tokens.
+type APIToken = db.APIToken
+
+// errorScope is the service error scope for privacy operations.
+const errorScope = "privacy"
+
+// Training is a completed training with its step timings.
+type Training struct {
+	TrainingLog
+	Steps []TrainingStepLog `json:"steps"`
+}
+
+// Export holds everything Motus stores about a user.
+type Export struct {
+	ExportedAt time.Time
+	Profile    User
+	Workouts   []Workout
+	Templates  []Workout
+	Exercises  []Exercise
+	Trainings  []Training
+	APITokens  []APIToken
+}
+
+// DeleteRequest confirms an account deletion.
+type DeleteRequest struct {
+	Confirm  string `json:"confirm"`  // Confirm must repeat the email address of the account.
+	Password string `json:"password"` // Password is required when users delete their own local account.
+}
@@ -0,0 +1,59 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// loadUser checks that actor may manage userID and returns the user with its password hash.
+func (s *Service) loadUser(ctx context.Context, actor policy.Actor, userID string) (*User, string, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	if err := policy.RequireOwner(actor, userID, errorScope); err != nil {
+		return nil, "", err
+	}
+	user, hash, err := s.store.GetUserWithPassword(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			return nil, "", errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
+		}
+		return nil, "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if user == nil {
+		return nil, "", errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
+	}
+	return user, hash, nil
+}
+
+// templateHeir returns the longest-standing admin other than userID, or "" if there is none.
+func templateHeir(users []User, userID string) string {
+	var heir *User
+	for i := range users {
+		candidate := &users[i]
+		if !candidate.IsAdmin || candidate.ID == userID {
+			continue
+		}
+		if heir == nil || candidate.CreatedAt.Before(heir.CreatedAt) {
+			heir = candidate
+		}
+	}
+	if heir == nil {
+		return ""
+	}
+	return heir.ID
+}
+
+// nonNil turns a nil slice into an empty one so it encodes as [] instead of null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
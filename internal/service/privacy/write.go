@@ -0,0 +1,51 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// Delete removes userID and all of its data after checking the confirmation.
+// Users deleting themselves must confirm with their password when they have one.
+// Owned templates move to the deleting admin, or to the longest-standing remaining
+// admin, and are deleted when no admin is left.
+func (s *Service) Delete(ctx context.Context, actor policy.Actor, userID string, req DeleteRequest) error {
+	user, hash, err := s.loadUser(ctx, actor, userID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(strings.TrimSpace(req.Confirm), user.ID) {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "confirm must match the account email", errorScope)
+	}
+	self := strings.TrimSpace(actor.UserID) == user.ID
+	if self && hash != "" && bcrypt.CompareHashAndPassword([]byte(hash), []byte(strings.TrimSpace(req.Password))) != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "invalid credentials", errorScope)
+	}
+
+	users, err := s.store.ListUsers(ctx)
+	if err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	heir := templateHeir(users, user.ID)
+	if user.IsAdmin && heir == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "the last admin cannot be deleted", errorScope)
+	}
+	if !self {
+		heir = actor.UserID
+	}
+
+	if err := s.store.DeleteUser(ctx, user.ID, heir); err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
+		}
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package privacy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	now := time.Now()
+	users := []User{
+		{ID: "u1"},
+		{ID: "new-admin", IsAdmin: true, CreatedAt: now},
+		{ID: "old-admin", IsAdmin: true, CreatedAt: now.Add(-time.Hour)},
+	}
+	newStore := func(deleted *[2]string) *fakeStore {
+		return &fakeStore{
+			getUserWithPasswordFn: func(_ context.Context, id string) (*User, string, error) {
+				for _, u := range users {
+					if u.ID == id {
+						return &u, string(hash), nil
+					}
+				}
+				return nil, "", db.ErrUserNotFound
+			},
+			listUsersFn: func(context.Context) ([]User, error) { return users, nil },
+			deleteUserFn: func(_ context.Context, userID, heir string) error {
+				*deleted = [2]string{userID, heir}
+				return nil
+			},
+		}
+	}
+
+	t.Run("Self deletion hands templates to the oldest admin", func(t *testing.T) {
+		t.Parallel()
+		var deleted [2]string
+		svc := New(newStore(&deleted))
+
+		err := svc.Delete(context.Background(), policy.Actor{UserID: "u1"}, "u1", DeleteRequest{Confirm: "U1", Password: "secret"})
+		require.NoError(t, err)
+		assert.Equal(t, [2]string{"u1", "old-admin"}, deleted)
+	})
+
+	t.Run("Self deletion requires password", func(t *testing.T) {
+		t.Parallel()
+		var deleted [2]string
+		svc := New(newStore(&deleted))
+
+		err := svc.Delete(context.Background(), policy.Actor{UserID: "u1"}, "u1", DeleteRequest{Confirm: "u1", Password: "wrong"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+		assert.Empty(t, deleted[0])
+	})
+
+	t.Run("Requires confirmation", func(t *testing.T) {
+		t.Parallel()
+		var deleted [2]string
+		svc := New(newStore(&deleted))
+
+		err := svc.Delete(context.Background(), policy.Actor{UserID: "u1"}, "u1", DeleteRequest{Password: "secret"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Admin deletes user and keeps templates", func(t *testing.T) {
+		t.Parallel()
+		var deleted [2]string
+		svc := New(newStore(&deleted))
+
+		err := svc.Delete(context.Background(), policy.Actor{UserID: "new-admin", IsAdmin: true}, "u1", DeleteRequest{Confirm: "u1"})
+		require.NoError(t, err)
+		assert.Equal(t, [2]string{"u1", "new-admin"}, deleted)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		t.Parallel()
+		var deleted [2]string
+		svc := New(newStore(&deleted))
+
+		err := svc.Delete(context.Background(), policy.Actor{UserID: "new-admin", IsAdmin: true}, "ghost", DeleteRequest{Confirm: "ghost"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Members cannot delete others", func(t *testing.T) {
+		t.Parallel()
+		var deleted [2]string
+		svc := New(newStore(&deleted))
+
+		err := svc.Delete(context.Background(), policy.Actor{UserID: "u1"}, "old-admin", DeleteRequest{Confirm: "old-admin"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Last admin cannot be deleted", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			getUserWithPasswordFn: func(_ context.Context, id string) (*User, string, error) {
+				return &User{ID: id, IsAdmin: true}, "", nil
+			},
+			listUsersFn: func(context.Context) ([]User, error) {
+				return []User{{ID: "admin", IsAdmin: true}, {ID: "u1"}}, nil
+			},
+		})
+
+		err := svc.Delete(context.Background(), policy.Actor{UserID: "admin", IsAdmin: true}, "admin", DeleteRequest{Confirm: "admin"})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
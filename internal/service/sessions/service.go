@@ -0,0 +1,23 @@
+package sessions
+
+import "time"
+
+// Service issues, rotates and revokes login sessions.
+type Service struct {
+	store       Store
+	ttl         time.Duration
+	rotateAfter time.Duration
+}
+
+// New creates a new sessions service. A non-positive ttl falls back to DefaultTTL.
+func New(store Store, ttl time.Duration) *Service {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Service{store: store, ttl: ttl, rotateAfter: min(ttl/2, 24*time.Hour)}
+}
+
+// TTL returns the configured session lifetime.
+func (s *Service) TTL() time.Duration {
+	return s.ttl
+}
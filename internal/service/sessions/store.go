@@ -0,0 +1,12 @@
+package sessions
+
+import "context"
+
+// Store defines persistence operations required by the sessions domain.
+type Store interface {
+	CreateSession(ctx context.Context, session Session) error
+	GetSession(ctx context.Context, id string) (*Session, error)
+	RotateSession(ctx context.Context, oldID string, next Session) error
+	RevokeSession(ctx context.Context, id string) error
+	RevokeUserSessions(ctx context.Context, userID string) (int64, error)
+}
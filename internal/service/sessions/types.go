@@ -0,0 +1,23 @@
+// Package sessions provides domain logic for server-side login sessions.
+package sessions
+
+import (
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+// Session is the domain-level DTO for login sessions.
+type Session = db.Session
+
+// errorScope is the service error scope for sessions.
+const errorScope = "sessions"
+
+// DefaultTTL is the session lifetime used when none is configured.
+const DefaultTTL = 30 * 24 * time.Hour
+
+// Issued pairs a stored session with the raw token handed to the client.
+type Issued struct {
+	Token   string  // Token is the secret sent to the client; only its hash is stored.
+	Session Session // Session is the persisted session row.
+}
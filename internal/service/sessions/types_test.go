@@ -0,0 +1,17 @@
+package sessions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Defaults TTL", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{}, 0)
+		assert.Equal(t, DefaultTTL, svc.TTL())
+	})
+}
@@ -0,0 +1,34 @@
+package sessions
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// newSession builds a fresh session and its raw token for a user.
+func newSession(userID, userAgent, ip string, ttl time.Duration) Issued {
+	token := utils.NewToken()
+	now := time.Now().UTC()
+	return Issued{
+		Token: token,
+		Session: Session{
+			ID:         utils.HashToken(token),
+			UserID:     userID,
+			UserAgent:  truncate(strings.TrimSpace(userAgent), 255),
+			IP:         strings.TrimSpace(ip),
+			CreatedAt:  now,
+			LastSeenAt: now,
+			ExpiresAt:  now.Add(ttl),
+		},
+	}
+}
+
+// truncate shortens value to at most limit bytes.
+func truncate(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	return value[:limit]
+}
@@ -0,0 +1,83 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// Create issues a new session for the user.
+func (s *Service) Create(ctx context.Context, userID, userAgent, ip string) (Issued, error) {
+	uid := utils.NormalizeToken(userID)
+	if uid == "" {
+		return Issued{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+
+	issued := newSession(uid, userAgent, ip, s.ttl)
+	if err := s.store.CreateSession(ctx, issued.Session); err != nil {
+		return Issued{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return issued, nil
+}
+
+// Rotate replaces an aging session with a new token; rotated is false when the session is still fresh.
+func (s *Service) Rotate(ctx context.Context, token string) (issued Issued, rotated bool, err error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return Issued{}, false, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "session is required", errorScope)
+	}
+
+	current, err := s.store.GetSession(ctx, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, db.ErrSessionNotFound) {
+			return Issued{}, false, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "session is invalid", errorScope)
+		}
+		return Issued{}, false, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	now := time.Now()
+	if !current.IsActive(now) {
+		return Issued{}, false, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "session is invalid", errorScope)
+	}
+	if now.Sub(current.CreatedAt) < s.rotateAfter {
+		return Issued{}, false, nil
+	}
+
+	next := newSession(current.UserID, current.UserAgent, current.IP, s.ttl)
+	if err := s.store.RotateSession(ctx, current.ID, next.Session); err != nil {
+		if errors.Is(err, db.ErrSessionNotFound) {
+			return Issued{}, false, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "session is invalid", errorScope)
+		}
+		return Issued{}, false, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return next, true, nil
+}
+
+// Revoke invalidates the session identified by token.
+func (s *Service) Revoke(ctx context.Context, token string) error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil
+	}
+	if err := s.store.RevokeSession(ctx, utils.HashToken(token)); err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
+
+// RevokeAll invalidates every active session of a user and returns how many were revoked.
+func (s *Service) RevokeAll(ctx context.Context, userID string) (int64, error) {
+	uid := utils.NormalizeToken(userID)
+	if uid == "" {
+		return 0, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	count, err := s.store.RevokeUserSessions(ctx, uid)
+	if err != nil {
+		return 0, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return count, nil
+}
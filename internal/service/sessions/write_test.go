@@ -0,0 +1,171 @@
+package sessions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+type fakeStore struct {
+	createFn    func(context.Context, Session) error
+	getFn       func(context.Context, string) (*Session, error)
+	rotateFn    func(context.Context, string, Session) error
+	revokeFn    func(context.Context, string) error
+	revokeAllFn func(context.Context, string) (int64, error)
+}
+
+func (f *fakeStore) CreateSession(ctx context.Context, session Session) error {
+	if f.createFn == nil {
+		return nil
+	}
+	return f.createFn(ctx, session)
+}
+
+func (f *fakeStore) GetSession(ctx context.Context, id string) (*Session, error) {
+	if f.getFn == nil {
+		return nil, db.ErrSessionNotFound
+	}
+	return f.getFn(ctx, id)
+}
+
+func (f *fakeStore) RotateSession(ctx context.Context, oldID string, next Session) error {
+	if f.rotateFn == nil {
+		return nil
+	}
+	return f.rotateFn(ctx, oldID, next)
+}
+
+func (f *fakeStore) RevokeSession(ctx context.Context, id string) error {
+	if f.revokeFn == nil {
+		return nil
+	}
+	return f.revokeFn(ctx, id)
+}
+
+func (f *fakeStore) RevokeUserSessions(ctx context.Context, userID string) (int64, error) {
+	if f.revokeAllFn == nil {
+		return 0, nil
+	}
+	return f.revokeAllFn(ctx, userID)
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stores hashed token", func(t *testing.T) {
+		t.Parallel()
+
+		var stored Session
+		svc := New(&fakeStore{
+			createFn: func(_ context.Context, session Session) error {
+				stored = session
+				return nil
+			},
+		}, time.Hour)
+		issued, err := svc.Create(context.Background(), "User@Example.com", "agent", "127.0.0.1")
+		require.NoError(t, err)
+		assert.NotEmpty(t, issued.Token)
+		assert.Equal(t, utils.HashToken(issued.Token), stored.ID)
+		assert.Equal(t, "user@example.com", stored.UserID)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Requires user", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, time.Hour)
+		_, err := svc.Create(context.Background(), " ", "", "")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Keeps fresh session", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			getFn: func(context.Context, string) (*Session, error) {
+				return &Session{UserID: "u", CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}, nil
+			},
+			rotateFn: func(context.Context, string, Session) error {
+				t.Fatal("unexpected rotation")
+				return nil
+			},
+		}, 48*time.Hour)
+		_, rotated, err := svc.Rotate(context.Background(), "token")
+		require.NoError(t, err)
+		assert.False(t, rotated)
+	})
+
+	t.Run("Rotates aging session", func(t *testing.T) {
+		t.Parallel()
+
+		var revoked string
+		svc := New(&fakeStore{
+			getFn: func(_ context.Context, id string) (*Session, error) {
+				return &Session{ID: id, UserID: "u", CreatedAt: time.Now().Add(-36 * time.Hour), ExpiresAt: time.Now().Add(time.Hour)}, nil
+			},
+			rotateFn: func(_ context.Context, oldID string, _ Session) error {
+				revoked = oldID
+				return nil
+			},
+		}, 48*time.Hour)
+		issued, rotated, err := svc.Rotate(context.Background(), "token")
+		require.NoError(t, err)
+		assert.True(t, rotated)
+		assert.Equal(t, utils.HashToken("token"), revoked)
+		assert.Equal(t, "u", issued.Session.UserID)
+		assert.NotEqual(t, "token", issued.Token)
+	})
+
+	t.Run("Rejects revoked session", func(t *testing.T) {
+		t.Parallel()
+
+		revokedAt := time.Now()
+		svc := New(&fakeStore{
+			getFn: func(context.Context, string) (*Session, error) {
+				return &Session{UserID: "u", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil
+			},
+		}, time.Hour)
+		_, _, err := svc.Rotate(context.Background(), "token")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+	})
+
+	t.Run("Rejects unknown session", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, time.Hour)
+		_, _, err := svc.Rotate(context.Background(), "token")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+	})
+}
+
+func TestRevokeAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Returns count", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			revokeAllFn: func(_ context.Context, userID string) (int64, error) {
+				assert.Equal(t, "user@example.com", userID)
+				return 3, nil
+			},
+		}, time.Hour)
+		count, err := svc.RevokeAll(context.Background(), "user@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+}
@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// Summary returns the totals, trends, streaks and most-trained exercises of the actor.
+func (s *Service) Summary(ctx context.Context, actor policy.Actor, query Query) (Summary, error) {
+	return s.summary(ctx, actor, query, time.Now())
+}
+
+// summary builds the statistics relative to now.
+func (s *Service) summary(ctx context.Context, actor policy.Actor, query Query, now time.Time) (Summary, error) {
+	userID := strings.TrimSpace(actor.UserID)
+	if userID == "" {
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	period, loc, err := parseQuery(query)
+	if err != nil {
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+
+	starts := periodStarts(period, now.In(loc))
+	filter := Filter{
+		UserID:   userID,
+		Period:   period,
+		TimeZone: loc.String(),
+		From:     starts[0],
+		To:       nextPeriod(period, starts[len(starts)-1]),
+	}
+	buckets, err := s.store.StatsBuckets(ctx, filter)
+	if err != nil {
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	days, err := s.store.TrainingDays(ctx, userID, filter.TimeZone)
+	if err != nil {
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	top, err := s.store.TopExercises(ctx, filter, topExerciseLimit)
+	if err != nil {
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if top == nil {
+		top = []ExerciseStats{}
+	}
+	for i := range top {
+		top[i].Volume = roundVolume(top[i].Volume)
+	}
+
+	filled := fillBuckets(starts, buckets)
+	return Summary{
+		Period:       period,
+		TimeZone:     filter.TimeZone,
+		Totals:       sumBuckets(filled),
+		Buckets:      filled,
+		Streaks:      countStreaks(days, now.In(loc)),
+		TopExercises: top,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// day returns midnight UTC of a calendar day, the way the store scans dates.
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	require.NoError(t, err)
+	// Wednesday evening in Zurich.
+	now := time.Date(2024, 5, 15, 20, 0, 0, 0, zurich)
+
+	t.Run("Weekly buckets in the requested zone", func(t *testing.T) {
+		t.Parallel()
+		var got Filter
+		store := &fakeStore{
+			bucketsFn: func(_ context.Context, filter Filter) ([]Bucket, error) {
+				got = filter
+				return []Bucket{
+					{Start: day(2024, 5, 6), Sessions: 2, TotalSeconds: 3600, WorkSeconds: 2400, PauseSeconds: 600, Volume: 1000.04, Load: 300},
+					{Start: day(2024, 5, 13), Sessions: 1, TotalSeconds: 1800, WorkSeconds: 1200, PauseSeconds: 300, Volume: 500, Load: 150},
+				}, nil
+			},
+			trainingDaysFn: func(_ context.Context, userID, timeZone string) ([]time.Time, error) {
+				assert.Equal(t, "u1", userID)
+				assert.Equal(t, "Europe/Zurich", timeZone)
+				return []time.Time{day(2024, 5, 14), day(2024, 5, 13), day(2024, 5, 10)}, nil
+			},
+		}
+		svc := New(store)
+
+		summary, err := svc.summary(context.Background(), policy.Actor{UserID: "u1"}, Query{TimeZone: "Europe/Zurich"}, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, PeriodWeek, got.Period)
+		assert.Equal(t, time.Date(2024, 2, 26, 0, 0, 0, 0, zurich), got.From)
+		assert.Equal(t, time.Date(2024, 5, 20, 0, 0, 0, 0, zurich), got.To)
+
+		require.Len(t, summary.Buckets, bucketCount)
+		assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, zurich), summary.Buckets[bucketCount-1].Start)
+		assert.Equal(t, 1, summary.Buckets[bucketCount-1].Sessions)
+		assert.Equal(t, 1000.0, summary.Buckets[bucketCount-2].Volume)
+		assert.Zero(t, summary.Buckets[0].Sessions)
+
+		assert.Equal(t, Totals{Sessions: 3, TotalSeconds: 5400, WorkSeconds: 3600, PauseSeconds: 900, Volume: 1500, Load: 450}, summary.Totals)
+		assert.Equal(t, 2, summary.Streaks.Current)
+		assert.Equal(t, 2, summary.Streaks.Longest)
+		assert.NotNil(t, summary.TopExercises)
+	})
+
+	t.Run("Monthly buckets", func(t *testing.T) {
+		t.Parallel()
+		var got Filter
+		store := &fakeStore{
+			bucketsFn: func(_ context.Context, filter Filter) ([]Bucket, error) {
+				got = filter
+				return nil, nil
+			},
+		}
+		svc := New(store)
+
+		summary, err := svc.summary(context.Background(), policy.Actor{UserID: "u1"}, Query{Period: "Month"}, now)
+		require.NoError(t, err)
+		assert.Equal(t, PeriodMonth, summary.Period)
+		assert.Equal(t, "UTC", summary.TimeZone)
+		assert.Equal(t, day(2023, 6, 1), got.From)
+		assert.Equal(t, day(2024, 6, 1), got.To)
+		assert.Equal(t, day(2024, 5, 1), summary.Buckets[bucketCount-1].Start)
+	})
+
+	t.Run("Invalid query", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+
+		for _, query := range []Query{{Period: "day"}, {TimeZone: "Mars/Olympus"}, {TimeZone: "Local"}} {
+			_, err := svc.summary(context.Background(), policy.Actor{UserID: "u1"}, query, now)
+			require.Error(t, err)
+			assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+		}
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		t.Parallel()
+		store := &fakeStore{
+			topExercisesFn: func(context.Context, Filter, int) ([]ExerciseStats, error) {
+				return nil, errors.New("boom")
+			},
+		}
+		svc := New(store)
+
+		_, err := svc.summary(context.Background(), policy.Actor{UserID: "u1"}, Query{}, now)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorInternal))
+	})
+
+	t.Run("Requires user", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+
+		_, err := svc.Summary(context.Background(), policy.Actor{}, Query{})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
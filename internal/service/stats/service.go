@@ -0,0 +1,11 @@
+package stats
+
+// Service aggregates training statistics.
+type Service struct {
+	store Store
+}
+
+// New creates a new stats service.
+func New(store Store) *Service {
+	return &Service{store: store}
+}
@@ -0,0 +1,13 @@
+package stats
+
+import (
+	"context"
+	"time"
+)
+
+// Store defines persistence operations required by the stats domain.
+type Store interface {
+	StatsBuckets(ctx context.Context, filter Filter) ([]Bucket, error)
+	TrainingDays(ctx context.Context, userID, timeZone string) ([]time.Time, error)
+	TopExercises(ctx context.Context, filter Filter, limit int) ([]ExerciseStats, error)
+}
@@ -0,0 +1,33 @@
+package stats
+
+import (
+	"context"
+	"time"
+)
+
+type fakeStore struct {
+	bucketsFn      func(context.Context, Filter) ([]Bucket, error)
+	trainingDaysFn func(context.Context, string, string) ([]time.Time, error)
+	topExercisesFn func(context.Context, Filter, int) ([]ExerciseStats, error)
+}
+
+func (f *fakeStore) StatsBuckets(ctx context.Context, filter Filter) ([]Bucket, error) {
+	if f.bucketsFn == nil {
+		return nil, nil
+	}
+	return f.bucketsFn(ctx, filter)
+}
+
+func (f *fakeStore) TrainingDays(ctx context.Context, userID, timeZone string) ([]time.Time, error) {
+	if f.trainingDaysFn == nil {
+		return nil, nil
+	}
+	return f.trainingDaysFn(ctx, userID, timeZone)
+}
+
+func (f *fakeStore) TopExercises(ctx context.Context, filter Filter, limit int) ([]ExerciseStats, error) {
+	if f.topExercisesFn == nil {
+		return nil, nil
+	}
+	return f.topExercisesFn(ctx, filter, limit)
+}
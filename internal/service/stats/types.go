@@ -0,0 +1,65 @@
+// Package stats provides training totals, trends and streaks.
+package stats
+
+import (
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+// Filter is the domain-level DTO for statistics queries.
+type Filter = db.StatsFilter
+
+// Bucket is the domain-level DTO for the totals of one period.
+type Bucket = db.StatsBucket
+
+// ExerciseStats is the domain-level DTO for per-exercise totals.
+type ExerciseStats = db.ExerciseStats
+
+// errorScope is the service error scope for statistics.
+const errorScope = "stats"
+
+// Supported bucket periods.
+const (
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// bucketCount is the number of periods a summary covers, including the current one.
+const bucketCount = 12
+
+// topExerciseLimit caps the most-trained exercises of a summary.
+const topExerciseLimit = 5
+
+// Query holds the raw, unvalidated parameters of a statistics request.
+type Query struct {
+	Period   string // Period is week (default) or month.
+	TimeZone string // TimeZone is the IANA zone used for buckets and streaks; defaults to UTC.
+}
+
+// Totals sums the buckets of a summary.
+type Totals struct {
+	Sessions     int     `json:"sessions"`
+	TotalSeconds int64   `json:"totalSeconds"`
+	WorkSeconds  int64   `json:"workSeconds"`
+	PauseSeconds int64   `json:"pauseSeconds"`
+	Volume       float64 `json:"volume"` // Volume is in kg.
+	Load         int     `json:"load"`
+}
+
+// Streaks counts consecutive training days.
+type Streaks struct {
+	Current     int        `json:"current"`               // Current is alive while the last training was today or yesterday.
+	Longest     int        `json:"longest"`               // Longest is the best streak ever.
+	LastTrained *time.Time `json:"lastTrained,omitempty"` // LastTrained is the latest training day.
+}
+
+// Summary is the statistics payload of a user.
+type Summary struct {
+	Period       string          `json:"period"`
+	TimeZone     string          `json:"timeZone"`
+	Totals       Totals          `json:"totals"`       // Totals covers every bucket.
+	Buckets      []Bucket        `json:"buckets"`      // Buckets lists every period, oldest first, including empty ones.
+	Streaks      Streaks         `json:"streaks"`      // Streaks covers the whole history.
+	TopExercises []ExerciseStats `json:"topExercises"` // TopExercises lists the exercises of most trainings in the covered periods.
+}
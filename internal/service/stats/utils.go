@@ -0,0 +1,139 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// dayLayout formats the calendar day buckets and streaks are matched on.
+const dayLayout = "2006-01-02"
+
+// parseQuery validates the raw query and resolves its period and location.
+func parseQuery(query Query) (string, *time.Location, error) {
+	period := strings.ToLower(strings.TrimSpace(query.Period))
+	switch period {
+	case "":
+		period = PeriodWeek
+	case PeriodWeek, PeriodMonth:
+	default:
+		return "", nil, errInvalid("period")
+	}
+	loc, err := utils.ParseTimeZone(query.TimeZone)
+	if err != nil {
+		return "", nil, err
+	}
+	return period, loc, nil
+}
+
+// periodStart returns the local midnight starting the period that contains t. Weeks start on Monday.
+func periodStart(period string, t time.Time) time.Time {
+	if period == PeriodMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// nextPeriod returns the start of the period after the one starting at start.
+func nextPeriod(period string, start time.Time) time.Time {
+	if period == PeriodMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+// periodStarts returns the starts of the covered periods, oldest first, ending with the one containing now.
+func periodStarts(period string, now time.Time) []time.Time {
+	starts := make([]time.Time, bucketCount)
+	current := periodStart(period, now)
+	for i := bucketCount - 1; i >= 0; i-- {
+		starts[i] = current
+		if period == PeriodMonth {
+			current = current.AddDate(0, -1, 0)
+		} else {
+			current = current.AddDate(0, 0, -7)
+		}
+	}
+	return starts
+}
+
+// fillBuckets returns one bucket per period start, using the stored totals where present.
+// Stored bucket starts carry the local wall clock as UTC, so they are matched by calendar day.
+func fillBuckets(starts []time.Time, stored []Bucket) []Bucket {
+	byDay := make(map[string]Bucket, len(stored))
+	for _, bucket := range stored {
+		byDay[bucket.Start.Format(dayLayout)] = bucket
+	}
+	buckets := make([]Bucket, 0, len(starts))
+	for _, start := range starts {
+		bucket := byDay[start.Format(dayLayout)]
+		bucket.Start = start
+		bucket.Volume = roundVolume(bucket.Volume)
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+// sumBuckets adds up the totals of buckets.
+func sumBuckets(buckets []Bucket) Totals {
+	var totals Totals
+	for _, bucket := range buckets {
+		totals.Sessions += bucket.Sessions
+		totals.TotalSeconds += bucket.TotalSeconds
+		totals.WorkSeconds += bucket.WorkSeconds
+		totals.PauseSeconds += bucket.PauseSeconds
+		totals.Volume += bucket.Volume
+		totals.Load += bucket.Load
+	}
+	totals.Volume = roundVolume(totals.Volume)
+	return totals
+}
+
+// countStreaks counts consecutive training days. days holds distinct calendar days newest first;
+// now decides whether the latest streak is still alive.
+func countStreaks(days []time.Time, now time.Time) Streaks {
+	var streaks Streaks
+	if len(days) == 0 {
+		return streaks
+	}
+	last := time.Date(days[0].Year(), days[0].Month(), days[0].Day(), 0, 0, 0, 0, now.Location())
+	streaks.LastTrained = &last
+
+	run := 0
+	for i, day := range days {
+		if i > 0 && !isDayBefore(day, days[i-1]) {
+			run = 0
+		}
+		run++
+		streaks.Longest = max(streaks.Longest, run)
+		if run == i+1 {
+			streaks.Current = run
+		}
+	}
+
+	today := now.Format(dayLayout)
+	yesterday := now.AddDate(0, 0, -1).Format(dayLayout)
+	if first := days[0].Format(dayLayout); first != today && first != yesterday {
+		streaks.Current = 0
+	}
+	return streaks
+}
+
+// isDayBefore reports whether day is the calendar day before next.
+func isDayBefore(day, next time.Time) bool {
+	return day.AddDate(0, 0, 1).Format(dayLayout) == next.Format(dayLayout)
+}
+
+// roundVolume rounds a volume to 0.1 kg.
+func roundVolume(volume float64) float64 {
+	return math.Round(volume*10) / 10
+}
+
+// errInvalid reports a malformed query parameter.
+func errInvalid(name string) error {
+	return fmt.Errorf("invalid %s", name)
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountStreaks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC)
+
+	t.Run("No trainings", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, Streaks{}, countStreaks(nil, now))
+	})
+
+	t.Run("Alive until yesterday", func(t *testing.T) {
+		t.Parallel()
+		days := []time.Time{day(2024, 5, 14), day(2024, 5, 13), day(2024, 5, 11), day(2024, 5, 10), day(2024, 5, 9)}
+
+		streaks := countStreaks(days, now)
+		assert.Equal(t, 2, streaks.Current)
+		assert.Equal(t, 3, streaks.Longest)
+		require.NotNil(t, streaks.LastTrained)
+		assert.Equal(t, day(2024, 5, 14), *streaks.LastTrained)
+	})
+
+	t.Run("Broken streak", func(t *testing.T) {
+		t.Parallel()
+		days := []time.Time{day(2024, 5, 12), day(2024, 5, 11)}
+
+		streaks := countStreaks(days, now)
+		assert.Zero(t, streaks.Current)
+		assert.Equal(t, 2, streaks.Longest)
+	})
+
+	t.Run("Across month ends", func(t *testing.T) {
+		t.Parallel()
+		days := []time.Time{day(2024, 3, 1), day(2024, 2, 29), day(2024, 2, 28)}
+
+		streaks := countStreaks(days, time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC))
+		assert.Equal(t, 3, streaks.Current)
+		assert.Equal(t, 3, streaks.Longest)
+	})
+}
+
+func TestPeriodStart(t *testing.T) {
+	t.Parallel()
+
+	sunday := time.Date(2024, 5, 19, 23, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), periodStart(PeriodWeek, sunday))
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), periodStart(PeriodMonth, sunday))
+
+	monday := time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, monday, periodStart(PeriodWeek, monday))
+}
@@ -0,0 +1,26 @@
+package summary
+
+import (
+	"embed"
+	"text/template"
+)
+
+//go:embed formats/*.tmpl
+var formatFiles embed.FS
+
+// builtins maps the built-in formats to their labels. Each format is rendered by formats/<format>.tmpl.
+var builtins = map[string]string{
+	FormatText:     "Plain text",
+	FormatMarkdown: "Markdown",
+	FormatAICoach:  "AI coach prompt",
+}
+
+// builtinTemplate returns the template of a built-in format. All built-in formats are
+// parsed together so one can include another.
+func builtinTemplate(format string, funcs template.FuncMap) (*template.Template, error) {
+	set, err := template.New("").Funcs(funcs).ParseFS(formatFiles, "formats/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	return set.Lookup(format + ".tmpl"), nil
+}
@@ -0,0 +1,114 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"text/template"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/service/trainings"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// Render renders a training of the actor in a built-in format or through one of the actor's templates.
+func (s *Service) Render(ctx context.Context, actor policy.Actor, trainingID string, query Query) (Summary, error) {
+	trainingID = strings.TrimSpace(trainingID)
+	if trainingID == "" {
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "training id is required", errorScope)
+	}
+	loc, err := utils.ParseTimeZone(query.TimeZone)
+	if err != nil {
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+
+	log, err := s.store.GetTraining(ctx, trainingID)
+	if err != nil {
+		if errors.Is(err, db.ErrTrainingNotFound) {
+			return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if err := policy.RequireOwner(actor, log.UserID, errorScope); err != nil {
+		return Summary{}, err
+	}
+
+	summary, tpl, err := s.resolveFormat(ctx, actor, query.Format, templateFuncs(loc))
+	if err != nil {
+		return Summary{}, err
+	}
+
+	steps, err := s.store.TrainingStepTimings(ctx, log.ID)
+	if err != nil {
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	items := trainings.BuildTrainingHistoryItems([]TrainingLog{*log}, map[string][]TrainingStepLog{log.ID: steps})
+	summary.Text, err = render(ctx, tpl, items[0])
+	if err != nil {
+		return Summary{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+	return summary, nil
+}
+
+// List returns the summary templates of the actor.
+func (s *Service) List(ctx context.Context, actor policy.Actor) ([]Template, error) {
+	userID := strings.TrimSpace(actor.UserID)
+	if userID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	templates, err := s.store.ListSummaryTemplates(ctx, userID)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if templates == nil {
+		templates = []Template{}
+	}
+	return templates, nil
+}
+
+// resolveFormat returns the template of a built-in format or of a template the actor owns.
+func (s *Service) resolveFormat(ctx context.Context, actor policy.Actor, format string, funcs template.FuncMap) (Summary, *template.Template, error) {
+	format = strings.TrimSpace(format)
+	if format == "" {
+		format = FormatText
+	}
+	if name, ok := builtins[strings.ToLower(format)]; ok {
+		format = strings.ToLower(format)
+		tpl, err := builtinTemplate(format, funcs)
+		if err != nil {
+			return Summary{}, nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		}
+		return Summary{Format: format, Name: name}, tpl, nil
+	}
+
+	stored, err := s.loadTemplate(ctx, actor, format)
+	if err != nil {
+		return Summary{}, nil, err
+	}
+	tpl, err := parseTemplate(stored.ID, stored.Body, funcs)
+	if err != nil {
+		return Summary{}, nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+	return Summary{Format: stored.ID, Name: stored.Name}, tpl, nil
+}
+
+// loadTemplate fetches a summary template the actor owns.
+func (s *Service) loadTemplate(ctx context.Context, actor policy.Actor, id string) (*Template, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "template id is required", errorScope)
+	}
+	tpl, err := s.store.GetSummaryTemplate(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrSummaryTemplateNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if err := policy.RequireOwner(actor, tpl.UserID, errorScope); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
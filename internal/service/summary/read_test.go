@@ -0,0 +1,171 @@
+package summary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// summaryStore returns a store holding one logged training of u1.
+func summaryStore() *fakeStore {
+	store := newFakeStore()
+	started := time.Date(2024, 5, 1, 16, 0, 0, 0, time.UTC)
+	store.trainings["t1"] = TrainingLog{
+		ID:          "t1",
+		WorkoutID:   "w1",
+		WorkoutName: "Legs | Core",
+		UserID:      "u1",
+		StartedAt:   started,
+		CompletedAt: started.Add(30*time.Minute + 5*time.Second),
+		Notes:       "Knees felt fine.",
+		RPE:         8,
+	}
+	store.steps["t1"] = []TrainingStepLog{
+		{
+			ID: "t1-0", TrainingID: "t1", Type: "set", Name: "Squat", EstimatedSeconds: 60, ElapsedMillis: 65_000,
+			Exercises: []db.TrainingStepExercise{
+				{Name: "Squat", Reps: 5, Weight: 100, WeightUnit: "kg", Status: db.ExerciseCompleted},
+				{Name: "Squat", Reps: 3, Weight: 102.5, WeightUnit: "kg", Status: db.ExerciseFailed},
+			},
+		},
+		{ID: "t1-1", TrainingID: "t1", Type: "pause", Name: "Rest", EstimatedSeconds: 90, ElapsedMillis: 91_500},
+	}
+	return store
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	actor := policy.Actor{UserID: "u1"}
+
+	t.Run("Plain text by default", func(t *testing.T) {
+		t.Parallel()
+		svc := New(summaryStore())
+
+		summary, err := svc.Render(context.Background(), actor, "t1", Query{TimeZone: "Europe/Zurich"})
+		require.NoError(t, err)
+		assert.Equal(t, FormatText, summary.Format)
+		assert.Equal(t, "Plain text", summary.Name)
+		assert.Equal(t, `Workout: Legs | Core
+User: u1
+Started: 2024-05-01 18:00 CEST
+Finished: 2024-05-01 18:30 CEST (00:30:05)
+RPE: 8/10 (load 241)
+Steps:
+1. Squat (target 60s, actual 01:05) — 5 × Squat @ 100 kg | 3 × Squat @ 102.5 kg (failed)
+2. Rest (target 90s, actual 01:31)
+Notes: Knees felt fine.
+`, summary.Text)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+		svc := New(summaryStore())
+
+		summary, err := svc.Render(context.Background(), actor, "t1", Query{Format: "Markdown"})
+		require.NoError(t, err)
+		assert.Equal(t, FormatMarkdown, summary.Format)
+		assert.Equal(t, `# Legs | Core
+
+- **Started:** 2024-05-01 16:00 UTC
+- **Finished:** 2024-05-01 16:30 UTC
+- **Duration:** 00:30:05
+- **RPE:** 8/10 (load 241)
+
+## Steps
+
+| # | Step | Target | Actual |
+| - | ---- | ------ | ------ |
+| 1 | Squat | 01:00 | 01:05 |
+| 2 | Rest | 01:30 | 01:31 |
+
+## Results
+
+- **Squat:** 5 × Squat @ 100 kg | 3 × Squat @ 102.5 kg (failed)
+
+## Notes
+
+Knees felt fine.
+`, summary.Text)
+	})
+
+	t.Run("AI coach prompt includes the plain text", func(t *testing.T) {
+		t.Parallel()
+		svc := New(summaryStore())
+
+		summary, err := svc.Render(context.Background(), actor, "t1", Query{Format: FormatAICoach})
+		require.NoError(t, err)
+		assert.Contains(t, summary.Text, "strength and conditioning coach")
+		assert.Contains(t, summary.Text, "1. Squat (target 60s, actual 01:05)")
+	})
+
+	t.Run("Training without steps", func(t *testing.T) {
+		t.Parallel()
+		store := summaryStore()
+		delete(store.steps, "t1")
+		svc := New(store)
+
+		summary, err := svc.Render(context.Background(), actor, "t1", Query{})
+		require.NoError(t, err)
+		assert.Contains(t, summary.Text, "Steps:\nNo steps available.\n")
+	})
+
+	t.Run("User template", func(t *testing.T) {
+		t.Parallel()
+		store := summaryStore()
+		store.templates["tpl1"] = Template{ID: "tpl1", UserID: "u1", Name: "Short", Body: "{{.WorkoutName}}: {{clockHours .Duration}}{{range .Steps}} / {{.Name}}{{end}}"}
+		svc := New(store)
+
+		summary, err := svc.Render(context.Background(), actor, "t1", Query{Format: "tpl1"})
+		require.NoError(t, err)
+		assert.Equal(t, Summary{Format: "tpl1", Name: "Short", Text: "Legs | Core: 00:30:05 / Squat / Rest\n"}, summary)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		store := summaryStore()
+		store.trainings["t2"] = TrainingLog{ID: "t2", UserID: "u2"}
+		store.templates["other"] = Template{ID: "other", UserID: "u2", Name: "Other", Body: "x"}
+		svc := New(store)
+
+		_, err := svc.Render(context.Background(), actor, "t2", Query{})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+
+		_, err = svc.Render(context.Background(), actor, "missing", Query{})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+
+		_, err = svc.Render(context.Background(), actor, "t1", Query{Format: "html"})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+
+		_, err = svc.Render(context.Background(), actor, "t1", Query{Format: "other"})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+
+		_, err = svc.Render(context.Background(), actor, "t1", Query{TimeZone: "Mars/Olympus"})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeStore()
+	store.templates["tpl1"] = Template{ID: "tpl1", UserID: "u1", Name: "Mine"}
+	store.templates["tpl2"] = Template{ID: "tpl2", UserID: "u2", Name: "Theirs"}
+	svc := New(store)
+
+	templates, err := svc.List(context.Background(), policy.Actor{UserID: "u1"})
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "tpl1", templates[0].ID)
+
+	templates, err = svc.List(context.Background(), policy.Actor{UserID: "u3"})
+	require.NoError(t, err)
+	assert.NotNil(t, templates)
+}
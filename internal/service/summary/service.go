@@ -0,0 +1,11 @@
+package summary
+
+// Service renders training summaries and manages user-defined summary templates.
+type Service struct {
+	store Store
+}
+
+// New creates a new summary service.
+func New(store Store) *Service {
+	return &Service{store: store}
+}
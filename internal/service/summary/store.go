@@ -0,0 +1,14 @@
+package summary
+
+import "context"
+
+// Store defines persistence operations required by the summary domain.
+type Store interface {
+	GetTraining(ctx context.Context, id string) (*TrainingLog, error)
+	TrainingStepTimings(ctx context.Context, trainingID string) ([]TrainingStepLog, error)
+	CreateSummaryTemplate(ctx context.Context, tpl Template) error
+	ListSummaryTemplates(ctx context.Context, userID string) ([]Template, error)
+	GetSummaryTemplate(ctx context.Context, id string) (*Template, error)
+	UpdateSummaryTemplate(ctx context.Context, tpl Template) error
+	DeleteSummaryTemplate(ctx context.Context, id string) error
+}
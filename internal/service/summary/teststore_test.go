@@ -0,0 +1,76 @@
+package summary
+
+import (
+	"context"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+type fakeStore struct {
+	trainings map[string]TrainingLog
+	steps     map[string][]TrainingStepLog
+	templates map[string]Template
+	createFn  func(context.Context, Template) error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		trainings: map[string]TrainingLog{},
+		steps:     map[string][]TrainingStepLog{},
+		templates: map[string]Template{},
+	}
+}
+
+func (f *fakeStore) GetTraining(_ context.Context, id string) (*TrainingLog, error) {
+	log, ok := f.trainings[id]
+	if !ok {
+		return nil, db.ErrTrainingNotFound
+	}
+	return &log, nil
+}
+
+func (f *fakeStore) TrainingStepTimings(_ context.Context, trainingID string) ([]TrainingStepLog, error) {
+	return f.steps[trainingID], nil
+}
+
+func (f *fakeStore) CreateSummaryTemplate(ctx context.Context, tpl Template) error {
+	if f.createFn != nil {
+		return f.createFn(ctx, tpl)
+	}
+	f.templates[tpl.ID] = tpl
+	return nil
+}
+
+func (f *fakeStore) ListSummaryTemplates(_ context.Context, userID string) ([]Template, error) {
+	var templates []Template
+	for _, tpl := range f.templates {
+		if tpl.UserID == userID {
+			templates = append(templates, tpl)
+		}
+	}
+	return templates, nil
+}
+
+func (f *fakeStore) GetSummaryTemplate(_ context.Context, id string) (*Template, error) {
+	tpl, ok := f.templates[id]
+	if !ok {
+		return nil, db.ErrSummaryTemplateNotFound
+	}
+	return &tpl, nil
+}
+
+func (f *fakeStore) UpdateSummaryTemplate(_ context.Context, tpl Template) error {
+	if _, ok := f.templates[tpl.ID]; !ok {
+		return db.ErrSummaryTemplateNotFound
+	}
+	f.templates[tpl.ID] = tpl
+	return nil
+}
+
+func (f *fakeStore) DeleteSummaryTemplate(_ context.Context, id string) error {
+	if _, ok := f.templates[id]; !ok {
+		return db.ErrSummaryTemplateNotFound
+	}
+	delete(f.templates, id)
+	return nil
+}
@@ -0,0 +1,82 @@
+// Package summary renders completed trainings as copyable text through text/template.
+package summary
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/trainings"
+)
+
+// Template is the domain-level DTO for user-defined summary templates.
+type Template = db.SummaryTemplate
+
+// TrainingLog is the domain-level DTO for completed training logs.
+type TrainingLog = db.TrainingLog
+
+// TrainingStepLog is the domain-level DTO for training step timing logs.
+type TrainingStepLog = db.TrainingStepLog
+
+// TrainingStepExercise is the domain-level DTO for logged exercise results.
+type TrainingStepExercise = db.TrainingStepExercise
+
+// TrainingHistoryItem is the training a summary renders.
+type TrainingHistoryItem = trainings.TrainingHistoryItem
+
+// errorScope is the service error scope for summaries.
+const errorScope = "summary"
+
+// Built-in formats.
+const (
+	FormatText     = "text"
+	FormatMarkdown = "markdown"
+	FormatAICoach  = "ai"
+)
+
+// Limits of user-defined templates and of rendering them.
+const (
+	maxNameLength  = 100
+	maxBodyBytes   = 16 << 10
+	maxOutputBytes = 64 << 10
+	maxIterations  = 100_000
+	renderTimeout  = 2 * time.Second
+	maxRenders     = 8
+)
+
+// tickFunc is the function parseTemplate calls at the start of every loop iteration.
+const tickFunc = "renderTick"
+
+var (
+	errOutputTooLarge    = fmt.Errorf("summary must be at most %d bytes", maxOutputBytes)
+	errRenderTimeout     = errors.New("summary took too long to render")
+	errTooManyIterations = fmt.Errorf("summary may loop at most %d times", maxIterations)
+)
+
+// Query holds the raw, unvalidated parameters of a summary request.
+type Query struct {
+	Format   string // Format is a built-in format or the id of a user template; defaults to text.
+	TimeZone string // TimeZone is the IANA zone timestamps are shown in; defaults to UTC.
+}
+
+// Summary is a rendered training summary.
+type Summary struct {
+	Format string `json:"format"` // Format is the built-in format or template id used.
+	Name   string `json:"name"`   // Name is the label of the format.
+	Text   string `json:"text"`   // Text is the rendered summary.
+}
+
+// TemplateRequest describes the payload for creating or changing a summary template.
+type TemplateRequest struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+// Data is what summary templates render. The training fields are available directly,
+// for example {{.WorkoutName}} or {{range .Steps}}.
+type Data struct {
+	TrainingHistoryItem
+	Duration   time.Duration // Duration is the time from start to completion.
+	HasResults bool          // HasResults reports whether any step logged exercise results.
+}
@@ -0,0 +1,264 @@
+package summary
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+// parseTemplate parses a summary template with the summary functions. Every loop and template
+// body starts with a call to tickFunc, so render can count iterations and stop abandoned renders
+// even when they write nothing.
+func parseTemplate(name, body string, funcs template.FuncMap) (*template.Template, error) {
+	funcs = maps.Clone(funcs)
+	funcs[tickFunc] = func() (string, error) { return "", nil }
+	tpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	probe, err := template.New("").Funcs(funcs).Parse("{{" + tickFunc + "}}")
+	if err != nil {
+		return nil, err
+	}
+	tick := probe.Tree.Root.Nodes[0]
+	for _, t := range tpl.Templates() {
+		if t.Tree != nil {
+			instrument(t.Tree.Root, tick)
+		}
+	}
+	return tpl, nil
+}
+
+// instrument prepends tick to list and to the body of every range below it.
+func instrument(list *parse.ListNode, tick parse.Node) {
+	if list == nil {
+		return
+	}
+	for _, node := range list.Nodes {
+		switch n := node.(type) {
+		case *parse.IfNode:
+			instrument(n.List, tick)
+			instrument(n.ElseList, tick)
+		case *parse.WithNode:
+			instrument(n.List, tick)
+			instrument(n.ElseList, tick)
+		case *parse.RangeNode:
+			instrument(n.List, tick)
+			instrument(n.ElseList, tick)
+		case *parse.ListNode:
+			instrument(n, tick)
+		}
+	}
+	list.Nodes = append([]parse.Node{tick}, list.Nodes...)
+}
+
+// renderSlots bounds the renders running at once.
+var renderSlots = make(chan struct{}, maxRenders)
+
+// render executes tpl for a training within renderTimeout and maxOutputBytes.
+func render(ctx context.Context, tpl *template.Template, item TrainingHistoryItem) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, renderTimeout)
+	defer cancel()
+
+	select {
+	case renderSlots <- struct{}{}:
+	case <-ctx.Done():
+		return "", renderError(ctx)
+	}
+	tpl, err := tpl.Clone()
+	if err != nil {
+		<-renderSlots
+		return "", err
+	}
+	budget := &renderBudget{ctx: ctx, left: maxIterations}
+	tpl.Funcs(template.FuncMap{tickFunc: budget.tick})
+
+	out := &limitedWriter{ctx: ctx, limit: maxOutputBytes}
+	done := make(chan error, 1)
+	go func() {
+		defer func() { <-renderSlots }()
+		done <- tpl.Execute(out, newData(item))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(out.buf.String()) + "\n", nil
+	case <-ctx.Done():
+		return "", renderError(ctx)
+	}
+}
+
+// renderError reports why ctx ended a render.
+func renderError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errRenderTimeout
+	}
+	return ctx.Err()
+}
+
+// renderBudget counts the loop iterations and template calls of one render.
+type renderBudget struct {
+	ctx  context.Context
+	left int
+}
+
+// tick spends one iteration and fails once the budget is used up or the render was abandoned.
+func (b *renderBudget) tick() (string, error) {
+	if b.ctx.Err() != nil {
+		return "", errRenderTimeout
+	}
+	if b.left <= 0 {
+		return "", errTooManyIterations
+	}
+	b.left--
+	return "", nil
+}
+
+// limitedWriter buffers template output and fails once it exceeds limit or ctx is done.
+type limitedWriter struct {
+	ctx   context.Context
+	buf   bytes.Buffer
+	limit int
+}
+
+// Write appends p unless the output grows too large or the render was abandoned.
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.ctx.Err() != nil {
+		return 0, errRenderTimeout
+	}
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errOutputTooLarge
+	}
+	return w.buf.Write(p)
+}
+
+// newData wraps a training with the values derived for templates.
+func newData(item TrainingHistoryItem) Data {
+	data := Data{TrainingHistoryItem: item}
+	if item.StartedAt != nil && item.CompletedAt != nil && !item.CompletedAt.Before(*item.StartedAt) {
+		data.Duration = item.CompletedAt.Sub(*item.StartedAt)
+	}
+	for _, step := range item.Steps {
+		if len(step.Exercises) > 0 {
+			data.HasResults = true
+			break
+		}
+	}
+	return data
+}
+
+// sampleData is the training user templates are checked against when they are saved.
+func sampleData() TrainingHistoryItem {
+	started := time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC)
+	completed := started.Add(45 * time.Minute)
+	return TrainingHistoryItem{
+		ID:          "sample",
+		TrainingID:  "sample",
+		WorkoutID:   "sample",
+		WorkoutName: "Sample",
+		UserID:      "user@example.com",
+		StartedAt:   &started,
+		CompletedAt: &completed,
+		Notes:       "Felt strong.",
+		RPE:         7,
+		Load:        315,
+		Steps: []TrainingStepLog{{
+			ID:               "sample-0",
+			TrainingID:       "sample",
+			Type:             "set",
+			Name:             "Squat",
+			EstimatedSeconds: 60,
+			ElapsedMillis:    65_000,
+			Exercises: []db.TrainingStepExercise{{
+				Name: "Squat", Reps: 5, Weight: 100, WeightUnit: db.WeightUnitKg, Status: db.ExerciseCompleted,
+			}},
+		}},
+	}
+}
+
+// templateFuncs returns the functions available to summary templates; timestamps are shown in loc.
+func templateFuncs(loc *time.Location) template.FuncMap {
+	return template.FuncMap{
+		"timestamp": func(t *time.Time) string {
+			if t == nil || t.IsZero() {
+				return "n/a"
+			}
+			return t.In(loc).Format("2006-01-02 15:04 MST")
+		},
+		"clock":      clock,
+		"clockHours": clockHours,
+		"seconds":    func(seconds int) time.Duration { return time.Duration(seconds) * time.Second },
+		"millis":     func(millis int64) time.Duration { return time.Duration(millis) * time.Millisecond },
+		"inc":        func(i int) int { return i + 1 },
+		"timing":     timing,
+		"result":     result,
+		"results":    results,
+		"cell":       func(value string) string { return strings.ReplaceAll(value, "|", `\|`) },
+	}
+}
+
+// clock formats a duration as MM:SS.
+func clock(d time.Duration) string {
+	total := max(int(d/time.Second), 0)
+	return fmt.Sprintf("%02d:%02d", total/60, total%60)
+}
+
+// clockHours formats a duration as HH:MM:SS.
+func clockHours(d time.Duration) string {
+	total := max(int(d/time.Second), 0)
+	return fmt.Sprintf("%02d:%02d:%02d", total/3600, total%3600/60, total%60)
+}
+
+// timing describes the target and actual time of a step, such as "target 60s, actual 01:05".
+func timing(step TrainingStepLog) string {
+	var parts []string
+	if step.EstimatedSeconds > 0 {
+		parts = append(parts, fmt.Sprintf("target %ds", step.EstimatedSeconds))
+	}
+	if step.ElapsedMillis > 0 {
+		parts = append(parts, "actual "+clock(time.Duration(step.ElapsedMillis)*time.Millisecond))
+	}
+	if step.RPE > 0 {
+		parts = append(parts, fmt.Sprintf("RPE %d", step.RPE))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// result describes what was performed for one exercise, such as "5 × Squat @ 100 kg".
+func result(ex TrainingStepExercise) string {
+	text := ex.Name
+	if ex.Reps > 0 {
+		text = fmt.Sprintf("%d × %s", ex.Reps, ex.Name)
+	}
+	if ex.Weight > 0 {
+		text += " @ " + strconv.FormatFloat(ex.Weight, 'f', -1, 64) + " " + ex.WeightUnit
+	}
+	if ex.DurationSeconds > 0 {
+		text += " " + clock(time.Duration(ex.DurationSeconds)*time.Second)
+	}
+	if ex.Status != "" && ex.Status != db.ExerciseCompleted {
+		text += " (" + ex.Status + ")"
+	}
+	return text
+}
+
+// results joins the exercise results of a step.
+func results(step TrainingStepLog) string {
+	parts := make([]string, 0, len(step.Exercises))
+	for _, ex := range step.Exercises {
+		parts = append(parts, result(ex))
+	}
+	return strings.Join(parts, " | ")
+}
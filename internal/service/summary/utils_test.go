@@ -0,0 +1,113 @@
+package summary
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderLimits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Output within the limit", func(t *testing.T) {
+		t.Parallel()
+		tpl, err := parseTemplate("ok", "{{.WorkoutName}}", templateFuncs(time.UTC))
+		require.NoError(t, err)
+
+		text, err := render(context.Background(), tpl, sampleData())
+		require.NoError(t, err)
+		assert.Equal(t, "Sample\n", text)
+	})
+
+	t.Run("Stops large output", func(t *testing.T) {
+		t.Parallel()
+		body := "{{range 1000000000}}" + strings.Repeat("x", 1024) + "{{end}}"
+		tpl, err := parseTemplate("large", body, templateFuncs(time.UTC))
+		require.NoError(t, err)
+
+		_, err = render(context.Background(), tpl, sampleData())
+		assert.ErrorIs(t, err, errOutputTooLarge)
+	})
+
+	t.Run("Stops loops without output", func(t *testing.T) {
+		t.Parallel()
+		tpl, err := parseTemplate("loop", "{{range 100000000000000}}{{end}}", templateFuncs(time.UTC))
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = render(context.Background(), tpl, sampleData())
+		assert.ErrorIs(t, err, errTooManyIterations)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Stops loops the sample training skips", func(t *testing.T) {
+		t.Parallel()
+		body := `{{if ne .WorkoutName "Sample"}}{{range 100000000000000}}{{end}}{{end}}`
+		tpl, err := parseTemplate("hidden", body, templateFuncs(time.UTC))
+		require.NoError(t, err)
+		_, err = render(context.Background(), tpl, sampleData())
+		require.NoError(t, err)
+
+		item := sampleData()
+		item.WorkoutName = "Real"
+		_, err = render(context.Background(), tpl, item)
+		assert.ErrorIs(t, err, errTooManyIterations)
+	})
+
+	t.Run("Stops recursive templates", func(t *testing.T) {
+		t.Parallel()
+		body := `{{define "a"}}{{template "a" .}}{{template "a" .}}{{end}}{{template "a" .}}`
+		tpl, err := parseTemplate("recursive", body, templateFuncs(time.UTC))
+		require.NoError(t, err)
+
+		_, err = render(context.Background(), tpl, sampleData())
+		assert.Error(t, err)
+	})
+
+	t.Run("Abandoned loops stop running", func(t *testing.T) {
+		t.Parallel()
+		var calls atomic.Int64
+		funcs := templateFuncs(time.UTC)
+		funcs["wait"] = func() string {
+			calls.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return ""
+		}
+		tpl, err := parseTemplate("quiet", "{{range 1000}}{{$_ := wait}}{{end}}", funcs)
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err = render(ctx, tpl, sampleData())
+		assert.ErrorIs(t, err, errRenderTimeout)
+
+		time.Sleep(50 * time.Millisecond)
+		stopped := calls.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, stopped, calls.Load())
+	})
+
+	t.Run("Stops at the deadline", func(t *testing.T) {
+		t.Parallel()
+		funcs := templateFuncs(time.UTC)
+		funcs["wait"] = func() string {
+			time.Sleep(50 * time.Millisecond)
+			return "x"
+		}
+		tpl, err := template.New("slow").Funcs(funcs).Parse("{{range 100}}{{wait}}{{end}}")
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err = render(ctx, tpl, sampleData())
+		assert.ErrorIs(t, err, errRenderTimeout)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
@@ -0,0 +1,106 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// Create stores a new summary template for the actor.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, req TemplateRequest) (Template, error) {
+	userID := strings.TrimSpace(actor.UserID)
+	if userID == "" {
+		return Template{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	name, err := validateTemplate(ctx, req)
+	if err != nil {
+		return Template{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+
+	now := time.Now().UTC()
+	tpl := Template{
+		ID:        utils.NewID(),
+		UserID:    userID,
+		Name:      name,
+		Body:      req.Body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.store.CreateSummaryTemplate(ctx, tpl); err != nil {
+		return Template{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return tpl, nil
+}
+
+// Update replaces the name and body of a summary template the actor owns.
+func (s *Service) Update(ctx context.Context, actor policy.Actor, id string, req TemplateRequest) (Template, error) {
+	tpl, err := s.loadTemplate(ctx, actor, id)
+	if err != nil {
+		return Template{}, err
+	}
+	name, err := validateTemplate(ctx, req)
+	if err != nil {
+		return Template{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+
+	tpl.Name = name
+	tpl.Body = req.Body
+	tpl.UpdatedAt = time.Now().UTC()
+	if err := s.store.UpdateSummaryTemplate(ctx, *tpl); err != nil {
+		return Template{}, mapTemplateError(err)
+	}
+	return *tpl, nil
+}
+
+// Delete removes a summary template the actor owns.
+func (s *Service) Delete(ctx context.Context, actor policy.Actor, id string) error {
+	tpl, err := s.loadTemplate(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteSummaryTemplate(ctx, tpl.ID); err != nil {
+		return mapTemplateError(err)
+	}
+	return nil
+}
+
+// validateTemplate checks the name and body of a template and returns the trimmed name.
+// The body must parse and render the sample training within the render limits.
+func validateTemplate(ctx context.Context, req TemplateRequest) (string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+	if len(name) > maxNameLength {
+		return "", fmt.Errorf("name must be at most %d characters", maxNameLength)
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return "", errors.New("body is required")
+	}
+	if len(req.Body) > maxBodyBytes {
+		return "", fmt.Errorf("body must be at most %d bytes", maxBodyBytes)
+	}
+	tpl, err := parseTemplate(name, req.Body, templateFuncs(time.UTC))
+	if err != nil {
+		return "", err
+	}
+	if _, err := render(ctx, tpl, sampleData()); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// mapTemplateError converts store errors to service errors.
+func mapTemplateError(err error) error {
+	if errors.Is(err, db.ErrSummaryTemplateNotFound) {
+		return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+	}
+	return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+}
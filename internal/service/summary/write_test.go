@@ -0,0 +1,84 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+func TestTemplates(t *testing.T) {
+	t.Parallel()
+
+	actor := policy.Actor{UserID: "u1"}
+
+	t.Run("Create, update and delete", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		svc := New(store)
+
+		created, err := svc.Create(context.Background(), actor, TemplateRequest{Name: " Short ", Body: "{{.WorkoutName}}"})
+		require.NoError(t, err)
+		assert.Equal(t, "Short", created.Name)
+		assert.Equal(t, "u1", created.UserID)
+		assert.NotEmpty(t, created.ID)
+
+		updated, err := svc.Update(context.Background(), actor, created.ID, TemplateRequest{Name: "Shorter", Body: "{{.UserID}}"})
+		require.NoError(t, err)
+		assert.Equal(t, "Shorter", updated.Name)
+		assert.Equal(t, "{{.UserID}}", store.templates[created.ID].Body)
+		assert.Equal(t, created.CreatedAt, updated.CreatedAt)
+
+		require.NoError(t, svc.Delete(context.Background(), actor, created.ID))
+		assert.Empty(t, store.templates)
+	})
+
+	t.Run("Rejects invalid templates", func(t *testing.T) {
+		t.Parallel()
+		svc := New(newFakeStore())
+
+		for name, req := range map[string]TemplateRequest{
+			"no name":       {Body: "x"},
+			"no body":       {Name: "x", Body: " "},
+			"long name":     {Name: strings.Repeat("a", maxNameLength+1), Body: "x"},
+			"large body":    {Name: "x", Body: strings.Repeat("a", maxBodyBytes+1)},
+			"syntax error":  {Name: "x", Body: "{{.WorkoutName"},
+			"unknown field": {Name: "x", Body: "{{.Workout}}"},
+			"unknown func":  {Name: "x", Body: "{{shout .WorkoutName}}"},
+			"large output":  {Name: "x", Body: "{{range 100000}}{{$.WorkoutName}}{{end}}"},
+			"endless loop":  {Name: "x", Body: "{{range 100000000000000}}{{end}}"},
+		} {
+			_, err := svc.Create(context.Background(), actor, req)
+			require.Error(t, err, name)
+			assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation), name)
+		}
+	})
+
+	t.Run("Only the owner changes a template", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		store.templates["tpl1"] = Template{ID: "tpl1", UserID: "u2", Name: "Theirs", Body: "x"}
+		svc := New(store)
+
+		_, err := svc.Update(context.Background(), actor, "tpl1", TemplateRequest{Name: "Mine", Body: "y"})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+		assert.True(t, errpkg.IsKind(svc.Delete(context.Background(), actor, "tpl1"), errpkg.ErrorForbidden))
+		assert.True(t, errpkg.IsKind(svc.Delete(context.Background(), actor, "missing"), errpkg.ErrorNotFound))
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		t.Parallel()
+		store := newFakeStore()
+		store.createFn = func(context.Context, Template) error { return errors.New("boom") }
+		svc := New(store)
+
+		_, err := svc.Create(context.Background(), actor, TemplateRequest{Name: "x", Body: "x"})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorInternal))
+	})
+}
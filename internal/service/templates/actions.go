@@ -4,20 +4,21 @@ import (
 	"context"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
-// Apply clones a template into a new workout.
-func (s *Service) Apply(ctx context.Context, templateID, userID, name string) (*Workout, error) {
-	tid, err := requireID(templateID, "template id is required")
+// Apply clones a template the actor can see into a new workout of the actor.
+func (s *Service) Apply(ctx context.Context, actor policy.Actor, templateID, name string) (*Workout, error) {
+	uid, err := requireID(actor.UserID, "userId is required")
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
-	uid, err := requireID(userID, "userId is required")
+	template, err := s.Get(ctx, actor, templateID)
 	if err != nil {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+		return nil, err
 	}
 
-	workout, err := s.store.CreateWorkoutFromTemplate(ctx, tid, uid, name)
+	workout, err := s.store.CreateWorkoutFromTemplate(ctx, template.ID, uid, name)
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
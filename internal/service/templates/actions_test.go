@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
 func TestApply(t *testing.T) {
@@ -17,7 +18,7 @@ func TestApply(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeTemplateStore{})
-		_, err := svc.Apply(context.Background(), " ", "user", "Name")
+		_, err := svc.Apply(context.Background(), policy.Actor{UserID: "user"}, " ", "Name")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
 	})
@@ -26,11 +27,14 @@ func TestApply(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeTemplateStore{
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "tmpl", IsTemplate: true}, nil
+			},
 			createWorkoutFromTemplate: func(context.Context, string, string, string) (*Workout, error) {
 				return &Workout{ID: "new", Name: "Copy"}, nil
 			},
 		})
-		workout, err := svc.Apply(context.Background(), "tmpl", "user", "Copy")
+		workout, err := svc.Apply(context.Background(), policy.Actor{UserID: "user"}, "tmpl", "Copy")
 		require.NoError(t, err)
 		assert.Equal(t, "new", workout.ID)
 	})
@@ -4,19 +4,25 @@ import (
 	"context"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/organizations"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
-// List returns all shared templates.
-func (s *Service) List(ctx context.Context) ([]Workout, error) {
-	list, err := s.store.ListTemplates(ctx)
+// List returns the global templates plus those of the requested or active organization.
+func (s *Service) List(ctx context.Context, actor policy.Actor, orgID string) ([]Workout, error) {
+	orgID, err := organizations.ResolveContext(ctx, s.store, actor, orgID, errorScope)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.store.ListTemplates(ctx, orgID)
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
 	return list, nil
 }
 
-// Get returns a template by id.
-func (s *Service) Get(ctx context.Context, id string) (*Workout, error) {
+// Get returns a template by id. Organization templates are hidden from non-members.
+func (s *Service) Get(ctx context.Context, actor policy.Actor, id string) (*Workout, error) {
 	tid, err := requireID(id, "template id is required")
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
@@ -29,5 +35,13 @@ func (s *Service) Get(ctx context.Context, id string) (*Workout, error) {
 	if template == nil || !template.IsTemplate {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "template not found", errorScope)
 	}
+	if template.OrgID != "" {
+		if err := organizations.RequireMember(ctx, s.store, actor, template.OrgID, errorScope); err != nil {
+			if errpkg.IsKind(err, errpkg.ErrorForbidden) {
+				return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "template not found", errorScope)
+			}
+			return nil, err
+		}
+	}
 	return template, nil
 }
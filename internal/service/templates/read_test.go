@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
 func TestList(t *testing.T) {
@@ -18,14 +19,56 @@ func TestList(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeTemplateStore{
-			listTemplatesFn: func(context.Context) ([]Workout, error) {
+			listTemplatesFn: func(context.Context, string) ([]Workout, error) {
 				return nil, errors.New("boom")
 			},
 		})
-		_, err := svc.List(context.Background())
+		_, err := svc.List(context.Background(), policy.Actor{UserID: "u1"}, "")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorInternal))
 	})
+
+	t.Run("Uses the active organization", func(t *testing.T) {
+		t.Parallel()
+
+		var gotOrg string
+		svc := New(&fakeTemplateStore{
+			listTemplatesFn: func(_ context.Context, orgID string) ([]Workout, error) {
+				gotOrg = orgID
+				return nil, nil
+			},
+			orgRoles:  map[string]string{"o1/u1": "member"},
+			activeOrg: "o1",
+		})
+		_, err := svc.List(context.Background(), policy.Actor{UserID: "u1"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "o1", gotOrg)
+	})
+
+	t.Run("Falls back to global templates after leaving", func(t *testing.T) {
+		t.Parallel()
+
+		gotOrg := "unset"
+		svc := New(&fakeTemplateStore{
+			listTemplatesFn: func(_ context.Context, orgID string) ([]Workout, error) {
+				gotOrg = orgID
+				return nil, nil
+			},
+			activeOrg: "o1",
+		})
+		_, err := svc.List(context.Background(), policy.Actor{UserID: "u1"}, "")
+		require.NoError(t, err)
+		assert.Empty(t, gotOrg)
+	})
+
+	t.Run("Rejects foreign organization filter", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeTemplateStore{})
+		_, err := svc.List(context.Background(), policy.Actor{UserID: "u1"}, "o2")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
 }
 
 func TestGet(t *testing.T) {
@@ -39,7 +82,20 @@ func TestGet(t *testing.T) {
 				return &Workout{ID: "w1", IsTemplate: false}, nil
 			},
 		})
-		_, err := svc.Get(context.Background(), "w1")
+		_, err := svc.Get(context.Background(), policy.Actor{UserID: "u1"}, "w1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Hides organization template from outsiders", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeTemplateStore{
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "t1", IsTemplate: true, OrgID: "o1"}, nil
+			},
+		})
+		_, err := svc.Get(context.Background(), policy.Actor{UserID: "u1"}, "t1")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
 	})
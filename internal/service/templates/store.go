@@ -1,11 +1,16 @@
 package templates
 
-import "context"
+import (
+	"context"
+
+	"github.com/gi8lino/motus/internal/service/organizations"
+)
 
 // Store defines persistence used by the template domain.
 type Store interface {
-	ListTemplates(ctx context.Context) ([]Workout, error)
-	CreateTemplateFromWorkout(ctx context.Context, workoutID, name string) (*Workout, error)
+	organizations.MembershipStore
+	ListTemplates(ctx context.Context, orgID string) ([]Workout, error)
+	CreateTemplateFromWorkout(ctx context.Context, workoutID, name, orgID string) (*Workout, error)
 	WorkoutWithSteps(ctx context.Context, id string) (*Workout, error)
 	CreateWorkoutFromTemplate(ctx context.Context, templateID, userID, name string) (*Workout, error)
 }
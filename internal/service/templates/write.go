@@ -2,18 +2,43 @@ package templates
 
 import (
 	"context"
+	"errors"
+	"strings"
 
+	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/organizations"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
-// Create marks a workout as a template.
-func (s *Service) Create(ctx context.Context, workoutID, name string) (*Workout, error) {
+// Create turns a copy of a workout owned by the actor into a template.
+// With orgID the template is shared within that organization, which needs an editor role.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, workoutID, name, orgID string) (*Workout, error) {
 	wid, err := requireID(workoutID, "workoutId is required")
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
 
-	template, err := s.store.CreateTemplateFromWorkout(ctx, wid, name)
+	workout, err := s.store.WorkoutWithSteps(ctx, wid)
+	if err != nil {
+		if errors.Is(err, db.ErrWorkoutNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if workout == nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "workout not found", errorScope)
+	}
+	if err := policy.RequireOwner(actor, workout.UserID, errorScope); err != nil {
+		return nil, err
+	}
+	if orgID = strings.TrimSpace(orgID); orgID != "" {
+		if err := organizations.RequireEditor(ctx, s.store, actor, orgID, errorScope); err != nil {
+			return nil, err
+		}
+	}
+
+	template, err := s.store.CreateTemplateFromWorkout(ctx, wid, name, orgID)
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
@@ -8,27 +8,38 @@ import (
 	"github.com/stretchr/testify/require"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
 type fakeTemplateStore struct {
-	listTemplatesFn           func(context.Context) ([]Workout, error)
-	createTemplateFn          func(context.Context, string, string) (*Workout, error)
+	listTemplatesFn           func(context.Context, string) ([]Workout, error)
+	createTemplateFn          func(context.Context, string, string, string) (*Workout, error)
 	workoutWithStepsFn        func(context.Context, string) (*Workout, error)
 	createWorkoutFromTemplate func(context.Context, string, string, string) (*Workout, error)
+	orgRoles                  map[string]string // orgRoles maps "org/user" to an organization role.
+	activeOrg                 string
 }
 
-func (f *fakeTemplateStore) ListTemplates(ctx context.Context) ([]Workout, error) {
+func (f *fakeTemplateStore) OrgRole(_ context.Context, orgID, userID string) (string, error) {
+	return f.orgRoles[orgID+"/"+userID], nil
+}
+
+func (f *fakeTemplateStore) ActiveOrganization(context.Context, string) (string, error) {
+	return f.activeOrg, nil
+}
+
+func (f *fakeTemplateStore) ListTemplates(ctx context.Context, orgID string) ([]Workout, error) {
 	if f.listTemplatesFn == nil {
 		return nil, nil
 	}
-	return f.listTemplatesFn(ctx)
+	return f.listTemplatesFn(ctx, orgID)
 }
 
-func (f *fakeTemplateStore) CreateTemplateFromWorkout(ctx context.Context, workoutID, name string) (*Workout, error) {
+func (f *fakeTemplateStore) CreateTemplateFromWorkout(ctx context.Context, workoutID, name, orgID string) (*Workout, error) {
 	if f.createTemplateFn == nil {
 		return nil, nil
 	}
-	return f.createTemplateFn(ctx, workoutID, name)
+	return f.createTemplateFn(ctx, workoutID, name, orgID)
 }
 
 func (f *fakeTemplateStore) WorkoutWithSteps(ctx context.Context, id string) (*Workout, error) {
@@ -52,8 +63,76 @@ func TestCreate(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeTemplateStore{})
-		_, err := svc.Create(context.Background(), " ", "Name")
+		_, err := svc.Create(context.Background(), policy.Actor{UserID: "u1"}, " ", "Name", "")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
 	})
+	t.Run("Forbids foreign workout", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		svc := New(&fakeTemplateStore{
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "w1", UserID: "u1"}, nil
+			},
+			createTemplateFn: func(context.Context, string, string, string) (*Workout, error) {
+				called = true
+				return &Workout{ID: "t1"}, nil
+			},
+		})
+		_, err := svc.Create(context.Background(), policy.Actor{UserID: "u2"}, "w1", "Name", "")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+		assert.False(t, called)
+	})
+
+	t.Run("Owner creates template", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeTemplateStore{
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "w1", UserID: "u1"}, nil
+			},
+			createTemplateFn: func(context.Context, string, string, string) (*Workout, error) {
+				return &Workout{ID: "t1"}, nil
+			},
+		})
+		template, err := svc.Create(context.Background(), policy.Actor{UserID: "u1"}, "w1", "Name", "")
+		require.NoError(t, err)
+		assert.Equal(t, "t1", template.ID)
+	})
+
+	t.Run("Organization template needs an editor", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeTemplateStore{
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "w1", UserID: "u1"}, nil
+			},
+			orgRoles: map[string]string{"o1/u1": "member"},
+		})
+		_, err := svc.Create(context.Background(), policy.Actor{UserID: "u1"}, "w1", "Name", "o1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Editor shares template in organization", func(t *testing.T) {
+		t.Parallel()
+
+		var gotOrg string
+		svc := New(&fakeTemplateStore{
+			workoutWithStepsFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "w1", UserID: "u1"}, nil
+			},
+			createTemplateFn: func(_ context.Context, _, _, orgID string) (*Workout, error) {
+				gotOrg = orgID
+				return &Workout{ID: "t1", OrgID: orgID}, nil
+			},
+			orgRoles: map[string]string{"o1/u1": "editor"},
+		})
+		template, err := svc.Create(context.Background(), policy.Actor{UserID: "u1"}, "w1", "Name", "o1")
+		require.NoError(t, err)
+		assert.Equal(t, "o1", gotOrg)
+		assert.Equal(t, "o1", template.OrgID)
+	})
 }
@@ -0,0 +1,24 @@
+package tokens
+
+import (
+	"context"
+	"strings"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+)
+
+// List returns the active tokens of a user.
+func (s *Service) List(ctx context.Context, userID string) ([]APIToken, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	tokens, err := s.store.ListAPITokens(ctx, userID)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if tokens == nil {
+		tokens = []APIToken{}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,11 @@
+package tokens
+
+// Service issues, lists and revokes personal API tokens.
+type Service struct {
+	store Store
+}
+
+// New creates a new tokens service.
+func New(store Store) *Service {
+	return &Service{store: store}
+}
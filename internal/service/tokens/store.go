@@ -0,0 +1,10 @@
+package tokens
+
+import "context"
+
+// Store defines persistence operations required by the tokens domain.
+type Store interface {
+	CreateAPIToken(ctx context.Context, token APIToken) error
+	ListAPITokens(ctx context.Context, userID string) ([]APIToken, error)
+	RevokeAPIToken(ctx context.Context, userID, id string) error
+}
@@ -0,0 +1,30 @@
+package tokens
+
+import "context"
+
+type fakeStore struct {
+	createFn func(context.Context, APIToken) error
+	listFn   func(context.Context, string) ([]APIToken, error)
+	revokeFn func(context.Context, string, string) error
+}
+
+func (f *fakeStore) CreateAPIToken(ctx context.Context, token APIToken) error {
+	if f.createFn == nil {
+		return nil
+	}
+	return f.createFn(ctx, token)
+}
+
+func (f *fakeStore) ListAPITokens(ctx context.Context, userID string) ([]APIToken, error) {
+	if f.listFn == nil {
+		return nil, nil
+	}
+	return f.listFn(ctx, userID)
+}
+
+func (f *fakeStore) RevokeAPIToken(ctx context.Context, userID, id string) error {
+	if f.revokeFn == nil {
+		return nil
+	}
+	return f.revokeFn(ctx, userID, id)
+}
@@ -0,0 +1,37 @@
+// Package tokens provides domain logic for personal API tokens.
+package tokens
+
+import (
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
+
+// APIToken is the domain-level DTO for personal access tokens.
+type APIToken = db.APIToken
+
+// errorScope is the service error scope for tokens.
+const errorScope = "tokens"
+
+// Token scopes. Each scope implies the ones before it: write includes read, admin includes write.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+// TokenPrefix marks secrets issued by Motus so they are easy to recognize.
+const TokenPrefix = "motus_"
+
+// CreateRequest describes the payload for issuing a token.
+type CreateRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// Created pairs a stored token with the raw secret, which is only returned once.
+type Created struct {
+	APIToken
+	Token string `json:"token"`
+}
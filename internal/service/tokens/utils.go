@@ -0,0 +1,50 @@
+package tokens
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// scopeRank orders scopes so higher scopes imply lower ones.
+var scopeRank = map[string]int{
+	ScopeRead:  1,
+	ScopeWrite: 2,
+	ScopeAdmin: 3,
+}
+
+// Allows reports whether the granted scopes cover the required scope.
+func Allows(granted []string, required string) bool {
+	need, ok := scopeRank[required]
+	if !ok {
+		return false
+	}
+	for _, scope := range granted {
+		if scopeRank[scope] >= need {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeScopes lowercases, validates and de-duplicates scopes, defaulting to read.
+func normalizeScopes(scopes []string) ([]string, error) {
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		clean := strings.ToLower(strings.TrimSpace(scope))
+		if clean == "" {
+			continue
+		}
+		if _, ok := scopeRank[clean]; !ok {
+			return nil, fmt.Errorf("unknown scope %q", scope)
+		}
+		if !slices.Contains(out, clean) {
+			out = append(out, clean)
+		}
+	}
+	if len(out) == 0 {
+		out = append(out, ScopeRead)
+	}
+	slices.SortFunc(out, func(a, b string) int { return scopeRank[a] - scopeRank[b] })
+	return out, nil
+}
@@ -0,0 +1,57 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		granted  []string
+		required string
+		want     bool
+	}{
+		{name: "read allows read", granted: []string{ScopeRead}, required: ScopeRead, want: true},
+		{name: "read denies write", granted: []string{ScopeRead}, required: ScopeWrite, want: false},
+		{name: "write implies read", granted: []string{ScopeWrite}, required: ScopeRead, want: true},
+		{name: "admin implies write", granted: []string{ScopeAdmin}, required: ScopeWrite, want: true},
+		{name: "write denies admin", granted: []string{ScopeRead, ScopeWrite}, required: ScopeAdmin, want: false},
+		{name: "unknown required scope", granted: []string{ScopeAdmin}, required: "other", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, Allows(tc.granted, tc.required))
+		})
+	}
+}
+
+func TestNormalizeScopes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Defaults to read", func(t *testing.T) {
+		t.Parallel()
+		scopes, err := normalizeScopes(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{ScopeRead}, scopes)
+	})
+
+	t.Run("Cleans and orders", func(t *testing.T) {
+		t.Parallel()
+		scopes, err := normalizeScopes([]string{" Write", "read", "write"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{ScopeRead, ScopeWrite}, scopes)
+	})
+
+	t.Run("Rejects unknown scope", func(t *testing.T) {
+		t.Parallel()
+		_, err := normalizeScopes([]string{"delete"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// Create issues a new token for the actor and returns its secret once.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, req CreateRequest) (Created, error) {
+	userID := strings.TrimSpace(actor.UserID)
+	name := strings.TrimSpace(req.Name)
+	if userID == "" {
+		return Created{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	if name == "" {
+		return Created{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "name is required", errorScope)
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return Created{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+	if Allows(scopes, ScopeAdmin) {
+		if err := policy.RequireAdmin(actor, errorScope); err != nil {
+			return Created{}, err
+		}
+	}
+
+	now := time.Now().UTC()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return Created{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "expiresAt must be in the future", errorScope)
+	}
+
+	secret := TokenPrefix + utils.NewToken()
+	token := APIToken{
+		ID:        utils.NewID(),
+		UserID:    userID,
+		Name:      name,
+		TokenHash: utils.HashToken(secret),
+		Prefix:    secret[:len(TokenPrefix)+6],
+		Scopes:    scopes,
+		CreatedAt: now,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.store.CreateAPIToken(ctx, token); err != nil {
+		return Created{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return Created{APIToken: token, Token: secret}, nil
+}
+
+// Revoke invalidates one of the user's tokens.
+func (s *Service) Revoke(ctx context.Context, userID, id string) error {
+	userID = strings.TrimSpace(userID)
+	id = strings.TrimSpace(id)
+	if userID == "" || id == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id and token id are required", errorScope)
+	}
+	if err := s.store.RevokeAPIToken(ctx, userID, id); err != nil {
+		if errors.Is(err, db.ErrAPITokenNotFound) {
+			return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		}
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
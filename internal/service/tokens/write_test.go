@@ -0,0 +1,77 @@
+package tokens
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stores hashed secret", func(t *testing.T) {
+		t.Parallel()
+		var stored APIToken
+		svc := New(&fakeStore{createFn: func(_ context.Context, token APIToken) error {
+			stored = token
+			return nil
+		}})
+
+		created, err := svc.Create(context.Background(), policy.Actor{UserID: "u1"}, CreateRequest{Name: "ci", Scopes: []string{"write"}})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Token, TokenPrefix))
+		assert.Equal(t, utils.HashToken(created.Token), stored.TokenHash)
+		assert.NotContains(t, stored.TokenHash, created.Token)
+		assert.True(t, strings.HasPrefix(created.Token, stored.Prefix))
+		assert.Equal(t, []string{ScopeWrite}, stored.Scopes)
+		assert.Equal(t, "u1", stored.UserID)
+	})
+
+	t.Run("Admin scope requires admin", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		_, err := svc.Create(context.Background(), policy.Actor{UserID: "u1"}, CreateRequest{Name: "ops", Scopes: []string{"admin"}})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Rejects past expiry", func(t *testing.T) {
+		t.Parallel()
+		past := time.Now().Add(-time.Hour)
+		svc := New(&fakeStore{})
+		_, err := svc.Create(context.Background(), policy.Actor{UserID: "u1"}, CreateRequest{Name: "ci", ExpiresAt: &past})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Requires name", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		_, err := svc.Create(context.Background(), policy.Actor{UserID: "u1"}, CreateRequest{Name: " "})
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Maps missing token", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{revokeFn: func(context.Context, string, string) error {
+			return db.ErrAPITokenNotFound
+		}})
+		err := svc.Revoke(context.Background(), "u1", "t1")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+}
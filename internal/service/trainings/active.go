@@ -0,0 +1,279 @@
+package trainings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// Active returns the training the actor has in progress, with elapsed time brought up to date.
+func (s *Service) Active(ctx context.Context, actor policy.Actor) (TrainingState, error) {
+	stored, err := s.store.ActiveTrainingForUser(ctx, actor.UserID)
+	if err != nil {
+		return TrainingState{}, mapActiveError(err)
+	}
+	state, err := decodeState(stored)
+	if err != nil {
+		return TrainingState{}, err
+	}
+	accumulate(&state, time.Now().UTC())
+	return state, nil
+}
+
+// Start begins a created training and runs its first step.
+func (s *Service) Start(ctx context.Context, actor policy.Actor, trainingID string) (TrainingState, error) {
+	return s.transition(ctx, actor, trainingID, func(state *TrainingState, at time.Time) error {
+		if !state.StartedAt.IsZero() {
+			return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "training already started", errorScope)
+		}
+		state.StartedAt = at
+		run(state, at)
+		return nil
+	})
+}
+
+// Pause stops the clock of a running training without completing the current step.
+func (s *Service) Pause(ctx context.Context, actor policy.Actor, trainingID string) (TrainingState, error) {
+	return s.transition(ctx, actor, trainingID, func(state *TrainingState, at time.Time) error {
+		if err := requireStarted(state); err != nil {
+			return err
+		}
+		if state.Running {
+			state.Running = false
+			state.PausedAt = &at
+			state.Steps[state.CurrentIndex].Running = false
+		}
+		return nil
+	})
+}
+
+// Resume continues a paused training.
+func (s *Service) Resume(ctx context.Context, actor policy.Actor, trainingID string) (TrainingState, error) {
+	return s.transition(ctx, actor, trainingID, func(state *TrainingState, at time.Time) error {
+		if err := requireStarted(state); err != nil {
+			return err
+		}
+		run(state, at)
+		return nil
+	})
+}
+
+// Advance completes the current step and moves to the next one; after the last step the training is done.
+// A non-empty from only advances while the training is still at that step index, so devices that
+// advance at the same moment do not skip a step.
+func (s *Service) Advance(ctx context.Context, actor policy.Actor, trainingID, from string) (TrainingState, error) {
+	index := -1
+	if from = strings.TrimSpace(from); from != "" {
+		n, err := strconv.Atoi(from)
+		if err != nil || n < 0 {
+			return TrainingState{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "from must be a step index", errorScope)
+		}
+		index = n
+	}
+	return s.transition(ctx, actor, trainingID, func(state *TrainingState, at time.Time) error {
+		if err := requireStarted(state); err != nil {
+			return err
+		}
+		if index >= 0 && index != state.CurrentIndex {
+			return nil
+		}
+		advance(state, at)
+		return nil
+	})
+}
+
+// Abort discards a training in progress without logging it.
+func (s *Service) Abort(ctx context.Context, actor policy.Actor, trainingID string) (TrainingState, error) {
+	state, err := s.loadActive(ctx, actor, trainingID)
+	if err != nil {
+		return TrainingState{}, err
+	}
+	if err := s.store.DeleteActiveTraining(ctx, state.TrainingID); err != nil {
+		return TrainingState{}, mapActiveError(err)
+	}
+	return state, nil
+}
+
+// saveNew stores a freshly created training as the actor's training in progress.
+func (s *Service) saveNew(ctx context.Context, state TrainingState) error {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if err := s.store.CreateActiveTraining(ctx, ActiveTraining{
+		ID:        state.TrainingID,
+		UserID:    state.UserID,
+		WorkoutID: state.WorkoutID,
+		State:     payload,
+		CreatedAt: state.UpdatedAt,
+		UpdatedAt: state.UpdatedAt,
+	}); err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
+
+// transition loads a training, applies change at the current server time and stores the result.
+// When another command changed the training in the meantime, change is applied again to the fresh state.
+func (s *Service) transition(ctx context.Context, actor policy.Actor, trainingID string, change func(*TrainingState, time.Time) error) (TrainingState, error) {
+	for attempt := 1; ; attempt++ {
+		state, err := s.tryTransition(ctx, actor, trainingID, change)
+		if !errors.Is(err, db.ErrActiveTrainingConflict) {
+			return state, err
+		}
+		if attempt == transitionAttempts {
+			return TrainingState{}, errpkg.NewErrorWithScope(errpkg.ErrorConflict, err.Error(), errorScope)
+		}
+	}
+}
+
+// tryTransition applies change once; it returns db.ErrActiveTrainingConflict when the stored training changed after it was read.
+func (s *Service) tryTransition(ctx context.Context, actor policy.Actor, trainingID string, change func(*TrainingState, time.Time) error) (TrainingState, error) {
+	stored, err := s.loadStored(ctx, actor, trainingID)
+	if err != nil {
+		return TrainingState{}, err
+	}
+	state, err := decodeState(stored)
+	if err != nil {
+		return TrainingState{}, err
+	}
+	if state.Done {
+		return TrainingState{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "training is already done", errorScope)
+	}
+	if len(state.Steps) == 0 {
+		return TrainingState{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "training has no steps", errorScope)
+	}
+
+	at := time.Now().UTC().Truncate(time.Microsecond)
+	if !at.After(stored.UpdatedAt) {
+		// Keep updated_at increasing so it identifies each stored version.
+		at = stored.UpdatedAt.Truncate(time.Microsecond).Add(time.Microsecond)
+	}
+	accumulate(&state, at)
+	if err := change(&state, at); err != nil {
+		return TrainingState{}, err
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return TrainingState{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if err := s.store.UpdateActiveTraining(ctx, ActiveTraining{ID: state.TrainingID, State: payload, UpdatedAt: at}, stored.UpdatedAt); err != nil {
+		if errors.Is(err, db.ErrActiveTrainingConflict) {
+			return TrainingState{}, err
+		}
+		return TrainingState{}, mapActiveError(err)
+	}
+	return state, nil
+}
+
+// loadActive fetches a training in progress owned by the actor.
+func (s *Service) loadActive(ctx context.Context, actor policy.Actor, trainingID string) (TrainingState, error) {
+	stored, err := s.loadStored(ctx, actor, trainingID)
+	if err != nil {
+		return TrainingState{}, err
+	}
+	return decodeState(stored)
+}
+
+// loadStored fetches the stored row of a training in progress owned by the actor.
+func (s *Service) loadStored(ctx context.Context, actor policy.Actor, trainingID string) (*ActiveTraining, error) {
+	trainingID = strings.TrimSpace(trainingID)
+	if trainingID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "trainingId is required", errorScope)
+	}
+	stored, err := s.store.GetActiveTraining(ctx, trainingID)
+	if err != nil {
+		return nil, mapActiveError(err)
+	}
+	if err := policy.RequireOwner(actor, stored.UserID, errorScope); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// decodeState unmarshals the stored state of a training in progress.
+func decodeState(stored *ActiveTraining) (TrainingState, error) {
+	var state TrainingState
+	if err := json.Unmarshal(stored.State, &state); err != nil {
+		return TrainingState{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return state, nil
+}
+
+// mapActiveError maps store errors for trainings in progress to service errors.
+func mapActiveError(err error) error {
+	if errors.Is(err, db.ErrActiveTrainingNotFound) {
+		return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+	}
+	return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+}
+
+// requireStarted rejects changes to trainings that were not started yet.
+func requireStarted(state *TrainingState) error {
+	if state.StartedAt.IsZero() {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "training has not started", errorScope)
+	}
+	return nil
+}
+
+// accumulate adds the time since the last change to the running step and moves the change marker to at.
+func accumulate(state *TrainingState, at time.Time) {
+	if state.Running && state.CurrentIndex < len(state.Steps) && at.After(state.UpdatedAt) {
+		state.Steps[state.CurrentIndex].ElapsedMillis += at.Sub(state.UpdatedAt).Milliseconds()
+	}
+	state.UpdatedAt = at
+}
+
+// run starts the clock on the current step.
+func run(state *TrainingState, at time.Time) {
+	state.Running = true
+	state.PausedAt = nil
+	step := &state.Steps[state.CurrentIndex]
+	step.Running = true
+	if step.StartedAt == nil {
+		step.StartedAt = &at
+	}
+}
+
+// advance completes the current step and runs the next one if the training was running.
+func advance(state *TrainingState, at time.Time) {
+	step := &state.Steps[state.CurrentIndex]
+	step.Running = false
+	step.Current = false
+	step.Completed = true
+	step.CompletedAt = &at
+
+	if state.CurrentIndex == len(state.Steps)-1 {
+		finish(state, at)
+		return
+	}
+	state.CurrentIndex++
+	state.Steps[state.CurrentIndex].Current = true
+	if state.Running {
+		run(state, at)
+	}
+}
+
+// finish marks a training as done at the given time.
+func finish(state *TrainingState, at time.Time) {
+	if state.CurrentIndex < len(state.Steps) {
+		step := &state.Steps[state.CurrentIndex]
+		step.Running = false
+		if !step.Completed {
+			step.Completed = true
+			step.CompletedAt = &at
+		}
+	}
+	state.Running = false
+	state.PausedAt = nil
+	state.Done = true
+	state.CompletedAt = at
+}
@@ -0,0 +1,265 @@
+package trainings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// newActiveService returns a service whose store serves workout w1 with two set steps owned by u1.
+func newActiveService() (*Service, *fakeStore) {
+	store := &fakeStore{
+		workoutFn: func(context.Context, string) (*Workout, error) {
+			return &Workout{ID: "w1", UserID: "u1", Name: "Workout", Steps: []WorkoutStep{
+				{ID: "s1", Type: "set", Name: "Squats", Subsets: []WorkoutSubset{{ID: "sub1", Exercises: []SubsetExercise{{ExerciseID: "squat", Name: "Squat", Type: "rep"}}}}},
+				{ID: "s2", Type: "pause", Name: "Rest", EstimatedSeconds: 30},
+			}}, nil
+		},
+	}
+	return New(store, func(string) string { return "" }), store
+}
+
+func TestActiveTrainingFlow(t *testing.T) {
+	t.Parallel()
+
+	owner := policy.Actor{UserID: "u1"}
+
+	t.Run("Create persists the state", func(t *testing.T) {
+		t.Parallel()
+		svc, store := newActiveService()
+
+		state, err := svc.CreateState(context.Background(), owner, "w1")
+		require.NoError(t, err)
+		require.Contains(t, store.active, state.TrainingID)
+
+		active, err := svc.Active(context.Background(), owner)
+		require.NoError(t, err)
+		assert.Equal(t, state.TrainingID, active.TrainingID)
+		assert.Len(t, active.Steps, 2)
+	})
+
+	t.Run("Start, pause, resume and advance", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newActiveService()
+		ctx := context.Background()
+		created, err := svc.CreateState(ctx, owner, "w1")
+		require.NoError(t, err)
+
+		state, err := svc.Start(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+		assert.True(t, state.Running)
+		assert.False(t, state.StartedAt.IsZero())
+		require.NotNil(t, state.Steps[0].StartedAt)
+
+		_, err = svc.Start(ctx, owner, created.TrainingID)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+
+		state, err = svc.Pause(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+		assert.False(t, state.Running)
+		assert.NotNil(t, state.PausedAt)
+
+		state, err = svc.Resume(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+		assert.True(t, state.Running)
+		assert.Nil(t, state.PausedAt)
+
+		state, err = svc.Advance(ctx, owner, created.TrainingID, "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, state.CurrentIndex)
+		assert.True(t, state.Steps[0].Completed)
+		assert.True(t, state.Steps[1].Running)
+
+		state, err = svc.Advance(ctx, owner, created.TrainingID, "")
+		require.NoError(t, err)
+		assert.True(t, state.Done)
+		assert.False(t, state.CompletedAt.IsZero())
+
+		_, err = svc.Advance(ctx, owner, created.TrainingID, "")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Advance from a stale step is ignored", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newActiveService()
+		ctx := context.Background()
+		created, err := svc.CreateState(ctx, owner, "w1")
+		require.NoError(t, err)
+		_, err = svc.Start(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+
+		state, err := svc.Advance(ctx, owner, created.TrainingID, "0")
+		require.NoError(t, err)
+		assert.Equal(t, 1, state.CurrentIndex)
+
+		state, err = svc.Advance(ctx, owner, created.TrainingID, "0")
+		require.NoError(t, err)
+		assert.Equal(t, 1, state.CurrentIndex)
+		assert.False(t, state.Done)
+
+		_, err = svc.Advance(ctx, owner, created.TrainingID, "next")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Advance requires a started training", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newActiveService()
+		created, err := svc.CreateState(context.Background(), owner, "w1")
+		require.NoError(t, err)
+
+		_, err = svc.Advance(context.Background(), owner, created.TrainingID, "")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Foreign trainings are forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newActiveService()
+		created, err := svc.CreateState(context.Background(), owner, "w1")
+		require.NoError(t, err)
+
+		_, err = svc.Start(context.Background(), policy.Actor{UserID: "u2"}, created.TrainingID)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Abort discards the training", func(t *testing.T) {
+		t.Parallel()
+		svc, store := newActiveService()
+		created, err := svc.CreateState(context.Background(), owner, "w1")
+		require.NoError(t, err)
+
+		_, err = svc.Abort(context.Background(), owner, created.TrainingID)
+		require.NoError(t, err)
+		assert.Empty(t, store.active)
+
+		_, err = svc.Active(context.Background(), owner)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Complete uses the stored state", func(t *testing.T) {
+		t.Parallel()
+		svc, store := newActiveService()
+		ctx := context.Background()
+		var recorded []TrainingStepLog
+		store.recordFn = func(_ context.Context, _ TrainingLog, steps []TrainingStepLog) (bool, error) {
+			recorded = steps
+			return true, nil
+		}
+		created, err := svc.CreateState(ctx, owner, "w1")
+		require.NoError(t, err)
+		_, err = svc.Start(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+
+		log, records, err := svc.RecordTraining(ctx, owner, CompleteRequest{
+			TrainingID: created.TrainingID,
+			WorkoutID:  "w1",
+			UserID:     "u1",
+			Steps: []TrainingStepState{
+				{ID: "client", Name: "Client only", ElapsedMillis: 999_999},
+				{ID: "s1-sub-1-ex-1", Results: []ExerciseResult{{Reps: 10, Weight: 60}}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Workout", log.WorkoutName)
+		require.Len(t, recorded, 2)
+		assert.Equal(t, "Squat", recorded[0].Name)
+		require.Len(t, recorded[0].Exercises, 1)
+		assert.Equal(t, 10, recorded[0].Exercises[0].Reps)
+		assert.Equal(t, "squat", recorded[0].Exercises[0].ExerciseID)
+		assert.Len(t, records, 3)
+		assert.Len(t, store.records, 3)
+	})
+}
+
+func TestActiveTrainingRace(t *testing.T) {
+	t.Parallel()
+
+	owner := policy.Actor{UserID: "u1"}
+
+	// startRacing returns a started training whose store runs race before every state write.
+	startRacing := func(t *testing.T, race func(svc *Service, trainingID string)) (*Service, string) {
+		t.Helper()
+		svc, store := newActiveService()
+		created, err := svc.CreateState(context.Background(), owner, "w1")
+		require.NoError(t, err)
+		_, err = svc.Start(context.Background(), owner, created.TrainingID)
+		require.NoError(t, err)
+
+		racing := false
+		store.beforeUpdateActive = func() {
+			if racing {
+				return
+			}
+			racing = true
+			defer func() { racing = false }()
+			race(svc, created.TrainingID)
+		}
+		return svc, created.TrainingID
+	}
+
+	t.Run("Concurrent commands are both applied", func(t *testing.T) {
+		t.Parallel()
+		raced := false
+		svc, trainingID := startRacing(t, func(svc *Service, trainingID string) {
+			if raced {
+				return
+			}
+			raced = true
+			_, err := svc.Pause(context.Background(), owner, trainingID)
+			require.NoError(t, err)
+		})
+
+		state, err := svc.Advance(context.Background(), owner, trainingID, "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, state.CurrentIndex)
+		assert.False(t, state.Running, "pause was overwritten")
+
+		active, err := svc.Active(context.Background(), owner)
+		require.NoError(t, err)
+		assert.Equal(t, 1, active.CurrentIndex)
+		assert.False(t, active.Running)
+	})
+
+	t.Run("Gives up with a conflict", func(t *testing.T) {
+		t.Parallel()
+		svc, trainingID := startRacing(t, func(svc *Service, trainingID string) {
+			_, err := svc.Pause(context.Background(), owner, trainingID)
+			require.NoError(t, err)
+		})
+
+		_, err := svc.Resume(context.Background(), owner, trainingID)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorConflict))
+	})
+}
+
+func TestActiveTrainingClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	state := TrainingState{
+		Steps:     []TrainingStepState{{ID: "a"}, {ID: "b"}},
+		UpdatedAt: start,
+	}
+	state.StartedAt = start
+	run(&state, start)
+
+	accumulate(&state, start.Add(5*time.Second))
+	advance(&state, start.Add(5*time.Second))
+	assert.Equal(t, int64(5000), state.Steps[0].ElapsedMillis)
+	assert.Equal(t, 1, state.CurrentIndex)
+
+	// Paused time is not counted.
+	state.Running = false
+	accumulate(&state, start.Add(65*time.Second))
+	run(&state, start.Add(65*time.Second))
+	accumulate(&state, start.Add(67*time.Second))
+	advance(&state, start.Add(67*time.Second))
+	assert.Equal(t, int64(2000), state.Steps[1].ElapsedMillis)
+	assert.True(t, state.Done)
+	assert.Equal(t, start.Add(67*time.Second), state.CompletedAt)
+}
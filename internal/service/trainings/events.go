@@ -0,0 +1,141 @@
+package trainings
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// watchers fans state changes of trainings in progress out to the subscribers of this replica.
+type watchers struct {
+	mu   sync.Mutex
+	subs map[string]map[chan TrainingState]struct{}
+}
+
+// Watch subscribes to state changes of a training in progress owned by the actor.
+// It returns the current state; the channel receives every later state and is closed
+// once the training is logged, aborted or replaced. The subscription ends with ctx.
+func (s *Service) Watch(ctx context.Context, actor policy.Actor, trainingID string) (TrainingState, <-chan TrainingState, error) {
+	state, err := s.loadActive(ctx, actor, trainingID)
+	if err != nil {
+		return TrainingState{}, nil, err
+	}
+	accumulate(&state, time.Now().UTC())
+	return state, s.subscribe(ctx, state.TrainingID), nil
+}
+
+// Follow subscribes to a training in progress like Watch, without checking ownership.
+// Callers authorize the subscription themselves, e.g. for participants of a class.
+func (s *Service) Follow(ctx context.Context, trainingID string) (TrainingState, <-chan TrainingState, error) {
+	state, err := s.Snapshot(ctx, trainingID)
+	if err != nil {
+		return TrainingState{}, nil, err
+	}
+	return state, s.subscribe(ctx, state.TrainingID), nil
+}
+
+// Snapshot returns the current state of a training in progress without checking ownership.
+func (s *Service) Snapshot(ctx context.Context, trainingID string) (TrainingState, error) {
+	stored, err := s.store.GetActiveTraining(ctx, strings.TrimSpace(trainingID))
+	if err != nil {
+		return TrainingState{}, mapActiveError(err)
+	}
+	state, err := decodeState(stored)
+	if err != nil {
+		return TrainingState{}, err
+	}
+	accumulate(&state, time.Now().UTC())
+	return state, nil
+}
+
+// subscribe registers a subscription to a training that ends with ctx.
+func (s *Service) subscribe(ctx context.Context, trainingID string) <-chan TrainingState {
+	ch := make(chan TrainingState, 1)
+	s.watchers.add(trainingID, ch)
+	context.AfterFunc(ctx, func() { s.watchers.remove(trainingID, ch) })
+	return ch
+}
+
+// Publish delivers the stored state of a changed training to its subscribers.
+// Trainings that no longer exist close their subscriptions.
+func (s *Service) Publish(ctx context.Context, trainingID string) {
+	if !s.watchers.watched(trainingID) {
+		return
+	}
+	stored, err := s.store.GetActiveTraining(ctx, trainingID)
+	if errors.Is(err, db.ErrActiveTrainingNotFound) {
+		s.watchers.end(trainingID)
+		return
+	}
+	if err != nil {
+		return
+	}
+	state, err := decodeState(stored)
+	if err != nil {
+		return
+	}
+	accumulate(&state, time.Now().UTC())
+	s.watchers.send(trainingID, state)
+}
+
+// add registers a subscriber for a training.
+func (w *watchers) add(trainingID string, ch chan TrainingState) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.subs == nil {
+		w.subs = map[string]map[chan TrainingState]struct{}{}
+	}
+	if w.subs[trainingID] == nil {
+		w.subs[trainingID] = map[chan TrainingState]struct{}{}
+	}
+	w.subs[trainingID][ch] = struct{}{}
+}
+
+// remove unregisters a subscriber and closes its channel unless the training already ended.
+func (w *watchers) remove(trainingID string, ch chan TrainingState) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.subs[trainingID][ch]; !ok {
+		return
+	}
+	delete(w.subs[trainingID], ch)
+	if len(w.subs[trainingID]) == 0 {
+		delete(w.subs, trainingID)
+	}
+	close(ch)
+}
+
+// watched reports whether a training has subscribers on this replica.
+func (w *watchers) watched(trainingID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.subs[trainingID]) > 0
+}
+
+// send hands the latest state to every subscriber, replacing a state that was not read yet.
+func (w *watchers) send(trainingID string, state TrainingState) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.subs[trainingID] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- state
+	}
+}
+
+// end closes all subscriptions of a training.
+func (w *watchers) end(trainingID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.subs[trainingID] {
+		close(ch)
+	}
+	delete(w.subs, trainingID)
+}
@@ -0,0 +1,88 @@
+package trainings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	owner := policy.Actor{UserID: "u1"}
+
+	t.Run("Publishes changes and ends with the training", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newActiveService()
+		ctx := context.Background()
+		created, err := svc.CreateState(ctx, owner, "w1")
+		require.NoError(t, err)
+
+		state, events, err := svc.Watch(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+		assert.False(t, state.Running)
+
+		_, err = svc.Start(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+		svc.Publish(ctx, created.TrainingID)
+		next := <-events
+		assert.True(t, next.Running)
+
+		_, err = svc.Abort(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+		svc.Publish(ctx, created.TrainingID)
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("Keeps only the latest unread state", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newActiveService()
+		ctx := context.Background()
+		created, err := svc.CreateState(ctx, owner, "w1")
+		require.NoError(t, err)
+		_, events, err := svc.Watch(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+
+		_, err = svc.Start(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+		svc.Publish(ctx, created.TrainingID)
+		_, err = svc.Advance(ctx, owner, created.TrainingID, "")
+		require.NoError(t, err)
+		svc.Publish(ctx, created.TrainingID)
+
+		next := <-events
+		assert.Equal(t, 1, next.CurrentIndex)
+	})
+
+	t.Run("Cancelling the context unsubscribes", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newActiveService()
+		created, err := svc.CreateState(context.Background(), owner, "w1")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		_, events, err := svc.Watch(ctx, owner, created.TrainingID)
+		require.NoError(t, err)
+		cancel()
+
+		_, ok := <-events
+		assert.False(t, ok)
+		assert.False(t, svc.watchers.watched(created.TrainingID))
+	})
+
+	t.Run("Foreign trainings are forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc, _ := newActiveService()
+		created, err := svc.CreateState(context.Background(), owner, "w1")
+		require.NoError(t, err)
+
+		_, _, err = svc.Watch(context.Background(), policy.Actor{UserID: "u2"}, created.TrainingID)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+}
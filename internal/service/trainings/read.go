@@ -3,8 +3,10 @@ package trainings
 import (
 	"context"
 	"strings"
+	"time"
 
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
 // TrainingStateFromWorkout creates a training state by delegating to the training domain logic.
@@ -12,9 +14,21 @@ func TrainingStateFromWorkout(workout *Workout, soundURLByKey func(string) strin
 	return NewStateFromWorkout(workout, soundURLByKey)
 }
 
-// CreateState builds a training state from a workout id.
-func (s *Service) CreateState(ctx context.Context, workoutID string) (TrainingState, error) {
-	return CreateState(ctx, s.store, workoutID, s.soundURLByKey)
+// CreateState builds a training state from a workout the actor may access and keeps it
+// on the server as the actor's training in progress.
+func (s *Service) CreateState(ctx context.Context, actor policy.Actor, workoutID string) (TrainingState, error) {
+	state, err := CreateState(ctx, s.store, workoutID, s.soundURLByKey)
+	if err != nil {
+		return TrainingState{}, err
+	}
+	if err := policy.RequireOwner(actor, state.UserID, errorScope); err != nil {
+		return TrainingState{}, err
+	}
+	state.UpdatedAt = time.Now().UTC()
+	if err := s.saveNew(ctx, state); err != nil {
+		return TrainingState{}, err
+	}
+	return state, nil
 }
 
 // CreateState builds a training state from a workout id.
@@ -30,8 +44,19 @@ func CreateState(ctx context.Context, store Store, workoutID string, soundURLByK
 	return NewStateFromWorkout(workout, soundURLByKey), nil
 }
 
-// FetchStepTimings returns stored step timings for a training.
-func (s *Service) FetchStepTimings(ctx context.Context, trainingID string) ([]TrainingStepLog, error) {
+// FetchStepTimings returns stored step timings for a training owned by the actor.
+func (s *Service) FetchStepTimings(ctx context.Context, actor policy.Actor, trainingID string) ([]TrainingStepLog, error) {
+	trainingID = strings.TrimSpace(trainingID)
+	if trainingID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "trainingId is required", errorScope)
+	}
+	log, err := s.store.GetTraining(ctx, trainingID)
+	if err != nil {
+		return nil, mapTrainingError(err)
+	}
+	if err := policy.RequireOwner(actor, log.UserID, errorScope); err != nil {
+		return nil, err
+	}
 	return FetchStepTimings(ctx, s.store, trainingID)
 }
 
@@ -46,33 +71,54 @@ func FetchStepTimings(ctx context.Context, store Store, trainingID string) ([]Tr
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
+	if steps == nil {
+		steps = []TrainingStepLog{}
+	}
 
 	return steps, nil
 }
 
-// BuildTrainingHistory loads step timings and maps training logs to response items.
-func (s *Service) BuildTrainingHistory(ctx context.Context, userID string, limit int) ([]TrainingHistoryItem, error) {
+// BuildTrainingHistory returns one page of the trainings of a user matching query.
+func (s *Service) BuildTrainingHistory(ctx context.Context, actor policy.Actor, userID string, query HistoryQuery) (HistoryPage, error) {
 	userID = strings.TrimSpace(userID)
 	if userID == "" {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "userId is required", errorScope)
+		return HistoryPage{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "userId is required", errorScope)
+	}
+	if err := policy.RequireOwner(actor, userID, errorScope); err != nil {
+		return HistoryPage{}, err
 	}
-	history, err := s.store.TrainingHistory(ctx, userID, limit)
+	filter, err := parseHistoryQuery(userID, query)
 	if err != nil {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		return HistoryPage{}, err
+	}
+
+	// Fetch one extra row to learn whether another page follows.
+	limit := filter.Limit
+	filter.Limit++
+	history, err := s.store.TrainingHistory(ctx, filter)
+	if err != nil {
+		return HistoryPage{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
-	return BuildTrainingHistory(ctx, s.store, history)
+	var page HistoryPage
+	if len(history) > limit {
+		history = history[:limit]
+		page.NextCursor = encodeHistoryCursor(history[limit-1])
+	}
+	if page.Items, err = BuildTrainingHistory(ctx, s.store, history); err != nil {
+		return HistoryPage{}, err
+	}
+	return page, nil
 }
 
-// BuildTrainingHistory loads step timings and maps training logs to response items.
+// BuildTrainingHistory loads step timings of all trainings in one query and maps them to response items.
 func BuildTrainingHistory(ctx context.Context, store Store, history []TrainingLog) ([]TrainingHistoryItem, error) {
-	// Collect step timing rows per training to enrich the history payload.
-	stepMap := make(map[string][]TrainingStepLog, len(history))
+	ids := make([]string, 0, len(history))
 	for _, entry := range history {
-		steps, err := store.TrainingStepTimings(ctx, entry.ID)
-		if err != nil {
-			return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
-		}
-		stepMap[entry.ID] = steps
+		ids = append(ids, entry.ID)
+	}
+	stepMap, err := store.StepTimingsForTrainings(ctx, ids)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
 	return BuildTrainingHistoryItems(history, stepMap), nil
 }
@@ -93,6 +139,14 @@ func BuildTrainingHistoryItems(history []TrainingLog, stepMap map[string][]Train
 			StartedAt:   &started,
 			CompletedAt: &completed,
 			Steps:       stepMap[h.ID],
+
+			Notes:          h.Notes,
+			RPE:            h.RPE,
+			Mood:           h.Mood,
+			Energy:         h.Energy,
+			Bodyweight:     h.Bodyweight,
+			BodyweightUnit: h.BodyweightUnit,
+			Load:           TrainingLoad(h),
 		})
 	}
 	return items
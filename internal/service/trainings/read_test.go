@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 	"github.com/gi8lino/motus/internal/utils"
 )
 
@@ -83,7 +85,7 @@ func TestCreateStateMethod(t *testing.T) {
 			},
 		}
 		svc := New(store, func(string) string { return "" })
-		state, err := svc.CreateState(context.Background(), "w2")
+		state, err := svc.CreateState(context.Background(), policy.Actor{UserID: "u1"}, "w2")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -91,6 +93,21 @@ func TestCreateStateMethod(t *testing.T) {
 			t.Fatalf("unexpected workout id")
 		}
 	})
+
+	t.Run("ForbidsForeignWorkout", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeStore{
+			workoutFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "w2", UserID: "u1", Name: "Workout"}, nil
+			},
+		}
+		svc := New(store, func(string) string { return "" })
+		_, err := svc.CreateState(context.Background(), policy.Actor{UserID: "u2"}, "w2")
+		if !errpkg.IsKind(err, errpkg.ErrorForbidden) {
+			t.Fatalf("expected forbidden error, got: %v", err)
+		}
+	})
 }
 
 func TestFetchStepTimings(t *testing.T) {
@@ -113,12 +130,15 @@ func TestFetchStepTimingsMethod(t *testing.T) {
 		t.Parallel()
 
 		store := &fakeStore{
+			getFn: func(context.Context, string) (*TrainingLog, error) {
+				return &TrainingLog{ID: "sess", UserID: "u1"}, nil
+			},
 			stepTimingsFn: func(context.Context, string) ([]TrainingStepLog, error) {
 				return []TrainingStepLog{{ID: "step"}}, nil
 			},
 		}
 		svc := New(store, func(string) string { return "" })
-		steps, err := svc.FetchStepTimings(context.Background(), "sess")
+		steps, err := svc.FetchStepTimings(context.Background(), policy.Actor{UserID: "u1"}, "sess")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -126,6 +146,31 @@ func TestFetchStepTimingsMethod(t *testing.T) {
 			t.Fatalf("expected steps")
 		}
 	})
+
+	t.Run("ForbidsOtherUser", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeStore{
+			getFn: func(context.Context, string) (*TrainingLog, error) {
+				return &TrainingLog{ID: "sess", UserID: "u1"}, nil
+			},
+		}
+		svc := New(store, func(string) string { return "" })
+		_, err := svc.FetchStepTimings(context.Background(), policy.Actor{UserID: "u2"}, "sess")
+		if !errpkg.IsKind(err, errpkg.ErrorForbidden) {
+			t.Fatalf("expected forbidden error, got: %v", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, func(string) string { return "" })
+		_, err := svc.FetchStepTimings(context.Background(), policy.Actor{UserID: "u1"}, "missing")
+		if !errpkg.IsKind(err, errpkg.ErrorNotFound) {
+			t.Fatalf("expected not found error, got: %v", err)
+		}
+	})
 }
 
 func TestBuildTrainingHistory(t *testing.T) {
@@ -157,11 +202,75 @@ func TestBuildTrainingHistoryMethod(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeStore{}, func(string) string { return "" })
-		_, err := svc.BuildTrainingHistory(context.Background(), " ", 10)
+		_, err := svc.BuildTrainingHistory(context.Background(), policy.Actor{UserID: "u1"}, " ", HistoryQuery{})
 		if err == nil {
 			t.Fatalf("expected error")
 		}
 	})
+
+	t.Run("ForbidsOtherUser", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, func(string) string { return "" })
+		_, err := svc.BuildTrainingHistory(context.Background(), policy.Actor{UserID: "u2"}, "u1", HistoryQuery{})
+		if !errpkg.IsKind(err, errpkg.ErrorForbidden) {
+			t.Fatalf("expected forbidden error, got: %v", err)
+		}
+	})
+
+	t.Run("Paginates with a cursor", func(t *testing.T) {
+		t.Parallel()
+
+		started := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+		var filters []TrainingHistoryFilter
+		store := &fakeStore{
+			historyFn: func(_ context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error) {
+				filters = append(filters, filter)
+				return []TrainingLog{
+					{ID: "t3", UserID: "u1", StartedAt: started},
+					{ID: "t2", UserID: "u1", StartedAt: started.Add(-time.Hour)},
+					{ID: "t1", UserID: "u1", StartedAt: started.Add(-2 * time.Hour)},
+				}[:min(filter.Limit, 3)], nil
+			},
+		}
+		svc := New(store, func(string) string { return "" })
+		actor := policy.Actor{UserID: "u1"}
+
+		page, err := svc.BuildTrainingHistory(context.Background(), actor, "u1", HistoryQuery{Limit: "2", WorkoutID: " w1 ", Search: "legs"})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 2)
+		require.NotEmpty(t, page.NextCursor)
+		assert.Equal(t, 3, filters[0].Limit)
+		assert.Equal(t, "w1", filters[0].WorkoutID)
+		assert.Equal(t, "legs", filters[0].Search)
+
+		_, err = svc.BuildTrainingHistory(context.Background(), actor, "u1", HistoryQuery{Cursor: page.NextCursor})
+		require.NoError(t, err)
+		require.NotNil(t, filters[1].BeforeStartedAt)
+		assert.True(t, filters[1].BeforeStartedAt.Equal(started.Add(-time.Hour)))
+		assert.Equal(t, "t2", filters[1].BeforeID)
+		assert.Equal(t, DefaultHistoryLimit+1, filters[1].Limit)
+	})
+
+	t.Run("Last page has no cursor", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, func(string) string { return "" })
+		page, err := svc.BuildTrainingHistory(context.Background(), policy.Actor{UserID: "u1"}, "u1", HistoryQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Invalid query", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, func(string) string { return "" })
+		for _, query := range []HistoryQuery{{From: "yesterday"}, {Cursor: "%%%"}, {Limit: "0"}} {
+			_, err := svc.BuildTrainingHistory(context.Background(), policy.Actor{UserID: "u1"}, "u1", query)
+			assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation), "query %+v", query)
+		}
+	})
 }
 
 func TestBuildTrainingHistoryItems(t *testing.T) {
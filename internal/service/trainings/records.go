@@ -0,0 +1,193 @@
+package trainings
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// kgPerLb converts pound weights to kilograms so records compare across units.
+const kgPerLb = 0.45359237
+
+// ExerciseRecords groups the personal records of one catalog exercise.
+type ExerciseRecords struct {
+	ExerciseID   string           `json:"exerciseId"`   // ExerciseID links to the catalog entry.
+	ExerciseName string           `json:"exerciseName"` // ExerciseName is the label of the latest record.
+	Records      []PersonalRecord `json:"records"`      // Records holds the standing best per kind, and per weight for max_reps.
+	History      []PersonalRecord `json:"history"`      // History lists every record set, newest first.
+}
+
+// Records returns the personal records of the actor grouped by exercise.
+func (s *Service) Records(ctx context.Context, actor policy.Actor) ([]ExerciseRecords, error) {
+	userID := strings.TrimSpace(actor.UserID)
+	if userID == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
+	}
+	records, err := s.store.PersonalRecords(ctx, userID)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return groupRecords(records), nil
+}
+
+// detectRecords returns the records set by a logged training, given every record set before.
+// Only completed results of catalog exercises count; each record key yields at most one record per training.
+func detectRecords(log TrainingLog, steps []TrainingStepLog, previous []PersonalRecord) []PersonalRecord {
+	best := make(map[string]PersonalRecord, len(previous))
+	for _, rec := range previous {
+		key := recordKey(rec)
+		if current, ok := best[key]; !ok || beats(rec, current) {
+			best[key] = rec
+		}
+	}
+
+	var (
+		order []string
+		found = make(map[string]PersonalRecord)
+	)
+	for _, step := range steps {
+		for _, ex := range step.Exercises {
+			if ex.ExerciseID == "" || ex.Status != db.ExerciseCompleted {
+				continue
+			}
+			for _, rec := range recordCandidates(log, ex) {
+				key := recordKey(rec)
+				if current, ok := best[key]; ok && !beats(rec, current) {
+					continue
+				}
+				if _, ok := found[key]; !ok {
+					order = append(order, key)
+				}
+				best[key] = rec
+				found[key] = rec
+			}
+		}
+	}
+
+	records := make([]PersonalRecord, 0, len(order))
+	for _, key := range order {
+		records = append(records, found[key])
+	}
+	return records
+}
+
+// recordCandidates returns every record kind a single exercise result could set.
+func recordCandidates(log TrainingLog, ex TrainingStepExercise) []PersonalRecord {
+	base := PersonalRecord{
+		UserID:          log.UserID,
+		ExerciseID:      ex.ExerciseID,
+		ExerciseName:    ex.Name,
+		Reps:            ex.Reps,
+		Weight:          ex.Weight,
+		WeightUnit:      ex.WeightUnit,
+		DurationSeconds: ex.DurationSeconds,
+		TrainingID:      log.ID,
+		AchievedAt:      log.CompletedAt,
+	}
+	with := func(kind string, value float64) PersonalRecord {
+		rec := base
+		rec.Kind = kind
+		rec.Value = value
+		rec.ID = ex.ID + "-" + kind
+		return rec
+	}
+
+	var candidates []PersonalRecord
+	kg := weightInKg(ex.Weight, ex.WeightUnit)
+	if kg > 0 {
+		candidates = append(candidates, with(db.RecordMaxWeight, kg))
+	}
+	if kg > 0 && ex.Reps > 0 {
+		candidates = append(candidates,
+			with(db.RecordMaxReps, float64(ex.Reps)),
+			with(db.RecordEstimated1RM, estimatedOneRepMax(kg, ex.Reps)),
+		)
+	}
+	timed := ex.Type == utils.ExerciseTypeCountdown || ex.Type == utils.ExerciseTypeStopwatch
+	if timed && ex.DurationSeconds > 0 {
+		candidates = append(candidates, with(db.RecordFastestTime, float64(ex.DurationSeconds)))
+	}
+	return candidates
+}
+
+// recordKey identifies the standing record a new one competes with. Reps only compare at the same weight.
+func recordKey(rec PersonalRecord) string {
+	if rec.Kind == db.RecordMaxReps {
+		return fmt.Sprintf("%s|%s|%.2f", rec.ExerciseID, rec.Kind, weightInKg(rec.Weight, rec.WeightUnit))
+	}
+	return rec.ExerciseID + "|" + rec.Kind
+}
+
+// beats reports whether rec improves on current; times improve by getting shorter.
+func beats(rec, current PersonalRecord) bool {
+	if rec.Kind == db.RecordFastestTime {
+		return rec.Value < current.Value
+	}
+	return rec.Value > current.Value
+}
+
+// weightInKg converts a logged weight to kilograms.
+func weightInKg(weight float64, unit string) float64 {
+	if unit == db.WeightUnitLb {
+		return weight * kgPerLb
+	}
+	return weight
+}
+
+// estimatedOneRepMax estimates the one-rep max of a set with the Epley formula, rounded to 0.1 kg.
+func estimatedOneRepMax(kg float64, reps int) float64 {
+	if reps == 1 {
+		return kg
+	}
+	return math.Round(kg*(1+float64(reps)/30)*10) / 10
+}
+
+// groupRecords groups records, oldest first, by exercise with the standing bests and the full history.
+func groupRecords(records []PersonalRecord) []ExerciseRecords {
+	byExercise := make(map[string]*ExerciseRecords)
+	standing := make(map[string]PersonalRecord)
+	var keys []string
+	for _, rec := range records {
+		group, ok := byExercise[rec.ExerciseID]
+		if !ok {
+			group = &ExerciseRecords{ExerciseID: rec.ExerciseID}
+			byExercise[rec.ExerciseID] = group
+		}
+		group.ExerciseName = rec.ExerciseName
+		group.History = append([]PersonalRecord{rec}, group.History...)
+
+		key := recordKey(rec)
+		current, ok := standing[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || beats(rec, current) {
+			standing[key] = rec
+		}
+	}
+	for _, key := range keys {
+		rec := standing[key]
+		group := byExercise[rec.ExerciseID]
+		group.Records = append(group.Records, rec)
+	}
+
+	groups := make([]ExerciseRecords, 0, len(byExercise))
+	for _, group := range byExercise {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := strings.ToLower(groups[i].ExerciseName), strings.ToLower(groups[j].ExerciseName)
+		if a != b {
+			return a < b
+		}
+		return groups[i].ExerciseID < groups[j].ExerciseID
+	})
+	return groups
+}
@@ -0,0 +1,142 @@
+package trainings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/db"
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+// loggedSets returns a training log with one step holding the given exercise results.
+func loggedSets(id string, at time.Time, exercises ...TrainingStepExercise) (TrainingLog, []TrainingStepLog) {
+	for i := range exercises {
+		exercises[i].ID = id + "-0-ex-" + string(rune('a'+i))
+		exercises[i].Status = db.ExerciseCompleted
+	}
+	log := TrainingLog{ID: id, UserID: "u1", StartedAt: at.Add(-time.Hour), CompletedAt: at}
+	return log, []TrainingStepLog{{ID: id + "-0", TrainingID: id, Exercises: exercises}}
+}
+
+// recordKinds lists the kinds of records in order.
+func recordKinds(records []PersonalRecord) []string {
+	kinds := make([]string, 0, len(records))
+	for _, rec := range records {
+		kinds = append(kinds, rec.Kind)
+	}
+	return kinds
+}
+
+func TestDetectRecords(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC)
+
+	t.Run("First sets are records", func(t *testing.T) {
+		t.Parallel()
+		log, steps := loggedSets("t1", start,
+			TrainingStepExercise{ExerciseID: "squat", Name: "Squat", Reps: 5, Weight: 100, WeightUnit: "kg"},
+			TrainingStepExercise{ExerciseID: "squat", Name: "Squat", Reps: 3, Weight: 110, WeightUnit: "kg"},
+		)
+
+		records := detectRecords(log, steps, nil)
+		assert.Equal(t, []string{db.RecordMaxWeight, db.RecordMaxReps, db.RecordEstimated1RM, db.RecordMaxReps}, recordKinds(records))
+		assert.Equal(t, 110.0, records[0].Value)
+		assert.Equal(t, 121.0, records[2].Value)
+		assert.Equal(t, "t1", records[0].TrainingID)
+		assert.Equal(t, start, records[0].AchievedAt)
+	})
+
+	t.Run("Only improvements count", func(t *testing.T) {
+		t.Parallel()
+		first, firstSteps := loggedSets("t1", start,
+			TrainingStepExercise{ExerciseID: "squat", Name: "Squat", Reps: 5, Weight: 100, WeightUnit: "kg"},
+		)
+		previous := detectRecords(first, firstSteps, nil)
+
+		log, steps := loggedSets("t2", start.AddDate(0, 0, 7),
+			TrainingStepExercise{ExerciseID: "squat", Name: "Squat", Reps: 6, Weight: 100, WeightUnit: "kg"},
+			TrainingStepExercise{ExerciseID: "squat", Name: "Squat", Reps: 8, Weight: 90, WeightUnit: "kg"},
+		)
+		records := detectRecords(log, steps, previous)
+		assert.Equal(t, []string{db.RecordMaxReps, db.RecordEstimated1RM, db.RecordMaxReps}, recordKinds(records))
+		assert.Equal(t, 6.0, records[0].Value)
+
+		assert.Empty(t, detectRecords(log, steps, append(previous, records...)))
+	})
+
+	t.Run("Pounds compare in kilograms", func(t *testing.T) {
+		t.Parallel()
+		first, firstSteps := loggedSets("t1", start,
+			TrainingStepExercise{ExerciseID: "bench", Name: "Bench", Reps: 1, Weight: 100, WeightUnit: "kg"},
+		)
+		previous := detectRecords(first, firstSteps, nil)
+
+		log, steps := loggedSets("t2", start.AddDate(0, 0, 1),
+			TrainingStepExercise{ExerciseID: "bench", Name: "Bench", Reps: 1, Weight: 225, WeightUnit: "lb"},
+		)
+		records := detectRecords(log, steps, previous)
+		require.Len(t, records, 3)
+		assert.InDelta(t, 102.06, records[0].Value, 0.01)
+	})
+
+	t.Run("Fastest time of timed exercises", func(t *testing.T) {
+		t.Parallel()
+		first, firstSteps := loggedSets("t1", start,
+			TrainingStepExercise{ExerciseID: "row", Name: "Row", Type: "stopwatch", DurationSeconds: 420},
+			TrainingStepExercise{ExerciseID: "plank", Name: "Plank", Type: "rep", DurationSeconds: 60},
+		)
+		previous := detectRecords(first, firstSteps, nil)
+		assert.Equal(t, []string{db.RecordFastestTime}, recordKinds(previous))
+
+		log, steps := loggedSets("t2", start.AddDate(0, 0, 1),
+			TrainingStepExercise{ExerciseID: "row", Name: "Row", Type: "stopwatch", DurationSeconds: 400},
+		)
+		records := detectRecords(log, steps, previous)
+		require.Len(t, records, 1)
+		assert.Equal(t, 400.0, records[0].Value)
+	})
+
+	t.Run("Skips uncataloged and unfinished results", func(t *testing.T) {
+		t.Parallel()
+		log, steps := loggedSets("t1", start,
+			TrainingStepExercise{Name: "Custom", Reps: 5, Weight: 50, WeightUnit: "kg"},
+			TrainingStepExercise{ExerciseID: "squat", Name: "Squat", Reps: 5, Weight: 100, WeightUnit: "kg"},
+		)
+		steps[0].Exercises[1].Status = db.ExerciseFailed
+
+		assert.Empty(t, detectRecords(log, steps, nil))
+	})
+}
+
+func TestRecords(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC)
+	store := &fakeStore{}
+	for i, weight := range []float64{100, 105} {
+		log, steps := loggedSets("t"+string(rune('1'+i)), start.AddDate(0, 0, 7*i),
+			TrainingStepExercise{ExerciseID: "squat", Name: "Squat", Reps: 5, Weight: weight, WeightUnit: "kg"},
+		)
+		store.records = append(store.records, detectRecords(log, steps, store.records)...)
+	}
+	log, steps := loggedSets("t3", start, TrainingStepExercise{ExerciseID: "bench", Name: "Bench", Reps: 5, Weight: 80, WeightUnit: "kg"})
+	store.records = append(store.records, detectRecords(log, steps, nil)...)
+	svc := New(store, func(string) string { return "" })
+
+	groups, err := svc.Records(context.Background(), policy.Actor{UserID: "u1"})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "bench", groups[0].ExerciseID)
+
+	squat := groups[1]
+	assert.Len(t, squat.History, 6)
+	assert.Equal(t, "t2", squat.History[0].TrainingID)
+	// Max reps are kept per weight; the other kinds only keep the standing best.
+	assert.Equal(t, []string{db.RecordMaxWeight, db.RecordMaxReps, db.RecordEstimated1RM, db.RecordMaxReps}, recordKinds(squat.Records))
+	assert.Equal(t, 105.0, squat.Records[0].Value)
+}
@@ -4,6 +4,7 @@ package trainings
 type Service struct {
 	store         Store
 	soundURLByKey func(string) string
+	watchers      watchers
 }
 
 // New creates a new trainings service.
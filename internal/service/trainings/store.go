@@ -1,11 +1,26 @@
 package trainings
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Store defines the persistence methods needed by training orchestration.
 type Store interface {
 	TrainingStepTimings(ctx context.Context, trainingID string) ([]TrainingStepLog, error)
+	StepTimingsForTrainings(ctx context.Context, trainingIDs []string) (map[string][]TrainingStepLog, error)
 	WorkoutWithSteps(ctx context.Context, id string) (*Workout, error)
-	RecordTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) error
-	TrainingHistory(ctx context.Context, userID string, limit int) ([]TrainingLog, error)
+	RecordTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) (bool, error)
+	TrainingHistory(ctx context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error)
+	GetTraining(ctx context.Context, id string) (*TrainingLog, error)
+	UpdateTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) error
+	ReplaceTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) error
+	DeleteTraining(ctx context.Context, id string) error
+	CreateActiveTraining(ctx context.Context, training ActiveTraining) error
+	GetActiveTraining(ctx context.Context, id string) (*ActiveTraining, error)
+	ActiveTrainingForUser(ctx context.Context, userID string) (*ActiveTraining, error)
+	UpdateActiveTraining(ctx context.Context, training ActiveTraining, prev time.Time) error
+	DeleteActiveTraining(ctx context.Context, id string) error
+	PersonalRecords(ctx context.Context, userID string) ([]PersonalRecord, error)
+	AddPersonalRecords(ctx context.Context, records []PersonalRecord) error
 }
@@ -1,12 +1,28 @@
 package trainings
 
-import "context"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
 
 type fakeStore struct {
 	stepTimingsFn func(context.Context, string) ([]TrainingStepLog, error)
 	workoutFn     func(context.Context, string) (*Workout, error)
-	recordFn      func(context.Context, TrainingLog, []TrainingStepLog) error
-	historyFn     func(context.Context, string, int) ([]TrainingLog, error)
+	recordFn      func(context.Context, TrainingLog, []TrainingStepLog) (bool, error)
+	historyFn     func(context.Context, TrainingHistoryFilter) ([]TrainingLog, error)
+	getFn         func(context.Context, string) (*TrainingLog, error)
+	updateFn      func(context.Context, TrainingLog, []TrainingStepLog) error
+	replaceFn     func(context.Context, TrainingLog, []TrainingStepLog) error
+	deleteFn      func(context.Context, string) error
+
+	mu      sync.Mutex
+	active  map[string]ActiveTraining // active keeps trainings in progress by id.
+	records []PersonalRecord          // records keeps stored personal records, oldest first.
+
+	beforeUpdateActive func() // beforeUpdateActive runs between reading and writing a training in progress.
 }
 
 func (f *fakeStore) TrainingStepTimings(ctx context.Context, trainingID string) ([]TrainingStepLog, error) {
@@ -23,16 +39,134 @@ func (f *fakeStore) WorkoutWithSteps(ctx context.Context, id string) (*Workout,
 	return f.workoutFn(ctx, id)
 }
 
-func (f *fakeStore) RecordTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) error {
+func (f *fakeStore) RecordTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) (bool, error) {
 	if f.recordFn == nil {
-		return nil
+		return true, nil
 	}
 	return f.recordFn(ctx, log, steps)
 }
 
-func (f *fakeStore) TrainingHistory(ctx context.Context, userID string, limit int) ([]TrainingLog, error) {
+func (f *fakeStore) StepTimingsForTrainings(ctx context.Context, trainingIDs []string) (map[string][]TrainingStepLog, error) {
+	timings := make(map[string][]TrainingStepLog, len(trainingIDs))
+	for _, id := range trainingIDs {
+		steps, err := f.TrainingStepTimings(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		timings[id] = steps
+	}
+	return timings, nil
+}
+
+func (f *fakeStore) TrainingHistory(ctx context.Context, filter TrainingHistoryFilter) ([]TrainingLog, error) {
 	if f.historyFn == nil {
 		return nil, nil
 	}
-	return f.historyFn(ctx, userID, limit)
+	return f.historyFn(ctx, filter)
+}
+
+func (f *fakeStore) GetTraining(ctx context.Context, id string) (*TrainingLog, error) {
+	if f.getFn == nil {
+		return nil, db.ErrTrainingNotFound
+	}
+	return f.getFn(ctx, id)
+}
+
+func (f *fakeStore) UpdateTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) error {
+	if f.updateFn == nil {
+		return nil
+	}
+	return f.updateFn(ctx, log, steps)
+}
+
+func (f *fakeStore) ReplaceTraining(ctx context.Context, log TrainingLog, steps []TrainingStepLog) error {
+	if f.replaceFn == nil {
+		return nil
+	}
+	return f.replaceFn(ctx, log, steps)
+}
+
+func (f *fakeStore) DeleteTraining(ctx context.Context, id string) error {
+	if f.deleteFn == nil {
+		return nil
+	}
+	return f.deleteFn(ctx, id)
+}
+
+func (f *fakeStore) CreateActiveTraining(_ context.Context, training ActiveTraining) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active == nil {
+		f.active = map[string]ActiveTraining{}
+	}
+	for id, existing := range f.active {
+		if existing.UserID == training.UserID {
+			delete(f.active, id)
+		}
+	}
+	f.active[training.ID] = training
+	return nil
+}
+
+func (f *fakeStore) GetActiveTraining(_ context.Context, id string) (*ActiveTraining, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	training, ok := f.active[id]
+	if !ok {
+		return nil, db.ErrActiveTrainingNotFound
+	}
+	return &training, nil
+}
+
+func (f *fakeStore) ActiveTrainingForUser(_ context.Context, userID string) (*ActiveTraining, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, training := range f.active {
+		if training.UserID == userID {
+			return &training, nil
+		}
+	}
+	return nil, db.ErrActiveTrainingNotFound
+}
+
+func (f *fakeStore) UpdateActiveTraining(_ context.Context, training ActiveTraining, prev time.Time) error {
+	if f.beforeUpdateActive != nil {
+		f.beforeUpdateActive()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	existing, ok := f.active[training.ID]
+	if !ok {
+		return db.ErrActiveTrainingNotFound
+	}
+	if !existing.UpdatedAt.Equal(prev) {
+		return db.ErrActiveTrainingConflict
+	}
+	existing.State = training.State
+	existing.UpdatedAt = training.UpdatedAt
+	f.active[training.ID] = existing
+	return nil
+}
+
+func (f *fakeStore) DeleteActiveTraining(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.active[id]; !ok {
+		return db.ErrActiveTrainingNotFound
+	}
+	delete(f.active, id)
+	return nil
+}
+
+func (f *fakeStore) PersonalRecords(context.Context, string) ([]PersonalRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]PersonalRecord(nil), f.records...), nil
+}
+
+func (f *fakeStore) AddPersonalRecords(_ context.Context, records []PersonalRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.records = append(f.records, records...)
+	return nil
 }
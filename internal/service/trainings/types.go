@@ -28,9 +28,37 @@ type TrainingLog = db.TrainingLog
 // TrainingStepLog is the domain-level DTO for training step timing logs.
 type TrainingStepLog = db.TrainingStepLog
 
+// TrainingStepExercise is the domain-level DTO for logged exercise results.
+type TrainingStepExercise = db.TrainingStepExercise
+
+// ActiveTraining is the domain-level DTO for stored trainings in progress.
+type ActiveTraining = db.ActiveTraining
+
+// TrainingHistoryFilter is the domain-level DTO for training history queries.
+type TrainingHistoryFilter = db.TrainingHistoryFilter
+
+// PersonalRecord is the domain-level DTO for personal records.
+type PersonalRecord = db.PersonalRecord
+
 // TrainingState captures the runtime status that the SPA consumes for an active training.
 const errorScope = "trainings"
 
+// Limits for the feedback on a logged training.
+const (
+	maxNotesLength = 2000
+	maxRPE         = 10
+	maxWellbeing   = 5
+)
+
+// Page sizes for listing the training history.
+const (
+	DefaultHistoryLimit = 25
+	MaxHistoryLimit     = 100
+)
+
+// transitionAttempts bounds how often a timer command is reapplied when other commands change the training meanwhile.
+const transitionAttempts = 5
+
 // TrainingState captures the runtime status that the SPA consumes for an active training.
 type TrainingState struct {
 	TrainingID   string              `json:"trainingId"`
@@ -42,42 +70,60 @@ type TrainingState struct {
 	Done         bool                `json:"done"`
 	StartedAt    time.Time           `json:"startedAt"`
 	CompletedAt  time.Time           `json:"completedAt"`
+	PausedAt     *time.Time          `json:"pausedAt,omitempty"`
+	UpdatedAt    time.Time           `json:"updatedAt"`
 	Steps        []TrainingStepState `json:"steps"`
 }
 
 // TrainingStepState describes a single card/step inside a training view.
 type TrainingStepState struct {
-	ID                     string       `json:"id"`
-	Name                   string       `json:"name"`
-	Type                   string       `json:"type"`
-	EstimatedSeconds       int          `json:"estimatedSeconds"`
-	SoundURL               string       `json:"soundUrl"`
-	SoundKey               string       `json:"soundKey,omitempty"`
-	SubsetEstimatedSeconds int          `json:"subsetEstimatedSeconds,omitempty"`
-	Running                bool         `json:"running"`
-	Completed              bool         `json:"completed"`
-	Current                bool         `json:"current"`
-	ElapsedMillis          int64        `json:"elapsedMillis"`
-	Exercises              []Exercise   `json:"exercises"`
-	PauseOptions           PauseOptions `json:"pauseOptions"`
-	AutoAdvance            bool         `json:"autoAdvance"`
-	LoopIndex              int          `json:"loopIndex,omitempty"`
-	LoopTotal              int          `json:"loopTotal,omitempty"`
-	SubsetID               string       `json:"subsetId,omitempty"`
-	Superset               bool         `json:"superset,omitempty"`
-	SubsetLabel            string       `json:"subsetLabel,omitempty"`
-	HasMultipleSubsets     bool         `json:"hasMultipleSubsets,omitempty"`
-	SetName                string       `json:"setName,omitempty"`
+	ID                     string           `json:"id"`
+	Name                   string           `json:"name"`
+	Type                   string           `json:"type"`
+	EstimatedSeconds       int              `json:"estimatedSeconds"`
+	SoundURL               string           `json:"soundUrl"`
+	SoundKey               string           `json:"soundKey,omitempty"`
+	SubsetEstimatedSeconds int              `json:"subsetEstimatedSeconds,omitempty"`
+	Running                bool             `json:"running"`
+	Completed              bool             `json:"completed"`
+	Current                bool             `json:"current"`
+	ElapsedMillis          int64            `json:"elapsedMillis"`
+	Exercises              []Exercise       `json:"exercises"`
+	PauseOptions           PauseOptions     `json:"pauseOptions"`
+	AutoAdvance            bool             `json:"autoAdvance"`
+	LoopIndex              int              `json:"loopIndex,omitempty"`
+	LoopTotal              int              `json:"loopTotal,omitempty"`
+	SubsetID               string           `json:"subsetId,omitempty"`
+	Superset               bool             `json:"superset,omitempty"`
+	SubsetLabel            string           `json:"subsetLabel,omitempty"`
+	HasMultipleSubsets     bool             `json:"hasMultipleSubsets,omitempty"`
+	SetName                string           `json:"setName,omitempty"`
+	StartedAt              *time.Time       `json:"startedAt,omitempty"`
+	CompletedAt            *time.Time       `json:"completedAt,omitempty"`
+	Results                []ExerciseResult `json:"results,omitempty"`
+	RPE                    int              `json:"rpe,omitempty"`
+}
+
+// ExerciseResult records what was actually performed for one exercise of a step.
+type ExerciseResult struct {
+	ExerciseID      string  `json:"exerciseId,omitempty"` // ExerciseID is the catalog entry; defaults to the planned exercise.
+	Name            string  `json:"name"`                 // Name is the exercise label; defaults to the planned exercise.
+	Reps            int     `json:"reps"`                 // Reps is the number of repetitions done.
+	Weight          float64 `json:"weight"`               // Weight is the load used.
+	WeightUnit      string  `json:"weightUnit,omitempty"` // WeightUnit is kg or lb; defaults to kg when a weight is set.
+	DurationSeconds int     `json:"durationSeconds"`      // DurationSeconds is the time spent on timed exercises.
+	Status          string  `json:"status"`               // Status is completed, skipped or failed; defaults to completed.
 }
 
 // Exercise represents a configured exercise inside a training step.
 type Exercise struct {
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	Reps     string `json:"reps"`
-	Weight   string `json:"weight"`
-	Duration string `json:"duration"`
-	SoundKey string `json:"soundKey,omitempty"`
+	ExerciseID string `json:"exerciseId,omitempty"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Reps       string `json:"reps"`
+	Weight     string `json:"weight"`
+	Duration   string `json:"duration"`
+	SoundKey   string `json:"soundKey,omitempty"`
 }
 
 // TrainingHistoryItem is the API payload for a completed training.
@@ -90,6 +136,14 @@ type TrainingHistoryItem struct {
 	StartedAt   *time.Time        `json:"startedAt,omitempty"`   // StartedAt is when the training began.
 	CompletedAt *time.Time        `json:"completedAt,omitempty"` // CompletedAt is when the training finished.
 	Steps       []TrainingStepLog `json:"steps,omitempty"`       // Steps contains logged timings when available.
+
+	Notes          string  `json:"notes,omitempty"`          // Notes is free text about how the session went.
+	RPE            int     `json:"rpe,omitempty"`            // RPE is the session rating of perceived exertion.
+	Mood           int     `json:"mood,omitempty"`           // Mood is rated from 1 to 5.
+	Energy         int     `json:"energy,omitempty"`         // Energy is rated from 1 to 5.
+	Bodyweight     float64 `json:"bodyweight,omitempty"`     // Bodyweight is the weight of the user on that day.
+	BodyweightUnit string  `json:"bodyweightUnit,omitempty"` // BodyweightUnit is kg or lb when a bodyweight was logged.
+	Load           int     `json:"load"`                     // Load is session RPE times duration in minutes, 0 without RPE.
 }
 
 // CompleteRequest captures the payload for logging a finished training.
@@ -101,4 +155,75 @@ type CompleteRequest struct {
 	StartedAt   time.Time           `json:"startedAt"`   // StartedAt records when the training began.
 	CompletedAt time.Time           `json:"completedAt"` // CompletedAt records when the training finished.
 	Steps       []TrainingStepState `json:"steps"`       // Steps includes timing details.
+
+	Notes          string  `json:"notes"`          // Notes is free text about how the session went.
+	RPE            int     `json:"rpe"`            // RPE is the session rating of perceived exertion from 1 to 10; 0 leaves it unrated.
+	Mood           int     `json:"mood"`           // Mood is rated from 1 to 5; 0 leaves it unrated.
+	Energy         int     `json:"energy"`         // Energy is rated from 1 to 5; 0 leaves it unrated.
+	Bodyweight     float64 `json:"bodyweight"`     // Bodyweight is the weight of the user on that day.
+	BodyweightUnit string  `json:"bodyweightUnit"` // BodyweightUnit is kg or lb; defaults to kg when a bodyweight is set.
+}
+
+// ReplaceRequest overwrites the times, step timings, results and feedback of a logged training.
+// The workout and owner of the training stay unchanged.
+type ReplaceRequest struct {
+	StartedAt   time.Time           `json:"startedAt"`   // StartedAt replaces the start; zero keeps it.
+	CompletedAt time.Time           `json:"completedAt"` // CompletedAt replaces the end; zero keeps it.
+	Steps       []TrainingStepState `json:"steps"`       // Steps replaces all step timings and results.
+
+	Notes          string  `json:"notes"`          // Notes is free text about how the session went.
+	RPE            int     `json:"rpe"`            // RPE is the session rating of perceived exertion from 1 to 10; 0 leaves it unrated.
+	Mood           int     `json:"mood"`           // Mood is rated from 1 to 5; 0 leaves it unrated.
+	Energy         int     `json:"energy"`         // Energy is rated from 1 to 5; 0 leaves it unrated.
+	Bodyweight     float64 `json:"bodyweight"`     // Bodyweight is the weight of the user on that day.
+	BodyweightUnit string  `json:"bodyweightUnit"` // BodyweightUnit is kg or lb; defaults to kg when a bodyweight is set.
+}
+
+// ManualRequest logs a training done without the app. Steps are built from the workout and
+// take their planned duration.
+type ManualRequest struct {
+	WorkoutID   string              `json:"workoutId"`   // WorkoutID identifies the workout that was done.
+	StartedAt   time.Time           `json:"startedAt"`   // StartedAt records when the training began.
+	CompletedAt time.Time           `json:"completedAt"` // CompletedAt defaults to the start plus the planned duration.
+	Steps       []TrainingStepState `json:"steps"`       // Steps carries exercise results and RPE by step id.
+
+	Notes          string  `json:"notes"`          // Notes is free text about how the session went.
+	RPE            int     `json:"rpe"`            // RPE is the session rating of perceived exertion from 1 to 10; 0 leaves it unrated.
+	Mood           int     `json:"mood"`           // Mood is rated from 1 to 5; 0 leaves it unrated.
+	Energy         int     `json:"energy"`         // Energy is rated from 1 to 5; 0 leaves it unrated.
+	Bodyweight     float64 `json:"bodyweight"`     // Bodyweight is the weight of the user on that day.
+	BodyweightUnit string  `json:"bodyweightUnit"` // BodyweightUnit is kg or lb; defaults to kg when a bodyweight is set.
+}
+
+// UpdateRequest changes the notes and ratings of a logged training; nil fields stay unchanged.
+type UpdateRequest struct {
+	Notes          *string      `json:"notes"`          // Notes replaces the session notes.
+	RPE            *int         `json:"rpe"`            // RPE replaces the session RPE; 0 clears it.
+	Mood           *int         `json:"mood"`           // Mood replaces the mood rating; 0 clears it.
+	Energy         *int         `json:"energy"`         // Energy replaces the energy rating; 0 clears it.
+	Bodyweight     *float64     `json:"bodyweight"`     // Bodyweight replaces the logged bodyweight; 0 clears it.
+	BodyweightUnit *string      `json:"bodyweightUnit"` // BodyweightUnit replaces the bodyweight unit.
+	Steps          []StepRating `json:"steps"`          // Steps rates individual logged steps.
+}
+
+// StepRating sets the RPE of a logged step.
+type StepRating struct {
+	ID  string `json:"id"`  // ID is the step log identifier.
+	RPE int    `json:"rpe"` // RPE is the rating from 1 to 10; 0 clears it.
+}
+
+// HistoryQuery holds the raw, unvalidated filter parameters of a history listing.
+type HistoryQuery struct {
+	From      string // From is an RFC 3339 time or a date the trainings started on or after.
+	To        string // To is an RFC 3339 time or a date, inclusive, the trainings started before.
+	WorkoutID string // WorkoutID matches the workout the training was based on.
+	Search    string // Search matches the workout name or notes.
+	Cursor    string // Cursor continues a previous listing.
+	Limit     string // Limit is the page size.
+}
+
+// HistoryPage is one page of the training history, newest first.
+type HistoryPage struct {
+	Items      []TrainingHistoryItem `json:"items"`
+	NextCursor string                `json:"nextCursor,omitempty"` // NextCursor is empty on the last page.
 }
@@ -1,9 +1,16 @@
 package trainings
 
 import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
 	"github.com/gi8lino/motus/internal/utils"
 )
 
@@ -22,12 +29,13 @@ func mapExercises(exercises []SubsetExercise) []Exercise {
 // mapExercise builds a training exercise from a subset exercise record.
 func mapExercise(ex SubsetExercise) Exercise {
 	return Exercise{
-		Name:     ex.Name,
-		Type:     utils.NormalizeExerciseType(ex.Type),
-		Reps:     ex.Reps,
-		Weight:   ex.Weight,
-		Duration: ex.Duration,
-		SoundKey: ex.SoundKey,
+		ExerciseID: ex.ExerciseID,
+		Name:       ex.Name,
+		Type:       utils.NormalizeExerciseType(ex.Type),
+		Reps:       ex.Reps,
+		Weight:     ex.Weight,
+		Duration:   ex.Duration,
+		SoundKey:   ex.SoundKey,
 	}
 }
 
@@ -59,3 +67,208 @@ func parseDurationSeconds(value string) int {
 	}
 	return 0
 }
+
+// buildExerciseResults validates the results of a step and maps them to log rows of stepID.
+func buildExerciseResults(stepID string, step TrainingStepState) ([]TrainingStepExercise, error) {
+	var exercises []TrainingStepExercise
+	for idx, res := range step.Results {
+		name := strings.TrimSpace(res.Name)
+		exerciseID := strings.TrimSpace(res.ExerciseID)
+		var exerciseType string
+		if idx < len(step.Exercises) {
+			planned := step.Exercises[idx]
+			name = utils.DefaultIfZero(name, strings.TrimSpace(planned.Name))
+			exerciseID = utils.DefaultIfZero(exerciseID, planned.ExerciseID)
+			exerciseType = planned.Type
+		}
+		if name == "" {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "exercise result name is required", errorScope)
+		}
+		if res.Reps < 0 || res.Weight < 0 || res.DurationSeconds < 0 {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "exercise results must not be negative", errorScope)
+		}
+
+		status := utils.DefaultIfZero(strings.ToLower(strings.TrimSpace(res.Status)), db.ExerciseCompleted)
+		switch status {
+		case db.ExerciseCompleted, db.ExerciseSkipped, db.ExerciseFailed:
+		default:
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "exercise status must be completed, skipped or failed", errorScope)
+		}
+
+		unit, err := normalizeWeightUnit(res.Weight, res.WeightUnit, "weightUnit")
+		if err != nil {
+			return nil, err
+		}
+
+		exercises = append(exercises, TrainingStepExercise{
+			ID:              fmt.Sprintf("%s-ex-%d", stepID, idx),
+			StepID:          stepID,
+			ExerciseOrder:   idx,
+			ExerciseID:      exerciseID,
+			Type:            exerciseType,
+			Name:            name,
+			Reps:            res.Reps,
+			Weight:          res.Weight,
+			WeightUnit:      unit,
+			DurationSeconds: res.DurationSeconds,
+			Status:          status,
+		})
+	}
+	return exercises, nil
+}
+
+// MergeResults copies the exercise results and RPE of submitted steps onto the stored steps with the same id.
+func MergeResults(steps, submitted []TrainingStepState) {
+	byID := make(map[string]TrainingStepState, len(submitted))
+	for _, st := range submitted {
+		if len(st.Results) > 0 || st.RPE != 0 {
+			byID[st.ID] = st
+		}
+	}
+	for i := range steps {
+		if st, ok := byID[steps[i].ID]; ok {
+			steps[i].Results = st.Results
+			steps[i].RPE = st.RPE
+		}
+	}
+}
+
+// normalizeWeightUnit returns the unit stored with weight: none without weight and kg by default.
+func normalizeWeightUnit(weight float64, unit, field string) (string, error) {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	switch {
+	case weight == 0:
+		return "", nil
+	case unit == "":
+		return db.WeightUnitKg, nil
+	case unit != db.WeightUnitKg && unit != db.WeightUnitLb:
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, field+" must be kg or lb", errorScope)
+	}
+	return unit, nil
+}
+
+// validateFeedback checks the notes and ratings of a training log and normalizes them in place.
+func validateFeedback(log *TrainingLog) error {
+	log.Notes = strings.TrimSpace(log.Notes)
+	if utf8.RuneCountInString(log.Notes) > maxNotesLength {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, fmt.Sprintf("notes must not exceed %d characters", maxNotesLength), errorScope)
+	}
+	if err := validateRating("rpe", log.RPE, maxRPE); err != nil {
+		return err
+	}
+	if err := validateRating("mood", log.Mood, maxWellbeing); err != nil {
+		return err
+	}
+	if err := validateRating("energy", log.Energy, maxWellbeing); err != nil {
+		return err
+	}
+	if log.Bodyweight < 0 {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "bodyweight must not be negative", errorScope)
+	}
+	unit, err := normalizeWeightUnit(log.Bodyweight, log.BodyweightUnit, "bodyweightUnit")
+	if err != nil {
+		return err
+	}
+	log.BodyweightUnit = unit
+	return nil
+}
+
+// validateRating checks that a rating is unset (0) or between 1 and limit.
+func validateRating(field string, value, limit int) error {
+	if value < 0 || value > limit {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, fmt.Sprintf("%s must be between 1 and %d", field, limit), errorScope)
+	}
+	return nil
+}
+
+// TrainingLoad returns the session RPE multiplied by the duration in minutes, or 0 for unrated trainings.
+func TrainingLoad(log TrainingLog) int {
+	if log.RPE <= 0 || !log.CompletedAt.After(log.StartedAt) {
+		return 0
+	}
+	minutes := log.CompletedAt.Sub(log.StartedAt).Minutes()
+	return int(math.Round(float64(log.RPE) * minutes))
+}
+
+// historyDateLayout is the date-only form accepted for history ranges.
+const historyDateLayout = "2006-01-02"
+
+// parseHistoryQuery validates raw history query parameters into a store filter for userID.
+func parseHistoryQuery(userID string, query HistoryQuery) (TrainingHistoryFilter, error) {
+	filter := TrainingHistoryFilter{
+		UserID:    userID,
+		WorkoutID: strings.TrimSpace(query.WorkoutID),
+		Search:    strings.TrimSpace(query.Search),
+		Limit:     DefaultHistoryLimit,
+	}
+	var err error
+	if filter.From, err = parseHistoryTime("from", query.From, false); err != nil {
+		return TrainingHistoryFilter{}, err
+	}
+	if filter.To, err = parseHistoryTime("to", query.To, true); err != nil {
+		return TrainingHistoryFilter{}, err
+	}
+	if cursor := strings.TrimSpace(query.Cursor); cursor != "" {
+		startedAt, id, err := decodeHistoryCursor(cursor)
+		if err != nil {
+			return TrainingHistoryFilter{}, err
+		}
+		filter.BeforeStartedAt = &startedAt
+		filter.BeforeID = id
+	}
+	if limit := strings.TrimSpace(query.Limit); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return TrainingHistoryFilter{}, errInvalidQuery("limit")
+		}
+		filter.Limit = min(n, MaxHistoryLimit)
+	}
+	return filter, nil
+}
+
+// parseHistoryTime parses an optional RFC 3339 time or date. With endOfDay a date covers the whole day.
+func parseHistoryTime(name, value string, endOfDay bool) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(historyDateLayout, value)
+	if err != nil {
+		return nil, errInvalidQuery(name)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// encodeHistoryCursor returns an opaque cursor continuing after the given training.
+func encodeHistoryCursor(log TrainingLog) string {
+	raw := log.StartedAt.UTC().Format(time.RFC3339Nano) + "|" + log.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeHistoryCursor reverses encodeHistoryCursor.
+func decodeHistoryCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", errInvalidQuery("cursor")
+	}
+	started, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return time.Time{}, "", errInvalidQuery("cursor")
+	}
+	startedAt, err := time.Parse(time.RFC3339Nano, started)
+	if err != nil {
+		return time.Time{}, "", errInvalidQuery("cursor")
+	}
+	return startedAt, id, nil
+}
+
+// errInvalidQuery reports a malformed history query parameter.
+func errInvalidQuery(name string) error {
+	return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "invalid "+name, errorScope)
+}
@@ -2,8 +2,10 @@ package trainings
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/gi8lino/motus/internal/utils"
 )
@@ -69,3 +71,44 @@ func TestParseDurationSeconds(t *testing.T) {
 		assert.Equal(t, 0, parseDurationSeconds(""))
 	})
 }
+
+func TestTrainingLoad(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, 420, TrainingLoad(TrainingLog{RPE: 7, StartedAt: start, CompletedAt: start.Add(time.Hour)}))
+	assert.Equal(t, 0, TrainingLoad(TrainingLog{StartedAt: start, CompletedAt: start.Add(time.Hour)}))
+	assert.Equal(t, 0, TrainingLoad(TrainingLog{RPE: 7, StartedAt: start, CompletedAt: start}))
+}
+
+func TestParseHistoryQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Dates cover whole days", func(t *testing.T) {
+		t.Parallel()
+		filter, err := parseHistoryQuery("u1", HistoryQuery{From: "2024-03-01", To: "2024-03-31"})
+		require.NoError(t, err)
+		require.NotNil(t, filter.From)
+		require.NotNil(t, filter.To)
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), *filter.From)
+		assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), *filter.To)
+		assert.Equal(t, DefaultHistoryLimit, filter.Limit)
+	})
+
+	t.Run("Times and limits", func(t *testing.T) {
+		t.Parallel()
+		filter, err := parseHistoryQuery("u1", HistoryQuery{To: "2024-03-01T12:00:00Z", Limit: "500"})
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), *filter.To)
+		assert.Equal(t, MaxHistoryLimit, filter.Limit)
+	})
+
+	t.Run("Cursor round trip", func(t *testing.T) {
+		t.Parallel()
+		started := time.Date(2024, 3, 1, 10, 0, 0, 123, time.UTC)
+		filter, err := parseHistoryQuery("u1", HistoryQuery{Cursor: encodeHistoryCursor(TrainingLog{ID: "t1", StartedAt: started})})
+		require.NoError(t, err)
+		assert.Equal(t, started, *filter.BeforeStartedAt)
+		assert.Equal(t, "t1", filter.BeforeID)
+	})
+}
@@ -2,11 +2,14 @@ package trainings
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 	"github.com/gi8lino/motus/internal/utils"
 )
 
@@ -38,6 +41,13 @@ func BuildTrainingLog(req CompleteRequest) (TrainingLog, []TrainingStepLog, erro
 		}
 		// Create a stable step log id per order position.
 		stepID := fmt.Sprintf("%s-%d", req.TrainingID, idx)
+		exercises, err := buildExerciseResults(stepID, st)
+		if err != nil {
+			return TrainingLog{}, nil, err
+		}
+		if err := validateRating("step rpe", st.RPE, maxRPE); err != nil {
+			return TrainingLog{}, nil, err
+		}
 		stepLogs = append(stepLogs, TrainingStepLog{
 			ID:               stepID,
 			TrainingID:       req.TrainingID,
@@ -46,31 +56,304 @@ func BuildTrainingLog(req CompleteRequest) (TrainingLog, []TrainingStepLog, erro
 			Name:             strings.TrimSpace(st.Name),
 			EstimatedSeconds: st.EstimatedSeconds,
 			ElapsedMillis:    st.ElapsedMillis,
+			RPE:              st.RPE,
+			Exercises:        exercises,
 		})
 	}
 
 	log := TrainingLog{
-		ID:          req.TrainingID,
-		WorkoutID:   req.WorkoutID,
-		WorkoutName: req.WorkoutName,
-		UserID:      req.UserID,
-		StartedAt:   req.StartedAt,
-		CompletedAt: req.CompletedAt,
+		ID:             req.TrainingID,
+		WorkoutID:      req.WorkoutID,
+		WorkoutName:    req.WorkoutName,
+		UserID:         req.UserID,
+		StartedAt:      req.StartedAt,
+		CompletedAt:    req.CompletedAt,
+		Notes:          req.Notes,
+		RPE:            req.RPE,
+		Mood:           req.Mood,
+		Energy:         req.Energy,
+		Bodyweight:     req.Bodyweight,
+		BodyweightUnit: req.BodyweightUnit,
+	}
+	if err := validateFeedback(&log); err != nil {
+		return TrainingLog{}, nil, err
 	}
 
 	return log, stepLogs, nil
 }
 
-// RecordTraining persists a training log and its step timings.
-func (s *Service) RecordTraining(ctx context.Context, req CompleteRequest) (TrainingLog, error) {
+// RecordTraining persists a training log and its step timings for the actor and returns the
+// personal records it set. Trainings kept on the server are finalized from their stored state;
+// the payload only serves clients that never stored the training.
+func (s *Service) RecordTraining(ctx context.Context, actor policy.Actor, req CompleteRequest) (TrainingLog, []PersonalRecord, error) {
+	req, err := s.completeFromStored(ctx, actor, req)
+	if err != nil {
+		return TrainingLog{}, nil, err
+	}
 	log, steps, err := BuildTrainingLog(req)
 	if err != nil {
-		return TrainingLog{}, err
+		return TrainingLog{}, nil, err
+	}
+	if err := policy.RequireOwner(actor, log.UserID, errorScope); err != nil {
+		return TrainingLog{}, nil, err
+	}
+	return s.Record(ctx, log, steps)
+}
+
+// Record stores a training log and returns the personal records it set.
+// Logging a training of the same user again returns the stored log without new records;
+// an id logged by another user is rejected.
+func (s *Service) Record(ctx context.Context, log TrainingLog, steps []TrainingStepLog) (TrainingLog, []PersonalRecord, error) {
+	inserted, err := s.store.RecordTraining(ctx, log, steps)
+	if err != nil {
+		return TrainingLog{}, nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if !inserted {
+		stored, err := s.store.GetTraining(ctx, log.ID)
+		if err != nil {
+			return TrainingLog{}, nil, mapTrainingError(err)
+		}
+		if stored.UserID != log.UserID {
+			return TrainingLog{}, nil, errpkg.NewErrorWithScope(errpkg.ErrorConflict, "training id is already taken", errorScope)
+		}
+		return *stored, nil, nil
+	}
+
+	records, err := s.recordPersonalRecords(ctx, log, steps)
+	if err != nil {
+		return TrainingLog{}, nil, err
 	}
+	return log, records, nil
+}
 
-	if err := s.store.RecordTraining(ctx, log, steps); err != nil {
-		return TrainingLog{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+// recordPersonalRecords stores the personal records set by a newly written training.
+func (s *Service) recordPersonalRecords(ctx context.Context, log TrainingLog, steps []TrainingStepLog) ([]PersonalRecord, error) {
+	previous, err := s.store.PersonalRecords(ctx, log.UserID)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	records := detectRecords(log, steps, previous)
+	if err := s.store.AddPersonalRecords(ctx, records); err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
+	return records, nil
+}
 
-	return log, nil
+// completeFromStored replaces req with the finished stored state when the training is in progress on the server.
+// Exercise results only exist on the client, so they are taken from the submitted steps.
+func (s *Service) completeFromStored(ctx context.Context, actor policy.Actor, req CompleteRequest) (CompleteRequest, error) {
+	trainingID := strings.TrimSpace(req.TrainingID)
+	if trainingID == "" {
+		return req, nil
+	}
+	stored, err := s.store.GetActiveTraining(ctx, trainingID)
+	if errors.Is(err, db.ErrActiveTrainingNotFound) {
+		return req, nil
+	}
+	if err != nil {
+		return CompleteRequest{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if err := policy.RequireOwner(actor, stored.UserID, errorScope); err != nil {
+		return CompleteRequest{}, err
+	}
+	state, err := decodeState(stored)
+	if err != nil {
+		return CompleteRequest{}, err
+	}
+
+	at := time.Now().UTC()
+	accumulate(&state, at)
+	if !state.Done {
+		finish(&state, at)
+	}
+	MergeResults(state.Steps, req.Steps)
+	return CompleteRequest{
+		TrainingID:     state.TrainingID,
+		WorkoutID:      state.WorkoutID,
+		WorkoutName:    state.WorkoutName,
+		UserID:         state.UserID,
+		StartedAt:      state.StartedAt,
+		CompletedAt:    state.CompletedAt,
+		Steps:          state.Steps,
+		Notes:          req.Notes,
+		RPE:            req.RPE,
+		Mood:           req.Mood,
+		Energy:         req.Energy,
+		Bodyweight:     req.Bodyweight,
+		BodyweightUnit: req.BodyweightUnit,
+	}, nil
+}
+
+// UpdateTraining changes the notes and ratings of a logged training owned by the actor.
+func (s *Service) UpdateTraining(ctx context.Context, actor policy.Actor, trainingID string, req UpdateRequest) (TrainingHistoryItem, error) {
+	trainingID = strings.TrimSpace(trainingID)
+	if trainingID == "" {
+		return TrainingHistoryItem{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "trainingId is required", errorScope)
+	}
+	log, err := s.store.GetTraining(ctx, trainingID)
+	if err != nil {
+		return TrainingHistoryItem{}, mapTrainingError(err)
+	}
+	if err := policy.RequireOwner(actor, log.UserID, errorScope); err != nil {
+		return TrainingHistoryItem{}, err
+	}
+
+	if req.Notes != nil {
+		log.Notes = *req.Notes
+	}
+	if req.RPE != nil {
+		log.RPE = *req.RPE
+	}
+	if req.Mood != nil {
+		log.Mood = *req.Mood
+	}
+	if req.Energy != nil {
+		log.Energy = *req.Energy
+	}
+	if req.Bodyweight != nil {
+		log.Bodyweight = *req.Bodyweight
+	}
+	if req.BodyweightUnit != nil {
+		log.BodyweightUnit = *req.BodyweightUnit
+	}
+	if err := validateFeedback(log); err != nil {
+		return TrainingHistoryItem{}, err
+	}
+
+	steps := make([]TrainingStepLog, 0, len(req.Steps))
+	for _, rating := range req.Steps {
+		if strings.TrimSpace(rating.ID) == "" {
+			return TrainingHistoryItem{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "step id is required", errorScope)
+		}
+		if err := validateRating("step rpe", rating.RPE, maxRPE); err != nil {
+			return TrainingHistoryItem{}, err
+		}
+		steps = append(steps, TrainingStepLog{ID: rating.ID, RPE: rating.RPE})
+	}
+
+	if err := s.store.UpdateTraining(ctx, *log, steps); err != nil {
+		return TrainingHistoryItem{}, mapTrainingError(err)
+	}
+	timings, err := s.store.TrainingStepTimings(ctx, log.ID)
+	if err != nil {
+		return TrainingHistoryItem{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	items := BuildTrainingHistoryItems([]TrainingLog{*log}, map[string][]TrainingStepLog{log.ID: timings})
+	return items[0], nil
+}
+
+// ReplaceTraining overwrites a logged training owned by the actor and returns it with the
+// personal records it sets now.
+func (s *Service) ReplaceTraining(ctx context.Context, actor policy.Actor, trainingID string, req ReplaceRequest) (TrainingHistoryItem, []PersonalRecord, error) {
+	trainingID = strings.TrimSpace(trainingID)
+	if trainingID == "" {
+		return TrainingHistoryItem{}, nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "trainingId is required", errorScope)
+	}
+	stored, err := s.store.GetTraining(ctx, trainingID)
+	if err != nil {
+		return TrainingHistoryItem{}, nil, mapTrainingError(err)
+	}
+	if err := policy.RequireOwner(actor, stored.UserID, errorScope); err != nil {
+		return TrainingHistoryItem{}, nil, err
+	}
+
+	log, steps, err := BuildTrainingLog(CompleteRequest{
+		TrainingID:     stored.ID,
+		WorkoutID:      stored.WorkoutID,
+		WorkoutName:    stored.WorkoutName,
+		UserID:         stored.UserID,
+		StartedAt:      utils.DefaultIfZero(req.StartedAt, stored.StartedAt),
+		CompletedAt:    utils.DefaultIfZero(req.CompletedAt, stored.CompletedAt),
+		Steps:          req.Steps,
+		Notes:          req.Notes,
+		RPE:            req.RPE,
+		Mood:           req.Mood,
+		Energy:         req.Energy,
+		Bodyweight:     req.Bodyweight,
+		BodyweightUnit: req.BodyweightUnit,
+	})
+	if err != nil {
+		return TrainingHistoryItem{}, nil, err
+	}
+	if err := s.store.ReplaceTraining(ctx, log, steps); err != nil {
+		return TrainingHistoryItem{}, nil, mapTrainingError(err)
+	}
+	records, err := s.recordPersonalRecords(ctx, log, steps)
+	if err != nil {
+		return TrainingHistoryItem{}, nil, err
+	}
+	items := BuildTrainingHistoryItems([]TrainingLog{log}, map[string][]TrainingStepLog{log.ID: steps})
+	return items[0], records, nil
+}
+
+// DeleteTraining removes a logged training owned by the actor.
+func (s *Service) DeleteTraining(ctx context.Context, actor policy.Actor, trainingID string) error {
+	trainingID = strings.TrimSpace(trainingID)
+	if trainingID == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "trainingId is required", errorScope)
+	}
+	log, err := s.store.GetTraining(ctx, trainingID)
+	if err != nil {
+		return mapTrainingError(err)
+	}
+	if err := policy.RequireOwner(actor, log.UserID, errorScope); err != nil {
+		return err
+	}
+	if err := s.store.DeleteTraining(ctx, log.ID); err != nil {
+		return mapTrainingError(err)
+	}
+	return nil
+}
+
+// LogManual records a training the actor did without the app. The steps are built from the
+// workout definition and each takes its planned duration.
+func (s *Service) LogManual(ctx context.Context, actor policy.Actor, req ManualRequest) (TrainingLog, []PersonalRecord, error) {
+	if req.StartedAt.IsZero() {
+		return TrainingLog{}, nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "startedAt is required", errorScope)
+	}
+	if req.StartedAt.After(time.Now()) {
+		return TrainingLog{}, nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "startedAt must not be in the future", errorScope)
+	}
+	state, err := CreateState(ctx, s.store, req.WorkoutID, s.soundURLByKey)
+	if err != nil {
+		return TrainingLog{}, nil, err
+	}
+	if err := policy.RequireOwner(actor, state.UserID, errorScope); err != nil {
+		return TrainingLog{}, nil, err
+	}
+
+	var planned time.Duration
+	for i := range state.Steps {
+		state.Steps[i].ElapsedMillis = int64(state.Steps[i].EstimatedSeconds) * 1000
+		planned += time.Duration(state.Steps[i].EstimatedSeconds) * time.Second
+	}
+	MergeResults(state.Steps, req.Steps)
+
+	log, steps, err := BuildTrainingLog(CompleteRequest{
+		TrainingID:     state.TrainingID,
+		WorkoutID:      state.WorkoutID,
+		WorkoutName:    state.WorkoutName,
+		UserID:         state.UserID,
+		StartedAt:      req.StartedAt,
+		CompletedAt:    utils.DefaultIfZero(req.CompletedAt, req.StartedAt.Add(planned)),
+		Steps:          state.Steps,
+		Notes:          req.Notes,
+		RPE:            req.RPE,
+		Mood:           req.Mood,
+		Energy:         req.Energy,
+		Bodyweight:     req.Bodyweight,
+		BodyweightUnit: req.BodyweightUnit,
+	})
+	if err != nil {
+		return TrainingLog{}, nil, err
+	}
+	return s.Record(ctx, log, steps)
+}
+
+// mapTrainingError maps store errors for logged trainings to service errors.
+func mapTrainingError(err error) error {
+	if errors.Is(err, db.ErrTrainingNotFound) || errors.Is(err, db.ErrTrainingStepNotFound) {
+		return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+	}
+	return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 }
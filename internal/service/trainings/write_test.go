@@ -2,12 +2,16 @@ package trainings
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 	"github.com/gi8lino/motus/internal/utils"
 )
 
@@ -39,6 +43,87 @@ func TestBuildTrainingLog(t *testing.T) {
 		assert.Equal(t, "sess", steps[0].TrainingID)
 		assert.Equal(t, 0, steps[0].StepOrder)
 	})
+
+	t.Run("Exercise results", func(t *testing.T) {
+		t.Parallel()
+
+		_, steps, err := BuildTrainingLog(CompleteRequest{
+			TrainingID: "sess",
+			WorkoutID:  "work",
+			UserID:     "user",
+			Steps: []TrainingStepState{{
+				Name:      "Superset",
+				Type:      string(utils.StepTypeSet),
+				Exercises: []Exercise{{Name: "Squat"}, {Name: "Plank"}},
+				Results: []ExerciseResult{
+					{Reps: 8, Weight: 60},
+					{DurationSeconds: 45, Status: "Failed"},
+				},
+			}},
+		})
+		require.NoError(t, err)
+		require.Len(t, steps, 1)
+		require.Len(t, steps[0].Exercises, 2)
+		assert.Equal(t, TrainingStepExercise{
+			ID: "sess-0-ex-0", StepID: "sess-0", Name: "Squat", Reps: 8, Weight: 60, WeightUnit: "kg", Status: "completed",
+		}, steps[0].Exercises[0])
+		assert.Equal(t, "Plank", steps[0].Exercises[1].Name)
+		assert.Equal(t, "failed", steps[0].Exercises[1].Status)
+		assert.Empty(t, steps[0].Exercises[1].WeightUnit)
+	})
+
+	t.Run("Feedback", func(t *testing.T) {
+		t.Parallel()
+
+		log, steps, err := BuildTrainingLog(CompleteRequest{
+			TrainingID: "sess",
+			WorkoutID:  "work",
+			UserID:     "user",
+			Steps:      []TrainingStepState{{Name: "Step", RPE: 8}},
+			Notes:      "  Heavy day  ",
+			RPE:        7,
+			Mood:       4,
+			Bodyweight: 80.5,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Heavy day", log.Notes)
+		assert.Equal(t, 7, log.RPE)
+		assert.Equal(t, 4, log.Mood)
+		assert.Equal(t, "kg", log.BodyweightUnit)
+		assert.Equal(t, 8, steps[0].RPE)
+
+		for name, req := range map[string]CompleteRequest{
+			"rpe too high":    {RPE: 11},
+			"mood too high":   {Mood: 6},
+			"negative energy": {Energy: -1},
+			"unknown unit":    {Bodyweight: 70, BodyweightUnit: "st"},
+			"notes too long":  {Notes: strings.Repeat("x", maxNotesLength+1)},
+			"step rpe":        {Steps: []TrainingStepState{{Name: "Step", RPE: 12}}},
+		} {
+			req.TrainingID, req.WorkoutID, req.UserID = "sess", "work", "user"
+			_, _, err := BuildTrainingLog(req)
+			assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation), name)
+		}
+	})
+
+	t.Run("Invalid exercise results", func(t *testing.T) {
+		t.Parallel()
+
+		for name, res := range map[string]ExerciseResult{
+			"negative reps":  {Name: "Squat", Reps: -1},
+			"unknown unit":   {Name: "Squat", Weight: 20, WeightUnit: "stone"},
+			"unknown status": {Name: "Squat", Status: "done"},
+			"missing name":   {Reps: 5},
+		} {
+			_, _, err := BuildTrainingLog(CompleteRequest{
+				TrainingID: "sess",
+				WorkoutID:  "work",
+				UserID:     "user",
+				Steps:      []TrainingStepState{{Name: "Step", Results: []ExerciseResult{res}}},
+			})
+			assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation), name)
+		}
+	})
 }
 
 func TestRecordTraining(t *testing.T) {
@@ -49,13 +134,13 @@ func TestRecordTraining(t *testing.T) {
 
 		called := false
 		store := &fakeStore{
-			recordFn: func(context.Context, TrainingLog, []TrainingStepLog) error {
+			recordFn: func(context.Context, TrainingLog, []TrainingStepLog) (bool, error) {
 				called = true
-				return nil
+				return true, nil
 			},
 		}
 		svc := New(store, func(string) string { return "" })
-		_, err := svc.RecordTraining(context.Background(), CompleteRequest{
+		_, _, err := svc.RecordTraining(context.Background(), policy.Actor{UserID: "u1"}, CompleteRequest{
 			TrainingID:  "s1",
 			WorkoutID:   "w1",
 			WorkoutName: "Workout",
@@ -70,4 +155,351 @@ func TestRecordTraining(t *testing.T) {
 			t.Fatalf("expected RecordTraining to be called")
 		}
 	})
+
+	// logTwice records a training of u1 and then the same id with heavier results, which the store reports as already logged by owner.
+	logTwice := func(t *testing.T, owner string) (*fakeStore, []PersonalRecord, error) {
+		t.Helper()
+		svc, store := newActiveService()
+		ctx := context.Background()
+		actor := policy.Actor{UserID: "u1"}
+		req := CompleteRequest{
+			TrainingID:  "t1",
+			WorkoutID:   "w1",
+			WorkoutName: "Workout",
+			UserID:      "u1",
+			StartedAt:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			CompletedAt: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+			Steps: []TrainingStepState{{ID: "s1", Name: "Squats", Type: "set", Exercises: []Exercise{{ExerciseID: "squat", Name: "Squat", Type: "rep"}},
+				Results: []ExerciseResult{{ExerciseID: "squat", Name: "Squat", Reps: 10, Weight: 60}}}},
+		}
+		_, records, err := svc.RecordTraining(ctx, actor, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, records)
+
+		store.recordFn = func(context.Context, TrainingLog, []TrainingStepLog) (bool, error) { return false, nil }
+		store.getFn = func(_ context.Context, id string) (*TrainingLog, error) {
+			return &TrainingLog{ID: id, UserID: owner, WorkoutID: "w1"}, nil
+		}
+		req.Steps[0].Results[0].Weight = 80
+		_, records, err = svc.RecordTraining(ctx, actor, req)
+		return store, records, err
+	}
+
+	t.Run("Logging again sets no records", func(t *testing.T) {
+		t.Parallel()
+		store, records, err := logTwice(t, "u1")
+		require.NoError(t, err)
+		assert.Empty(t, records)
+		assert.Len(t, store.records, 3)
+	})
+
+	t.Run("Rejects ids logged by another user", func(t *testing.T) {
+		t.Parallel()
+		store, _, err := logTwice(t, "u2")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorConflict))
+		assert.Len(t, store.records, 3)
+	})
+}
+
+func TestUpdateTraining(t *testing.T) {
+	t.Parallel()
+
+	owner := policy.Actor{UserID: "u1"}
+	newStore := func() (*fakeStore, *TrainingLog) {
+		stored := &TrainingLog{
+			ID:          "t1",
+			UserID:      "u1",
+			StartedAt:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			CompletedAt: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+			Notes:       "Before",
+			Mood:        3,
+		}
+		store := &fakeStore{
+			getFn: func(_ context.Context, id string) (*TrainingLog, error) {
+				if id != stored.ID {
+					return nil, db.ErrTrainingNotFound
+				}
+				copied := *stored
+				return &copied, nil
+			},
+			updateFn: func(_ context.Context, log TrainingLog, _ []TrainingStepLog) error {
+				*stored = log
+				return nil
+			},
+		}
+		return store, stored
+	}
+
+	t.Run("Changes only the given fields", func(t *testing.T) {
+		t.Parallel()
+		store, stored := newStore()
+		var rated []TrainingStepLog
+		store.updateFn = func(_ context.Context, log TrainingLog, steps []TrainingStepLog) error {
+			*stored = log
+			rated = steps
+			return nil
+		}
+		svc := New(store, func(string) string { return "" })
+		rpe := 5
+
+		item, err := svc.UpdateTraining(context.Background(), owner, "t1", UpdateRequest{
+			RPE:   &rpe,
+			Steps: []StepRating{{ID: "t1-0", RPE: 9}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Before", stored.Notes)
+		assert.Equal(t, 3, stored.Mood)
+		assert.Equal(t, 5, stored.RPE)
+		assert.Equal(t, 300, item.Load)
+		require.Len(t, rated, 1)
+		assert.Equal(t, 9, rated[0].RPE)
+	})
+
+	t.Run("Rejects invalid ratings", func(t *testing.T) {
+		t.Parallel()
+		store, _ := newStore()
+		svc := New(store, func(string) string { return "" })
+		mood := 9
+
+		_, err := svc.UpdateTraining(context.Background(), owner, "t1", UpdateRequest{Mood: &mood})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Foreign trainings are forbidden", func(t *testing.T) {
+		t.Parallel()
+		store, _ := newStore()
+		svc := New(store, func(string) string { return "" })
+
+		_, err := svc.UpdateTraining(context.Background(), policy.Actor{UserID: "u2"}, "t1", UpdateRequest{})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Unknown training", func(t *testing.T) {
+		t.Parallel()
+		store, _ := newStore()
+		svc := New(store, func(string) string { return "" })
+
+		_, err := svc.UpdateTraining(context.Background(), owner, "missing", UpdateRequest{})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+}
+
+func TestReplaceTraining(t *testing.T) {
+	t.Parallel()
+
+	owner := policy.Actor{UserID: "u1"}
+	newStore := func() *fakeStore {
+		return &fakeStore{
+			getFn: func(_ context.Context, id string) (*TrainingLog, error) {
+				if id != "t1" {
+					return nil, db.ErrTrainingNotFound
+				}
+				return &TrainingLog{
+					ID:          "t1",
+					WorkoutID:   "w1",
+					WorkoutName: "Workout",
+					UserID:      "u1",
+					StartedAt:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+					CompletedAt: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+					Notes:       "Before",
+				}, nil
+			},
+		}
+	}
+
+	t.Run("Replaces times and steps", func(t *testing.T) {
+		t.Parallel()
+		store := newStore()
+		var (
+			replaced TrainingLog
+			steps    []TrainingStepLog
+		)
+		store.replaceFn = func(_ context.Context, log TrainingLog, st []TrainingStepLog) error {
+			replaced, steps = log, st
+			return nil
+		}
+		svc := New(store, func(string) string { return "" })
+
+		item, _, err := svc.ReplaceTraining(context.Background(), owner, "t1", ReplaceRequest{
+			CompletedAt: time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC),
+			Steps:       []TrainingStepState{{ID: "s1", Name: "Run", Type: "set", ElapsedMillis: 1_800_000}},
+			RPE:         6,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "w1", replaced.WorkoutID)
+		assert.Equal(t, "u1", replaced.UserID)
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), replaced.StartedAt)
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC), replaced.CompletedAt)
+		assert.Empty(t, replaced.Notes)
+		require.Len(t, steps, 1)
+		assert.Equal(t, "t1-0", steps[0].ID)
+		assert.Equal(t, int64(1_800_000), steps[0].ElapsedMillis)
+		assert.Equal(t, 180, item.Load)
+		assert.Len(t, item.Steps, 1)
+	})
+
+	t.Run("Foreign trainings are forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc := New(newStore(), func(string) string { return "" })
+
+		_, _, err := svc.ReplaceTraining(context.Background(), policy.Actor{UserID: "u2"}, "t1", ReplaceRequest{})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Unknown training", func(t *testing.T) {
+		t.Parallel()
+		svc := New(newStore(), func(string) string { return "" })
+
+		_, _, err := svc.ReplaceTraining(context.Background(), owner, "missing", ReplaceRequest{})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+}
+
+func TestDeleteTraining(t *testing.T) {
+	t.Parallel()
+
+	newStore := func(deleted *string) *fakeStore {
+		return &fakeStore{
+			getFn: func(_ context.Context, id string) (*TrainingLog, error) {
+				if id != "t1" {
+					return nil, db.ErrTrainingNotFound
+				}
+				return &TrainingLog{ID: "t1", UserID: "u1"}, nil
+			},
+			deleteFn: func(_ context.Context, id string) error {
+				*deleted = id
+				return nil
+			},
+		}
+	}
+
+	t.Run("Deletes own training", func(t *testing.T) {
+		t.Parallel()
+		var deleted string
+		svc := New(newStore(&deleted), func(string) string { return "" })
+
+		require.NoError(t, svc.DeleteTraining(context.Background(), policy.Actor{UserID: "u1"}, "t1"))
+		assert.Equal(t, "t1", deleted)
+	})
+
+	t.Run("Foreign trainings are forbidden", func(t *testing.T) {
+		t.Parallel()
+		var deleted string
+		svc := New(newStore(&deleted), func(string) string { return "" })
+
+		err := svc.DeleteTraining(context.Background(), policy.Actor{UserID: "u2"}, "t1")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("Unknown training", func(t *testing.T) {
+		t.Parallel()
+		var deleted string
+		svc := New(newStore(&deleted), func(string) string { return "" })
+
+		err := svc.DeleteTraining(context.Background(), policy.Actor{UserID: "u1"}, "missing")
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+}
+
+func TestLogManual(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	newStore := func(recorded *[]TrainingStepLog, log *TrainingLog) *fakeStore {
+		return &fakeStore{
+			workoutFn: func(_ context.Context, id string) (*Workout, error) {
+				if id != "w1" {
+					return nil, db.ErrWorkoutNotFound
+				}
+				return &Workout{
+					ID:     "w1",
+					UserID: "u1",
+					Name:   "Intervals",
+					Steps: []WorkoutStep{
+						{ID: "s1", Type: utils.StepTypePause.String(), Name: "Warm up", EstimatedSeconds: 60},
+						{ID: "s2", Type: utils.StepTypePause.String(), Name: "Rest", EstimatedSeconds: 30, RepeatCount: 2},
+					},
+				}, nil
+			},
+			recordFn: func(_ context.Context, l TrainingLog, steps []TrainingStepLog) (bool, error) {
+				*log, *recorded = l, steps
+				return true, nil
+			},
+		}
+	}
+
+	t.Run("Builds steps from the workout", func(t *testing.T) {
+		t.Parallel()
+		var (
+			steps []TrainingStepLog
+			log   TrainingLog
+		)
+		svc := New(newStore(&steps, &log), func(string) string { return "" })
+
+		created, _, err := svc.LogManual(context.Background(), policy.Actor{UserID: "u1"}, ManualRequest{
+			WorkoutID: "w1",
+			StartedAt: started,
+			Steps:     []TrainingStepState{{ID: "s2-r2", RPE: 8}},
+			RPE:       5,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, created, log)
+		assert.Equal(t, "Intervals", log.WorkoutName)
+		assert.Equal(t, "u1", log.UserID)
+		assert.Equal(t, started.Add(2*time.Minute), log.CompletedAt)
+		require.Len(t, steps, 3)
+		assert.Equal(t, int64(60_000), steps[0].ElapsedMillis)
+		assert.Equal(t, int64(30_000), steps[2].ElapsedMillis)
+		assert.Equal(t, 8, steps[2].RPE)
+	})
+
+	t.Run("Keeps the given completion", func(t *testing.T) {
+		t.Parallel()
+		var (
+			steps []TrainingStepLog
+			log   TrainingLog
+		)
+		svc := New(newStore(&steps, &log), func(string) string { return "" })
+
+		_, _, err := svc.LogManual(context.Background(), policy.Actor{UserID: "u1"}, ManualRequest{
+			WorkoutID:   "w1",
+			StartedAt:   started,
+			CompletedAt: started.Add(45 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, started.Add(45*time.Minute), log.CompletedAt)
+	})
+
+	t.Run("Requires a past start", func(t *testing.T) {
+		t.Parallel()
+		var (
+			steps []TrainingStepLog
+			log   TrainingLog
+		)
+		svc := New(newStore(&steps, &log), func(string) string { return "" })
+
+		_, _, err := svc.LogManual(context.Background(), policy.Actor{UserID: "u1"}, ManualRequest{WorkoutID: "w1"})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+
+		_, _, err = svc.LogManual(context.Background(), policy.Actor{UserID: "u1"}, ManualRequest{
+			WorkoutID: "w1",
+			StartedAt: time.Now().Add(time.Hour),
+		})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Foreign workouts are forbidden", func(t *testing.T) {
+		t.Parallel()
+		var (
+			steps []TrainingStepLog
+			log   TrainingLog
+		)
+		svc := New(newStore(&steps, &log), func(string) string { return "" })
+
+		_, _, err := svc.LogManual(context.Background(), policy.Actor{UserID: "u2"}, ManualRequest{WorkoutID: "w1", StartedAt: started})
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+		assert.Empty(t, steps)
+	})
 }
@@ -2,42 +2,95 @@ package users
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
 	"github.com/gi8lino/motus/internal/utils"
 )
 
 // Login validates credentials when using local authentication.
-func (s *Service) Login(ctx context.Context, email, password string) (*User, error) {
+// Accounts with two-factor authentication get a challenge to redeem with VerifyLogin.
+func (s *Service) Login(ctx context.Context, email, password string) (LoginResult, error) {
 	if s.authHeader != "" {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "passwords managed by proxy", errorScope)
+		return LoginResult{}, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "passwords managed by proxy", errorScope)
 	}
 
 	normalized, err := utils.NormalizeEmail(email)
 	if err != nil {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+		return LoginResult{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
 
 	password = strings.TrimSpace(password)
 	if password == "" {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "email and password are required", errorScope)
+		return LoginResult{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "email and password are required", errorScope)
 	}
 
 	user, hash, err := s.store.GetUserWithPassword(ctx, normalized)
 	if err != nil {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		return LoginResult{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
 	if user == nil || hash == "" {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "invalid credentials", errorScope)
+		return LoginResult{}, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "invalid credentials", errorScope)
 	}
 
 	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
-		return nil, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "invalid credentials", errorScope)
+		return LoginResult{}, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "invalid credentials", errorScope)
+	}
+	if user.EmailVerifiedAt == nil {
+		return LoginResult{}, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "email is not verified", errorScope)
+	}
+
+	if user.TOTPEnabled {
+		challenge, err := s.issueChallenge(ctx, user.ID)
+		if err != nil {
+			return LoginResult{}, err
+		}
+		return LoginResult{Challenge: challenge}, nil
+	}
+	return LoginResult{User: user}, nil
+}
+
+// SignInExternal resolves a user authenticated by an external identity provider.
+// Missing users are created when autoCreate is set; a non-nil isAdmin is synced to the user.
+func (s *Service) SignInExternal(ctx context.Context, email string, isAdmin *bool, autoCreate bool) (*User, error) {
+	normalized, err := utils.NormalizeEmail(email)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+
+	user, err := s.store.GetUser(ctx, normalized)
+	if err != nil && !errors.Is(err, db.ErrUserNotFound) {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if user == nil {
+		if !autoCreate {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "user is not provisioned", errorScope)
+		}
+		if user, err = s.store.CreateUser(ctx, normalized, "", ""); err != nil {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		}
 	}
 
+	// The admin group grants the admin role; leaving it demotes admins to members.
+	if isAdmin != nil && user.IsAdmin != *isAdmin {
+		role := db.RoleMember
+		if *isAdmin {
+			role = db.RoleAdmin
+		}
+		// The last admin keeps the role, so nobody is locked out of administration.
+		err := s.store.UpdateUserRole(ctx, user.ID, role)
+		switch {
+		case errors.Is(err, db.ErrLastAdmin):
+		case err != nil:
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		default:
+			user.SetRole(role)
+		}
+	}
 	return user, nil
 }
 
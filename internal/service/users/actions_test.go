@@ -3,13 +3,16 @@ package users
 import (
 	"context"
 	"testing"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/utils"
 )
 
 func TestLogin(t *testing.T) {
@@ -37,7 +40,7 @@ func TestLogin(t *testing.T) {
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
 	})
 
-	t.Run("Success", func(t *testing.T) {
+	t.Run("Unverified email", func(t *testing.T) {
 		t.Parallel()
 
 		hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.DefaultCost)
@@ -47,11 +50,142 @@ func TestLogin(t *testing.T) {
 			getUserWithPassFn: func(context.Context, string) (*User, string, error) {
 				return &User{ID: "user"}, string(hash), nil
 			},
+		}, "", true)
+		_, err = svc.Login(context.Background(), "user@example.com", "secret")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+		assert.EqualError(t, err, "email is not verified")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.DefaultCost)
+		require.NoError(t, err)
+
+		verified := time.Now()
+		svc := New(&fakeStore{
+			getUserWithPassFn: func(context.Context, string) (*User, string, error) {
+				return &User{ID: "user", EmailVerifiedAt: &verified}, string(hash), nil
+			},
 		}, "", false)
-		user, err := svc.Login(context.Background(), "user@example.com", "secret")
+		result, err := svc.Login(context.Background(), "user@example.com", "secret")
 		require.NoError(t, err)
-		require.NotNil(t, user)
-		assert.Equal(t, "user", user.ID)
+		require.NotNil(t, result.User)
+		assert.Equal(t, "user", result.User.ID)
+		assert.Empty(t, result.Challenge)
+	})
+
+	t.Run("Two-factor challenge", func(t *testing.T) {
+		t.Parallel()
+
+		hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.DefaultCost)
+		require.NoError(t, err)
+
+		verified := time.Now()
+		var stored OneTimeToken
+		svc := New(&fakeStore{
+			getUserWithPassFn: func(context.Context, string) (*User, string, error) {
+				return &User{ID: "user", EmailVerifiedAt: &verified, TOTPEnabled: true}, string(hash), nil
+			},
+			createTokenFn: func(_ context.Context, token OneTimeToken) error {
+				stored = token
+				return nil
+			},
+		}, "", false)
+		result, err := svc.Login(context.Background(), "user@example.com", "secret")
+		require.NoError(t, err)
+		assert.Nil(t, result.User)
+		require.NotEmpty(t, result.Challenge)
+		assert.Equal(t, "user", stored.UserID)
+		assert.Equal(t, db.TokenPurposeLoginTOTP, stored.Purpose)
+		assert.Equal(t, utils.HashToken(result.Challenge), stored.ID)
+	})
+}
+
+func TestSignInExternal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Existing user", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			getUserFn: func(_ context.Context, id string) (*User, error) {
+				return &User{ID: id}, nil
+			},
+		}, "", false)
+		user, err := svc.SignInExternal(context.Background(), "User@Example.com", nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", user.ID)
+	})
+
+	t.Run("Unknown user without auto-create", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			getUserFn: func(context.Context, string) (*User, error) {
+				return nil, db.ErrUserNotFound
+			},
+		}, "", false)
+		_, err := svc.SignInExternal(context.Background(), "user@example.com", nil, false)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Auto-creates and syncs admin flag", func(t *testing.T) {
+		t.Parallel()
+
+		var created, promoted string
+		svc := New(&fakeStore{
+			getUserFn: func(context.Context, string) (*User, error) {
+				return nil, db.ErrUserNotFound
+			},
+			createUserFn: func(_ context.Context, email, _, passwordHash string) (*User, error) {
+				created = email
+				assert.Empty(t, passwordHash)
+				return &User{ID: email}, nil
+			},
+			updateUserRoleFn: func(_ context.Context, id, role string) error {
+				if role == db.RoleAdmin {
+					promoted = id
+				}
+				return nil
+			},
+		}, "", false)
+		isAdmin := true
+		user, err := svc.SignInExternal(context.Background(), "user@example.com", &isAdmin, true)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", created)
+		assert.Equal(t, "user@example.com", promoted)
+		assert.True(t, user.IsAdmin)
+	})
+
+	t.Run("Last admin keeps the role", func(t *testing.T) {
+		t.Parallel()
+
+		admin := &User{ID: "admin@example.com"}
+		admin.SetRole(db.RoleAdmin)
+		svc := New(&fakeStore{
+			getUserFn: func(context.Context, string) (*User, error) {
+				return admin, nil
+			},
+			updateUserRoleFn: func(context.Context, string, string) error {
+				return db.ErrLastAdmin
+			},
+		}, "", false)
+		isAdmin := false
+		user, err := svc.SignInExternal(context.Background(), "admin@example.com", &isAdmin, false)
+		require.NoError(t, err)
+		assert.True(t, user.IsAdmin)
+	})
+
+	t.Run("Invalid email", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, "", false)
+		_, err := svc.SignInExternal(context.Background(), "not-an-email", nil, true)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
 	})
 }
 
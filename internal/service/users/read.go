@@ -2,7 +2,9 @@ package users
 
 import (
 	"context"
+	"errors"
 
+	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
 )
 
@@ -23,6 +25,9 @@ func (s *Service) Get(ctx context.Context, id string) (*User, error) {
 	}
 	user, err := s.store.GetUser(ctx, cleanID)
 	if err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
+		}
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
 	if user == nil {
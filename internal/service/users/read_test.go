@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
 )
 
@@ -38,4 +39,15 @@ func TestGet(t *testing.T) {
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
 	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			getUserFn: func(context.Context, string) (*User, error) { return nil, db.ErrUserNotFound },
+		}, "", false)
+		_, err := svc.Get(context.Background(), "ghost@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
 }
@@ -1,14 +1,28 @@
 package users
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Store defines persistence operations required by the users domain logic.
 type Store interface {
 	ListUsers(ctx context.Context) ([]User, error)
 	GetUser(ctx context.Context, id string) (*User, error)
 	CreateUser(ctx context.Context, email, avatarURL, passwordHash string) (*User, error)
-	UpdateUserAdmin(ctx context.Context, id string, isAdmin bool) error
+	CreatePendingUser(ctx context.Context, email, passwordHash string) (*User, error)
+	CreateInvitedUser(ctx context.Context, tokenHash, email, avatarURL, passwordHash string, at time.Time) (*User, error)
+	UpdateUserRole(ctx context.Context, id, role string) error
 	GetUserWithPassword(ctx context.Context, id string) (*User, string, error)
 	UpdateUserPassword(ctx context.Context, id, passwordHash string) error
 	UpdateUserName(ctx context.Context, id, name string) error
+	CreateOneTimeToken(ctx context.Context, token OneTimeToken) error
+	ConsumeOneTimeToken(ctx context.Context, tokenHash, purpose string, at time.Time) (string, error)
+	GetTOTP(ctx context.Context, userID string) (*TOTP, error)
+	SetPendingTOTP(ctx context.Context, userID, secret string) error
+	EnableTOTP(ctx context.Context, userID string, step int64, codeHashes []string, at time.Time) error
+	DisableTOTP(ctx context.Context, userID string) error
+	ClaimTOTPStep(ctx context.Context, userID string, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error)
 }
@@ -0,0 +1,222 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/totp"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+// VerifyLogin completes a login challenge with a TOTP or recovery code.
+// A challenge can be redeemed once; a wrong code requires signing in again.
+func (s *Service) VerifyLogin(ctx context.Context, challenge, code string) (*User, error) {
+	challenge = strings.TrimSpace(challenge)
+	code = strings.TrimSpace(code)
+	if challenge == "" || code == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "challenge and code are required", errorScope)
+	}
+
+	now := time.Now().UTC()
+	userID, err := s.store.ConsumeOneTimeToken(ctx, utils.HashToken(challenge), db.TokenPurposeLoginTOTP, now)
+	if err != nil {
+		if errors.Is(err, db.ErrOneTimeTokenNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "login challenge expired", errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	state, err := s.loadTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if state.EnabledAt == nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "invalid code", errorScope)
+	}
+
+	ok, err := s.checkCode(ctx, userID, state.Secret, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Anything that is not a TOTP code may be a recovery code.
+		hash := utils.HashToken(totp.NormalizeRecoveryCode(code))
+		if ok, err = s.store.UseRecoveryCode(ctx, userID, hash, now); err != nil {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+		}
+	}
+	if !ok {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "invalid code", errorScope)
+	}
+
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return user, nil
+}
+
+// BeginTOTP stores a new pending secret for the user and returns its provisioning URI.
+func (s *Service) BeginTOTP(ctx context.Context, userID string) (Enrollment, error) {
+	if s.authHeader != "" {
+		return Enrollment{}, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "passwords managed by proxy", errorScope)
+	}
+	cleanID, err := requireEntityID(userID, "user id is required")
+	if err != nil {
+		return Enrollment{}, err
+	}
+	state, err := s.loadTOTP(ctx, cleanID)
+	if err != nil {
+		return Enrollment{}, err
+	}
+	if state.EnabledAt != nil {
+		return Enrollment{}, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "two-factor authentication is already enabled", errorScope)
+	}
+
+	secret := totp.NewSecret()
+	if err := s.store.SetPendingTOTP(ctx, cleanID, secret); err != nil {
+		return Enrollment{}, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return Enrollment{Secret: secret, URI: totp.URI(totpIssuer, cleanID, secret)}, nil
+}
+
+// ConfirmTOTP enables the pending secret once the user proves it with a code
+// and returns the plaintext recovery codes, which are shown only this once.
+func (s *Service) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	cleanID, err := requireEntityID(userID, "user id is required")
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.loadTOTP(ctx, cleanID)
+	if err != nil {
+		return nil, err
+	}
+	if state.EnabledAt != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "two-factor authentication is already enabled", errorScope)
+	}
+	if state.Secret == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "two-factor enrollment has not been started", errorScope)
+	}
+
+	step, ok := totp.Validate(state.Secret, code, time.Now())
+	if !ok {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "invalid code", errorScope)
+	}
+	codes, hashes := newRecoveryCodes()
+	if err := s.store.EnableTOTP(ctx, cleanID, step, hashes, time.Now().UTC()); err != nil {
+		if errors.Is(err, db.ErrTOTPNotEnrolled) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "two-factor enrollment has not been started", errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor authentication off after re-checking the password.
+func (s *Service) DisableTOTP(ctx context.Context, userID, password string) error {
+	cleanID, err := requireEntityID(userID, "user id is required")
+	if err != nil {
+		return err
+	}
+	password = strings.TrimSpace(password)
+	if password == "" {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "password is required", errorScope)
+	}
+
+	user, hash, err := s.store.GetUserWithPassword(ctx, cleanID)
+	if err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	if user == nil || hash == "" || bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorUnauthorized, "invalid credentials", errorScope)
+	}
+	if err := s.store.DisableTOTP(ctx, cleanID); err != nil {
+		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after checking a current TOTP code.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	cleanID, err := requireEntityID(userID, "user id is required")
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.loadTOTP(ctx, cleanID)
+	if err != nil {
+		return nil, err
+	}
+	if state.EnabledAt == nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "two-factor authentication is not enabled", errorScope)
+	}
+	ok, err := s.checkCode(ctx, cleanID, state.Secret, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "invalid code", errorScope)
+	}
+
+	codes, hashes := newRecoveryCodes()
+	if err := s.store.ReplaceRecoveryCodes(ctx, cleanID, hashes); err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return codes, nil
+}
+
+// issueChallenge stores a short-lived login challenge and returns its plaintext value.
+func (s *Service) issueChallenge(ctx context.Context, userID string) (string, error) {
+	secret := utils.NewToken()
+	now := time.Now().UTC()
+	token := OneTimeToken{
+		ID:        utils.HashToken(secret),
+		UserID:    userID,
+		Purpose:   db.TokenPurposeLoginTOTP,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ChallengeTTL),
+	}
+	if err := s.store.CreateOneTimeToken(ctx, token); err != nil {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return secret, nil
+}
+
+// loadTOTP fetches the two-factor state and maps a missing user to not found.
+func (s *Service) loadTOTP(ctx context.Context, userID string) (*TOTP, error) {
+	state, err := s.store.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorNotFound, "user not found", errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return state, nil
+}
+
+// checkCode validates a TOTP code and claims its time step so it cannot be replayed.
+func (s *Service) checkCode(ctx context.Context, userID, secret, code string) (bool, error) {
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	claimed, err := s.store.ClaimTOTPStep(ctx, userID, step)
+	if err != nil {
+		return false, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return claimed, nil
+}
+
+// newRecoveryCodes returns fresh recovery codes together with their hashes.
+func newRecoveryCodes() ([]string, []string) {
+	codes := totp.NewRecoveryCodes()
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = utils.HashToken(totp.NormalizeRecoveryCode(code))
+	}
+	return codes, hashes
+}
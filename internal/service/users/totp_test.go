@@ -0,0 +1,301 @@
+package users
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gi8lino/motus/internal/db"
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/totp"
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+const testSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func currentCode(t *testing.T) string {
+	t.Helper()
+	code, err := totp.Code(testSecret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestVerifyLogin(t *testing.T) {
+	t.Parallel()
+
+	enabled := time.Now()
+
+	t.Run("Expired challenge", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			consumeTokenFn: func(context.Context, string, string, time.Time) (string, error) {
+				return "", db.ErrOneTimeTokenNotFound
+			},
+		}, "", false)
+		_, err := svc.VerifyLogin(context.Background(), "challenge", "123456")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+	})
+
+	t.Run("Valid TOTP code", func(t *testing.T) {
+		t.Parallel()
+
+		var claimed int64
+		svc := New(&fakeStore{
+			consumeTokenFn: func(_ context.Context, hash, purpose string, _ time.Time) (string, error) {
+				assert.Equal(t, utils.HashToken("challenge"), hash)
+				assert.Equal(t, db.TokenPurposeLoginTOTP, purpose)
+				return "user", nil
+			},
+			getTOTPFn: func(context.Context, string) (*TOTP, error) {
+				return &TOTP{Secret: testSecret, EnabledAt: &enabled}, nil
+			},
+			claimStepFn: func(_ context.Context, _ string, step int64) (bool, error) {
+				claimed = step
+				return true, nil
+			},
+			getUserFn: func(_ context.Context, id string) (*User, error) {
+				return &User{ID: id}, nil
+			},
+		}, "", false)
+		user, err := svc.VerifyLogin(context.Background(), "challenge", currentCode(t))
+		require.NoError(t, err)
+		assert.Equal(t, "user", user.ID)
+		assert.NotZero(t, claimed)
+	})
+
+	t.Run("Replayed TOTP code", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			consumeTokenFn: func(context.Context, string, string, time.Time) (string, error) {
+				return "user", nil
+			},
+			getTOTPFn: func(context.Context, string) (*TOTP, error) {
+				return &TOTP{Secret: testSecret, EnabledAt: &enabled}, nil
+			},
+			claimStepFn: func(context.Context, string, int64) (bool, error) {
+				return false, nil
+			},
+		}, "", false)
+		_, err := svc.VerifyLogin(context.Background(), "challenge", currentCode(t))
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+	})
+
+	t.Run("Recovery code", func(t *testing.T) {
+		t.Parallel()
+
+		var used string
+		svc := New(&fakeStore{
+			consumeTokenFn: func(context.Context, string, string, time.Time) (string, error) {
+				return "user", nil
+			},
+			getTOTPFn: func(context.Context, string) (*TOTP, error) {
+				return &TOTP{Secret: testSecret, EnabledAt: &enabled}, nil
+			},
+			useRecoveryFn: func(_ context.Context, _ string, hash string, _ time.Time) (bool, error) {
+				used = hash
+				return true, nil
+			},
+			getUserFn: func(_ context.Context, id string) (*User, error) {
+				return &User{ID: id}, nil
+			},
+		}, "", false)
+		user, err := svc.VerifyLogin(context.Background(), "challenge", " ABCDE-fghjk ")
+		require.NoError(t, err)
+		assert.Equal(t, "user", user.ID)
+		assert.Equal(t, utils.HashToken("abcdefghjk"), used)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			consumeTokenFn: func(context.Context, string, string, time.Time) (string, error) {
+				return "user", nil
+			},
+			getTOTPFn: func(context.Context, string) (*TOTP, error) {
+				return &TOTP{Secret: testSecret, EnabledAt: &enabled}, nil
+			},
+		}, "", false)
+		_, err := svc.VerifyLogin(context.Background(), "challenge", "abcde-fghjk")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+		assert.EqualError(t, err, "invalid code")
+	})
+}
+
+func TestBeginTOTP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Already enabled", func(t *testing.T) {
+		t.Parallel()
+
+		enabled := time.Now()
+		svc := New(&fakeStore{
+			getTOTPFn: func(context.Context, string) (*TOTP, error) {
+				return &TOTP{Secret: testSecret, EnabledAt: &enabled}, nil
+			},
+		}, "", false)
+		_, err := svc.BeginTOTP(context.Background(), "user@example.com")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Stores pending secret", func(t *testing.T) {
+		t.Parallel()
+
+		var stored string
+		svc := New(&fakeStore{
+			setPendingTOTPFn: func(_ context.Context, _ string, secret string) error {
+				stored = secret
+				return nil
+			},
+		}, "", false)
+		enrollment, err := svc.BeginTOTP(context.Background(), "user@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, stored, enrollment.Secret)
+		assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/Motus:user@example.com?"))
+		assert.Contains(t, enrollment.URI, "secret="+stored)
+	})
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Not started", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, "", false)
+		_, err := svc.ConfirmTOTP(context.Background(), "user@example.com", "123456")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			getTOTPFn: func(context.Context, string) (*TOTP, error) {
+				return nil, db.ErrUserNotFound
+			},
+		}, "", false)
+		_, err := svc.ConfirmTOTP(context.Background(), "ghost@example.com", "123456")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			getTOTPFn: func(context.Context, string) (*TOTP, error) {
+				return &TOTP{Secret: testSecret}, nil
+			},
+		}, "", false)
+		_, err := svc.ConfirmTOTP(context.Background(), "user@example.com", "000000x")
+		require.Error(t, err)
+		assert.EqualError(t, err, "invalid code")
+	})
+
+	t.Run("Enables and returns recovery codes", func(t *testing.T) {
+		t.Parallel()
+
+		var hashes []string
+		svc := New(&fakeStore{
+			getTOTPFn: func(context.Context, string) (*TOTP, error) {
+				return &TOTP{Secret: testSecret}, nil
+			},
+			enableTOTPFn: func(_ context.Context, _ string, _ int64, codeHashes []string, _ time.Time) error {
+				hashes = codeHashes
+				return nil
+			},
+		}, "", false)
+		codes, err := svc.ConfirmTOTP(context.Background(), "user@example.com", currentCode(t))
+		require.NoError(t, err)
+		require.Len(t, codes, totp.RecoveryCodeCount)
+		require.Len(t, hashes, totp.RecoveryCodeCount)
+		assert.Equal(t, utils.HashToken(totp.NormalizeRecoveryCode(codes[0])), hashes[0])
+	})
+}
+
+func TestDisableTOTP(t *testing.T) {
+	t.Parallel()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	t.Run("Wrong password", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			getUserWithPassFn: func(context.Context, string) (*User, string, error) {
+				return &User{ID: "user"}, string(hash), nil
+			},
+			disableTOTPFn: func(context.Context, string) error {
+				t.Fatalf("unexpected disable")
+				return nil
+			},
+		}, "", false)
+		err := svc.DisableTOTP(context.Background(), "user", "wrong")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorUnauthorized))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		disabled := false
+		svc := New(&fakeStore{
+			getUserWithPassFn: func(context.Context, string) (*User, string, error) {
+				return &User{ID: "user"}, string(hash), nil
+			},
+			disableTOTPFn: func(context.Context, string) error {
+				disabled = true
+				return nil
+			},
+		}, "", false)
+		require.NoError(t, svc.DisableTOTP(context.Background(), "user", "secret"))
+		assert.True(t, disabled)
+	})
+}
+
+func TestRegenerateRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Not enabled", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, "", false)
+		_, err := svc.RegenerateRecoveryCodes(context.Background(), "user", "123456")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Replaces codes", func(t *testing.T) {
+		t.Parallel()
+
+		enabled := time.Now()
+		var replaced []string
+		svc := New(&fakeStore{
+			getTOTPFn: func(context.Context, string) (*TOTP, error) {
+				return &TOTP{Secret: testSecret, EnabledAt: &enabled}, nil
+			},
+			replaceCodesFn: func(_ context.Context, _ string, codeHashes []string) error {
+				replaced = codeHashes
+				return nil
+			},
+		}, "", false)
+		codes, err := svc.RegenerateRecoveryCodes(context.Background(), "user", currentCode(t))
+		require.NoError(t, err)
+		assert.Len(t, codes, totp.RecoveryCodeCount)
+		assert.Len(t, replaced, totp.RecoveryCodeCount)
+	})
+}
@@ -1,10 +1,40 @@
 // Package users provides domain logic for user management.
 package users
 
-import "github.com/gi8lino/motus/internal/db"
+import (
+	"time"
+
+	"github.com/gi8lino/motus/internal/db"
+)
 
 // User is the domain-level DTO for users.
 type User = db.User
 
+// OneTimeToken is the domain-level DTO for login challenges.
+type OneTimeToken = db.OneTimeToken
+
+// TOTP is the domain-level DTO for a user's two-factor state.
+type TOTP = db.TOTP
+
 // errorScope is the service error scope for users.
 const errorScope = "users"
+
+const (
+	// ChallengeTTL is how long a password-verified login waits for the second factor.
+	ChallengeTTL = 5 * time.Minute
+	// totpIssuer is the issuer shown in authenticator apps.
+	totpIssuer = "Motus"
+)
+
+// LoginResult is the outcome of a password login.
+// Challenge is set instead of User when the account requires a second factor.
+type LoginResult struct {
+	User      *User
+	Challenge string
+}
+
+// Enrollment holds a pending TOTP secret and its provisioning URI.
+type Enrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
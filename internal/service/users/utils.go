@@ -1,6 +1,10 @@
 package users
 
 import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
 	"github.com/gi8lino/motus/internal/utils"
 )
@@ -12,3 +16,19 @@ func requireEntityID(value, message string) (string, error) {
 	}
 	return "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, message, errorScope)
 }
+
+// hashPassword hashes the password of a new account; only proxy auth allows accounts without one.
+func (s *Service) hashPassword(password string) (string, error) {
+	password = strings.TrimSpace(password)
+	if password == "" {
+		if s.authHeader == "" {
+			return "", errpkg.NewErrorWithScope(errpkg.ErrorValidation, "email and password are required", errorScope)
+		}
+		return "", nil
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return string(hash), nil
+}
@@ -2,53 +2,110 @@ package users
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 	"github.com/gi8lino/motus/internal/utils"
 )
 
-// Create registers a new local user.
-func (s *Service) Create(ctx context.Context, email, avatarURL, password string) (*User, error) {
+// Create adds a user on behalf of an administrator; the account counts as verified.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, email, avatarURL, password string) (*User, error) {
+	if err := policy.RequirePermission(actor, policy.PermUsersManage, errorScope); err != nil {
+		return nil, err
+	}
+	normalized, err := utils.NormalizeEmail(email)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+	passwordHash, err := s.hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.CreateUser(ctx, normalized, avatarURL, passwordHash)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return user, nil
+}
+
+// AcceptInvitation registers a user through an invite link.
+// A valid invite token bypasses disabled registration and applies the invitation's role.
+func (s *Service) AcceptInvitation(ctx context.Context, email, avatarURL, password, inviteToken string) (*User, error) {
+	normalized, err := utils.NormalizeEmail(email)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
+	}
+	inviteToken = strings.TrimSpace(inviteToken)
+	if inviteToken == "" {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "invite token is required", errorScope)
+	}
+	passwordHash, err := s.hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.CreateInvitedUser(ctx, utils.HashToken(inviteToken), normalized, avatarURL, passwordHash, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, db.ErrInvitationInvalid) {
+			return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, err.Error(), errorScope)
+		}
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
+	}
+	return user, nil
+}
+
+// Register creates a self-registered local user whose email still needs verification.
+func (s *Service) Register(ctx context.Context, email, password string) (*User, error) {
 	normalized, err := utils.NormalizeEmail(email)
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
 
-	if s.authHeader == "" && !s.allowRegistration {
+	if s.authHeader != "" || !s.allowRegistration {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorForbidden, "registration is disabled", errorScope)
 	}
 
 	password = strings.TrimSpace(password)
-	if s.authHeader == "" && password == "" {
+	if password == "" {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "email and password are required", errorScope)
 	}
 
-	var passwordHash string
-	if password != "" {
-		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-		if err != nil {
-			return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
-		}
-		passwordHash = string(hash)
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
 
-	user, err := s.store.CreateUser(ctx, normalized, avatarURL, passwordHash)
+	user, err := s.store.CreatePendingUser(ctx, normalized, string(hash))
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
 	return user, nil
 }
 
-// UpdateRole toggles admin access.
-func (s *Service) UpdateRole(ctx context.Context, id string, isAdmin bool) error {
+// UpdateRole assigns one of the known roles to a user. The last admin cannot be demoted.
+func (s *Service) UpdateRole(ctx context.Context, id, role string) error {
 	cleanID, err := requireEntityID(id, "user id is required")
 	if err != nil {
 		return err
 	}
-	if err := s.store.UpdateUserAdmin(ctx, cleanID, isAdmin); err != nil {
+	cleanRole, ok := policy.NormalizeRole(role)
+	if !ok {
+		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "role must be one of "+strings.Join(policy.Roles, ", "), errorScope)
+	}
+	if err := s.store.UpdateUserRole(ctx, cleanID, cleanRole); err != nil {
+		switch {
+		case errors.Is(err, db.ErrUserNotFound):
+			return errpkg.NewErrorWithScope(errpkg.ErrorNotFound, err.Error(), errorScope)
+		case errors.Is(err, db.ErrLastAdmin):
+			return errpkg.NewErrorWithScope(errpkg.ErrorConflict, err.Error(), errorScope)
+		}
 		return errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
 	}
 	return nil
@@ -3,21 +3,37 @@ package users
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 
+	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
+	"github.com/gi8lino/motus/internal/utils"
 )
 
 type fakeStore struct {
 	createUserFn      func(context.Context, string, string, string) (*User, error)
-	updateUserAdminFn func(context.Context, string, bool) error
+	createPendingFn   func(context.Context, string, string) (*User, error)
+	createInvitedFn   func(context.Context, string, string, string, string, time.Time) (*User, error)
+	updateUserRoleFn  func(context.Context, string, string) error
 	getUserWithPassFn func(context.Context, string) (*User, string, error)
 	updateUserPassFn  func(context.Context, string, string) error
 	updateUserNameFn  func(context.Context, string, string) error
 	getUserFn         func(context.Context, string) (*User, error)
 	listUsersFn       func(context.Context) ([]User, error)
+	createTokenFn     func(context.Context, OneTimeToken) error
+	consumeTokenFn    func(context.Context, string, string, time.Time) (string, error)
+	getTOTPFn         func(context.Context, string) (*TOTP, error)
+	setPendingTOTPFn  func(context.Context, string, string) error
+	enableTOTPFn      func(context.Context, string, int64, []string, time.Time) error
+	disableTOTPFn     func(context.Context, string) error
+	claimStepFn       func(context.Context, string, int64) (bool, error)
+	replaceCodesFn    func(context.Context, string, []string) error
+	useRecoveryFn     func(context.Context, string, string, time.Time) (bool, error)
 }
 
 func (f *fakeStore) ListUsers(ctx context.Context) ([]User, error) {
@@ -34,11 +50,25 @@ func (f *fakeStore) CreateUser(ctx context.Context, email, avatarURL, passwordHa
 	return f.createUserFn(ctx, email, avatarURL, passwordHash)
 }
 
-func (f *fakeStore) UpdateUserAdmin(ctx context.Context, id string, isAdmin bool) error {
-	if f.updateUserAdminFn == nil {
+func (f *fakeStore) CreatePendingUser(ctx context.Context, email, passwordHash string) (*User, error) {
+	if f.createPendingFn == nil {
+		return nil, nil
+	}
+	return f.createPendingFn(ctx, email, passwordHash)
+}
+
+func (f *fakeStore) CreateInvitedUser(ctx context.Context, tokenHash, email, avatarURL, passwordHash string, at time.Time) (*User, error) {
+	if f.createInvitedFn == nil {
+		return nil, db.ErrInvitationInvalid
+	}
+	return f.createInvitedFn(ctx, tokenHash, email, avatarURL, passwordHash, at)
+}
+
+func (f *fakeStore) UpdateUserRole(ctx context.Context, id, role string) error {
+	if f.updateUserRoleFn == nil {
 		return nil
 	}
-	return f.updateUserAdminFn(ctx, id, isAdmin)
+	return f.updateUserRoleFn(ctx, id, role)
 }
 
 func (f *fakeStore) GetUserWithPassword(ctx context.Context, id string) (*User, string, error) {
@@ -71,23 +101,107 @@ func (f *fakeStore) GetUser(ctx context.Context, id string) (*User, error) {
 	return f.getUserFn(ctx, id)
 }
 
+func (f *fakeStore) CreateOneTimeToken(ctx context.Context, token OneTimeToken) error {
+	if f.createTokenFn == nil {
+		return nil
+	}
+	return f.createTokenFn(ctx, token)
+}
+
+func (f *fakeStore) ConsumeOneTimeToken(ctx context.Context, tokenHash, purpose string, at time.Time) (string, error) {
+	if f.consumeTokenFn == nil {
+		return "", nil
+	}
+	return f.consumeTokenFn(ctx, tokenHash, purpose, at)
+}
+
+func (f *fakeStore) GetTOTP(ctx context.Context, userID string) (*TOTP, error) {
+	if f.getTOTPFn == nil {
+		return &TOTP{}, nil
+	}
+	return f.getTOTPFn(ctx, userID)
+}
+
+func (f *fakeStore) SetPendingTOTP(ctx context.Context, userID, secret string) error {
+	if f.setPendingTOTPFn == nil {
+		return nil
+	}
+	return f.setPendingTOTPFn(ctx, userID, secret)
+}
+
+func (f *fakeStore) EnableTOTP(ctx context.Context, userID string, step int64, codeHashes []string, at time.Time) error {
+	if f.enableTOTPFn == nil {
+		return nil
+	}
+	return f.enableTOTPFn(ctx, userID, step, codeHashes, at)
+}
+
+func (f *fakeStore) DisableTOTP(ctx context.Context, userID string) error {
+	if f.disableTOTPFn == nil {
+		return nil
+	}
+	return f.disableTOTPFn(ctx, userID)
+}
+
+func (f *fakeStore) ClaimTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	if f.claimStepFn == nil {
+		return true, nil
+	}
+	return f.claimStepFn(ctx, userID, step)
+}
+
+func (f *fakeStore) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	if f.replaceCodesFn == nil {
+		return nil
+	}
+	return f.replaceCodesFn(ctx, userID, codeHashes)
+}
+
+func (f *fakeStore) UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error) {
+	if f.useRecoveryFn == nil {
+		return false, nil
+	}
+	return f.useRecoveryFn(ctx, userID, codeHash, at)
+}
+
 func TestCreate(t *testing.T) {
 	t.Parallel()
 
-	t.Run("Registration disabled", func(t *testing.T) {
+	admin := policy.NewActor("admin@example.com", db.RoleAdmin)
+
+	t.Run("Requires users:manage", func(t *testing.T) {
 		t.Parallel()
 
-		svc := New(&fakeStore{}, "", false)
-		_, err := svc.Create(context.Background(), "user@example.com", "", "secret")
+		svc := New(&fakeStore{
+			createUserFn: func(context.Context, string, string, string) (*User, error) {
+				t.Fatal("member created a verified account")
+				return nil, nil
+			},
+		}, "", true)
+		_, err := svc.Create(context.Background(), policy.NewActor("member@example.com", db.RoleMember), "user@example.com", "", "secret")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
 	})
 
+	t.Run("Ignores disabled registration", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			createUserFn: func(_ context.Context, email, _, passwordHash string) (*User, error) {
+				assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte("secret")))
+				return &User{ID: email}, nil
+			},
+		}, "", false)
+		user, err := svc.Create(context.Background(), admin, "User@Example.com", "", "secret")
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", user.ID)
+	})
+
 	t.Run("Password required", func(t *testing.T) {
 		t.Parallel()
 
 		svc := New(&fakeStore{}, "", true)
-		_, err := svc.Create(context.Background(), "user@example.com", "", " ")
+		_, err := svc.Create(context.Background(), admin, "user@example.com", "", " ")
 		require.Error(t, err)
 		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
 	})
@@ -102,7 +216,7 @@ func TestCreate(t *testing.T) {
 				return &User{ID: "user"}, nil
 			},
 		}, "X-User", false)
-		user, err := svc.Create(context.Background(), "user@example.com", "", "")
+		user, err := svc.Create(context.Background(), admin, "user@example.com", "", "")
 		require.NoError(t, err)
 		require.NotNil(t, user)
 		assert.Equal(t, "user", user.ID)
@@ -110,26 +224,144 @@ func TestCreate(t *testing.T) {
 	})
 }
 
+func TestAcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Invite bypasses disabled registration", func(t *testing.T) {
+		t.Parallel()
+
+		var tokenHash string
+		svc := New(&fakeStore{
+			createInvitedFn: func(_ context.Context, hash, email, _, passwordHash string, _ time.Time) (*User, error) {
+				tokenHash = hash
+				assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte("secret")))
+				return &User{ID: email, IsAdmin: true}, nil
+			},
+		}, "", false)
+		user, err := svc.AcceptInvitation(context.Background(), "User@Example.com", "", "secret", " invite ")
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", user.ID)
+		assert.True(t, user.IsAdmin)
+		assert.Equal(t, utils.HashToken("invite"), tokenHash)
+	})
+
+	t.Run("Invite required", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, "", true)
+		_, err := svc.AcceptInvitation(context.Background(), "user@example.com", "", "secret", " ")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Password required", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, "", false)
+		_, err := svc.AcceptInvitation(context.Background(), "user@example.com", "", " ", "invite")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Invalid invite", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, "", false)
+		_, err := svc.AcceptInvitation(context.Background(), "user@example.com", "", "secret", "invite")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+		assert.EqualError(t, err, db.ErrInvitationInvalid.Error())
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Registration disabled", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, "", false)
+		_, err := svc.Register(context.Background(), "user@example.com", "secret")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorForbidden))
+	})
+
+	t.Run("Password required", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, "", true)
+		_, err := svc.Register(context.Background(), "user@example.com", " ")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
+
+	t.Run("Creates pending user", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			createPendingFn: func(_ context.Context, email, passwordHash string) (*User, error) {
+				assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte("secret")))
+				return &User{ID: email}, nil
+			},
+		}, "", true)
+		user, err := svc.Register(context.Background(), "User@Example.com", "secret")
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", user.ID)
+		assert.Nil(t, user.EmailVerifiedAt)
+	})
+}
+
 func TestUpdateRole(t *testing.T) {
 	t.Parallel()
 
-	t.Run("UpdatesAdminFlag", func(t *testing.T) {
+	t.Run("UpdatesRole", func(t *testing.T) {
 		t.Parallel()
 
-		called := false
+		var updated string
 		svc := New(&fakeStore{
-			updateUserAdminFn: func(context.Context, string, bool) error {
-				called = true
+			updateUserRoleFn: func(_ context.Context, _ string, role string) error {
+				updated = role
 				return nil
 			},
 		}, "", false)
-		if err := svc.UpdateRole(context.Background(), "user", true); err != nil {
+		if err := svc.UpdateRole(context.Background(), "user", " Coach "); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if !called {
-			t.Fatalf("expected UpdateUserAdmin to be called")
+		if updated != db.RoleCoach {
+			t.Fatalf("expected role %q, got %q", db.RoleCoach, updated)
 		}
 	})
+
+	t.Run("KeepsLastAdmin", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			updateUserRoleFn: func(context.Context, string, string) error { return db.ErrLastAdmin },
+		}, "", false)
+		err := svc.UpdateRole(context.Background(), "admin", db.RoleMember)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorConflict))
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{
+			updateUserRoleFn: func(context.Context, string, string) error { return db.ErrUserNotFound },
+		}, "", false)
+		err := svc.UpdateRole(context.Background(), "ghost", db.RoleCoach)
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorNotFound))
+	})
+
+	t.Run("RejectsUnknownRole", func(t *testing.T) {
+		t.Parallel()
+
+		svc := New(&fakeStore{}, "", false)
+		err := svc.UpdateRole(context.Background(), "user", "owner")
+		require.Error(t, err)
+		assert.True(t, errpkg.IsKind(err, errpkg.ErrorValidation))
+	})
 }
 
 func TestUpdateName(t *testing.T) {
@@ -7,10 +7,23 @@ import (
 
 	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 )
 
-// Get returns a workout by id.
-func (s *Service) Get(ctx context.Context, id string) (*Workout, error) {
+// Get returns a workout by id when the actor may access it.
+func (s *Service) Get(ctx context.Context, actor policy.Actor, id string) (*Workout, error) {
+	workout, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.RequireOwner(actor, workout.UserID, errorScope); err != nil {
+		return nil, err
+	}
+	return workout, nil
+}
+
+// load fetches a workout by id without authorization checks.
+func (s *Service) load(ctx context.Context, id string) (*Workout, error) {
 	id = strings.TrimSpace(id)
 	if id == "" {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "workout id is required", errorScope)
@@ -29,8 +42,8 @@ func (s *Service) Get(ctx context.Context, id string) (*Workout, error) {
 }
 
 // Export returns a workout for sharing.
-func (s *Service) Export(ctx context.Context, id string) (*Workout, error) {
-	workout, err := s.Get(ctx, id)
+func (s *Service) Export(ctx context.Context, actor policy.Actor, id string) (*Workout, error) {
+	workout, err := s.Get(ctx, actor, id)
 	if err != nil {
 		return nil, err
 	}
@@ -38,11 +51,14 @@ func (s *Service) Export(ctx context.Context, id string) (*Workout, error) {
 }
 
 // List returns workouts for the given user.
-func (s *Service) List(ctx context.Context, userID string) ([]Workout, error) {
+func (s *Service) List(ctx context.Context, actor policy.Actor, userID string) ([]Workout, error) {
 	userID = strings.TrimSpace(userID)
 	if userID == "" {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "user id is required", errorScope)
 	}
+	if err := policy.RequireOwner(actor, userID, errorScope); err != nil {
+		return nil, err
+	}
 	workouts, err := s.store.WorkoutsByUser(ctx, userID)
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorInternal, err.Error(), errorScope)
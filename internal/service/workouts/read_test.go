@@ -3,6 +3,8 @@ package workouts
 import (
 	"context"
 	"testing"
+
+	errpkg "github.com/gi8lino/motus/internal/service/errors"
 )
 
 func TestGet(t *testing.T) {
@@ -12,10 +14,10 @@ func TestGet(t *testing.T) {
 		t.Parallel()
 		svc := New(&fakeStore{
 			getFn: func(context.Context, string) (*Workout, error) {
-				return &Workout{ID: "w1"}, nil
+				return &Workout{ID: "w1", UserID: "u1"}, nil
 			},
 		})
-		workout, err := svc.Get(context.Background(), "w1")
+		workout, err := svc.Get(context.Background(), owner, "w1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -23,6 +25,31 @@ func TestGet(t *testing.T) {
 			t.Fatalf("expected workout")
 		}
 	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			getFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "w1", UserID: "u1"}, nil
+			},
+		})
+		_, err := svc.Get(context.Background(), other, "w1")
+		if !errpkg.IsKind(err, errpkg.ErrorForbidden) {
+			t.Fatalf("expected forbidden error, got: %v", err)
+		}
+	})
+
+	t.Run("AdminOverride", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{
+			getFn: func(context.Context, string) (*Workout, error) {
+				return &Workout{ID: "w1", UserID: "u1"}, nil
+			},
+		})
+		if _, err := svc.Get(context.Background(), admin, "w1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
 }
 
 func TestExport(t *testing.T) {
@@ -32,10 +59,10 @@ func TestExport(t *testing.T) {
 		t.Parallel()
 		svc := New(&fakeStore{
 			getFn: func(context.Context, string) (*Workout, error) {
-				return &Workout{ID: "w1"}, nil
+				return &Workout{ID: "w1", UserID: "u1"}, nil
 			},
 		})
-		workout, err := svc.Export(context.Background(), "w1")
+		workout, err := svc.Export(context.Background(), owner, "w1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -55,7 +82,7 @@ func TestList(t *testing.T) {
 				return []Workout{{ID: "w1"}}, nil
 			},
 		})
-		workouts, err := svc.List(context.Background(), "u1")
+		workouts, err := svc.List(context.Background(), owner, "u1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -63,4 +90,12 @@ func TestList(t *testing.T) {
 			t.Fatalf("expected workouts")
 		}
 	})
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		_, err := svc.List(context.Background(), other, "u1")
+		if !errpkg.IsKind(err, errpkg.ErrorForbidden) {
+			t.Fatalf("expected forbidden error, got: %v", err)
+		}
+	})
 }
@@ -1,6 +1,23 @@
 package workouts
 
-import "context"
+import (
+	"context"
+
+	"github.com/gi8lino/motus/internal/service/policy"
+)
+
+var (
+	owner = policy.Actor{UserID: "u1"}
+	other = policy.Actor{UserID: "u2"}
+	admin = policy.Actor{UserID: "admin", IsAdmin: true}
+)
+
+// ownedBy returns a workout lookup that reports the given owner.
+func ownedBy(userID string) func(context.Context, string) (*Workout, error) {
+	return func(_ context.Context, id string) (*Workout, error) {
+		return &Workout{ID: id, UserID: userID}, nil
+	}
+}
 
 type fakeStore struct {
 	createFn func(context.Context, *Workout) (*Workout, error)
@@ -7,16 +7,20 @@ import (
 
 	"github.com/gi8lino/motus/internal/db"
 	errpkg "github.com/gi8lino/motus/internal/service/errors"
+	"github.com/gi8lino/motus/internal/service/policy"
 	"github.com/gi8lino/motus/internal/service/sounds"
 )
 
 // Create stores a new workout for the user.
-func (s *Service) Create(ctx context.Context, req WorkoutRequest) (*Workout, error) {
+func (s *Service) Create(ctx context.Context, actor policy.Actor, req WorkoutRequest) (*Workout, error) {
 	req.UserID = strings.TrimSpace(req.UserID)
 	req.Name = strings.TrimSpace(req.Name)
 	if req.UserID == "" || req.Name == "" || len(req.Steps) == 0 {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "userId, name, and steps are required", errorScope)
 	}
+	if err := policy.RequireOwner(actor, req.UserID, errorScope); err != nil {
+		return nil, err
+	}
 
 	steps, err := NormalizeSteps(req.Steps, sounds.ValidKey)
 	if err != nil {
@@ -32,10 +36,9 @@ func (s *Service) Create(ctx context.Context, req WorkoutRequest) (*Workout, err
 	return created, nil
 }
 
-// Update replaces a workout and its steps.
-func (s *Service) Update(ctx context.Context, id string, req WorkoutRequest) (*Workout, error) {
+// Update replaces a workout and its steps; the stored owner is kept.
+func (s *Service) Update(ctx context.Context, actor policy.Actor, id string, req WorkoutRequest) (*Workout, error) {
 	id = strings.TrimSpace(id)
-	req.UserID = strings.TrimSpace(req.UserID)
 	req.Name = strings.TrimSpace(req.Name)
 	if id == "" {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "workout id is required", errorScope)
@@ -44,12 +47,17 @@ func (s *Service) Update(ctx context.Context, id string, req WorkoutRequest) (*W
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, "name and steps are required", errorScope)
 	}
 
+	existing, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
 	steps, err := NormalizeSteps(req.Steps, sounds.ValidKey)
 	if err != nil {
 		return nil, errpkg.NewErrorWithScope(errpkg.ErrorValidation, err.Error(), errorScope)
 	}
 
-	workout := &Workout{ID: id, UserID: req.UserID, Name: req.Name, Steps: steps}
+	workout := &Workout{ID: id, UserID: existing.UserID, Name: req.Name, Steps: steps}
 	updated, err := s.store.UpdateWorkout(ctx, workout)
 	if err != nil {
 		if errors.Is(err, db.ErrWorkoutNotFound) {
@@ -62,11 +70,14 @@ func (s *Service) Update(ctx context.Context, id string, req WorkoutRequest) (*W
 }
 
 // Delete removes a workout by id.
-func (s *Service) Delete(ctx context.Context, id string) error {
+func (s *Service) Delete(ctx context.Context, actor policy.Actor, id string) error {
 	id = strings.TrimSpace(id)
 	if id == "" {
 		return errpkg.NewErrorWithScope(errpkg.ErrorValidation, "workout id is required", errorScope)
 	}
+	if _, err := s.Get(ctx, actor, id); err != nil {
+		return err
+	}
 
 	if err := s.store.DeleteWorkout(ctx, id); err != nil {
 		if errors.Is(err, db.ErrWorkoutNotFound) {
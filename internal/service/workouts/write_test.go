@@ -20,7 +20,7 @@ func TestCreate(t *testing.T) {
 				return &Workout{ID: "w1"}, nil
 			},
 		})
-		workout, err := svc.Create(context.Background(), WorkoutRequest{UserID: "u1", Name: "Workout", Steps: []StepInput{{Type: "set", Name: "A", Subsets: []SubsetInput{{Exercises: []ExerciseInput{{Name: "X"}}}}}}})
+		workout, err := svc.Create(context.Background(), owner, WorkoutRequest{UserID: "u1", Name: "Workout", Steps: []StepInput{{Type: "set", Name: "A", Subsets: []SubsetInput{{Exercises: []ExerciseInput{{Name: "X"}}}}}}})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -28,6 +28,15 @@ func TestCreate(t *testing.T) {
 			t.Fatalf("expected create to run")
 		}
 	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{})
+		_, err := svc.Create(context.Background(), other, WorkoutRequest{UserID: "u1", Name: "Workout", Steps: []StepInput{{Type: "set", Name: "A", Subsets: []SubsetInput{{Exercises: []ExerciseInput{{Name: "X"}}}}}}})
+		if !errpkg.IsKind(err, errpkg.ErrorForbidden) {
+			t.Fatalf("expected forbidden error, got: %v", err)
+		}
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -37,12 +46,13 @@ func TestUpdate(t *testing.T) {
 		t.Parallel()
 		called := false
 		svc := New(&fakeStore{
+			getFn: ownedBy("u1"),
 			updateFn: func(context.Context, *Workout) (*Workout, error) {
 				called = true
 				return &Workout{ID: "w1"}, nil
 			},
 		})
-		workout, err := svc.Update(context.Background(), "w1", WorkoutRequest{Name: "Workout", Steps: []StepInput{{Type: "set", Name: "A", Subsets: []SubsetInput{{Exercises: []ExerciseInput{{Name: "X"}}}}}}})
+		workout, err := svc.Update(context.Background(), owner, "w1", WorkoutRequest{Name: "Workout", Steps: []StepInput{{Type: "set", Name: "A", Subsets: []SubsetInput{{Exercises: []ExerciseInput{{Name: "X"}}}}}}})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -51,14 +61,43 @@ func TestUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("AdminKeepsOwner", func(t *testing.T) {
+		t.Parallel()
+		var stored *Workout
+		svc := New(&fakeStore{
+			getFn: ownedBy("u1"),
+			updateFn: func(_ context.Context, workout *Workout) (*Workout, error) {
+				stored = workout
+				return workout, nil
+			},
+		})
+		_, err := svc.Update(context.Background(), admin, "w1", WorkoutRequest{UserID: "admin", Name: "Workout", Steps: []StepInput{{Type: "set", Name: "A", Subsets: []SubsetInput{{Exercises: []ExerciseInput{{Name: "X"}}}}}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stored == nil || stored.UserID != "u1" {
+			t.Fatalf("expected stored owner to be kept, got: %+v", stored)
+		}
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+		svc := New(&fakeStore{getFn: ownedBy("u1")})
+		_, err := svc.Update(context.Background(), other, "w1", WorkoutRequest{Name: "Workout", Steps: []StepInput{{Type: "set", Name: "A", Subsets: []SubsetInput{{Exercises: []ExerciseInput{{Name: "X"}}}}}}})
+		if !errpkg.IsKind(err, errpkg.ErrorForbidden) {
+			t.Fatalf("expected forbidden error, got: %v", err)
+		}
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		t.Parallel()
 		svc := New(&fakeStore{
+			getFn: ownedBy("u1"),
 			updateFn: func(context.Context, *Workout) (*Workout, error) {
 				return nil, db.ErrWorkoutNotFound
 			},
 		})
-		workout, err := svc.Update(context.Background(), "w1", WorkoutRequest{Name: "Workout", Steps: []StepInput{{Type: "set", Name: "A", Subsets: []SubsetInput{{Exercises: []ExerciseInput{{Name: "X"}}}}}}})
+		workout, err := svc.Update(context.Background(), owner, "w1", WorkoutRequest{Name: "Workout", Steps: []StepInput{{Type: "set", Name: "A", Subsets: []SubsetInput{{Exercises: []ExerciseInput{{Name: "X"}}}}}}})
 		if workout != nil {
 			t.Fatalf("expected nil workout")
 		}
@@ -81,16 +120,35 @@ func TestDelete(t *testing.T) {
 		t.Parallel()
 		called := false
 		svc := New(&fakeStore{
+			getFn: ownedBy("u1"),
 			deleteFn: func(context.Context, string) error {
 				called = true
 				return nil
 			},
 		})
-		if err := svc.Delete(context.Background(), "w1"); err != nil {
+		if err := svc.Delete(context.Background(), owner, "w1"); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 		if !called {
 			t.Fatalf("expected delete to run")
 		}
 	})
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+		called := false
+		svc := New(&fakeStore{
+			getFn: ownedBy("u1"),
+			deleteFn: func(context.Context, string) error {
+				called = true
+				return nil
+			},
+		})
+		err := svc.Delete(context.Background(), other, "w1")
+		if !errpkg.IsKind(err, errpkg.ErrorForbidden) {
+			t.Fatalf("expected forbidden error, got: %v", err)
+		}
+		if called {
+			t.Fatalf("expected delete to be skipped")
+		}
+	})
 }
@@ -0,0 +1,38 @@
+package totp
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// RecoveryCodeCount is the number of recovery codes issued per enrollment.
+const RecoveryCodeCount = 10
+
+// recoveryAlphabet has 32 characters without the easily confused i, l, o and 0.
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz123456789"
+
+// NewRecoveryCodes returns single-use codes formatted as "xxxxx-xxxxx".
+func NewRecoveryCodes() []string {
+	codes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		var b [10]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			panic(err)
+		}
+		var sb strings.Builder
+		for j, v := range b {
+			if j == 5 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(recoveryAlphabet[v&31])
+		}
+		codes[i] = sb.String()
+	}
+	return codes
+}
+
+// NormalizeRecoveryCode lowercases a recovery code and strips separators for comparison.
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
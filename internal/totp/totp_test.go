@@ -0,0 +1,108 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the base32 form of the RFC 6238 SHA-1 test key "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+	for _, tc := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, "unix %d", tc.unix)
+	}
+
+	t.Run("Invalid secret", func(t *testing.T) {
+		t.Parallel()
+		_, err := Code("not base32!", 1)
+		require.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1234567890, 0)
+
+	t.Run("Current step", func(t *testing.T) {
+		t.Parallel()
+		step, ok := Validate(rfcSecret, "005924", now)
+		assert.True(t, ok)
+		assert.Equal(t, Step(now), step)
+	})
+
+	t.Run("Previous step within skew", func(t *testing.T) {
+		t.Parallel()
+		step, ok := Validate(rfcSecret, "005924", now.Add(Period))
+		assert.True(t, ok)
+		assert.Equal(t, Step(now), step)
+	})
+
+	t.Run("Outside skew", func(t *testing.T) {
+		t.Parallel()
+		_, ok := Validate(rfcSecret, "005924", now.Add(3*Period))
+		assert.False(t, ok)
+	})
+
+	t.Run("Wrong length", func(t *testing.T) {
+		t.Parallel()
+		_, ok := Validate(rfcSecret, "5924", now)
+		assert.False(t, ok)
+	})
+}
+
+func TestNewSecret(t *testing.T) {
+	t.Parallel()
+
+	secret := NewSecret()
+	assert.Len(t, secret, 32)
+	assert.NotEqual(t, secret, NewSecret())
+	_, err := Code(secret, 1)
+	require.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	t.Parallel()
+
+	raw := URI("Motus", "user@example.com", rfcSecret)
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Motus:user@example.com", u.Path)
+	assert.Equal(t, rfcSecret, u.Query().Get("secret"))
+	assert.Equal(t, "Motus", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	codes := NewRecoveryCodes()
+	require.Len(t, codes, RecoveryCodeCount)
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z1-9]{5}-[a-z1-9]{5}$`, code)
+		seen[code] = true
+	}
+	assert.Len(t, seen, RecoveryCodeCount)
+	assert.Equal(t, "abcdefghjk", NormalizeRecoveryCode(" ABCDE-fghjk "))
+}
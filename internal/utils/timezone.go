@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"time"
+	// Embed the zone database so zones resolve on images without one.
+	_ "time/tzdata"
+)
+
+// ParseTimeZone resolves the IANA zone a client asked for. An empty name means UTC.
+func ParseTimeZone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return time.UTC, nil
+	}
+	// Local would follow the server zone, which is never what a client means.
+	if name == "Local" {
+		return nil, errors.New("invalid tz")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("invalid tz")
+	}
+	return loc, nil
+}
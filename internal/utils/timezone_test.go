@@ -0,0 +1,37 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gi8lino/motus/internal/utils"
+)
+
+func TestParseTimeZone(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty means UTC", func(t *testing.T) {
+		t.Parallel()
+		loc, err := utils.ParseTimeZone(" ")
+		require.NoError(t, err)
+		assert.Equal(t, time.UTC, loc)
+	})
+
+	t.Run("IANA zone", func(t *testing.T) {
+		t.Parallel()
+		loc, err := utils.ParseTimeZone("Europe/Zurich")
+		require.NoError(t, err)
+		assert.Equal(t, "Europe/Zurich", loc.String())
+	})
+
+	t.Run("Rejects Local and unknown zones", func(t *testing.T) {
+		t.Parallel()
+		for _, name := range []string{"Local", "Mars/Olympus"} {
+			_, err := utils.ParseTimeZone(name)
+			assert.EqualError(t, err, "invalid tz", name)
+		}
+	})
+}
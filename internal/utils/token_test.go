@@ -0,0 +1,42 @@
+package utils_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/gi8lino/motus/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Decodes to 32 bytes", func(t *testing.T) {
+		t.Parallel()
+		token := utils.NewToken()
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		require.NoError(t, err)
+		assert.Len(t, raw, 32)
+	})
+
+	t.Run("Unique tokens", func(t *testing.T) {
+		t.Parallel()
+		assert.NotEqual(t, utils.NewToken(), utils.NewToken())
+	})
+}
+
+func TestHashToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stable digest", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, utils.HashToken("secret"), utils.HashToken("secret"))
+		assert.Len(t, utils.HashToken("secret"), 64)
+	})
+
+	t.Run("Different input", func(t *testing.T) {
+		t.Parallel()
+		assert.NotEqual(t, utils.HashToken("a"), utils.HashToken("b"))
+	})
+}
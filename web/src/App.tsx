@@ -20,10 +20,18 @@ import {
   applyTemplate,
   getWorkout,
   listExercises,
+  listTrainingHistory,
+  logoutUser,
+  updateTrainingFeedback,
   updateUserName,
 } from "./api";
 
-import type { CatalogExercise, ThemeMode, User } from "./types";
+import type {
+  CatalogExercise,
+  ThemeMode,
+  TrainingFeedback,
+  User,
+} from "./types";
 
 import { useTrainingTimer } from "./hooks/useTrainingTimer";
 import { useDialog } from "./hooks/useDialog";
@@ -38,10 +46,14 @@ import DialogModal from "./components/common/DialogModal";
 import { isValidEmail } from "./utils/validation";
 import { PROMPTS, toErrorMessage } from "./utils/messages";
 import { UI_TEXT } from "./utils/uiText";
+import { canCoach, canEditCatalog } from "./utils/roles";
 import { buildAppTheme } from "./theme";
 
 import { useAuthActions } from "./hooks/useAuthActions";
 import { useAdminActions } from "./hooks/useAdminActions";
+import { useAccountLinks } from "./hooks/useAccountLinks";
+import { useCoaching } from "./hooks/useCoaching";
+import { useOrganizations } from "./hooks/useOrganizations";
 import { useExerciseActions } from "./hooks/useExerciseActions";
 import { useProfileActions } from "./hooks/useProfileActions";
 import { useTrainingActions } from "./hooks/useTrainingActions";
@@ -106,6 +118,8 @@ function shouldResetStoredUserID(error: string | null): boolean {
     text.includes("unauthorized") ||
     text.includes("not found") ||
     text.includes("auth header is required") ||
+    text.includes("session is required") ||
+    text.includes("session is invalid") ||
     text.includes("userid is required")
   );
 }
@@ -172,7 +186,12 @@ export default function App() {
   const [exerciseCatalog, setExerciseCatalog] = useState<CatalogExercise[]>([]);
   const [toast, setToast] = useState<string | null>(null);
   const [profileTab, setProfileTab] = useState<
-    "settings" | "password" | "transfer"
+    | "settings"
+    | "password"
+    | "security"
+    | "coaching"
+    | "organizations"
+    | "transfer"
   >("settings");
   const [exportWorkoutId, setExportWorkoutId] = useState("");
 
@@ -195,9 +214,13 @@ export default function App() {
   });
   const allowRegistration = config?.allowRegistration ?? true;
   const authHeaderEnabled = config?.authHeaderEnabled ?? false;
+  // Proxy and OIDC users have no local password to change.
+  const passwordManagedExternally =
+    authHeaderEnabled || config?.authMode === "oidc";
   const appVersion = config?.version || "dev";
   const {
     users,
+    invitations,
     sounds,
     workouts,
     history,
@@ -304,7 +327,7 @@ export default function App() {
   ]);
 
   // ---------- keep exercise catalog in sync ----------
-  useEffect(() => {
+  const reloadExercises = useCallback(() => {
     if (!authHeaderEnabled && !currentUserId) {
       setExerciseCatalog([]);
       return;
@@ -314,6 +337,10 @@ export default function App() {
       .catch(() => {});
   }, [authHeaderEnabled, currentUserId]);
 
+  useEffect(() => {
+    reloadExercises();
+  }, [reloadExercises]);
+
   // ---------- toast ----------
   const showToast = useCallback((message: string) => {
     setToast(message);
@@ -329,20 +356,59 @@ export default function App() {
 
   const onRegisterSuccess = (user: User) => {
     users.setData?.((prev) => (prev ? [...prev, user] : [user]));
+    // Self-registered users confirm their email before they can log in.
+    if (!authHeaderEnabled && !user.emailVerifiedAt) {
+      void notify(UI_TEXT.toasts.verificationSent);
+      return;
+    }
     setCurrentUserId(user.id);
     if (!authHeaderEnabled) localStorage.setItem("motus:userId", user.id);
   };
 
-  const { login: handleLogin, register: handleRegister } = useAuthActions({
+  const {
+    login: handleLogin,
+    register: handleRegister,
+    acceptInvitation: handleAcceptInvitation,
+    requestReset: handleRequestReset,
+    completeReset: handleCompleteReset,
+    resend: handleResendVerification,
+    totpChallenge,
+    verifyTotp: handleVerifyTotp,
+    cancelTotp: handleCancelTotp,
+  } = useAuthActions({
     setLoginError,
     onLoginSuccess,
     onRegisterSuccess,
+    notify,
+  });
+  const { resetToken, clearResetToken, inviteToken, clearInviteToken } =
+    useAccountLinks({ notify });
+  const coaching = useCoaching({ currentUserId, notify });
+
+  // Switching organizations changes which shared templates and exercises are visible.
+  const reloadTemplates = templates.reload;
+  const reloadLibrary = useCallback(() => {
+    reloadTemplates();
+    reloadExercises();
+  }, [reloadTemplates, reloadExercises]);
+  const organizations = useOrganizations({
+    currentUserId,
+    notify,
+    onContextChange: reloadLibrary,
   });
 
   // ---------- admin actions ----------
-  const { toggleAdmin: handleToggleAdmin, backfillCatalog } = useAdminActions({
+  const {
+    changeRole: handleChangeRole,
+    createUser: handleAdminCreateUser,
+    sendPasswordReset: handleSendPasswordReset,
+    backfillCatalog,
+    inviteUser: handleCreateInvitation,
+    cancelInvitation: handleRevokeInvitation,
+  } = useAdminActions({
     currentUserId,
     setUsers: (updater) => users.setData?.(updater),
+    setInvitations: (updater) => invitations.setData?.(updater),
     setView,
     notify,
   });
@@ -355,7 +421,7 @@ export default function App() {
     renameExercise: handleRenameExercise,
     deleteExerciseEntry: handleDeleteExercise,
   } = useExerciseActions({
-    isAdmin: Boolean(currentUser?.isAdmin),
+    canEditCatalog: canEditCatalog(currentUser),
     setExerciseCatalog,
     askPrompt,
     askConfirm,
@@ -370,6 +436,11 @@ export default function App() {
     exportSelectedWorkout: handleExportSelected,
     importWorkoutFile: handleImportSelected,
     updatePassword: handlePasswordSubmit,
+    startTotp: handleStartTotp,
+    enableTotp: handleEnableTotp,
+    turnOffTotp: handleDisableTotp,
+    renewRecoveryCodes: handleRenewRecoveryCodes,
+    removeAccount: handleDeleteAccount,
   } = useProfileActions({
     currentUserId,
     exportWorkoutId,
@@ -377,6 +448,8 @@ export default function App() {
     setWorkouts: (updater) => workouts.setData?.(updater),
     showToast,
     notify,
+    onTotpChange: () => currentUserLoader.reload(),
+    onAccountDeleted: () => handleLogout(),
   });
 
   // ---------- update user name ----------
@@ -405,6 +478,7 @@ export default function App() {
     nextStep,
     finishAndLog,
     markSoundPlayed,
+    abort: abortTraining,
     clear: clearTraining,
   } = useTrainingTimer({ currentUserId });
 
@@ -417,13 +491,18 @@ export default function App() {
   } = useTrainingActions({
     selectedWorkoutId,
     training,
-    currentWorkoutName,
     setTrainingView: () => setView("train"),
     setPromptedResume,
     setResumeSuppressed,
     startFromState,
     finishAndLog,
     historyReload: () => history.reload(),
+    onPersonalRecords: (records) =>
+      showToast(
+        `${UI_TEXT.pages.history.records.newRecordsToast} ${[
+          ...new Set(records.map((record) => record.exerciseName)),
+        ].join(", ")}`,
+      ),
     askConfirm,
     notify,
   });
@@ -455,12 +534,48 @@ export default function App() {
 
   // ---------- logout ----------
   const handleLogout = () => {
+    logoutUser().catch(() => {
+      // The local state is cleared regardless; an expired session is already gone.
+    });
     localStorage.removeItem("motus:userId");
     setCurrentUserId(null);
     setView("login");
     clearTraining();
   };
 
+  // ---------- history paging ----------
+  const handleLoadMoreHistory = useCallback(async () => {
+    const cursor = history.data?.nextCursor;
+    if (!currentUserId || !cursor) return;
+    try {
+      const next = await listTrainingHistory(currentUserId, { cursor });
+      history.setData((prev) => ({
+        items: [...(prev?.items || []), ...next.items],
+        nextCursor: next.nextCursor,
+      }));
+    } catch (err) {
+      await notify(toErrorMessage(err, UI_TEXT.history.loadMoreFailed));
+    }
+  }, [currentUserId, history, notify]);
+
+  // ---------- training feedback ----------
+  const handleUpdateTraining = useCallback(
+    async (id: string, feedback: TrainingFeedback) => {
+      try {
+        const updated = await updateTrainingFeedback(id, feedback);
+        history.reload();
+        showToast(UI_TEXT.pages.history.feedback.savedToast);
+        return updated;
+      } catch (err) {
+        await notify(
+          toErrorMessage(err, UI_TEXT.pages.history.feedback.saveFailed),
+        );
+        return null;
+      }
+    },
+    [history, notify, showToast],
+  );
+
   // ---------- template apply ----------
   const handleApplyTemplate = useCallback(
     async (templateId: string) => {
@@ -541,6 +656,7 @@ export default function App() {
         }}
         onDismissResume={() => {
           setPromptedResume(false);
+          abortTraining();
           clearTraining();
           setResumeSuppressed(true);
         }}
@@ -551,20 +667,41 @@ export default function App() {
             {view === "login" && !authHeaderEnabled && (
               <LoginView
             data={{
+              oidcEnabled: config?.authMode === "oidc",
               allowRegistration,
               loginError,
+              resetToken,
+              inviteToken,
+              totpChallenge,
             }}
             actions={{
               onLogin: handleLogin,
               onCreateUser: async (email, password) => {
+                if (inviteToken) {
+                  if (
+                    await handleAcceptInvitation(inviteToken, email, password)
+                  ) {
+                    clearInviteToken();
+                  }
+                  return;
+                }
                 try {
                   await handleRegister(email, password);
-                  setView("train");
                 } catch (err) {
                   await notify(toErrorMessage(err, "Unable to create user"));
                 }
               },
               onClearError: () => setLoginError(null),
+              onRequestReset: handleRequestReset,
+              onResetPassword: async (newPassword) => {
+                if (!resetToken) return;
+                if (await handleCompleteReset(resetToken, newPassword)) {
+                  clearResetToken();
+                }
+              },
+              onResendVerification: handleResendVerification,
+              onVerifyTotp: handleVerifyTotp,
+              onCancelTotp: handleCancelTotp,
             }}
               />
             )}
@@ -573,20 +710,19 @@ export default function App() {
               <AdminView
             data={{
               users: users.data || [],
+              invitations: invitations.data || [],
               loading: users.loading,
               currentUserId,
               allowRegistration,
+              passwordResetEnabled: config?.authMode === "local",
             }}
             actions={{
-              onToggleAdmin: handleToggleAdmin,
-              onCreateUser: async (email, password) => {
-                try {
-                  await handleRegister(email, password);
-                } catch (err) {
-                  await notify(toErrorMessage(err, "Unable to create user"));
-                }
-              },
+              onChangeRole: handleChangeRole,
+              onSendPasswordReset: handleSendPasswordReset,
+              onCreateUser: handleAdminCreateUser,
               onBackfill: backfillCatalog,
+              onCreateInvitation: handleCreateInvitation,
+              onRevokeInvitation: handleRevokeInvitation,
             }}
               />
             )}
@@ -634,6 +770,7 @@ export default function App() {
               sounds: sounds.data || [],
               pauseOnTabHidden,
               showHours,
+              canCoach: canCoach(currentUser),
             }}
             actions={{
               onSelectWorkout: setSelectedWorkoutId,
@@ -645,6 +782,7 @@ export default function App() {
               onFinishTraining: handleFinishTraining,
               onCopySummary: () => showToast(UI_TEXT.toasts.copiedSummary),
               onToast: showToast,
+              onStartFromState: startFromState,
             }}
               />
             )}
@@ -666,13 +804,16 @@ export default function App() {
             {view === "history" && (
               <HistoryView
             data={{
-              items: history.data || [],
+              items: history.data?.items || [],
+              hasMore: Boolean(history.data?.nextCursor),
               activeTraining: training,
             }}
             actions={{
               onResume: () => setView("train"),
               loadWorkout: getWorkout,
               onCopySummary: () => showToast(UI_TEXT.toasts.copiedSummary),
+              onLoadMore: handleLoadMoreHistory,
+              onUpdateTraining: handleUpdateTraining,
             }}
               />
             )}
@@ -694,7 +835,14 @@ export default function App() {
               exportWorkoutId,
               activeWorkouts,
               importInputRef,
-              authHeaderEnabled,
+              authHeaderEnabled: passwordManagedExternally,
+              totpEnabled: Boolean(currentUser?.totpEnabled),
+              adminTotpRequired: config?.requireAdminTotp ?? false,
+              currentUserId: currentUserId || "",
+              coachLinks: coaching.links,
+              canCoach: canCoach(currentUser),
+              organizations: organizations.organizations,
+              orgMembers: organizations.members,
             }}
             actions={{
               onProfileTabChange: setProfileTab,
@@ -712,6 +860,21 @@ export default function App() {
               onExportWorkout: handleExportSelected,
               onImportWorkout: handleImportSelected,
               onPasswordChange: handlePasswordSubmit,
+              onBeginTotp: handleStartTotp,
+              onConfirmTotp: handleEnableTotp,
+              onDisableTotp: handleDisableTotp,
+              onRegenerateRecoveryCodes: handleRenewRecoveryCodes,
+              onDeleteAccount: handleDeleteAccount,
+              onInviteAthlete: coaching.invite,
+              onAcceptCoachLink: coaching.accept,
+              onRemoveCoachLink: coaching.remove,
+              onAssignWorkout: coaching.assign,
+              onCreateOrganization: organizations.create,
+              onSwitchOrganization: organizations.select,
+              onLeaveOrganization: organizations.leave,
+              onDeleteOrganization: organizations.remove,
+              onAddOrgMember: organizations.addMember,
+              onRemoveOrgMember: organizations.removeMember,
             }}
               />
             )}
@@ -720,7 +883,7 @@ export default function App() {
               <ExercisesView
             data={{
               exercises: exerciseCatalog,
-              isAdmin: Boolean(currentUser?.isAdmin),
+              canEditCatalog: canEditCatalog(currentUser),
             }}
             actions={{
               onAddExercise: handleAddExercise,
@@ -1,37 +1,52 @@
 import type {
   CatalogExercise,
+  CoachLink,
+  Organization,
+  OrgMember,
+  OrgRole,
+  PacingAnalysis,
+  CreatedInvitation,
+  ExerciseRecords,
+  ExerciseResult,
+  GroupClass,
+  Invitation,
+  PersonalRecord,
+  Role,
+  TrainingFeedback,
   TrainingHistoryItem,
+  TrainingHistoryPage,
+  TrainingHistoryQuery,
   TrainingState,
   TrainingStepLog,
   SoundOption,
+  StatsPeriod,
+  SummaryTemplate,
+  TrainingStats,
+  TrainingSummary,
   User,
   Workout,
   WorkoutStep,
   Template,
+  TotpChallenge,
+  TotpEnrollment,
 } from "./types";
 import { withBasePath } from "./utils/basePath";
 
 type AppConfig = {
+  authMode: "local" | "proxy" | "oidc";
   authHeaderEnabled: boolean;
   allowRegistration: boolean;
+  requireAdminTotp: boolean;
   version: string;
   commit: string;
 };
 
-let useLocalUserHeader = true;
-
-// setAuthHeaderEnabled toggles local header usage based on proxy auth.
-export const setAuthHeaderEnabled = (enabled: boolean) => {
-  useLocalUserHeader = !enabled;
-};
-
-// request wraps fetch with JSON handling, error surfacing, and user header.
+// request wraps fetch with JSON handling and error surfacing; the session cookie authenticates.
 async function request<T>(path: string, init?: RequestInit): Promise<T> {
-  const userId = localStorage.getItem("motus:userId") || "";
   const res = await fetch(withBasePath(path), {
+    credentials: "same-origin",
     headers: {
       "Content-Type": "application/json",
-      ...(useLocalUserHeader && userId ? { "X-User-ID": userId } : {}),
       ...(init?.headers || {}),
     },
     ...init,
@@ -58,6 +73,11 @@ export async function getConfig(): Promise<AppConfig> {
   return request("/api/config");
 }
 
+// oidcLoginUrl returns the endpoint that starts the OpenID Connect login redirect.
+export function oidcLoginUrl(): string {
+  return withBasePath("/api/auth/oidc/login");
+}
+
 // getCurrentUser resolves the authenticated user.
 export async function getCurrentUser(): Promise<User> {
   return request("/api/me");
@@ -68,28 +88,96 @@ export async function listUsers(): Promise<User[]> {
   return request("/api/users");
 }
 
-// createUser creates a new user with the given email.
+// createUser creates a new user with the given email; an invite token bypasses closed registration.
 export async function createUser(
   email: string,
   password?: string,
+  inviteToken?: string,
 ): Promise<User> {
   return request("/api/users", {
+    method: "POST",
+    body: JSON.stringify({ email, password, inviteToken }),
+  });
+}
+
+// adminCreateUser creates an already verified account; admin only.
+export async function adminCreateUser(
+  email: string,
+  password: string,
+): Promise<User> {
+  return request("/api/admin/users", {
     method: "POST",
     body: JSON.stringify({ email, password }),
   });
 }
 
-// loginUser authenticates a local user.
+// loginUser authenticates a local user; accounts with 2FA get a challenge instead.
 export async function loginUser(
   email: string,
   password: string,
-): Promise<User> {
+): Promise<User | TotpChallenge> {
   return request("/api/login", {
     method: "POST",
     body: JSON.stringify({ email, password }),
   });
 }
 
+// loginTotp completes a login challenge with an authenticator or recovery code.
+export async function loginTotp(
+  challenge: string,
+  code: string,
+): Promise<User> {
+  return request("/api/login/totp", {
+    method: "POST",
+    body: JSON.stringify({ challenge, code }),
+  });
+}
+
+// requestPasswordReset mails a reset link if the address belongs to a user.
+export async function requestPasswordReset(email: string): Promise<void> {
+  return request("/api/password/forgot", {
+    method: "POST",
+    body: JSON.stringify({ email }),
+  });
+}
+
+// resetPassword sets a new password using the token from a reset link.
+export async function resetPassword(
+  token: string,
+  newPassword: string,
+): Promise<void> {
+  return request("/api/password/reset", {
+    method: "POST",
+    body: JSON.stringify({ token, newPassword }),
+  });
+}
+
+// verifyEmail confirms an email address using the token from a verification link.
+export async function verifyEmail(token: string): Promise<void> {
+  return request("/api/email/verify", {
+    method: "POST",
+    body: JSON.stringify({ token }),
+  });
+}
+
+// resendVerification mails a new verification link.
+export async function resendVerification(email: string): Promise<void> {
+  return request("/api/email/verify/resend", {
+    method: "POST",
+    body: JSON.stringify({ email }),
+  });
+}
+
+// logoutUser revokes the current session.
+export async function logoutUser(): Promise<void> {
+  return request("/api/logout", { method: "POST" });
+}
+
+// logoutAllDevices revokes every session of the current user.
+export async function logoutAllDevices(): Promise<void> {
+  return request("/api/logout/all", { method: "POST" });
+}
+
 // changePassword updates the current user's password.
 export async function changePassword(
   currentPassword: string,
@@ -109,17 +197,209 @@ export async function updateUserName(name: string): Promise<void> {
   });
 }
 
-// updateUserAdmin toggles admin flag for a user.
-export async function updateUserAdmin(
+// beginTotp starts two-factor enrollment for the current user.
+export async function beginTotp(): Promise<TotpEnrollment> {
+  return request("/api/me/totp", { method: "POST" });
+}
+
+// confirmTotp enables two-factor authentication and returns recovery codes.
+export async function confirmTotp(
+  code: string,
+): Promise<{ recoveryCodes: string[] }> {
+  return request("/api/me/totp/confirm", {
+    method: "POST",
+    body: JSON.stringify({ code }),
+  });
+}
+
+// disableTotp turns two-factor authentication off.
+export async function disableTotp(password: string): Promise<void> {
+  return request("/api/me/totp/disable", {
+    method: "POST",
+    body: JSON.stringify({ password }),
+  });
+}
+
+// regenerateRecoveryCodes replaces all recovery codes.
+export async function regenerateRecoveryCodes(
+  code: string,
+): Promise<{ recoveryCodes: string[] }> {
+  return request("/api/me/totp/recovery-codes", {
+    method: "POST",
+    body: JSON.stringify({ code }),
+  });
+}
+
+// myDataExportUrl returns the endpoint that downloads all personal data as a zip archive.
+export function myDataExportUrl(): string {
+  return withBasePath("/api/me/export");
+}
+
+// deleteAccount permanently removes the current user's account.
+export async function deleteAccount(
+  confirm: string,
+  password: string,
+): Promise<void> {
+  return request("/api/me", {
+    method: "DELETE",
+    body: JSON.stringify({ confirm, password }),
+  });
+}
+
+// updateUserRole assigns a role to a user (admin only).
+export async function updateUserRole(
   userId: string,
-  isAdmin: boolean,
+  role: Role,
 ): Promise<void> {
-  return request(`/api/users/${userId}/admin`, {
+  return request(`/api/users/${encodeURIComponent(userId)}/role`, {
+    method: "PUT",
+    body: JSON.stringify({ role }),
+  });
+}
+
+// sendUserPasswordReset mails a reset link to a user (admin only).
+export async function sendUserPasswordReset(userId: string): Promise<void> {
+  return request(`/api/users/${encodeURIComponent(userId)}/password-reset`, {
+    method: "POST",
+  });
+}
+
+// listInvitations returns all invitations that were not revoked (admin only).
+export async function listInvitations(): Promise<Invitation[]> {
+  return request("/api/admin/invitations");
+}
+
+// createInvitation issues an invite link (admin only).
+export async function createInvitation(payload: {
+  maxUses: number;
+  expiresAt: string;
+  role: Role;
+}): Promise<CreatedInvitation> {
+  return request("/api/admin/invitations", {
+    method: "POST",
+    body: JSON.stringify(payload),
+  });
+}
+
+// revokeInvitation invalidates an invite link (admin only).
+export async function revokeInvitation(id: string): Promise<void> {
+  return request(`/api/admin/invitations/${encodeURIComponent(id)}`, {
+    method: "DELETE",
+  });
+}
+
+// listCoachLinks returns the coach links of the current user.
+export async function listCoachLinks(): Promise<CoachLink[]> {
+  return request("/api/coaching/links");
+}
+
+// inviteAthlete asks an athlete to accept the current user as coach.
+export async function inviteAthlete(athleteId: string): Promise<CoachLink> {
+  return request("/api/coaching/links", {
+    method: "POST",
+    body: JSON.stringify({ athleteId }),
+  });
+}
+
+// acceptCoachLink accepts a pending coach invitation.
+export async function acceptCoachLink(id: string): Promise<CoachLink> {
+  return request(`/api/coaching/links/${encodeURIComponent(id)}/accept`, {
+    method: "POST",
+  });
+}
+
+// removeCoachLink ends a coach link or declines an invitation.
+export async function removeCoachLink(id: string): Promise<void> {
+  return request(`/api/coaching/links/${encodeURIComponent(id)}`, {
+    method: "DELETE",
+  });
+}
+
+// assignWorkout copies a workout into an athlete's workouts.
+export async function assignWorkout(
+  athleteId: string,
+  workoutId: string,
+): Promise<Workout> {
+  return request(
+    `/api/coaching/athletes/${encodeURIComponent(athleteId)}/workouts`,
+    {
+      method: "POST",
+      body: JSON.stringify({ workoutId }),
+    },
+  );
+}
+
+// listOrganizations returns the organizations of the current user.
+export async function listOrganizations(): Promise<Organization[]> {
+  return request("/api/organizations");
+}
+
+// createOrganization founds an organization owned by the current user.
+export async function createOrganization(name: string): Promise<Organization> {
+  return request("/api/organizations", {
+    method: "POST",
+    body: JSON.stringify({ name }),
+  });
+}
+
+// deleteOrganization removes an organization with its shared library.
+export async function deleteOrganization(id: string): Promise<void> {
+  return request(`/api/organizations/${encodeURIComponent(id)}`, {
+    method: "DELETE",
+  });
+}
+
+// switchOrganization sets the active organization; an empty id leaves all.
+export async function switchOrganization(orgId: string): Promise<void> {
+  return request("/api/me/organization", {
     method: "PUT",
-    body: JSON.stringify({ isAdmin }),
+    body: JSON.stringify({ orgId }),
+  });
+}
+
+// listOrgMembers returns the members of an organization.
+export async function listOrgMembers(orgId: string): Promise<OrgMember[]> {
+  return request(`/api/organizations/${encodeURIComponent(orgId)}/members`);
+}
+
+// addOrgMember adds an existing user to an organization.
+export async function addOrgMember(
+  orgId: string,
+  userId: string,
+  role: OrgRole,
+): Promise<OrgMember> {
+  return request(`/api/organizations/${encodeURIComponent(orgId)}/members`, {
+    method: "POST",
+    body: JSON.stringify({ userId, role }),
   });
 }
 
+// updateOrgMember changes the role of an organization member.
+export async function updateOrgMember(
+  orgId: string,
+  userId: string,
+  role: OrgRole,
+): Promise<void> {
+  return request(
+    `/api/organizations/${encodeURIComponent(orgId)}/members/${encodeURIComponent(userId)}`,
+    {
+      method: "PUT",
+      body: JSON.stringify({ role }),
+    },
+  );
+}
+
+// removeOrgMember removes a member from an organization or leaves it.
+export async function removeOrgMember(
+  orgId: string,
+  userId: string,
+): Promise<void> {
+  return request(
+    `/api/organizations/${encodeURIComponent(orgId)}/members/${encodeURIComponent(userId)}`,
+    { method: "DELETE" },
+  );
+}
+
 // listWorkouts returns all workouts for a user.
 export async function listWorkouts(userId: string): Promise<Workout[]> {
   return request(`/api/users/${encodeURIComponent(userId)}/workouts`);
@@ -130,6 +410,18 @@ export async function getWorkout(id: string): Promise<Workout> {
   return request(`/api/workouts/${id}`);
 }
 
+// getWorkoutPacing compares the targets of a workout with its logged trainings.
+export async function getWorkoutPacing(id: string): Promise<PacingAnalysis> {
+  return request(`/api/workouts/${id}/pacing`);
+}
+
+// applyWorkoutPacing replaces the workout targets with the suggested ones.
+export async function applyWorkoutPacing(
+  id: string,
+): Promise<{ workout: Workout; applied: number }> {
+  return request(`/api/workouts/${id}/pacing/apply`, { method: "POST" });
+}
+
 // exportWorkout fetches a workout JSON payload for sharing.
 export async function exportWorkout(id: string): Promise<Workout> {
   return request(`/api/workouts/${id}/export`);
@@ -174,11 +466,15 @@ export async function importWorkout(payload: {
   });
 }
 
-// shareTemplate makes a workout available as a template.
-export async function shareTemplate(workoutId: string, name?: string) {
+// shareTemplate makes a workout available as a template, optionally within an organization.
+export async function shareTemplate(
+  workoutId: string,
+  name?: string,
+  orgId?: string,
+) {
   return request("/api/templates", {
     method: "POST",
-    body: JSON.stringify({ workoutId, name }),
+    body: JSON.stringify({ workoutId, name, orgId }),
   });
 }
 
@@ -192,14 +488,15 @@ export async function backfillExercises(): Promise<void> {
   return request("/api/exercises/backfill", { method: "POST" });
 }
 
-// createExercise adds a new exercise.
+// createExercise adds a new exercise, optionally shared within an organization.
 export async function createExercise(
   name: string,
   isCore = false,
+  orgId?: string,
 ): Promise<CatalogExercise> {
   return request("/api/exercises", {
     method: "POST",
-    body: JSON.stringify({ name, isCore }),
+    body: JSON.stringify({ name, isCore, orgId }),
   });
 }
 
@@ -236,6 +533,114 @@ export async function startTraining(workoutId: string): Promise<TrainingState> {
   return res.state;
 }
 
+// TrainingAction names a server-side transition of a training in progress.
+export type TrainingAction = "start" | "pause" | "resume" | "advance" | "abort";
+
+// updateTraining applies a transition to a training in progress; from guards advances against double clicks across devices.
+export async function updateTraining(
+  trainingId: string,
+  action: TrainingAction,
+  from?: number,
+): Promise<TrainingState | void> {
+  const query = from === undefined ? "" : `?from=${from}`;
+  return request(`/api/trainings/${trainingId}/${action}${query}`, {
+    method: "POST",
+  });
+}
+
+// watchTraining follows live state changes of a training in progress and returns an unsubscribe function.
+export function watchTraining(
+  trainingId: string,
+  onState: (state: TrainingState) => void,
+  onEnd: () => void,
+): () => void {
+  const source = new EventSource(
+    withBasePath(`/api/trainings/${trainingId}/events`),
+    { withCredentials: true },
+  );
+  source.addEventListener("state", (event) => {
+    onState(JSON.parse((event as MessageEvent<string>).data));
+  });
+  source.addEventListener("end", () => {
+    source.close();
+    onEnd();
+  });
+  return () => source.close();
+}
+
+// startClass opens a group class for a workout and returns its join code.
+export async function startClass(workoutId: string): Promise<GroupClass> {
+  return request("/api/classes", {
+    method: "POST",
+    body: JSON.stringify({ workoutId }),
+  });
+}
+
+// joinClass joins an open group class by its code.
+export async function joinClass(code: string): Promise<GroupClass> {
+  return request("/api/classes/join", {
+    method: "POST",
+    body: JSON.stringify({ code }),
+  });
+}
+
+// getClass returns a group class with the current state of its timer.
+export async function getClass(id: string): Promise<GroupClass> {
+  return request(`/api/classes/${id}`);
+}
+
+// leaveClass drops out of a group class and logs the training so far.
+export async function leaveClass(
+  id: string,
+  steps: Array<{ id: string; results: ExerciseResult[] }> = [],
+): Promise<void> {
+  return request(`/api/classes/${id}/leave`, {
+    method: "POST",
+    body: JSON.stringify({ steps }),
+  });
+}
+
+// submitClassResults stores the exercise results recorded so far in a group class.
+export async function submitClassResults(
+  id: string,
+  steps: Array<{ id: string; results: ExerciseResult[] }>,
+): Promise<void> {
+  return request(`/api/classes/${id}/results`, {
+    method: "PUT",
+    body: JSON.stringify({ steps }),
+  });
+}
+
+// endClass ends a group class and logs the training of all participants.
+export async function endClass(id: string): Promise<void> {
+  return request(`/api/classes/${id}/end`, { method: "POST" });
+}
+
+// watchClass follows the timer of a group class and returns an unsubscribe function.
+export function watchClass(
+  classId: string,
+  onState: (state: TrainingState) => void,
+  onEnd: () => void,
+): () => void {
+  const source = new EventSource(
+    withBasePath(`/api/classes/${classId}/events`),
+    { withCredentials: true },
+  );
+  source.addEventListener("state", (event) => {
+    onState(JSON.parse((event as MessageEvent<string>).data));
+  });
+  source.addEventListener("end", () => {
+    source.close();
+    onEnd();
+  });
+  return () => source.close();
+}
+
+// getActiveTraining returns the training the user has in progress.
+export async function getActiveTraining(): Promise<TrainingState> {
+  return request("/api/me/trainings/active");
+}
+
 // logTrainingCompletion records a completed training.
 export async function logTrainingCompletion(payload: {
   trainingId: string;
@@ -250,19 +655,149 @@ export async function logTrainingCompletion(payload: {
     type: string;
     estimatedSeconds?: number;
     elapsedMillis?: number;
+    results?: ExerciseResult[];
+    rpe?: number;
   }>;
-}) {
+  notes?: string;
+  rpe?: number;
+  mood?: number;
+  energy?: number;
+  bodyweight?: number;
+}): Promise<{ id: string; personalRecords?: PersonalRecord[] }> {
   return request("/api/trainings/complete", {
     method: "POST",
     body: JSON.stringify(payload),
   });
 }
 
-// listTrainingHistory returns all completed trainings for a user.
+// updateTrainingFeedback changes the notes and ratings of a logged training.
+export async function updateTrainingFeedback(
+  id: string,
+  feedback: TrainingFeedback,
+): Promise<TrainingHistoryItem> {
+  return request(`/api/trainings/${encodeURIComponent(id)}`, {
+    method: "PATCH",
+    body: JSON.stringify(feedback),
+  });
+}
+
+// replaceTraining overwrites the times, steps and feedback of a logged training.
+export async function replaceTraining(
+  id: string,
+  payload: Omit<TrainingFeedback, "steps"> & {
+    startedAt?: string;
+    completedAt?: string;
+    steps: Array<{
+      id?: string;
+      name: string;
+      type: string;
+      estimatedSeconds?: number;
+      elapsedMillis?: number;
+      results?: ExerciseResult[];
+      rpe?: number;
+    }>;
+  },
+): Promise<TrainingHistoryItem & { personalRecords: PersonalRecord[] }> {
+  return request(`/api/trainings/${encodeURIComponent(id)}`, {
+    method: "PUT",
+    body: JSON.stringify(payload),
+  });
+}
+
+// deleteTraining removes a logged training.
+export async function deleteTraining(id: string): Promise<void> {
+  return request(`/api/trainings/${encodeURIComponent(id)}`, {
+    method: "DELETE",
+  });
+}
+
+// logManualTraining backfills a training done without the app from a workout.
+export async function logManualTraining(
+  payload: Omit<TrainingFeedback, "steps"> & {
+    workoutId: string;
+    startedAt: string;
+    completedAt?: string;
+  },
+): Promise<{ id: string; personalRecords: PersonalRecord[] }> {
+  return request("/api/trainings/manual", {
+    method: "POST",
+    body: JSON.stringify(payload),
+  });
+}
+
+// listTrainingHistory returns one page of completed trainings for a user.
 export async function listTrainingHistory(
   userId: string,
-): Promise<TrainingHistoryItem[]> {
-  return request(`/api/users/${encodeURIComponent(userId)}/trainings/history`);
+  query: TrainingHistoryQuery = {},
+): Promise<TrainingHistoryPage> {
+  const params = new URLSearchParams();
+  Object.entries(query).forEach(([key, value]) => {
+    if (value !== undefined && value !== "") params.set(key, String(value));
+  });
+  const search = params.toString();
+  return request(
+    `/api/users/${encodeURIComponent(userId)}/trainings/history${search ? `?${search}` : ""}`,
+  );
+}
+
+// listPersonalRecords returns the personal records of the current user per exercise.
+export async function listPersonalRecords(): Promise<ExerciseRecords[]> {
+  return request("/api/me/records");
+}
+
+// getStats returns the training statistics of the current user in the browser time zone.
+export async function getStats(period: StatsPeriod): Promise<TrainingStats> {
+  const params = new URLSearchParams({ period });
+  const tz = Intl.DateTimeFormat().resolvedOptions().timeZone;
+  if (tz) params.set("tz", tz);
+  return request(`/api/me/stats?${params.toString()}`);
+}
+
+// getTrainingSummary renders a training in the browser time zone.
+export async function getTrainingSummary(
+  trainingId: string,
+  format = "ai",
+): Promise<TrainingSummary> {
+  const params = new URLSearchParams({ format });
+  const tz = Intl.DateTimeFormat().resolvedOptions().timeZone;
+  if (tz) params.set("tz", tz);
+  return request(
+    `/api/trainings/${encodeURIComponent(trainingId)}/summary?${params.toString()}`,
+  );
+}
+
+// listSummaryTemplates returns the summary templates of the current user.
+export async function listSummaryTemplates(): Promise<SummaryTemplate[]> {
+  return request("/api/me/summary-templates");
+}
+
+// createSummaryTemplate stores a new summary template.
+export async function createSummaryTemplate(payload: {
+  name: string;
+  body: string;
+}): Promise<SummaryTemplate> {
+  return request("/api/me/summary-templates", {
+    method: "POST",
+    body: JSON.stringify(payload),
+  });
+}
+
+// updateSummaryTemplate changes a summary template.
+export async function updateSummaryTemplate(
+  id: string,
+  payload: { name: string; body: string },
+): Promise<SummaryTemplate> {
+  return request(`/api/me/summary-templates/${encodeURIComponent(id)}`, {
+    method: "PUT",
+    body: JSON.stringify(payload),
+  });
+}
+
+// deleteSummaryTemplate removes a summary template.
+export async function deleteSummaryTemplate(id: string): Promise<void> {
+  return request(`/api/me/summary-templates/${encodeURIComponent(id)}`, {
+    method: "DELETE",
+  });
 }
 
 // getTrainingSteps fetches stored per-step timings for a training.
@@ -121,3 +121,123 @@ export function LoginForm({
     </form>
   );
 }
+
+// EmailActionForm submits a single email address, e.g. to request a reset link.
+export function EmailActionForm({
+  submitLabel,
+  onSubmit,
+}: {
+  submitLabel: string;
+  onSubmit: (email: string) => void | Promise<void>;
+}) {
+  const [email, setEmail] = useState("");
+  const trimmedEmail = email.trim();
+  const emailInvalid = trimmedEmail !== "" && !isValidEmail(trimmedEmail);
+  return (
+    <form
+      onSubmit={(e) => {
+        e.preventDefault();
+        if (!trimmedEmail || emailInvalid) return;
+        onSubmit(trimmedEmail);
+        setEmail("");
+      }}
+      className="stack"
+    >
+      <div className="field">
+        <label>Email</label>
+        <input
+          value={email}
+          onChange={(e) => setEmail(e.target.value)}
+          placeholder="you@example.com"
+          className={emailInvalid ? "input-error" : undefined}
+          required
+        />
+      </div>
+      <button className="btn subtle" type="submit" disabled={!trimmedEmail}>
+        {submitLabel}
+      </button>
+    </form>
+  );
+}
+
+// ResetPasswordForm sets a new password from a reset link.
+export function ResetPasswordForm({
+  onReset,
+}: {
+  onReset: (newPassword: string) => void | Promise<void>;
+}) {
+  const [password, setPassword] = useState("");
+  return (
+    <form
+      onSubmit={(e) => {
+        e.preventDefault();
+        if (!password.trim()) return;
+        onReset(password.trim());
+      }}
+      className="stack"
+    >
+      <div className="field">
+        <label>New password</label>
+        <input
+          type="password"
+          value={password}
+          onChange={(e) => setPassword(e.target.value)}
+          placeholder={UI_TEXT.auth.enterPassword}
+          required
+        />
+      </div>
+      <button
+        className="btn primary"
+        type="submit"
+        disabled={!password.trim()}
+      >
+        {UI_TEXT.pages.auth.resetButton}
+      </button>
+    </form>
+  );
+}
+
+// TotpCodeForm submits an authenticator or recovery code.
+export function TotpCodeForm({
+  submitLabel,
+  onSubmit,
+  onCancel,
+}: {
+  submitLabel: string;
+  onSubmit: (code: string) => void | Promise<void>;
+  onCancel?: () => void;
+}) {
+  const [code, setCode] = useState("");
+  return (
+    <form
+      onSubmit={(e) => {
+        e.preventDefault();
+        if (!code.trim()) return;
+        onSubmit(code.trim());
+        setCode("");
+      }}
+      className="stack"
+    >
+      <div className="field">
+        <label>{UI_TEXT.auth.totpCodeLabel}</label>
+        <input
+          value={code}
+          onChange={(e) => setCode(e.target.value)}
+          placeholder={UI_TEXT.auth.totpCodePlaceholder}
+          autoComplete="one-time-code"
+          required
+        />
+      </div>
+      <div className="btn-group">
+        <button className="btn primary" type="submit" disabled={!code.trim()}>
+          {submitLabel}
+        </button>
+        {onCancel && (
+          <button className="btn subtle" type="button" onClick={onCancel}>
+            {UI_TEXT.dialog.cancelButton}
+          </button>
+        )}
+      </div>
+    </form>
+  );
+}
@@ -1,14 +1,22 @@
-import { useMemo } from "react";
+import { useEffect, useMemo, useState } from "react";
+
+import { getTrainingSummary } from "../../api";
 
 import type {
+  TrainingFeedback,
   TrainingHistoryItem,
+  TrainingStepExercise,
   TrainingStepLog,
   Workout,
 } from "../../types";
-import { formatExerciseLine, formatElapsedMillis } from "../../utils/format";
-import { buildSummary } from "../../utils/summary";
+import {
+  formatExerciseLine,
+  formatExerciseResult,
+  formatElapsedMillis,
+} from "../../utils/format";
 import { expandWorkoutSteps } from "../../utils/workout";
 import { AISummary } from "./HistoryCard";
+import { TrainingFeedbackForm } from "./TrainingFeedbackForm";
 import { Modal } from "../common/Modal";
 
 type HistoryPreviewModalProps = {
@@ -17,6 +25,7 @@ type HistoryPreviewModalProps = {
   loading: boolean;
   onClose: () => void;
   onCopySummary: () => void;
+  onSaveFeedback: (feedback: TrainingFeedback) => void | Promise<void>;
 };
 
 // mapHistoryDurations builds an elapsed duration lookup by step order.
@@ -29,6 +38,15 @@ const mapHistoryDurations = (steps: TrainingStepLog[]) => {
   return map;
 };
 
+// mapHistoryResults builds a lookup of logged exercise results by step order.
+const mapHistoryResults = (steps: TrainingStepLog[]) => {
+  const map: Record<string, TrainingStepExercise[]> = {};
+  steps.forEach((s) => {
+    if (s.exercises?.length) map[`order-${s.stepOrder}`] = s.exercises;
+  });
+  return map;
+};
+
 // mergeWorkoutDurations merges logged timings into expanded workout steps.
 const mergeWorkoutDurations = (
   workout: Workout,
@@ -70,17 +88,41 @@ export function HistoryPreviewModal({
   loading,
   onClose,
   onCopySummary,
+  onSaveFeedback,
 }: HistoryPreviewModalProps) {
   const previewDurations = useMemo(
     () => (preview?.steps?.length ? mapHistoryDurations(preview.steps) : {}),
     [preview],
   );
 
+  const previewResults = useMemo(
+    () => (preview?.steps?.length ? mapHistoryResults(preview.steps) : {}),
+    [preview],
+  );
+
   const expandedSteps = useMemo(() => {
     if (!workout) return [];
     return mergeWorkoutDurations(workout, previewDurations);
   }, [previewDurations, workout]);
 
+  const previewId = preview ? preview.trainingId || preview.id : "";
+  const [summary, setSummary] = useState("");
+  useEffect(() => {
+    if (!previewId) return;
+    let active = true;
+    setSummary("");
+    getTrainingSummary(previewId)
+      .then((data) => {
+        if (active) setSummary(data.text);
+      })
+      .catch(() => {
+        if (active) setSummary("");
+      });
+    return () => {
+      active = false;
+    };
+  }, [previewId, preview?.notes, preview?.rpe]);
+
   if (!preview) return null;
 
   return (
@@ -135,6 +177,13 @@ export function HistoryPreviewModal({
                             .join(" | ")}
                         </div>
                       ) : null}
+                      {previewResults[`order-${idx}`] ? (
+                        <div className="small">
+                          {previewResults[`order-${idx}`]
+                            .map((result) => formatExerciseResult(result))
+                            .join(" | ")}
+                        </div>
+                      ) : null}
                     </div>
                   </div>
                 </li>
@@ -145,20 +194,10 @@ export function HistoryPreviewModal({
             <div className="muted small">No step data available.</div>
           )}
         </div>
+        <TrainingFeedbackForm item={preview} onSave={onSaveFeedback} />
       </div>
       <AISummary
-        summary={
-          workout
-            ? buildSummary({
-                workoutName: preview.workoutName || workout.name,
-                workoutId: preview.workoutId,
-                userId: preview.userId,
-                startedAt: preview.startedAt,
-                completedAt: preview.completedAt,
-                steps: expandedSteps,
-              })
-            : ""
-        }
+        summary={summary}
         loading={loading}
         onCopy={onCopySummary}
       />
@@ -0,0 +1,55 @@
+import { useEffect, useState } from "react";
+
+import { listPersonalRecords } from "../../api";
+import type { ExerciseRecords } from "../../types";
+import { formatPersonalRecord } from "../../utils/format";
+import { toErrorMessage } from "../../utils/messages";
+import { UI_TEXT } from "../../utils/uiText";
+
+// PersonalRecordsPanel lists the standing personal records per exercise.
+export function PersonalRecordsPanel({ reloadKey }: { reloadKey: string }) {
+  const text = UI_TEXT.pages.history.records;
+  const [groups, setGroups] = useState<ExerciseRecords[] | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    let active = true;
+    listPersonalRecords()
+      .then((data) => {
+        if (!active) return;
+        setGroups(data);
+        setError(null);
+      })
+      .catch((err) => {
+        if (active) setError(toErrorMessage(err, text.loadFailed));
+      });
+    return () => {
+      active = false;
+    };
+  }, [reloadKey, text.loadFailed]);
+
+  return (
+    <section className="panel">
+      <div className="panel-header">
+        <h3>{text.title}</h3>
+      </div>
+      {error && <p className="muted small">{error}</p>}
+      {groups && !groups.length && <p className="muted">{text.empty}</p>}
+      {groups && groups.length > 0 && (
+        <ul className="list">
+          {groups.map((group) => (
+            <li key={group.exerciseId} className="list-item">
+              <strong>{group.exerciseName}</strong>
+              {group.records.map((record) => (
+                <div key={record.id} className="muted small">
+                  {text.kinds[record.kind]}: {formatPersonalRecord(record)} •{" "}
+                  {new Date(record.achievedAt).toLocaleDateString()}
+                </div>
+              ))}
+            </li>
+          ))}
+        </ul>
+      )}
+    </section>
+  );
+}
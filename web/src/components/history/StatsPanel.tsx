@@ -0,0 +1,127 @@
+import { useEffect, useState } from "react";
+
+import { getStats } from "../../api";
+import type { StatsPeriod, TrainingStats } from "../../types";
+import { formatElapsedMillis } from "../../utils/format";
+import { toErrorMessage } from "../../utils/messages";
+import { UI_TEXT } from "../../utils/uiText";
+
+const PERIODS: StatsPeriod[] = ["week", "month"];
+
+// StatsPanel shows training totals, streaks and the most trained exercises.
+export function StatsPanel({ reloadKey }: { reloadKey: string }) {
+  const text = UI_TEXT.pages.history.stats;
+  const [period, setPeriod] = useState<StatsPeriod>("week");
+  const [stats, setStats] = useState<TrainingStats | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    let active = true;
+    getStats(period)
+      .then((data) => {
+        if (!active) return;
+        setStats(data);
+        setError(null);
+      })
+      .catch((err) => {
+        if (active) setError(toErrorMessage(err, text.loadFailed));
+      });
+    return () => {
+      active = false;
+    };
+  }, [period, reloadKey, text.loadFailed]);
+
+  const duration = (seconds: number) =>
+    formatElapsedMillis(seconds * 1000, { showHours: true });
+  const busiest = Math.max(
+    1,
+    ...(stats?.buckets || []).map((bucket) => bucket.sessions),
+  );
+
+  return (
+    <section className="panel">
+      <div className="panel-header">
+        <h3>{text.title}</h3>
+        <div className="btn-group">
+          {PERIODS.map((value) => (
+            <button
+              key={value}
+              className={value === period ? "btn primary" : "btn subtle"}
+              onClick={() => setPeriod(value)}
+            >
+              {text[value]}
+            </button>
+          ))}
+        </div>
+      </div>
+      {error && <p className="muted small">{error}</p>}
+      {stats && (
+        <>
+          <ul className="list">
+            <li className="list-item">
+              {text.sessions}: <strong>{stats.totals.sessions}</strong>
+            </li>
+            <li className="list-item">
+              {text.totalTime}:{" "}
+              <strong>{duration(stats.totals.totalSeconds)}</strong>{" "}
+              <span className="muted small">
+                {text.workTime} {duration(stats.totals.workSeconds)} •{" "}
+                {text.pauseTime} {duration(stats.totals.pauseSeconds)}
+              </span>
+            </li>
+            <li className="list-item">
+              {text.volume}: <strong>{stats.totals.volume}</strong>
+            </li>
+            <li className="list-item">
+              {text.currentStreak}:{" "}
+              <strong>{stats.streaks.current}</strong> •{" "}
+              {text.longestStreak}: <strong>{stats.streaks.longest}</strong>
+            </li>
+          </ul>
+          <div
+            style={{
+              display: "flex",
+              alignItems: "flex-end",
+              gap: 4,
+              height: 64,
+              margin: "12px 0",
+            }}
+          >
+            {stats.buckets.map((bucket) => (
+              <div
+                key={bucket.start}
+                title={`${new Date(bucket.start).toLocaleDateString()}: ${bucket.sessions}`}
+                style={{
+                  flex: 1,
+                  minHeight: 2,
+                  height: `${(bucket.sessions / busiest) * 100}%`,
+                  background: "var(--primary)",
+                  opacity: bucket.sessions ? 0.8 : 0.2,
+                }}
+              />
+            ))}
+          </div>
+          {stats.topExercises.length > 0 && (
+            <>
+              <h4>{text.topExercises}</h4>
+              <ul className="list">
+                {stats.topExercises.map((exercise) => (
+                  <li
+                    key={exercise.exerciseId || exercise.name}
+                    className="list-item"
+                  >
+                    <strong>{exercise.name}</strong>{" "}
+                    <span className="muted small">
+                      {exercise.sessions} {text.sessionsSuffix} •{" "}
+                      {exercise.volume} kg
+                    </span>
+                  </li>
+                ))}
+              </ul>
+            </>
+          )}
+        </>
+      )}
+    </section>
+  );
+}
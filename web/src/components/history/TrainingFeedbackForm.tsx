@@ -0,0 +1,135 @@
+import { useEffect, useState } from "react";
+
+import type { TrainingFeedback, TrainingHistoryItem } from "../../types";
+import { UI_TEXT } from "../../utils/uiText";
+
+const RPE_OPTIONS = [1, 2, 3, 4, 5, 6, 7, 8, 9, 10];
+const WELLBEING_OPTIONS = [1, 2, 3, 4, 5];
+
+// RatingSelect picks a rating or leaves it unrated (0).
+function RatingSelect({
+  label,
+  value,
+  options,
+  onChange,
+}: {
+  label: string;
+  value: number;
+  options: number[];
+  onChange: (value: number) => void;
+}) {
+  return (
+    <div className="field">
+      <label>{label}</label>
+      <select
+        value={value}
+        onChange={(e) => onChange(Number(e.target.value))}
+      >
+        <option value={0}>{UI_TEXT.pages.history.feedback.unrated}</option>
+        {options.map((option) => (
+          <option key={option} value={option}>
+            {option}
+          </option>
+        ))}
+      </select>
+    </div>
+  );
+}
+
+// TrainingFeedbackForm edits how a logged training felt.
+export function TrainingFeedbackForm({
+  item,
+  onSave,
+}: {
+  item: TrainingHistoryItem;
+  onSave: (feedback: TrainingFeedback) => void | Promise<void>;
+}) {
+  const text = UI_TEXT.pages.history.feedback;
+  const [notes, setNotes] = useState(item.notes || "");
+  const [rpe, setRpe] = useState(item.rpe || 0);
+  const [mood, setMood] = useState(item.mood || 0);
+  const [energy, setEnergy] = useState(item.energy || 0);
+  const [bodyweight, setBodyweight] = useState(
+    item.bodyweight ? String(item.bodyweight) : "",
+  );
+  const [saving, setSaving] = useState(false);
+
+  useEffect(() => {
+    setNotes(item.notes || "");
+    setRpe(item.rpe || 0);
+    setMood(item.mood || 0);
+    setEnergy(item.energy || 0);
+    setBodyweight(item.bodyweight ? String(item.bodyweight) : "");
+  }, [item]);
+
+  return (
+    <form
+      className="stack"
+      onSubmit={async (e) => {
+        e.preventDefault();
+        setSaving(true);
+        try {
+          await onSave({
+            notes,
+            rpe,
+            mood,
+            energy,
+            bodyweight: Number(bodyweight) || 0,
+            bodyweightUnit: item.bodyweightUnit,
+          });
+        } finally {
+          setSaving(false);
+        }
+      }}
+    >
+      <div className="label">{text.title}</div>
+      {item.load > 0 && (
+        <div className="muted small">
+          {text.loadLabel}: {item.load}
+        </div>
+      )}
+      <RatingSelect
+        label={text.rpeLabel}
+        value={rpe}
+        options={RPE_OPTIONS}
+        onChange={setRpe}
+      />
+      <RatingSelect
+        label={text.moodLabel}
+        value={mood}
+        options={WELLBEING_OPTIONS}
+        onChange={setMood}
+      />
+      <RatingSelect
+        label={text.energyLabel}
+        value={energy}
+        options={WELLBEING_OPTIONS}
+        onChange={setEnergy}
+      />
+      <div className="field">
+        <label>
+          {text.bodyweightLabel} ({item.bodyweightUnit || "kg"})
+        </label>
+        <input
+          type="number"
+          min={0}
+          step="0.1"
+          value={bodyweight}
+          onChange={(e) => setBodyweight(e.target.value)}
+        />
+      </div>
+      <div className="field">
+        <label>{text.notesLabel}</label>
+        <textarea
+          value={notes}
+          maxLength={2000}
+          rows={3}
+          onChange={(e) => setNotes(e.target.value)}
+        />
+      </div>
+      <button className="btn primary" type="submit" disabled={saving}>
+        {text.saveButton}
+      </button>
+    </form>
+  );
+}
@@ -1,24 +1,34 @@
 import { useState } from "react";
-import type { User } from "../../types";
+import type { Invitation, Role, User } from "../../types";
 import { UserForm } from "../auth/AuthForm";
+import { ROLES } from "../../utils/roles";
 import { UI_TEXT } from "../../utils/uiText";
 
-type AdminTab = "users" | "settings";
+type AdminTab = "users" | "invitations" | "settings";
 
 export type AdminViewData = {
   users: User[];
+  invitations: Invitation[];
   loading: boolean;
   currentUserId: string | null;
   allowRegistration: boolean;
+  passwordResetEnabled: boolean;
 };
 
 export type AdminViewActions = {
-  onToggleAdmin: (user: User) => void | Promise<void>;
+  onChangeRole: (user: User, role: Role) => void | Promise<void>;
+  onSendPasswordReset: (user: User) => void | Promise<void>;
   onCreateUser: (email: string, password: string) => void | Promise<void>;
   onBackfill: () => void | Promise<void>;
+  onCreateInvitation: (
+    maxUses: number,
+    expiresInDays: number,
+    role: Role,
+  ) => void | Promise<void>;
+  onRevokeInvitation: (invitation: Invitation) => void | Promise<void>;
 };
 
-// AdminView manages users and admin access.
+// AdminView manages users and their roles.
 export function AdminView({
   data,
   actions,
@@ -26,8 +36,22 @@ export function AdminView({
   data: AdminViewData;
   actions: AdminViewActions;
 }) {
-  const { users, loading, currentUserId, allowRegistration } = data;
-  const { onToggleAdmin, onCreateUser, onBackfill } = actions;
+  const {
+    users,
+    invitations,
+    loading,
+    currentUserId,
+    allowRegistration,
+    passwordResetEnabled,
+  } = data;
+  const {
+    onChangeRole,
+    onSendPasswordReset,
+    onCreateUser,
+    onBackfill,
+    onCreateInvitation,
+    onRevokeInvitation,
+  } = actions;
   // tab tracks the active admin section.
   const [tab, setTab] = useState<AdminTab>("users");
   // backfilling controls the backfill button state.
@@ -81,21 +105,32 @@ export function AdminView({
                         <div>
                           <strong>{u.name}</strong>
                           <div className="muted">
-                            {u.isAdmin
-                              ? UI_TEXT.roles.admin
-                              : UI_TEXT.roles.user}{" "}
-                            • {new Date(u.createdAt).toLocaleDateString()}
+                            {UI_TEXT.roles[u.role || "member"]} •{" "}
+                            {new Date(u.createdAt).toLocaleDateString()}
                           </div>
                         </div>
                         <div className="btn-group">
-                          <button
-                            className="btn subtle"
-                            onClick={() => onToggleAdmin(u)}
+                          <select
+                            aria-label={UI_TEXT.pages.admin.roleLabel}
+                            value={u.role || "member"}
+                            onChange={(e) =>
+                              onChangeRole(u, e.target.value as Role)
+                            }
                           >
-                            {u.isAdmin
-                              ? UI_TEXT.admin.revokeAdmin
-                              : UI_TEXT.admin.makeAdmin}
-                          </button>
+                            {ROLES.map((role) => (
+                              <option key={role} value={role}>
+                                {UI_TEXT.roles[role]}
+                              </option>
+                            ))}
+                          </select>
+                          {passwordResetEnabled && (
+                            <button
+                              className="btn subtle"
+                              onClick={() => onSendPasswordReset(u)}
+                            >
+                              {UI_TEXT.admin.sendResetLink}
+                            </button>
+                          )}
                         </div>
                       </div>
                     </li>
@@ -114,6 +149,55 @@ export function AdminView({
               </div>
             </div>
           )}
+          {tab === "invitations" && (
+            <div className="stack">
+              <div className="panel">
+                <div className="panel-header">
+                  <div>
+                    <h3>{UI_TEXT.pages.admin.invitationsTitle}</h3>
+                    <p className="muted small hint">
+                      {UI_TEXT.pages.admin.invitationsHint}
+                    </p>
+                  </div>
+                </div>
+                <InvitationForm onCreate={onCreateInvitation} />
+              </div>
+              <div className="panel">
+                {invitations.length === 0 && (
+                  <p className="muted small">
+                    {UI_TEXT.pages.admin.invitationsEmpty}
+                  </p>
+                )}
+                <ul className="list">
+                  {invitations.map((inv) => (
+                    <li key={inv.id} className="list-item">
+                      <div className="list-row">
+                        <div>
+                          <strong>{UI_TEXT.roles[inv.role]}</strong>
+                          <div className="muted">
+                            {UI_TEXT.pages.admin.invitationUsage(
+                              inv.uses,
+                              inv.maxUses,
+                            )}{" "}
+                            •{" "}
+                            {UI_TEXT.pages.admin.invitationExpires(
+                              new Date(inv.expiresAt).toLocaleDateString(),
+                            )}
+                          </div>
+                        </div>
+                        <button
+                          className="btn subtle"
+                          onClick={() => onRevokeInvitation(inv)}
+                        >
+                          {UI_TEXT.admin.revokeInvitation}
+                        </button>
+                      </div>
+                    </li>
+                  ))}
+                </ul>
+              </div>
+            </div>
+          )}
           {tab === "settings" && (
             <div className="stack">
               <div className="panel">
@@ -144,6 +228,12 @@ export function AdminView({
           >
             Users
           </button>
+          <button
+            className={tab === "invitations" ? "tab active" : "tab"}
+            onClick={() => setTab("invitations")}
+          >
+            Invitations
+          </button>
           <button
             className={tab === "settings" ? "tab active" : "tab"}
             onClick={() => setTab("settings")}
@@ -155,3 +245,70 @@ export function AdminView({
     </section>
   );
 }
+
+// InvitationForm creates invite links with a usage limit, lifetime and role.
+function InvitationForm({
+  onCreate,
+}: {
+  onCreate: (
+    maxUses: number,
+    expiresInDays: number,
+    role: Role,
+  ) => void | Promise<void>;
+}) {
+  const [maxUses, setMaxUses] = useState("1");
+  const [expiresInDays, setExpiresInDays] = useState("7");
+  const [role, setRole] = useState<Role>("member");
+  const uses = Number(maxUses);
+  const days = Number(expiresInDays);
+  const valid = uses >= 1 && days > 0;
+  return (
+    <form
+      onSubmit={(e) => {
+        e.preventDefault();
+        // Guard: require positive limits before submit.
+        if (!valid) return;
+        onCreate(uses, days, role);
+      }}
+      className="stack"
+    >
+      <div className="input-row">
+        <div className="field">
+          <label>{UI_TEXT.pages.admin.maxUsesLabel}</label>
+          <input
+            type="number"
+            min={1}
+            value={maxUses}
+            onChange={(e) => setMaxUses(e.target.value)}
+          />
+        </div>
+        <div className="field">
+          <label>{UI_TEXT.pages.admin.expiresInDaysLabel}</label>
+          <input
+            type="number"
+            min={1}
+            value={expiresInDays}
+            onChange={(e) => setExpiresInDays(e.target.value)}
+          />
+        </div>
+      </div>
+      <div className="field">
+        <label>{UI_TEXT.pages.admin.roleLabel}</label>
+        <select value={role} onChange={(e) => setRole(e.target.value as Role)}>
+          {ROLES.map((option) => (
+            <option key={option} value={option}>
+              {UI_TEXT.roles[option]}
+            </option>
+          ))}
+        </select>
+      </div>
+      <button
+        className={valid ? "btn primary" : "btn subtle"}
+        type="submit"
+        disabled={!valid}
+      >
+        {UI_TEXT.pages.admin.createInvitation}
+      </button>
+    </form>
+  );
+}
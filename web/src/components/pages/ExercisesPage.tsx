@@ -3,7 +3,7 @@ import { UI_TEXT } from "../../utils/uiText";
 
 export type ExercisesViewData = {
   exercises: CatalogExercise[];
-  isAdmin: boolean;
+  canEditCatalog: boolean;
 };
 
 export type ExercisesViewActions = {
@@ -21,7 +21,7 @@ export function ExercisesView({
   data: ExercisesViewData;
   actions: ExercisesViewActions;
 }) {
-  const { exercises, isAdmin } = data;
+  const { exercises, canEditCatalog } = data;
   const {
     onAddExercise,
     onAddCoreExercise,
@@ -39,7 +39,7 @@ export function ExercisesView({
           <button className="btn primary" onClick={() => onAddExercise()}>
             {UI_TEXT.pages.exercises.addExercise}
           </button>
-          {isAdmin && (
+          {canEditCatalog && (
             <button className="btn subtle" onClick={() => onAddCoreExercise()}>
               {UI_TEXT.pages.exercises.addCoreExercise}
             </button>
@@ -65,7 +65,7 @@ export function ExercisesView({
                 </div>
               </div>
               <div className="btn-group">
-                {isAdmin && (
+                {canEditCatalog && (
                   <>
                     <button
                       className="btn subtle"
@@ -1,12 +1,20 @@
 import { useState } from "react";
 
-import type { TrainingHistoryItem, TrainingState, Workout } from "../../types";
+import type {
+  TrainingFeedback,
+  TrainingHistoryItem,
+  TrainingState,
+  Workout,
+} from "../../types";
 import { HistoryList } from "../history/HistoryCard";
 import { HistoryPreviewModal } from "../history/HistoryPreviewModal";
+import { PersonalRecordsPanel } from "../history/PersonalRecordsPanel";
+import { StatsPanel } from "../history/StatsPanel";
 import { UI_TEXT } from "../../utils/uiText";
 
 export type HistoryViewData = {
   items: TrainingHistoryItem[];
+  hasMore: boolean;
   activeTraining: TrainingState | null;
 };
 
@@ -14,6 +22,11 @@ export type HistoryViewActions = {
   onResume: () => void;
   loadWorkout: (id: string) => Promise<Workout>;
   onCopySummary: () => void;
+  onLoadMore: () => Promise<void>;
+  onUpdateTraining: (
+    id: string,
+    feedback: TrainingFeedback,
+  ) => Promise<TrainingHistoryItem | null>;
 };
 
 // HistoryView lists logged trainings and opens a training preview.
@@ -24,8 +37,10 @@ export function HistoryView({
   data: HistoryViewData;
   actions: HistoryViewActions;
 }) {
-  const { items, activeTraining } = data;
-  const { onResume, loadWorkout, onCopySummary } = actions;
+  const { items, hasMore, activeTraining } = data;
+  const { onResume, loadWorkout, onCopySummary, onLoadMore, onUpdateTraining } =
+    actions;
+  const [loadingMore, setLoadingMore] = useState(false);
   const [preview, setPreview] = useState<TrainingHistoryItem | null>(null);
   const [previewWorkout, setPreviewWorkout] = useState<Workout | null>(null);
   const [previewLoading, setPreviewLoading] = useState(false);
@@ -54,13 +69,32 @@ export function HistoryView({
           onResume={onResume}
           onSelect={handleSelect}
         />
+        {hasMore && (
+          <button
+            className="btn subtle"
+            disabled={loadingMore}
+            onClick={() => {
+              setLoadingMore(true);
+              onLoadMore().finally(() => setLoadingMore(false));
+            }}
+          >
+            {UI_TEXT.history.loadMore}
+          </button>
+        )}
       </section>
+      <StatsPanel reloadKey={items[0]?.id || ""} />
+      <PersonalRecordsPanel reloadKey={items[0]?.id || ""} />
       {/* Preview modal */}
       <HistoryPreviewModal
         preview={preview}
         workout={previewWorkout}
         loading={previewLoading}
         onCopySummary={onCopySummary}
+        onSaveFeedback={async (feedback) => {
+          if (!preview) return;
+          const updated = await onUpdateTraining(preview.id, feedback);
+          if (updated) setPreview({ ...preview, ...updated });
+        }}
         onClose={() => {
           setPreview(null);
           setPreviewWorkout(null);
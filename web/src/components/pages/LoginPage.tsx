@@ -1,18 +1,34 @@
-import { LoginForm, UserForm } from "./../auth/AuthForm";
+import {
+  EmailActionForm,
+  LoginForm,
+  ResetPasswordForm,
+  TotpCodeForm,
+  UserForm,
+} from "./../auth/AuthForm";
+import { oidcLoginUrl } from "../../api";
 import { UI_TEXT } from "../../utils/uiText";
 
 export type LoginViewData = {
+  oidcEnabled: boolean;
   allowRegistration: boolean;
   loginError: string | null;
+  resetToken: string | null;
+  inviteToken: string | null;
+  totpChallenge: string | null;
 };
 
 export type LoginViewActions = {
   onLogin: (email: string, password: string) => void | Promise<void>;
   onCreateUser: (email: string, password: string) => void | Promise<void>;
   onClearError: () => void;
+  onRequestReset: (email: string) => void | Promise<void>;
+  onResetPassword: (newPassword: string) => void | Promise<void>;
+  onResendVerification: (email: string) => void | Promise<void>;
+  onVerifyTotp: (code: string) => void | Promise<void>;
+  onCancelTotp: () => void;
 };
 
-// LoginView renders local login and optional registration.
+// LoginView renders local login and optional registration, or the SSO entry point.
 export function LoginView({
   data,
   actions,
@@ -20,23 +36,112 @@ export function LoginView({
   data: LoginViewData;
   actions: LoginViewActions;
 }) {
-  const { allowRegistration, loginError } = data;
-  const { onLogin, onCreateUser, onClearError } = actions;
+  const {
+    oidcEnabled,
+    allowRegistration,
+    loginError,
+    resetToken,
+    inviteToken,
+    totpChallenge,
+  } = data;
+  const {
+    onLogin,
+    onCreateUser,
+    onClearError,
+    onRequestReset,
+    onResetPassword,
+    onResendVerification,
+    onVerifyTotp,
+    onCancelTotp,
+  } = actions;
+  if (oidcEnabled) {
+    return (
+      <section className="grid two">
+        <div className="panel">
+          <h3>{UI_TEXT.pages.auth.ssoTitle}</h3>
+          <p className="muted small hint">{UI_TEXT.pages.auth.ssoHint}</p>
+          <a className="btn primary" href={oidcLoginUrl()}>
+            {UI_TEXT.pages.auth.ssoButton}
+          </a>
+        </div>
+      </section>
+    );
+  }
+  if (resetToken) {
+    return (
+      <section className="grid two">
+        <div className="panel">
+          <h3>{UI_TEXT.pages.auth.resetTitle}</h3>
+          <ResetPasswordForm onReset={onResetPassword} />
+        </div>
+      </section>
+    );
+  }
+  if (totpChallenge) {
+    return (
+      <section className="grid two">
+        <div className="panel">
+          <h3>{UI_TEXT.pages.auth.totpTitle}</h3>
+          <p className="muted small hint">{UI_TEXT.pages.auth.totpHint}</p>
+          <TotpCodeForm
+            submitLabel={UI_TEXT.pages.auth.totpButton}
+            onSubmit={onVerifyTotp}
+            onCancel={onCancelTotp}
+          />
+        </div>
+      </section>
+    );
+  }
+  if (inviteToken) {
+    return (
+      <section className="grid two">
+        <div className="panel">
+          <h3>{UI_TEXT.pages.auth.invitationTitle}</h3>
+          <p className="muted small hint">
+            {UI_TEXT.pages.auth.invitationHint}
+          </p>
+          <UserForm onCreate={onCreateUser} />
+          {loginError && <p className="muted small">{loginError}</p>}
+        </div>
+      </section>
+    );
+  }
   return (
     <section className="grid two">
-      <div className="panel">
-        <h3>{UI_TEXT.pages.auth.loginTitle}</h3>
-        {/* Local login form */}
-        <LoginForm
-          onLogin={onLogin}
-          error={loginError}
-          onClearError={onClearError}
-        />
+      <div className="stack">
+        <div className="panel">
+          <h3>{UI_TEXT.pages.auth.loginTitle}</h3>
+          {/* Local login form */}
+          <LoginForm
+            onLogin={onLogin}
+            error={loginError}
+            onClearError={onClearError}
+          />
+        </div>
+        <details className="panel">
+          <summary>{UI_TEXT.pages.auth.forgotPassword}</summary>
+          <p className="muted small hint">
+            {UI_TEXT.pages.auth.forgotPasswordHint}
+          </p>
+          <EmailActionForm
+            submitLabel={UI_TEXT.pages.auth.sendResetLink}
+            onSubmit={onRequestReset}
+          />
+        </details>
       </div>
       {allowRegistration ? (
-        <div className="panel">
-          <h3>{UI_TEXT.pages.auth.createUserTitle}</h3>
-          <UserForm onCreate={onCreateUser} />
+        <div className="stack">
+          <div className="panel">
+            <h3>{UI_TEXT.pages.auth.createUserTitle}</h3>
+            <UserForm onCreate={onCreateUser} />
+          </div>
+          <details className="panel">
+            <summary>{UI_TEXT.pages.auth.resendVerification}</summary>
+            <EmailActionForm
+              submitLabel={UI_TEXT.pages.auth.resendVerification}
+              onSubmit={onResendVerification}
+            />
+          </details>
         </div>
       ) : (
         <div className="panel">
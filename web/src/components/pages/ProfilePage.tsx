@@ -1,11 +1,27 @@
 import { useEffect, useState } from "react";
 import type { RefObject } from "react";
-import type { SoundOption, Workout } from "../../types";
+import type {
+  CoachLink,
+  Organization,
+  OrgMember,
+  OrgRole,
+  SoundOption,
+  TotpEnrollment,
+  Workout,
+} from "../../types";
 import { SelectDropdown } from "../common/SelectDropdown";
+import { TotpCodeForm } from "../auth/AuthForm";
+import { myDataExportUrl } from "../../api";
 import { MESSAGES, toErrorMessage } from "../../utils/messages";
 import { UI_TEXT } from "../../utils/uiText";
 
-type ProfileTab = "settings" | "password" | "transfer";
+type ProfileTab =
+  | "settings"
+  | "password"
+  | "security"
+  | "coaching"
+  | "organizations"
+  | "transfer";
 type ThemeMode = "auto" | "dark" | "light";
 
 export type ProfileViewData = {
@@ -24,6 +40,13 @@ export type ProfileViewData = {
   activeWorkouts: Workout[];
   importInputRef: RefObject<HTMLInputElement | null>;
   authHeaderEnabled: boolean;
+  totpEnabled: boolean;
+  adminTotpRequired: boolean;
+  currentUserId: string;
+  coachLinks: CoachLink[];
+  canCoach: boolean;
+  organizations: Organization[];
+  orgMembers: OrgMember[];
 };
 
 export type ProfileViewActions = {
@@ -44,6 +67,24 @@ export type ProfileViewActions = {
     currentPassword: string,
     newPassword: string,
   ) => void | Promise<void>;
+  onBeginTotp: () => Promise<TotpEnrollment | null>;
+  onConfirmTotp: (code: string) => Promise<string[] | null>;
+  onDisableTotp: (password: string) => void | Promise<void>;
+  onRegenerateRecoveryCodes: (code: string) => Promise<string[] | null>;
+  onDeleteAccount: (confirm: string, password: string) => void | Promise<void>;
+  onInviteAthlete: (athleteId: string) => void | Promise<void>;
+  onAcceptCoachLink: (link: CoachLink) => void | Promise<void>;
+  onRemoveCoachLink: (link: CoachLink) => void | Promise<void>;
+  onAssignWorkout: (
+    athleteId: string,
+    workoutId: string,
+  ) => void | Promise<void>;
+  onCreateOrganization: (name: string) => void | Promise<void>;
+  onSwitchOrganization: (orgId: string) => void | Promise<void>;
+  onLeaveOrganization: (org: Organization) => void | Promise<void>;
+  onDeleteOrganization: (org: Organization) => void | Promise<void>;
+  onAddOrgMember: (userId: string, role: OrgRole) => void | Promise<void>;
+  onRemoveOrgMember: (member: OrgMember) => void | Promise<void>;
 };
 
 // ProfileView renders account preferences and transfer actions.
@@ -70,6 +111,13 @@ export function ProfileView({
     activeWorkouts,
     importInputRef,
     authHeaderEnabled,
+    totpEnabled,
+    adminTotpRequired,
+    currentUserId,
+    coachLinks,
+    canCoach,
+    organizations,
+    orgMembers,
   } = data;
   const {
     onProfileTabChange,
@@ -86,11 +134,29 @@ export function ProfileView({
     onExportWorkout,
     onImportWorkout,
     onPasswordChange,
+    onBeginTotp,
+    onConfirmTotp,
+    onDisableTotp,
+    onRegenerateRecoveryCodes,
+    onDeleteAccount,
+    onInviteAthlete,
+    onAcceptCoachLink,
+    onRemoveCoachLink,
+    onAssignWorkout,
+    onCreateOrganization,
+    onSwitchOrganization,
+    onLeaveOrganization,
+    onDeleteOrganization,
+    onAddOrgMember,
+    onRemoveOrgMember,
   } = actions;
   const canExport = Boolean(exportWorkoutId);
-  // Prevent password tab access when auth headers are enabled.
+  // Prevent password and security tab access when auth headers are enabled.
   useEffect(() => {
-    if (authHeaderEnabled && profileTab === "password") {
+    if (
+      authHeaderEnabled &&
+      (profileTab === "password" || profileTab === "security")
+    ) {
       onProfileTabChange("settings");
     }
   }, [authHeaderEnabled, profileTab, onProfileTabChange]);
@@ -238,6 +304,41 @@ export function ProfileView({
           {profileTab === "password" && !authHeaderEnabled && (
             <PasswordForm onSubmit={onPasswordChange} />
           )}
+          {profileTab === "security" && !authHeaderEnabled && (
+            <TwoFactorSettings
+              enabled={totpEnabled}
+              adminTotpRequired={adminTotpRequired}
+              onBegin={onBeginTotp}
+              onConfirm={onConfirmTotp}
+              onDisable={onDisableTotp}
+              onRegenerate={onRegenerateRecoveryCodes}
+            />
+          )}
+          {profileTab === "coaching" && (
+            <CoachingSettings
+              userId={currentUserId}
+              links={coachLinks}
+              canCoach={canCoach}
+              workouts={activeWorkouts}
+              onInvite={onInviteAthlete}
+              onAccept={onAcceptCoachLink}
+              onRemove={onRemoveCoachLink}
+              onAssign={onAssignWorkout}
+            />
+          )}
+          {profileTab === "organizations" && (
+            <OrganizationSettings
+              userId={currentUserId}
+              organizations={organizations}
+              members={orgMembers}
+              onCreate={onCreateOrganization}
+              onSwitch={onSwitchOrganization}
+              onLeave={onLeaveOrganization}
+              onDelete={onDeleteOrganization}
+              onAddMember={onAddOrgMember}
+              onRemoveMember={onRemoveOrgMember}
+            />
+          )}
           {profileTab === "transfer" && (
             <div className="stack">
               <div className="label">{UI_TEXT.pages.profile.transferLabel}</div>
@@ -275,6 +376,18 @@ export function ProfileView({
                   {UI_TEXT.pages.profile.importButton}
                 </button>
               </div>
+              <div className="label">{UI_TEXT.pages.profile.dataLabel}</div>
+              <p className="muted small">{UI_TEXT.pages.profile.dataHint}</p>
+              <div className="btn-group">
+                <a className="btn primary" href={myDataExportUrl()} download>
+                  {UI_TEXT.pages.profile.dataExportButton}
+                </a>
+              </div>
+              <DeleteAccountForm
+                userId={currentUserId}
+                requirePassword={!authHeaderEnabled}
+                onSubmit={onDeleteAccount}
+              />
             </div>
           )}
         </div>
@@ -293,6 +406,26 @@ export function ProfileView({
               {UI_TEXT.pages.profile.passwordTab}
             </button>
           )}
+          {!authHeaderEnabled && (
+            <button
+              className={profileTab === "security" ? "tab active" : "tab"}
+              onClick={() => onProfileTabChange("security")}
+            >
+              {UI_TEXT.pages.profile.securityTab}
+            </button>
+          )}
+          <button
+            className={profileTab === "coaching" ? "tab active" : "tab"}
+            onClick={() => onProfileTabChange("coaching")}
+          >
+            {UI_TEXT.pages.profile.coachingTab}
+          </button>
+          <button
+            className={profileTab === "organizations" ? "tab active" : "tab"}
+            onClick={() => onProfileTabChange("organizations")}
+          >
+            {UI_TEXT.pages.profile.organizationsTab}
+          </button>
           <button
             className={profileTab === "transfer" ? "tab active" : "tab"}
             onClick={() => onProfileTabChange("transfer")}
@@ -385,6 +518,64 @@ function PasswordForm({
   );
 }
 
+// DeleteAccountForm asks for the user id, and the password for local accounts, before deleting.
+function DeleteAccountForm({
+  userId,
+  requirePassword,
+  onSubmit,
+}: {
+  userId: string;
+  requirePassword: boolean;
+  onSubmit: (confirm: string, password: string) => void | Promise<void>;
+}) {
+  const [confirm, setConfirm] = useState("");
+  const [password, setPassword] = useState("");
+  const canDelete =
+    confirm.trim().toLowerCase() === userId.toLowerCase() &&
+    (!requirePassword || password.trim().length > 0);
+  return (
+    <form
+      onSubmit={(e) => {
+        e.preventDefault();
+        // Guard: require the typed confirmation before submit.
+        if (!canDelete) return;
+        onSubmit(confirm.trim(), password);
+      }}
+      className="stack"
+    >
+      <div className="label">{UI_TEXT.pages.profile.deleteAccountLabel}</div>
+      <p className="muted small">{UI_TEXT.pages.profile.deleteAccountHint}</p>
+      <div className="field">
+        <label>{UI_TEXT.pages.profile.deleteAccountConfirmLabel}</label>
+        <input
+          value={confirm}
+          onChange={(e) => setConfirm(e.target.value)}
+          placeholder={userId}
+          autoComplete="off"
+        />
+      </div>
+      {requirePassword && (
+        <div className="field">
+          <label>{UI_TEXT.pages.profile.currentPasswordLabel}</label>
+          <input
+            type="password"
+            value={password}
+            onChange={(e) => setPassword(e.target.value)}
+            placeholder={UI_TEXT.placeholders.currentPassword}
+          />
+        </div>
+      )}
+      <button
+        className={canDelete ? "btn primary" : "btn subtle"}
+        type="submit"
+        disabled={!canDelete}
+      >
+        {UI_TEXT.pages.profile.deleteAccountButton}
+      </button>
+    </form>
+  );
+}
+
 // DisplayNameForm edits the user-friendly display name.
 function DisplayNameForm({
   currentName,
@@ -440,3 +631,470 @@ function DisplayNameForm({
     </div>
   );
 }
+
+// TwoFactorSettings enrolls an authenticator app and manages recovery codes.
+function TwoFactorSettings({
+  enabled,
+  adminTotpRequired,
+  onBegin,
+  onConfirm,
+  onDisable,
+  onRegenerate,
+}: {
+  enabled: boolean;
+  adminTotpRequired: boolean;
+  onBegin: () => Promise<TotpEnrollment | null>;
+  onConfirm: (code: string) => Promise<string[] | null>;
+  onDisable: (password: string) => void | Promise<void>;
+  onRegenerate: (code: string) => Promise<string[] | null>;
+}) {
+  const [enrollment, setEnrollment] = useState<TotpEnrollment | null>(null);
+  const [recoveryCodes, setRecoveryCodes] = useState<string[] | null>(null);
+  const [password, setPassword] = useState("");
+
+  // Drop a pending enrollment once the server reports a change.
+  useEffect(() => {
+    setEnrollment(null);
+  }, [enabled]);
+
+  return (
+    <div className="stack">
+      <div className="label">{UI_TEXT.pages.profile.twoFactorLabel}</div>
+      <p className="muted small hint">
+        {enabled
+          ? UI_TEXT.pages.profile.twoFactorOn
+          : UI_TEXT.pages.profile.twoFactorOff}
+      </p>
+      {!enabled && adminTotpRequired && (
+        <p className="muted small hint">
+          {UI_TEXT.pages.profile.twoFactorAdminRequired}
+        </p>
+      )}
+      {recoveryCodes && (
+        <div className="stack">
+          <div className="label">
+            {UI_TEXT.pages.profile.recoveryCodesLabel}
+          </div>
+          <p className="muted small hint">
+            {UI_TEXT.pages.profile.recoveryCodesHint}
+          </p>
+          <pre>{recoveryCodes.join("\n")}</pre>
+        </div>
+      )}
+      {!enabled && !enrollment && (
+        <button
+          className="btn primary"
+          type="button"
+          onClick={async () => setEnrollment(await onBegin())}
+        >
+          {UI_TEXT.pages.profile.enableTwoFactor}
+        </button>
+      )}
+      {!enabled && enrollment && (
+        <div className="stack">
+          <p className="muted small hint">{UI_TEXT.pages.profile.enrollHint}</p>
+          <div className="field">
+            <label>{UI_TEXT.pages.profile.secretLabel}</label>
+            <input value={enrollment.secret} readOnly />
+          </div>
+          <a className="btn subtle" href={enrollment.uri}>
+            {UI_TEXT.pages.profile.openAuthenticator}
+          </a>
+          <TotpCodeForm
+            submitLabel={UI_TEXT.pages.profile.confirmTwoFactor}
+            onSubmit={async (code) => {
+              const codes = await onConfirm(code);
+              if (codes) setRecoveryCodes(codes);
+            }}
+            onCancel={() => setEnrollment(null)}
+          />
+        </div>
+      )}
+      {enabled && (
+        <>
+          <div className="divider" />
+          <p className="muted small hint">
+            {UI_TEXT.pages.profile.regenerateHint}
+          </p>
+          <TotpCodeForm
+            submitLabel={UI_TEXT.pages.profile.regenerateCodes}
+            onSubmit={async (code) => {
+              const codes = await onRegenerate(code);
+              if (codes) setRecoveryCodes(codes);
+            }}
+          />
+          <div className="divider" />
+          <p className="muted small hint">
+            {UI_TEXT.pages.profile.disableHint}
+          </p>
+          <form
+            className="stack"
+            onSubmit={async (e) => {
+              e.preventDefault();
+              if (!password.trim()) return;
+              await onDisable(password.trim());
+              setPassword("");
+              setRecoveryCodes(null);
+            }}
+          >
+            <div className="field">
+              <label>{UI_TEXT.pages.profile.currentPasswordLabel}</label>
+              <input
+                type="password"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+                placeholder={UI_TEXT.placeholders.currentPassword}
+                required
+              />
+            </div>
+            <button
+              className="btn subtle"
+              type="submit"
+              disabled={!password.trim()}
+            >
+              {UI_TEXT.pages.profile.disableTwoFactor}
+            </button>
+          </form>
+        </>
+      )}
+    </div>
+  );
+}
+
+// CoachingSettings lists coaches and athletes and lets coaches assign workouts.
+function CoachingSettings({
+  userId,
+  links,
+  canCoach,
+  workouts,
+  onInvite,
+  onAccept,
+  onRemove,
+  onAssign,
+}: {
+  userId: string;
+  links: CoachLink[];
+  canCoach: boolean;
+  workouts: Workout[];
+  onInvite: (athleteId: string) => void | Promise<void>;
+  onAccept: (link: CoachLink) => void | Promise<void>;
+  onRemove: (link: CoachLink) => void | Promise<void>;
+  onAssign: (athleteId: string, workoutId: string) => void | Promise<void>;
+}) {
+  const [athleteId, setAthleteId] = useState("");
+  const [assignAthleteId, setAssignAthleteId] = useState("");
+  const [assignWorkoutId, setAssignWorkoutId] = useState("");
+  const coaches = links.filter((link) => link.athleteId === userId);
+  const athletes = links.filter((link) => link.coachId === userId);
+  const activeAthletes = athletes.filter((link) => link.status === "active");
+
+  return (
+    <div className="stack">
+      <div className="label">{UI_TEXT.pages.profile.coachesLabel}</div>
+      {coaches.length === 0 && (
+        <p className="muted small">{UI_TEXT.pages.profile.noCoaches}</p>
+      )}
+      {coaches.length > 0 && (
+        <ul className="list">
+          {coaches.map((link) => (
+            <li key={link.id} className="list-item list-row">
+              <div>
+                <strong>{link.coachId}</strong>
+                {link.status === "pending" && (
+                  <div className="muted small">
+                    {UI_TEXT.pages.profile.pendingLink}
+                  </div>
+                )}
+              </div>
+              <div className="btn-group">
+                {link.status === "pending" && (
+                  <button
+                    className="btn primary"
+                    type="button"
+                    onClick={() => onAccept(link)}
+                  >
+                    {UI_TEXT.pages.profile.acceptCoach}
+                  </button>
+                )}
+                <button
+                  className="btn subtle"
+                  type="button"
+                  onClick={() => onRemove(link)}
+                >
+                  {UI_TEXT.pages.profile.removeLink}
+                </button>
+              </div>
+            </li>
+          ))}
+        </ul>
+      )}
+      {canCoach && (
+        <>
+          <div className="divider" />
+          <div className="label">{UI_TEXT.pages.profile.athletesLabel}</div>
+          {athletes.length === 0 && (
+            <p className="muted small">{UI_TEXT.pages.profile.noAthletes}</p>
+          )}
+          {athletes.length > 0 && (
+            <ul className="list">
+              {athletes.map((link) => (
+                <li key={link.id} className="list-item list-row">
+                  <div>
+                    <strong>{link.athleteId}</strong>
+                    {link.status === "pending" && (
+                      <div className="muted small">
+                        {UI_TEXT.pages.profile.pendingLink}
+                      </div>
+                    )}
+                  </div>
+                  <button
+                    className="btn subtle"
+                    type="button"
+                    onClick={() => onRemove(link)}
+                  >
+                    {UI_TEXT.pages.profile.removeLink}
+                  </button>
+                </li>
+              ))}
+            </ul>
+          )}
+          <form
+            className="stack"
+            onSubmit={async (e) => {
+              e.preventDefault();
+              if (!athleteId.trim()) return;
+              await onInvite(athleteId.trim());
+              setAthleteId("");
+            }}
+          >
+            <div className="field">
+              <label>{UI_TEXT.pages.profile.inviteAthleteLabel}</label>
+              <input
+                value={athleteId}
+                onChange={(e) => setAthleteId(e.target.value)}
+                placeholder={UI_TEXT.placeholders.athleteId}
+              />
+            </div>
+            <button
+              className="btn primary"
+              type="submit"
+              disabled={!athleteId.trim()}
+            >
+              {UI_TEXT.pages.profile.inviteAthleteButton}
+            </button>
+          </form>
+          {activeAthletes.length > 0 && (
+            <>
+              <div className="divider" />
+              <div className="label">
+                {UI_TEXT.pages.profile.assignWorkoutLabel}
+              </div>
+              <SelectDropdown
+                items={activeAthletes.map((link) => ({
+                  id: link.athleteId,
+                  label: link.athleteId,
+                }))}
+                value={assignAthleteId || null}
+                placeholder={UI_TEXT.placeholders.selectAthlete}
+                onSelect={(item) => setAssignAthleteId(item.id)}
+              />
+              <SelectDropdown
+                items={workouts.map((workout) => ({
+                  id: workout.id,
+                  label: workout.name,
+                }))}
+                value={assignWorkoutId || null}
+                placeholder={UI_TEXT.placeholders.selectWorkout}
+                onSelect={(item) => setAssignWorkoutId(item.id)}
+              />
+              <button
+                className="btn primary"
+                type="button"
+                disabled={!assignAthleteId || !assignWorkoutId}
+                onClick={() => onAssign(assignAthleteId, assignWorkoutId)}
+              >
+                {UI_TEXT.pages.profile.assignWorkoutButton}
+              </button>
+            </>
+          )}
+        </>
+      )}
+    </div>
+  );
+}
+
+// OrganizationSettings lists the user's organizations and manages the active one.
+function OrganizationSettings({
+  userId,
+  organizations,
+  members,
+  onCreate,
+  onSwitch,
+  onLeave,
+  onDelete,
+  onAddMember,
+  onRemoveMember,
+}: {
+  userId: string;
+  organizations: Organization[];
+  members: OrgMember[];
+  onCreate: (name: string) => void | Promise<void>;
+  onSwitch: (orgId: string) => void | Promise<void>;
+  onLeave: (org: Organization) => void | Promise<void>;
+  onDelete: (org: Organization) => void | Promise<void>;
+  onAddMember: (userId: string, role: OrgRole) => void | Promise<void>;
+  onRemoveMember: (member: OrgMember) => void | Promise<void>;
+}) {
+  const [name, setName] = useState("");
+  const [memberId, setMemberId] = useState("");
+  const [memberRole, setMemberRole] = useState<OrgRole>("member");
+  const active = organizations.find((org) => org.active);
+  const isOwner = active?.role === "owner";
+
+  return (
+    <div className="stack">
+      <div className="label">{UI_TEXT.pages.profile.organizationsLabel}</div>
+      <p className="muted small">{UI_TEXT.pages.profile.organizationsHint}</p>
+      {organizations.length === 0 && (
+        <p className="muted small">{UI_TEXT.pages.profile.noOrganizations}</p>
+      )}
+      {organizations.length > 0 && (
+        <ul className="list">
+          {organizations.map((org) => (
+            <li key={org.id} className="list-item list-row">
+              <div>
+                <strong>{org.name}</strong>
+                <div className="muted small">
+                  {UI_TEXT.orgRoles[org.role]}
+                  {org.active &&
+                    ` · ${UI_TEXT.pages.profile.activeOrganization}`}
+                </div>
+              </div>
+              <div className="btn-group">
+                {!org.active && (
+                  <button
+                    className="btn primary"
+                    type="button"
+                    onClick={() => onSwitch(org.id)}
+                  >
+                    {UI_TEXT.pages.profile.useOrganization}
+                  </button>
+                )}
+                <button
+                  className="btn subtle"
+                  type="button"
+                  onClick={() => onLeave(org)}
+                >
+                  {UI_TEXT.pages.profile.leaveOrganization}
+                </button>
+                {org.role === "owner" && (
+                  <button
+                    className="btn subtle"
+                    type="button"
+                    onClick={() => onDelete(org)}
+                  >
+                    {UI_TEXT.pages.profile.deleteOrganization}
+                  </button>
+                )}
+              </div>
+            </li>
+          ))}
+        </ul>
+      )}
+      {active && (
+        <button
+          className="btn subtle"
+          type="button"
+          onClick={() => onSwitch("")}
+        >
+          {UI_TEXT.pages.profile.leaveAllOrganizations}
+        </button>
+      )}
+      <form
+        className="stack"
+        onSubmit={async (e) => {
+          e.preventDefault();
+          if (!name.trim()) return;
+          await onCreate(name.trim());
+          setName("");
+        }}
+      >
+        <div className="field">
+          <label>{UI_TEXT.pages.profile.createOrganizationLabel}</label>
+          <input
+            value={name}
+            onChange={(e) => setName(e.target.value)}
+            placeholder={UI_TEXT.placeholders.organizationName}
+          />
+        </div>
+        <button className="btn primary" type="submit" disabled={!name.trim()}>
+          {UI_TEXT.pages.profile.createOrganizationButton}
+        </button>
+      </form>
+      {active && (
+        <>
+          <div className="divider" />
+          <div className="label">
+            {UI_TEXT.pages.profile.orgMembersLabel} · {active.name}
+          </div>
+          <ul className="list">
+            {members.map((member) => (
+              <li key={member.userId} className="list-item list-row">
+                <div>
+                  <strong>{member.name || member.userId}</strong>
+                  <div className="muted small">
+                    {UI_TEXT.orgRoles[member.role]}
+                  </div>
+                </div>
+                {isOwner && member.userId !== userId && (
+                  <button
+                    className="btn subtle"
+                    type="button"
+                    onClick={() => onRemoveMember(member)}
+                  >
+                    {UI_TEXT.pages.profile.removeLink}
+                  </button>
+                )}
+              </li>
+            ))}
+          </ul>
+          {isOwner && (
+            <form
+              className="stack"
+              onSubmit={async (e) => {
+                e.preventDefault();
+                if (!memberId.trim()) return;
+                await onAddMember(memberId.trim(), memberRole);
+                setMemberId("");
+              }}
+            >
+              <div className="field">
+                <label>{UI_TEXT.pages.profile.addOrgMemberLabel}</label>
+                <input
+                  value={memberId}
+                  onChange={(e) => setMemberId(e.target.value)}
+                  placeholder={UI_TEXT.placeholders.memberId}
+                />
+              </div>
+              <SelectDropdown
+                items={(["member", "editor", "owner"] as OrgRole[]).map(
+                  (role) => ({ id: role, label: UI_TEXT.orgRoles[role] }),
+                )}
+                value={memberRole}
+                placeholder={UI_TEXT.placeholders.selectOrgRole}
+                onSelect={(item) => setMemberRole(item.id as OrgRole)}
+              />
+              <button
+                className="btn primary"
+                type="submit"
+                disabled={!memberId.trim()}
+              >
+                {UI_TEXT.pages.profile.addOrgMemberButton}
+              </button>
+            </form>
+          )}
+        </>
+      )}
+    </div>
+  );
+}
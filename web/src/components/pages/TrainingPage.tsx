@@ -24,6 +24,7 @@ import { formatElapsedMillis } from "../../utils/format";
 import { PROMPTS } from "../../utils/messages";
 import { UI_TEXT } from "../../utils/uiText";
 import { getTrainingHeaderStatus } from "../../utils/training";
+import { ClassPanel } from "../training/ClassPanel";
 import { TrainingCard } from "../training/TrainingCard";
 import { TrainingFinishModal } from "../training/FinishTrainingModal";
 import { TrainingOverrunModal } from "../training/OverrunTrainingModal";
@@ -43,6 +44,7 @@ export type TrainingViewData = {
   sounds: SoundOption[];
   pauseOnTabHidden: boolean;
   showHours: boolean;
+  canCoach: boolean;
 };
 
 export type TrainingViewActions = {
@@ -55,6 +57,7 @@ export type TrainingViewActions = {
   onFinishTraining: () => Promise<string | null>;
   onCopySummary: () => void;
   onToast: (message: string) => void;
+  onStartFromState: (state: TrainingState) => void;
 };
 
 export function TrainingView({
@@ -86,6 +89,7 @@ export function TrainingView({
     onFinishTraining,
     onCopySummary,
     onToast,
+    onStartFromState,
   } = actions;
   const [finishSummary, setFinishSummary] = useState<string | null>(null);
   const autoFinishRef = useRef<string | null>(null);
@@ -300,6 +304,15 @@ export function TrainingView({
               </Box>
             ) : null}
 
+            <ClassPanel
+              canCoach={data.canCoach}
+              selectedWorkoutId={selectedWorkoutId}
+              hasTraining={Boolean(training && !training.done)}
+              showHours={data.showHours}
+              onStartFromState={onStartFromState}
+              onToast={onToast}
+            />
+
             <TrainingCard
               training={training}
               currentStep={currentStep}
@@ -0,0 +1,273 @@
+import { useEffect, useRef, useState } from "react";
+import GroupsRoundedIcon from "@mui/icons-material/GroupsRounded";
+import {
+  Box,
+  Button,
+  Chip,
+  Stack,
+  TextField,
+  Typography,
+} from "@mui/material";
+
+import {
+  endClass,
+  getClass,
+  joinClass,
+  leaveClass,
+  startClass,
+  watchClass,
+} from "../../api";
+import type { GroupClass, TrainingState } from "../../types";
+import { formatElapsedMillis } from "../../utils/format";
+import { toErrorMessage } from "../../utils/messages";
+import { UI_TEXT } from "../../utils/uiText";
+import { formatStepCounter, getStepName } from "../../utils/training";
+
+// followedElapsed returns the elapsed time of the current step of a followed class.
+function followedElapsed(state: TrainingState, nowMs: number): number {
+  const step = state.steps[state.currentIndex];
+  if (!step) return 0;
+  const updatedAt = state.updatedAt ? Date.parse(state.updatedAt) : nowMs;
+  const running = state.running ? Math.max(0, nowMs - updatedAt) : 0;
+  return (step.elapsedMillis || 0) + running;
+}
+
+// ClassPanel lets coaches open a group class and participants follow one.
+export function ClassPanel({
+  canCoach,
+  selectedWorkoutId,
+  hasTraining,
+  showHours,
+  onStartFromState,
+  onToast,
+}: {
+  canCoach: boolean;
+  selectedWorkoutId: string | null;
+  hasTraining: boolean;
+  showHours: boolean;
+  onStartFromState: (state: TrainingState) => void;
+  onToast: (message: string) => void;
+}) {
+  const text = UI_TEXT.pages.training.classes;
+  const [hosted, setHosted] = useState<GroupClass | null>(null);
+  const [joined, setJoined] = useState<GroupClass | null>(null);
+  const [followed, setFollowed] = useState<TrainingState | null>(null);
+  const [code, setCode] = useState("");
+  const [nowMs, setNowMs] = useState(() => Date.now());
+
+  const onToastRef = useRef(onToast);
+  const joinedId = joined?.id;
+
+  useEffect(() => {
+    onToastRef.current = onToast;
+  }, [onToast]);
+
+  // Follow the coach's timer while joined.
+  useEffect(() => {
+    if (!joinedId) return;
+    return watchClass(joinedId, setFollowed, () => {
+      setJoined(null);
+      setFollowed(null);
+      onToastRef.current(UI_TEXT.pages.training.classes.endedToast);
+    });
+  }, [joinedId]);
+
+  useEffect(() => {
+    if (!followed?.running) return;
+    const id = window.setInterval(() => setNowMs(Date.now()), 1000);
+    return () => window.clearInterval(id);
+  }, [followed?.running]);
+
+  const handleStart = async () => {
+    if (!selectedWorkoutId) return;
+    try {
+      const view = await startClass(selectedWorkoutId);
+      setHosted(view);
+      if (view.state) onStartFromState(view.state);
+    } catch (err) {
+      onToast(toErrorMessage(err, text.startFailed));
+    }
+  };
+
+  const handleRefresh = async () => {
+    if (!hosted) return;
+    try {
+      setHosted(await getClass(hosted.id));
+    } catch (err) {
+      onToast(toErrorMessage(err, text.loadFailed));
+    }
+  };
+
+  const handleEnd = async () => {
+    if (!hosted) return;
+    try {
+      await endClass(hosted.id);
+      setHosted(null);
+      onToast(text.endedToast);
+    } catch (err) {
+      onToast(toErrorMessage(err, text.endFailed));
+    }
+  };
+
+  const handleJoin = async () => {
+    if (!code.trim()) return;
+    try {
+      const view = await joinClass(code.trim());
+      setJoined(view);
+      setFollowed(view.state ?? null);
+      setCode("");
+    } catch (err) {
+      onToast(toErrorMessage(err, text.joinFailed));
+    }
+  };
+
+  const handleLeave = async () => {
+    if (!joined) return;
+    try {
+      await leaveClass(joined.id);
+      setJoined(null);
+      setFollowed(null);
+      onToast(text.leftToast);
+    } catch (err) {
+      onToast(toErrorMessage(err, text.leaveFailed));
+    }
+  };
+
+  const followedStep = followed?.steps[followed.currentIndex] ?? null;
+  const participants = (hosted?.participants || []).filter(
+    (participant) => !participant.leftAt,
+  );
+
+  return (
+    <Box
+      sx={{
+        px: 1.5,
+        py: 1.25,
+        borderRadius: 3,
+        border: 1,
+        borderColor: "divider",
+      }}
+    >
+      <Stack spacing={1.25}>
+        <Stack direction="row" spacing={1} sx={{ alignItems: "center" }}>
+          <GroupsRoundedIcon fontSize="small" />
+          <Typography variant="subtitle2">{text.title}</Typography>
+        </Stack>
+
+        {hosted && (
+          <Stack spacing={1}>
+            <Stack
+              direction="row"
+              spacing={1}
+              useFlexGap
+              flexWrap="wrap"
+              sx={{ alignItems: "center" }}
+            >
+              <Chip
+                color="primary"
+                label={`${text.codeLabel} ${hosted.joinCode}`}
+              />
+              <Chip
+                variant="outlined"
+                label={`${participants.length} ${text.participantsLabel}`}
+              />
+            </Stack>
+            {participants.length > 0 && (
+              <Typography variant="body2" color="text.secondary">
+                {participants
+                  .map((participant) => participant.name || participant.userId)
+                  .join(", ")}
+              </Typography>
+            )}
+            <Stack direction="row" spacing={1}>
+              <Button size="small" onClick={handleRefresh}>
+                {text.refreshButton}
+              </Button>
+              <Button size="small" color="error" onClick={handleEnd}>
+                {text.endButton}
+              </Button>
+            </Stack>
+          </Stack>
+        )}
+
+        {joined && (
+          <Stack spacing={1}>
+            <Typography variant="body2" color="text.secondary">
+              {joined.workoutName || UI_TEXT.training.headers.workoutFallback}
+            </Typography>
+            {followed && followedStep ? (
+              <Stack
+                direction="row"
+                spacing={1}
+                useFlexGap
+                flexWrap="wrap"
+                sx={{ alignItems: "center" }}
+              >
+                <Chip color="primary" label={getStepName(followedStep)} />
+                <Chip
+                  size="small"
+                  label={formatStepCounter(
+                    followed.currentIndex + 1,
+                    followed.steps.length,
+                  )}
+                />
+                <Chip
+                  size="small"
+                  variant="outlined"
+                  label={formatElapsedMillis(followedElapsed(followed, nowMs), {
+                    showHours,
+                  })}
+                />
+                {!followed.running && (
+                  <Chip size="small" label={text.waitingLabel} />
+                )}
+              </Stack>
+            ) : (
+              <Typography variant="body2" color="text.secondary">
+                {text.waitingLabel}
+              </Typography>
+            )}
+            <Box>
+              <Button size="small" color="error" onClick={handleLeave}>
+                {text.leaveButton}
+              </Button>
+            </Box>
+          </Stack>
+        )}
+
+        {!hosted && !joined && (
+          <Stack
+            direction={{ xs: "column", sm: "row" }}
+            spacing={1}
+            sx={{ alignItems: { xs: "stretch", sm: "center" } }}
+          >
+            <TextField
+              size="small"
+              label={text.codeLabel}
+              value={code}
+              onChange={(event) => setCode(event.target.value.toUpperCase())}
+              slotProps={{ htmlInput: { maxLength: 6 } }}
+            />
+            <Button
+              variant="outlined"
+              onClick={handleJoin}
+              disabled={!code.trim()}
+            >
+              {text.joinButton}
+            </Button>
+            {canCoach && (
+              <Button
+                variant="outlined"
+                onClick={handleStart}
+                disabled={!selectedWorkoutId || hasTraining}
+                title={hasTraining ? text.trainingActive : ""}
+              >
+                {text.startButton}
+              </Button>
+            )}
+          </Stack>
+        )}
+      </Stack>
+    </Box>
+  );
+}
@@ -66,7 +66,12 @@ export function WorkoutsList({
             <li key={workout.id} className="list-item list-row">
               <div>
                 <strong>{workout.name}</strong>
-                <div className="muted small">{workout.steps.length} steps</div>
+                <div className="muted small">
+                  {workout.steps.length} steps
+                  {workout.assignedBy
+                    ? ` · assigned by ${workout.assignedBy}`
+                    : ""}
+                </div>
               </div>
 
               <div className="btn-group">
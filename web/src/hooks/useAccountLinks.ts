@@ -0,0 +1,52 @@
+import { useCallback, useEffect, useState } from "react";
+
+import { verifyEmail } from "../api";
+import { MESSAGES, toErrorMessage } from "../utils/messages";
+import { UI_TEXT } from "../utils/uiText";
+
+type UseAccountLinksArgs = {
+  notify: (message: string) => Promise<void>;
+};
+
+// stripParam removes a query parameter from the address bar without reloading.
+function stripParam(name: string) {
+  const url = new URL(window.location.href);
+  url.searchParams.delete(name);
+  window.history.replaceState(null, "", url.toString());
+}
+
+// useAccountLinks handles password reset, email verification and invitation links.
+export function useAccountLinks({ notify }: UseAccountLinksArgs) {
+  const [resetToken, setResetToken] = useState<string | null>(() =>
+    new URLSearchParams(window.location.search).get("resetToken"),
+  );
+
+  const [inviteToken, setInviteToken] = useState<string | null>(() =>
+    new URLSearchParams(window.location.search).get("invite"),
+  );
+
+  useEffect(() => {
+    const token = new URLSearchParams(window.location.search).get(
+      "verifyToken",
+    );
+    if (!token) return;
+    stripParam("verifyToken");
+    verifyEmail(token)
+      .then(() => notify(UI_TEXT.toasts.emailVerified))
+      .catch((err) => notify(toErrorMessage(err, MESSAGES.verifyEmailFailed)));
+  }, [notify]);
+
+  // clearResetToken drops the reset token once it was used or dismissed.
+  const clearResetToken = useCallback(() => {
+    stripParam("resetToken");
+    setResetToken(null);
+  }, []);
+
+  // clearInviteToken drops the invite token once the account was created.
+  const clearInviteToken = useCallback(() => {
+    stripParam("invite");
+    setInviteToken(null);
+  }, []);
+
+  return { resetToken, clearResetToken, inviteToken, clearInviteToken };
+}
@@ -1,7 +1,14 @@
 import { useCallback } from "react";
 
-import { backfillExercises, updateUserAdmin } from "../api";
-import type { User, View } from "../types";
+import {
+  adminCreateUser,
+  backfillExercises,
+  createInvitation,
+  revokeInvitation,
+  sendUserPasswordReset,
+  updateUserRole,
+} from "../api";
+import type { Invitation, Role, User, View } from "../types";
 import { MESSAGES, toErrorMessage } from "../utils/messages";
 import { UI_TEXT } from "../utils/uiText";
 
@@ -9,6 +16,9 @@ import { UI_TEXT } from "../utils/uiText";
 type UseAdminActionsArgs = {
   currentUserId: string | null;
   setUsers: (updater: (prev: User[] | null) => User[] | null) => void;
+  setInvitations: (
+    updater: (prev: Invitation[] | null) => Invitation[] | null,
+  ) => void;
   setView: (view: View) => void;
   notify: (message: string) => Promise<void>;
 };
@@ -17,22 +27,25 @@ type UseAdminActionsArgs = {
 export function useAdminActions({
   currentUserId,
   setUsers,
+  setInvitations,
   setView,
   notify,
 }: UseAdminActionsArgs) {
-  // toggleAdmin flips admin privileges for a user.
-  const toggleAdmin = useCallback(
-    async (user: User) => {
+  // changeRole assigns a new role to a user.
+  const changeRole = useCallback(
+    async (user: User, role: Role) => {
       try {
         // Persist role change before updating local cache.
-        await updateUserAdmin(user.id, !user.isAdmin);
+        await updateUserRole(user.id, role);
         setUsers(
           (prev) =>
             prev?.map((usr) =>
-              usr.id === user.id ? { ...usr, isAdmin: !user.isAdmin } : usr,
+              usr.id === user.id
+                ? { ...usr, role, isAdmin: role === "admin" }
+                : usr,
             ) || prev,
         );
-        if (user.id === currentUserId && !user.isAdmin) {
+        if (user.id === currentUserId && role === "admin") {
           setView("admin");
         }
       } catch (err) {
@@ -42,6 +55,33 @@ export function useAdminActions({
     [currentUserId, setUsers, setView, notify],
   );
 
+  // createUser adds a verified account without the email confirmation step.
+  const createUser = useCallback(
+    async (email: string, password: string) => {
+      try {
+        const created = await adminCreateUser(email, password);
+        setUsers((prev) => (prev ? [...prev, created] : [created]));
+        await notify(UI_TEXT.toasts.userCreated);
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.createUserFailed));
+      }
+    },
+    [setUsers, notify],
+  );
+
+  // sendPasswordReset mails a reset link to the user.
+  const sendPasswordReset = useCallback(
+    async (user: User) => {
+      try {
+        await sendUserPasswordReset(user.id);
+        await notify(UI_TEXT.toasts.passwordResetLinkSent);
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.sendResetLinkFailed));
+      }
+    },
+    [notify],
+  );
+
   // backfillCatalog triggers the exercise catalog backfill.
   const backfillCatalog = useCallback(async () => {
     try {
@@ -52,5 +92,50 @@ export function useAdminActions({
     }
   }, [notify]);
 
-  return { toggleAdmin, backfillCatalog };
+  // inviteUser creates an invite link and copies it to the clipboard.
+  const inviteUser = useCallback(
+    async (maxUses: number, expiresInDays: number, role: Role) => {
+      try {
+        const expiresAt = new Date(
+          Date.now() + expiresInDays * 24 * 60 * 60 * 1000,
+        ).toISOString();
+        const created = await createInvitation({ maxUses, expiresAt, role });
+        setInvitations((prev) => (prev ? [created, ...prev] : [created]));
+        try {
+          await navigator.clipboard.writeText(created.url);
+          await notify(UI_TEXT.toasts.invitationCopied);
+        } catch {
+          // Show the link when the clipboard is unavailable; it is only returned once.
+          await notify(`${UI_TEXT.toasts.invitationCreated} ${created.url}`);
+        }
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.createInvitationFailed));
+      }
+    },
+    [setInvitations, notify],
+  );
+
+  // cancelInvitation revokes an invite link.
+  const cancelInvitation = useCallback(
+    async (invitation: Invitation) => {
+      try {
+        await revokeInvitation(invitation.id);
+        setInvitations(
+          (prev) => prev?.filter((item) => item.id !== invitation.id) || prev,
+        );
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.revokeInvitationFailed));
+      }
+    },
+    [setInvitations, notify],
+  );
+
+  return {
+    changeRole,
+    createUser,
+    sendPasswordReset,
+    backfillCatalog,
+    inviteUser,
+    cancelInvitation,
+  };
 }
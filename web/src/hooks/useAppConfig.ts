@@ -1,10 +1,11 @@
 import { useEffect, useRef, useState } from "react";
-import { getConfig, getCurrentUser, setAuthHeaderEnabled } from "../api";
+import { getConfig, getCurrentUser } from "../api";
 import { MESSAGES, toErrorMessage } from "../utils/messages";
 import type { View } from "../types";
 
 // AppConfig describes runtime settings exposed to the SPA.
 export type AppConfig = {
+  authMode: "local" | "proxy" | "oidc";
   authHeaderEnabled: boolean;
   allowRegistration: boolean;
   version: string;
@@ -17,7 +18,7 @@ type UseAppConfigArgs = {
   setCurrentUserId: (id: string | null) => void;
 };
 
-// useAppConfig loads config and resolves proxy-auth users when enabled.
+// useAppConfig loads config and resolves proxy-auth or OIDC users when enabled.
 export function useAppConfig({
   view,
   setView,
@@ -35,7 +36,19 @@ export function useAppConfig({
     getConfig()
       .then((cfg) => {
         setConfig(cfg);
-        setAuthHeaderEnabled(cfg.authHeaderEnabled);
+
+        // OIDC logins land back on the SPA with a fresh session cookie.
+        if (cfg.authMode === "oidc") {
+          return getCurrentUser()
+            .then((user) => {
+              localStorage.setItem("motus:userId", user.id);
+              setCurrentUserId(user.id);
+              if (viewRef.current === "login") setView("train");
+            })
+            .catch(() => {
+              // Not signed in yet; the login view offers the SSO button.
+            });
+        }
 
         if (!cfg.authHeaderEnabled) return;
 
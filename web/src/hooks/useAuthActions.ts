@@ -1,7 +1,15 @@
-import { useCallback } from "react";
+import { useCallback, useState } from "react";
 import { MESSAGES, toErrorMessage } from "../utils/messages";
+import { UI_TEXT } from "../utils/uiText";
 
-import { createUser, loginUser } from "../api";
+import {
+  createUser,
+  loginTotp,
+  loginUser,
+  requestPasswordReset,
+  resendVerification,
+  resetPassword,
+} from "../api";
 import type { User } from "../types";
 
 // UseAuthActionsArgs configures login and registration behavior.
@@ -9,22 +17,31 @@ type UseAuthActionsArgs = {
   setLoginError: (message: string | null) => void;
   onLoginSuccess: (user: User) => void;
   onRegisterSuccess: (user: User) => void;
+  notify: (message: string) => Promise<void>;
 };
 
-// useAuthActions provides login and registration handlers.
+// useAuthActions provides login, registration and password recovery handlers.
 export function useAuthActions({
   setLoginError,
   onLoginSuccess,
   onRegisterSuccess,
+  notify,
 }: UseAuthActionsArgs) {
+  // totpChallenge is set while a password login waits for the second factor.
+  const [totpChallenge, setTotpChallenge] = useState<string | null>(null);
+
   // login authenticates a user and forwards the result.
   const login = useCallback(
     async (email: string, password: string) => {
       try {
         // Clear prior error state before attempting login.
         setLoginError(null);
-        const user = await loginUser(email, password);
-        onLoginSuccess(user);
+        const result = await loginUser(email, password);
+        if ("totpRequired" in result) {
+          setTotpChallenge(result.challenge);
+          return;
+        }
+        onLoginSuccess(result);
       } catch (err) {
         setLoginError(toErrorMessage(err, MESSAGES.loginFailed));
       }
@@ -32,6 +49,26 @@ export function useAuthActions({
     [setLoginError, onLoginSuccess],
   );
 
+  // verifyTotp completes a pending login; a failed code requires a new login.
+  const verifyTotp = useCallback(
+    async (code: string) => {
+      if (!totpChallenge) return;
+      try {
+        setLoginError(null);
+        const user = await loginTotp(totpChallenge, code);
+        onLoginSuccess(user);
+      } catch (err) {
+        setLoginError(toErrorMessage(err, MESSAGES.verifyTotpFailed));
+      } finally {
+        setTotpChallenge(null);
+      }
+    },
+    [totpChallenge, setLoginError, onLoginSuccess],
+  );
+
+  // cancelTotp abandons a pending login.
+  const cancelTotp = useCallback(() => setTotpChallenge(null), []);
+
   // register creates a new user and forwards the result.
   const register = useCallback(
     async (email: string, password: string) => {
@@ -46,5 +83,72 @@ export function useAuthActions({
     [onRegisterSuccess, setLoginError],
   );
 
-  return { login, register };
+  // acceptInvitation creates an account from an invite link; it reports success.
+  const acceptInvitation = useCallback(
+    async (inviteToken: string, email: string, password: string) => {
+      try {
+        setLoginError(null);
+        await createUser(email, password, inviteToken);
+        await notify(UI_TEXT.toasts.invitationAccepted);
+        return true;
+      } catch (err) {
+        setLoginError(toErrorMessage(err, MESSAGES.authFailed));
+        return false;
+      }
+    },
+    [notify, setLoginError],
+  );
+
+  // requestReset asks for a password reset link.
+  const requestReset = useCallback(
+    async (email: string) => {
+      try {
+        await requestPasswordReset(email);
+        await notify(UI_TEXT.toasts.passwordResetSent);
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.requestPasswordResetFailed));
+      }
+    },
+    [notify],
+  );
+
+  // completeReset sets a new password from a reset link; it reports success.
+  const completeReset = useCallback(
+    async (token: string, newPassword: string) => {
+      try {
+        await resetPassword(token, newPassword);
+        await notify(UI_TEXT.toasts.passwordResetDone);
+        return true;
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.resetPasswordFailed));
+        return false;
+      }
+    },
+    [notify],
+  );
+
+  // resend mails a new email verification link.
+  const resend = useCallback(
+    async (email: string) => {
+      try {
+        await resendVerification(email);
+        await notify(UI_TEXT.toasts.verificationSent);
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.authFailed));
+      }
+    },
+    [notify],
+  );
+
+  return {
+    login,
+    register,
+    acceptInvitation,
+    requestReset,
+    completeReset,
+    resend,
+    totpChallenge,
+    verifyTotp,
+    cancelTotp,
+  };
 }
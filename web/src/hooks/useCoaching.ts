@@ -0,0 +1,91 @@
+import { useCallback, useEffect, useState } from "react";
+
+import {
+  acceptCoachLink,
+  assignWorkout,
+  inviteAthlete,
+  listCoachLinks,
+  removeCoachLink,
+} from "../api";
+import type { CoachLink } from "../types";
+import { MESSAGES, toErrorMessage } from "../utils/messages";
+import { UI_TEXT } from "../utils/uiText";
+
+type UseCoachingArgs = {
+  currentUserId: string | null;
+  notify: (message: string) => Promise<void>;
+};
+
+// useCoaching loads the coach links of the current user and wraps their actions.
+export function useCoaching({ currentUserId, notify }: UseCoachingArgs) {
+  const [links, setLinks] = useState<CoachLink[]>([]);
+
+  useEffect(() => {
+    if (!currentUserId) {
+      setLinks([]);
+      return;
+    }
+    listCoachLinks()
+      .then(setLinks)
+      .catch((err) =>
+        notify(toErrorMessage(err, MESSAGES.loadCoachLinksFailed)),
+      );
+  }, [currentUserId, notify]);
+
+  // invite asks an athlete to accept the current user as coach.
+  const invite = useCallback(
+    async (athleteId: string) => {
+      try {
+        const link = await inviteAthlete(athleteId);
+        setLinks((prev) => [link, ...prev]);
+        await notify(UI_TEXT.toasts.athleteInvited);
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.inviteAthleteFailed));
+      }
+    },
+    [notify],
+  );
+
+  // accept confirms a pending coach invitation.
+  const accept = useCallback(
+    async (link: CoachLink) => {
+      try {
+        const accepted = await acceptCoachLink(link.id);
+        setLinks((prev) =>
+          prev.map((item) => (item.id === accepted.id ? accepted : item)),
+        );
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.acceptCoachLinkFailed));
+      }
+    },
+    [notify],
+  );
+
+  // remove ends a coach link or declines an invitation.
+  const remove = useCallback(
+    async (link: CoachLink) => {
+      try {
+        await removeCoachLink(link.id);
+        setLinks((prev) => prev.filter((item) => item.id !== link.id));
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.removeCoachLinkFailed));
+      }
+    },
+    [notify],
+  );
+
+  // assign copies a workout into an athlete's workouts.
+  const assign = useCallback(
+    async (athleteId: string, workoutId: string) => {
+      try {
+        await assignWorkout(athleteId, workoutId);
+        await notify(UI_TEXT.toasts.workoutAssigned);
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.assignWorkoutFailed));
+      }
+    },
+    [notify],
+  );
+
+  return { links, invite, accept, remove, assign };
+}
@@ -8,7 +8,7 @@ import type { AskConfirmOptions, CatalogExercise } from "../types";
 
 // UseExerciseActionsArgs wires exercise management actions.
 type UseExerciseActionsArgs = {
-  isAdmin: boolean;
+  canEditCatalog: boolean;
   setExerciseCatalog: Dispatch<SetStateAction<CatalogExercise[]>>;
   askPrompt: (message: string, defaultValue?: string) => Promise<string | null>;
   askConfirm: (
@@ -21,7 +21,7 @@ type UseExerciseActionsArgs = {
 
 // useExerciseActions provides exercise CRUD handlers.
 export function useExerciseActions({
-  isAdmin,
+  canEditCatalog,
   setExerciseCatalog,
   askPrompt,
   askConfirm,
@@ -71,7 +71,7 @@ export function useExerciseActions({
   const renameExercise = useCallback(
     async (ex: CatalogExercise) => {
       const name = await askPrompt(
-        ex.isCore && !isAdmin
+        ex.isCore && !canEditCatalog
           ? UI_TEXT.exercises.createCopy
           : UI_TEXT.exercises.rename,
         ex.name,
@@ -96,7 +96,7 @@ export function useExerciseActions({
         await notify(toErrorMessage(err, MESSAGES.renameExerciseFailed));
       }
     },
-    [askPrompt, isAdmin, notify, setExerciseCatalog, showToast],
+    [askPrompt, canEditCatalog, notify, setExerciseCatalog, showToast],
   );
 
   // deleteExerciseEntry removes an exercise after confirmation.
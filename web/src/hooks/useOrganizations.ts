@@ -0,0 +1,162 @@
+import { useCallback, useEffect, useState } from "react";
+
+import {
+  addOrgMember,
+  createOrganization,
+  deleteOrganization,
+  listOrganizations,
+  listOrgMembers,
+  removeOrgMember,
+  switchOrganization,
+} from "../api";
+import type { Organization, OrgMember, OrgRole } from "../types";
+import { MESSAGES, toErrorMessage } from "../utils/messages";
+import { UI_TEXT } from "../utils/uiText";
+
+type UseOrganizationsArgs = {
+  currentUserId: string | null;
+  notify: (message: string) => Promise<void>;
+  onContextChange: () => void;
+};
+
+// useOrganizations loads the organizations of the current user and wraps their actions.
+export function useOrganizations({
+  currentUserId,
+  notify,
+  onContextChange,
+}: UseOrganizationsArgs) {
+  const [organizations, setOrganizations] = useState<Organization[]>([]);
+  const [members, setMembers] = useState<OrgMember[]>([]);
+  const active = organizations.find((org) => org.active) || null;
+  const activeId = active?.id || "";
+
+  const reload = useCallback(async () => {
+    if (!currentUserId) {
+      setOrganizations([]);
+      return;
+    }
+    try {
+      setOrganizations((await listOrganizations()) || []);
+    } catch (err) {
+      await notify(toErrorMessage(err, MESSAGES.loadOrganizationsFailed));
+    }
+  }, [currentUserId, notify]);
+
+  useEffect(() => {
+    reload();
+  }, [reload]);
+
+  // Members are only listed for the active organization.
+  useEffect(() => {
+    if (!activeId) {
+      setMembers([]);
+      return;
+    }
+    listOrgMembers(activeId)
+      .then((items) => setMembers(items || []))
+      .catch(() => setMembers([]));
+  }, [activeId]);
+
+  // create founds an organization owned by the current user.
+  const create = useCallback(
+    async (name: string) => {
+      try {
+        const org = await createOrganization(name);
+        setOrganizations((prev) => [
+          ...prev,
+          { ...org, role: "owner", active: false },
+        ]);
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.createOrganizationFailed));
+      }
+    },
+    [notify],
+  );
+
+  // select switches the shared library context; an empty id leaves all organizations.
+  const select = useCallback(
+    async (orgId: string) => {
+      try {
+        await switchOrganization(orgId);
+        setOrganizations((prev) =>
+          prev.map((org) => ({ ...org, active: org.id === orgId })),
+        );
+        onContextChange();
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.switchOrganizationFailed));
+      }
+    },
+    [notify, onContextChange],
+  );
+
+  // leave removes the current user from an organization.
+  const leave = useCallback(
+    async (org: Organization) => {
+      if (!currentUserId) return;
+      try {
+        await removeOrgMember(org.id, currentUserId);
+        setOrganizations((prev) => prev.filter((item) => item.id !== org.id));
+        if (org.active) onContextChange();
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.leaveOrganizationFailed));
+      }
+    },
+    [currentUserId, notify, onContextChange],
+  );
+
+  // remove deletes an organization with its shared library.
+  const remove = useCallback(
+    async (org: Organization) => {
+      try {
+        await deleteOrganization(org.id);
+        setOrganizations((prev) => prev.filter((item) => item.id !== org.id));
+        if (org.active) onContextChange();
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.deleteOrganizationFailed));
+      }
+    },
+    [notify, onContextChange],
+  );
+
+  // addMember adds a user to the active organization.
+  const addMember = useCallback(
+    async (userId: string, role: OrgRole) => {
+      if (!activeId) return;
+      try {
+        const member = await addOrgMember(activeId, userId, role);
+        setMembers((prev) => [...prev, member]);
+        await notify(UI_TEXT.toasts.orgMemberAdded);
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.addOrgMemberFailed));
+      }
+    },
+    [activeId, notify],
+  );
+
+  // removeMember removes a user from the active organization.
+  const removeMember = useCallback(
+    async (member: OrgMember) => {
+      try {
+        await removeOrgMember(member.orgId, member.userId);
+        setMembers((prev) =>
+          prev.filter((item) => item.userId !== member.userId),
+        );
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.removeOrgMemberFailed));
+      }
+    },
+    [notify],
+  );
+
+  return {
+    organizations,
+    active,
+    members,
+    create,
+    select,
+    leave,
+    remove,
+    addMember,
+    removeMember,
+  };
+}
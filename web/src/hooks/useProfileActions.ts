@@ -2,8 +2,17 @@ import { useCallback } from "react";
 import { MESSAGES, PROMPTS, toErrorMessage } from "../utils/messages";
 import { UI_TEXT } from "../utils/uiText";
 
-import { changePassword, exportWorkout, importWorkout } from "../api";
-import type { Workout } from "../types";
+import {
+  beginTotp,
+  changePassword,
+  confirmTotp,
+  deleteAccount,
+  disableTotp,
+  exportWorkout,
+  importWorkout,
+  regenerateRecoveryCodes,
+} from "../api";
+import type { TotpEnrollment, Workout } from "../types";
 
 // UseProfileActionsArgs wires profile and transfer actions.
 type UseProfileActionsArgs = {
@@ -13,6 +22,8 @@ type UseProfileActionsArgs = {
   setWorkouts: (updater: (prev: Workout[] | null) => Workout[] | null) => void;
   showToast: (message: string) => void;
   notify: (message: string) => Promise<void>;
+  onTotpChange: () => void;
+  onAccountDeleted: () => void;
 };
 
 // useProfileActions provides profile settings handlers.
@@ -23,6 +34,8 @@ export function useProfileActions({
   setWorkouts,
   showToast,
   notify,
+  onTotpChange,
+  onAccountDeleted,
 }: UseProfileActionsArgs) {
   // exportSelectedWorkout downloads the selected workout JSON.
   const exportSelectedWorkout = useCallback(async () => {
@@ -87,5 +100,83 @@ export function useProfileActions({
     [notify],
   );
 
-  return { exportSelectedWorkout, importWorkoutFile, updatePassword };
+  // startTotp creates a pending authenticator secret.
+  const startTotp = useCallback(async (): Promise<TotpEnrollment | null> => {
+    try {
+      return await beginTotp();
+    } catch (err) {
+      await notify(toErrorMessage(err, MESSAGES.enableTotpFailed));
+      return null;
+    }
+  }, [notify]);
+
+  // enableTotp confirms the pending secret and returns the recovery codes.
+  const enableTotp = useCallback(
+    async (code: string): Promise<string[] | null> => {
+      try {
+        const { recoveryCodes } = await confirmTotp(code);
+        onTotpChange();
+        showToast(UI_TEXT.toasts.totpEnabled);
+        return recoveryCodes;
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.enableTotpFailed));
+        return null;
+      }
+    },
+    [notify, onTotpChange, showToast],
+  );
+
+  // turnOffTotp disables two-factor authentication after a password check.
+  const turnOffTotp = useCallback(
+    async (password: string) => {
+      try {
+        await disableTotp(password);
+        onTotpChange();
+        showToast(UI_TEXT.toasts.totpDisabled);
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.disableTotpFailed));
+      }
+    },
+    [notify, onTotpChange, showToast],
+  );
+
+  // renewRecoveryCodes replaces the recovery codes after a code check.
+  const renewRecoveryCodes = useCallback(
+    async (code: string): Promise<string[] | null> => {
+      try {
+        const { recoveryCodes } = await regenerateRecoveryCodes(code);
+        return recoveryCodes;
+      } catch (err) {
+        await notify(
+          toErrorMessage(err, MESSAGES.regenerateRecoveryCodesFailed),
+        );
+        return null;
+      }
+    },
+    [notify],
+  );
+
+  // removeAccount deletes the current account and signs out.
+  const removeAccount = useCallback(
+    async (confirm: string, password: string) => {
+      try {
+        await deleteAccount(confirm, password);
+        onAccountDeleted();
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.deleteAccountFailed));
+      }
+    },
+    [notify, onAccountDeleted],
+  );
+
+  return {
+    exportSelectedWorkout,
+    importWorkoutFile,
+    updatePassword,
+    startTotp,
+    enableTotp,
+    turnOffTotp,
+    renewRecoveryCodes,
+    removeAccount,
+  };
 }
@@ -1,14 +1,19 @@
-import { startTraining as startTrainingApi } from "../api";
-import type { AskConfirmOptions, TrainingState } from "../types";
-import { buildSummary } from "../utils/summary";
-import { MESSAGES, PROMPTS } from "../utils/messages";
+import {
+  getTrainingSummary,
+  startTraining as startTrainingApi,
+} from "../api";
+import type {
+  AskConfirmOptions,
+  PersonalRecord,
+  TrainingState,
+} from "../types";
+import { MESSAGES, PROMPTS, toErrorMessage } from "../utils/messages";
 import { UI_TEXT } from "../utils/uiText";
 
 // UseTrainingActionsArgs describes dependencies for training actions.
 type UseTrainingActionsArgs = {
   selectedWorkoutId: string | null;
   training: TrainingState | null;
-  currentWorkoutName: string;
   setTrainingView: () => void;
   setPromptedResume: (next: boolean) => void;
   setResumeSuppressed: (next: boolean) => void;
@@ -17,8 +22,10 @@ type UseTrainingActionsArgs = {
     ok: boolean;
     error?: string;
     training?: TrainingState;
+    personalRecords?: PersonalRecord[];
   } | null>;
   historyReload: () => void;
+  onPersonalRecords: (records: PersonalRecord[]) => void;
   askConfirm: (
     message: string,
     options?: AskConfirmOptions,
@@ -30,13 +37,13 @@ type UseTrainingActionsArgs = {
 export function useTrainingActions({
   selectedWorkoutId,
   training,
-  currentWorkoutName,
   setTrainingView,
   setPromptedResume,
   setResumeSuppressed,
   startFromState,
   finishAndLog,
   historyReload,
+  onPersonalRecords,
   askConfirm,
   notify,
 }: UseTrainingActionsArgs): {
@@ -76,16 +83,19 @@ export function useTrainingActions({
       return null;
     }
 
+    if (result.personalRecords?.length) {
+      onPersonalRecords(result.personalRecords);
+    }
+
     if (result.training) {
       historyReload();
-      return buildSummary({
-        workoutName: currentWorkoutName || result.training.workoutName,
-        workoutId: result.training.workoutId,
-        userId: result.training.userId,
-        startedAt: result.training.startedAt,
-        completedAt: result.training.completedAt,
-        steps: result.training.steps,
-      });
+      try {
+        const summary = await getTrainingSummary(result.training.trainingId);
+        return summary.text;
+      } catch (err) {
+        await notify(toErrorMessage(err, MESSAGES.loadSummaryFailed));
+        return null;
+      }
     }
 
     historyReload();
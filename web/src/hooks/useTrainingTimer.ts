@@ -1,5 +1,11 @@
 import { useCallback, useEffect, useMemo, useRef, useState } from "react";
-import { logTrainingCompletion } from "../api";
+import {
+  getActiveTraining,
+  logTrainingCompletion,
+  updateTraining,
+  watchTraining,
+  type TrainingAction,
+} from "../api";
 import type { TrainingState } from "../types";
 import { getCountdownAutoAdvanceDelay } from "../utils/countdown";
 import { MESSAGES, toErrorMessage } from "../utils/messages";
@@ -60,6 +66,9 @@ export function useTrainingTimer({
   // Finish/log concurrency guard.
   const finishingRef = useRef<string | null>(null);
 
+  // Transitions sent to the server that did not settle yet.
+  const pendingSyncRef = useRef(0);
+
   useEffect(() => {
     trainingRef.current = training;
   }, [training]);
@@ -92,6 +101,22 @@ export function useTrainingTimer({
     [],
   );
 
+  // syncServer mirrors a transition to the server so the training can be resumed on any device.
+  const syncServer = useCallback(
+    (action: TrainingAction, trainingId?: string, from?: number) => {
+      if (!trainingId) return;
+      pendingSyncRef.current += 1;
+      updateTraining(trainingId, action, from)
+        .catch((err) => {
+          console.warn(`sync training ${action} failed`, err);
+        })
+        .finally(() => {
+          pendingSyncRef.current -= 1;
+        });
+    },
+    [],
+  );
+
   // startFromState initializes training from server state.
   const startFromState = useCallback(
     (raw: TrainingState) => {
@@ -115,11 +140,15 @@ export function useTrainingTimer({
 
   // startCurrentStep begins or resumes the current step.
   const startCurrentStep = useCallback(() => {
+    const current = trainingRef.current;
+    if (current && !current.running) {
+      syncServer(current.startedAt ? "resume" : "start", current.trainingId);
+    }
     update((next) => {
       setRunning(next, true);
       return next;
     });
-  }, [update]);
+  }, [update, syncServer]);
 
   // pause stops the timer without completing the step.
   const pause = useCallback(() => {
@@ -132,13 +161,14 @@ export function useTrainingTimer({
         stepId: current.steps?.[current.currentIndex ?? 0]?.id,
         elapsedMs: currentStepElapsedNow(current, at),
       });
+      if (current.running) syncServer("pause", current.trainingId);
     }
 
     update((next) => {
       setRunning(next, false);
       return next;
     });
-  }, [update]);
+  }, [update, syncServer]);
 
   // nextStep completes the current step and advances to the next one.
   const nextStep = useCallback(
@@ -159,6 +189,13 @@ export function useTrainingTimer({
         } else {
           logTimerEvent("advance-step", payload);
         }
+        if (current.startedAt && !current.done) {
+          syncServer(
+            "advance",
+            current.trainingId,
+            current.currentIndex ?? 0,
+          );
+        }
       }
 
       update((next) => {
@@ -172,7 +209,7 @@ export function useTrainingTimer({
         return next;
       });
     },
-    [update],
+    [update, syncServer],
   );
 
   // finishAndLog completes training and sends it to the backend.
@@ -214,7 +251,7 @@ export function useTrainingTimer({
       });
 
       try {
-        await logTrainingCompletion({
+        const logged = await logTrainingCompletion({
           trainingId: next.trainingId,
           workoutId: next.workoutId,
           workoutName: next.workoutName,
@@ -227,6 +264,7 @@ export function useTrainingTimer({
             type: step.type,
             estimatedSeconds: step.estimatedSeconds,
             elapsedMillis: step.elapsedMillis,
+            results: step.results,
           })),
         });
 
@@ -236,7 +274,11 @@ export function useTrainingTimer({
             : prev,
         );
 
-        return { ok: true, training: next };
+        return {
+          ok: true,
+          training: next,
+          personalRecords: logged.personalRecords || [],
+        };
       } catch (err) {
         console.warn("log train failed", err);
         return { ok: false, error: toErrorMessage(err, MESSAGES.logTrainingFailed) };
@@ -391,6 +433,55 @@ export function useTrainingTimer({
     nextStep,
   ]);
 
+  // Restore a training started on another device when nothing is stored locally.
+  useEffect(() => {
+    if (!currentUserId || trainingRef.current) return;
+    let cancelled = false;
+    getActiveTraining()
+      .then((state) => {
+        if (cancelled || trainingRef.current || state.done) return;
+        const restored = startFromState({ ...state, running: false });
+        if (state.running) syncServer("pause", restored.trainingId);
+        setRestoredTrainingId(restored.trainingId);
+      })
+      .catch(() => {
+        // No training in progress.
+      });
+    return () => {
+      cancelled = true;
+    };
+  }, [currentUserId, startFromState, syncServer]);
+
+  // Follow transitions made on other devices while the training is open here.
+  useEffect(() => {
+    const trainingId = training?.trainingId;
+    if (!trainingId || training?.done) return;
+    return watchTraining(
+      trainingId,
+      (remote) => {
+        const current = trainingRef.current;
+        if (pendingSyncRef.current > 0) return;
+        if (!current || current.trainingId !== remote.trainingId) return;
+        const unchanged =
+          current.currentIndex === remote.currentIndex &&
+          Boolean(current.running) === Boolean(remote.running) &&
+          Boolean(current.done) === Boolean(remote.done);
+        if (unchanged) return;
+        // The device that finished the training logs it.
+        startFromState({ ...remote, logged: Boolean(remote.done) });
+      },
+      () => {
+        // Logged, aborted or replaced elsewhere.
+        const current = trainingRef.current;
+        if (!current || current.trainingId !== trainingId || current.done) {
+          return;
+        }
+        setTraining(null);
+        clearPersistedTraining();
+      },
+    );
+  }, [training?.trainingId, training?.done, startFromState]);
+
   // Persist state on page hide/unload.
   useEffect(() => {
     const handlePageHide = () => {
@@ -470,6 +561,12 @@ export function useTrainingTimer({
     nextStep,
     finishAndLog,
     markSoundPlayed,
+    abort: () => {
+      const current = trainingRef.current;
+      if (current && !current.done) {
+        syncServer("abort", current.trainingId);
+      }
+    },
     clear: () => {
       setTraining(null);
       setRestoredTrainingId(null);
@@ -1,6 +1,7 @@
 import { useMemo } from "react";
 import {
   getCurrentUser,
+  listInvitations,
   listTrainingHistory,
   listSounds,
   listTemplates,
@@ -8,7 +9,8 @@ import {
   listWorkouts,
 } from "../api";
 import type {
-  TrainingHistoryItem,
+  Invitation,
+  TrainingHistoryPage,
   SoundOption,
   Template,
   User,
@@ -36,16 +38,20 @@ export function useWorkoutsData({
     () => (isAdmin ? listUsers() : Promise.resolve([])),
     [isAdmin],
   );
+  const invitations = useDataLoader<Invitation[]>(
+    () => (isAdmin ? listInvitations() : Promise.resolve([])),
+    [isAdmin],
+  );
   const sounds = useDataLoader<SoundOption[]>(listSounds, []);
   const workouts = useDataLoader<Workout[]>(
     () => (currentUserId ? listWorkouts(currentUserId) : Promise.resolve([])),
     [currentUserId],
   );
-  const history = useDataLoader<TrainingHistoryItem[]>(
+  const history = useDataLoader<TrainingHistoryPage>(
     () =>
       currentUserId
         ? listTrainingHistory(currentUserId)
-        : Promise.resolve([] as TrainingHistoryItem[]),
+        : Promise.resolve({ items: [] } as TrainingHistoryPage),
     [currentUserId],
   );
   const templates = useDataLoader<Template[]>(listTemplates, []);
@@ -59,6 +65,7 @@ export function useWorkoutsData({
   return {
     currentUserLoader,
     users,
+    invitations,
     sounds,
     workouts,
     history,
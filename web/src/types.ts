@@ -67,6 +67,8 @@ export type Workout = {
   name: string;
   createdAt?: string;
   isTemplate?: boolean;
+  assignedBy?: string;
+  orgId?: string;
   steps: WorkoutStep[];
 };
 
@@ -84,12 +86,79 @@ export type View =
   | "templates"
   | "admin";
 
+// Role names the permission set of an account.
+export type Role = "admin" | "coach" | "catalog-editor" | "member";
+
 // User describes a Motus account.
 export type User = {
   id: string;
   name: string;
+  role?: Role;
   isAdmin?: boolean;
   createdAt: string;
+  emailVerifiedAt?: string;
+  totpEnabled?: boolean;
+};
+
+// Invitation lets people register while open registration is disabled.
+export type Invitation = {
+  id: string;
+  createdBy: string;
+  role: Role;
+  maxUses: number;
+  uses: number;
+  createdAt: string;
+  expiresAt: string;
+};
+
+// CoachLink connects a coach with an athlete once the athlete accepted.
+export type CoachLink = {
+  id: string;
+  coachId: string;
+  athleteId: string;
+  status: "pending" | "active";
+  createdAt: string;
+  acceptedAt?: string;
+};
+
+// OrgRole names the role of a user within an organization.
+export type OrgRole = "owner" | "editor" | "member";
+
+// Organization groups users around shared templates and exercises.
+export type Organization = {
+  id: string;
+  name: string;
+  createdBy: string;
+  createdAt: string;
+  role: OrgRole;
+  active: boolean;
+};
+
+// OrgMember describes a member of an organization.
+export type OrgMember = {
+  orgId: string;
+  userId: string;
+  name: string;
+  role: OrgRole;
+  joinedAt: string;
+};
+
+// CreatedInvitation carries the invite link, which is only returned once.
+export type CreatedInvitation = Invitation & {
+  token: string;
+  url: string;
+};
+
+// TotpChallenge asks for a second factor after a valid password.
+export type TotpChallenge = {
+  totpRequired: true;
+  challenge: string;
+};
+
+// TotpEnrollment carries a pending authenticator secret.
+export type TotpEnrollment = {
+  secret: string;
+  uri: string;
 };
 
 // TrainingStepState captures a live training step.
@@ -107,6 +176,156 @@ export type TrainingStepState = WorkoutStep & {
   superset?: boolean;
   setName?: string;
   subsetEstimatedSeconds?: number;
+  results?: ExerciseResult[];
+  rpe?: number;
+};
+
+// ExerciseStatus tells whether an exercise was done as planned.
+export type ExerciseStatus = "completed" | "skipped" | "failed";
+
+// WeightUnit is the unit of a logged weight.
+export type WeightUnit = "kg" | "lb";
+
+// ExerciseResult records what was actually performed for one exercise.
+export type ExerciseResult = {
+  exerciseId?: string;
+  name?: string;
+  reps?: number;
+  weight?: number;
+  weightUnit?: WeightUnit;
+  durationSeconds?: number;
+  status?: ExerciseStatus;
+};
+
+// TrainingStepExercise is a logged exercise result of a step.
+export type TrainingStepExercise = {
+  id: string;
+  stepId: string;
+  exerciseOrder: number;
+  exerciseId?: string;
+  type?: "rep" | "stopwatch" | "countdown";
+  name: string;
+  reps: number;
+  weight: number;
+  weightUnit?: WeightUnit;
+  durationSeconds: number;
+  status: ExerciseStatus;
+};
+
+// PersonalRecordKind names what a personal record measures.
+export type PersonalRecordKind =
+  | "max_weight"
+  | "max_reps"
+  | "estimated_1rm"
+  | "fastest_time";
+
+// PersonalRecord is a best performance on a catalog exercise.
+export type PersonalRecord = {
+  id: string;
+  userId: string;
+  exerciseId: string;
+  exerciseName: string;
+  kind: PersonalRecordKind;
+  value: number;
+  reps: number;
+  weight: number;
+  weightUnit?: WeightUnit;
+  durationSeconds: number;
+  trainingId: string;
+  achievedAt: string;
+};
+
+// ExerciseRecords groups the personal records of one exercise.
+export type ExerciseRecords = {
+  exerciseId: string;
+  exerciseName: string;
+  records: PersonalRecord[];
+  history: PersonalRecord[];
+};
+
+// PacingTarget compares one workout step or subset with its logged durations.
+export type PacingTarget = {
+  kind: "step" | "subset";
+  stepId: string;
+  subsetId?: string;
+  name: string;
+  type: string;
+  estimatedSeconds: number;
+  samples: number;
+  medianSeconds: number;
+  p25Seconds: number;
+  p75Seconds: number;
+  p90Seconds: number;
+  overrunRatio?: number;
+  trend?: "faster" | "slower" | "steady";
+  trendSeconds?: number;
+  suggestedSeconds?: number;
+};
+
+// PacingAnalysis is the pacing of a workout across its recent trainings.
+export type PacingAnalysis = {
+  workoutId: string;
+  trainings: number;
+  skippedTrainings: number;
+  targets: PacingTarget[];
+};
+
+// SummaryFormat is a built-in summary format; user templates are addressed by id.
+export type SummaryFormat = "text" | "markdown" | "ai";
+
+// TrainingSummary is a training rendered through a summary format or template.
+export type TrainingSummary = {
+  format: string;
+  name: string;
+  text: string;
+};
+
+// SummaryTemplate is a user-defined text/template for training summaries.
+export type SummaryTemplate = {
+  id: string;
+  userId: string;
+  name: string;
+  body: string;
+  createdAt: string;
+  updatedAt: string;
+};
+
+// StatsPeriod is the bucket size of training statistics.
+export type StatsPeriod = "week" | "month";
+
+// StatsTotals sums training time, volume and load.
+export type StatsTotals = {
+  sessions: number;
+  totalSeconds: number;
+  workSeconds: number;
+  pauseSeconds: number;
+  volume: number;
+  load: number;
+};
+
+// StatsBucket holds the totals of one week or month.
+export type StatsBucket = StatsTotals & {
+  start: string;
+};
+
+// ExerciseStats holds the totals of one exercise.
+export type ExerciseStats = {
+  exerciseId?: string;
+  name: string;
+  sessions: number;
+  sets: number;
+  reps: number;
+  volume: number;
+};
+
+// TrainingStats summarizes the trainings of the current user.
+export type TrainingStats = {
+  period: StatsPeriod;
+  timeZone: string;
+  totals: StatsTotals;
+  buckets: StatsBucket[];
+  streaks: { current: number; longest: number; lastTrained?: string };
+  topExercises: ExerciseStats[];
 };
 
 // TrainingStepLog stores a completed step timing.
@@ -118,6 +337,8 @@ export type TrainingStepLog = {
   name: string;
   estimatedSeconds: number;
   elapsedMillis: number;
+  rpe?: number;
+  exercises?: TrainingStepExercise[];
 };
 
 // TrainingState tracks the active workout training.
@@ -134,11 +355,38 @@ export type TrainingState = {
   done: boolean;
   startedAt?: string | null;
   completedAt?: string | null;
+  updatedAt?: string | null;
   logged?: boolean;
 
   steps: TrainingStepState[];
 };
 
+// ClassParticipant is a user following a group class.
+export type ClassParticipant = {
+  classId: string;
+  userId: string;
+  name: string;
+  joinedAt: string;
+  leftAt?: string;
+  trainingId?: string;
+  seenAt: string;
+  seenIndex: number;
+};
+
+// GroupClass is a coach-led training that participants follow live.
+export type GroupClass = {
+  id: string;
+  coachId: string;
+  workoutId: string;
+  workoutName: string;
+  trainingId: string;
+  joinCode: string;
+  createdAt: string;
+  endedAt?: string;
+  state?: TrainingState;
+  participants?: ClassParticipant[];
+};
+
 // TrainingHistoryItem summarizes a completed training with optional steps.
 export type TrainingHistoryItem = {
   id: string;
@@ -149,6 +397,38 @@ export type TrainingHistoryItem = {
   startedAt?: string;
   completedAt?: string;
   steps?: TrainingStepLog[];
+  notes?: string;
+  rpe?: number;
+  mood?: number;
+  energy?: number;
+  bodyweight?: number;
+  bodyweightUnit?: WeightUnit;
+  load: number;
+};
+
+export type TrainingHistoryPage = {
+  items: TrainingHistoryItem[];
+  nextCursor?: string;
+};
+
+export type TrainingHistoryQuery = {
+  from?: string;
+  to?: string;
+  workoutId?: string;
+  q?: string;
+  cursor?: string;
+  limit?: number;
+};
+
+// TrainingFeedback captures how a logged training felt; omitted fields stay unchanged.
+export type TrainingFeedback = {
+  notes?: string;
+  rpe?: number;
+  mood?: number;
+  energy?: number;
+  bodyweight?: number;
+  bodyweightUnit?: WeightUnit;
+  steps?: Array<{ id: string; rpe: number }>;
 };
 
 // SoundOption describes an available sound effect.
@@ -165,6 +445,7 @@ export type CatalogExercise = {
   name: string;
   ownerUserId?: string;
   isCore?: boolean;
+  orgId?: string;
   createdAt?: string;
 };
 
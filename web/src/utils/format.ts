@@ -1,4 +1,8 @@
-import type { Exercise } from "../types";
+import type {
+  Exercise,
+  PersonalRecord,
+  TrainingStepExercise,
+} from "../types";
 import { isDurationExercise } from "./exercise";
 
 // Shared helper: formats whole minutes/seconds as MM:SS
@@ -74,3 +78,28 @@ export function formatExerciseLine(ex: Exercise) {
   }
   return base;
 }
+
+// formatExerciseResult renders what was actually performed for an exercise.
+export function formatExerciseResult(result: TrainingStepExercise) {
+  const parts: string[] = [];
+  if (result.reps) parts.push(`${result.reps} × ${result.name}`);
+  else parts.push(result.name);
+  if (result.weight) parts.push(`${result.weight} ${result.weightUnit || "kg"}`);
+  if (result.durationSeconds) parts.push(formatMMSS(result.durationSeconds));
+  if (result.status !== "completed") parts.push(result.status);
+  return parts.join(" · ");
+}
+
+// formatPersonalRecord renders the value of a personal record.
+export function formatPersonalRecord(record: PersonalRecord) {
+  switch (record.kind) {
+    case "max_weight":
+      return `${record.weight} ${record.weightUnit || "kg"}`;
+    case "max_reps":
+      return `${record.reps} × ${record.weight} ${record.weightUnit || "kg"}`;
+    case "estimated_1rm":
+      return `${record.value} kg`;
+    case "fastest_time":
+      return formatMMSS(record.durationSeconds);
+  }
+}
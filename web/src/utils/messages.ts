@@ -12,6 +12,7 @@ export const MESSAGES = {
   applyTemplateFailed: "Unable to apply template",
   startTrainingFailed: "Unable to start training",
   saveTrainingFailed: "Unable to save training.",
+  loadSummaryFailed: "Unable to load training summary.",
   createExerciseFailed: "Unable to create exercise",
   createCoreExerciseFailed: "Unable to create core exercise",
   renameExerciseFailed: "Unable to rename exercise",
@@ -19,10 +20,34 @@ export const MESSAGES = {
   exportWorkoutFailed: "Unable to export workout",
   importWorkoutFailed: "Unable to import workout",
   updatePasswordFailed: "Unable to update password",
+  requestPasswordResetFailed: "Unable to request a password reset",
+  resetPasswordFailed: "Unable to reset password",
+  verifyTotpFailed: "Invalid code",
+  enableTotpFailed: "Unable to enable two-factor authentication",
+  disableTotpFailed: "Unable to disable two-factor authentication",
+  regenerateRecoveryCodesFailed: "Unable to create new recovery codes",
+  verifyEmailFailed: "Unable to confirm email address",
+  sendResetLinkFailed: "Unable to send reset link",
   updateRoleFailed: "Unable to update role",
+  createUserFailed: "Unable to create user",
   backfillExercisesFailed: "Unable to backfill exercises",
   updateNameFailed: "Unable to update name",
   logTrainingFailed: "Unable to log training",
+  deleteAccountFailed: "Unable to delete account",
+  createInvitationFailed: "Unable to create invitation",
+  revokeInvitationFailed: "Unable to revoke invitation",
+  loadCoachLinksFailed: "Unable to load coach links",
+  inviteAthleteFailed: "Unable to invite athlete",
+  acceptCoachLinkFailed: "Unable to accept coach",
+  removeCoachLinkFailed: "Unable to remove coach link",
+  assignWorkoutFailed: "Unable to assign workout",
+  loadOrganizationsFailed: "Unable to load organizations",
+  createOrganizationFailed: "Unable to create organization",
+  switchOrganizationFailed: "Unable to switch organization",
+  leaveOrganizationFailed: "Unable to leave organization",
+  deleteOrganizationFailed: "Unable to delete organization",
+  addOrgMemberFailed: "Unable to add member",
+  removeOrgMemberFailed: "Unable to remove member",
 } as const;
 
 // PROMPTS centralizes non-error UI copy.
@@ -0,0 +1,14 @@
+import type { Role, User } from "../types";
+
+// ROLES lists the assignable roles from most to least privileged.
+export const ROLES: Role[] = ["admin", "coach", "catalog-editor", "member"];
+
+// canEditCatalog reports whether the user may curate core exercises.
+export function canEditCatalog(user: User | null | undefined): boolean {
+  return user?.role === "admin" || user?.role === "catalog-editor";
+}
+
+// canCoach reports whether the user may invite athletes and assign workouts.
+export function canCoach(user: User | null | undefined): boolean {
+  return user?.role === "admin" || user?.role === "coach";
+}
@@ -75,7 +75,22 @@ export const UI_TEXT = {
     createdPersonalCopy: "Created a personal copy.",
     invalidWorkoutJson: "Invalid workout JSON.",
     passwordUpdated: "Password updated.",
+    passwordResetSent:
+      "If the address is registered, a reset link is on its way.",
+    passwordResetDone: "Password reset. Log in with your new password.",
+    passwordResetLinkSent: "Reset link sent.",
+    userCreated: "User created.",
+    verificationSent: "Check your inbox to confirm your email address.",
+    emailVerified: "Email confirmed. You can log in now.",
     backfillComplete: "Exercise catalog backfill complete.",
+    totpEnabled: "Two-factor authentication enabled.",
+    totpDisabled: "Two-factor authentication disabled.",
+    invitationAccepted: "Account created. Log in with your new password.",
+    invitationCopied: "Invite link copied to the clipboard.",
+    invitationCreated: "Invite link created:",
+    athleteInvited: "Invitation sent. The athlete has to accept it.",
+    workoutAssigned: "Workout assigned.",
+    orgMemberAdded: "Member added.",
   },
   labels: {
     workout: "Workout",
@@ -128,6 +143,11 @@ export const UI_TEXT = {
     newPassword: "New password",
     confirmPassword: "Confirm password",
     yourName: "Your name",
+    selectAthlete: "Select athlete",
+    athleteId: "athlete@example.com",
+    organizationName: "Organization name",
+    memberId: "member@example.com",
+    selectOrgRole: "Select role",
   },
   themes: {
     auto: "Auto (system)",
@@ -136,11 +156,18 @@ export const UI_TEXT = {
   },
   roles: {
     admin: "Admin",
-    user: "User",
+    coach: "Coach",
+    "catalog-editor": "Catalog editor",
+    member: "Member",
+  },
+  orgRoles: {
+    owner: "Owner",
+    editor: "Editor",
+    member: "Member",
   },
   admin: {
-    revokeAdmin: "Revoke Admin",
-    makeAdmin: "Make Admin",
+    sendResetLink: "Send reset link",
+    revokeInvitation: "Revoke",
     backfill: {
       working: "Backfilling…",
       action: "Backfill exercises",
@@ -165,6 +192,22 @@ export const UI_TEXT = {
       createUserTitle: "Create user",
       registrationDisabledTitle: "Registration disabled",
       registrationDisabledHint: "Ask an admin to create an account for you.",
+      invitationTitle: "Accept invitation",
+      invitationHint:
+        "You were invited to Motus. Choose your login to continue.",
+      ssoTitle: "Single sign-on",
+      ssoHint: "Sign in with your organization account.",
+      ssoButton: "Sign in with SSO",
+      forgotPassword: "Forgot password?",
+      forgotPasswordHint: "We will email you a link to choose a new password.",
+      sendResetLink: "Send reset link",
+      resendVerification: "Resend confirmation email",
+      resetTitle: "Choose a new password",
+      resetButton: "Set password",
+      totpTitle: "Two-factor authentication",
+      totpHint:
+        "Enter the code from your authenticator app or one of your recovery codes.",
+      totpButton: "Verify",
     },
     admin: {
       title: "Admin",
@@ -172,6 +215,17 @@ export const UI_TEXT = {
       usersTitle: "Users",
       usersHint: "Manage roles and switch user.",
       createUserTitle: "Create user",
+      invitationsTitle: "Invitations",
+      invitationsHint:
+        "Invite links let people register while open registration is disabled.",
+      invitationsEmpty: "No open invitations.",
+      maxUsesLabel: "Uses",
+      expiresInDaysLabel: "Valid for (days)",
+      roleLabel: "Role",
+      createInvitation: "Create invite link",
+      invitationUsage: (uses: number, maxUses: number) =>
+        `${uses}/${maxUses} used`,
+      invitationExpires: (date: string) => `expires ${date}`,
       maintenanceLabel: "Maintenance",
       backfillHint:
         "Backfill promotes workouts exercises into the Core catalog.",
@@ -194,12 +248,71 @@ export const UI_TEXT = {
     history: {
       title: "Training history",
       hint: "Completed trainings for the selected user.",
+      feedback: {
+        title: "How it felt",
+        unrated: "Not rated",
+        loadLabel: "Training load",
+        rpeLabel: "Session RPE (1-10)",
+        moodLabel: "Mood (1-5)",
+        energyLabel: "Energy (1-5)",
+        bodyweightLabel: "Bodyweight",
+        notesLabel: "Notes",
+        saveButton: "Save",
+        savedToast: "Training updated.",
+        saveFailed: "Unable to update training",
+      },
+      records: {
+        title: "Personal records",
+        empty: "No personal records yet.",
+        loadFailed: "Unable to load personal records",
+        newRecordsToast: "New personal record:",
+        kinds: {
+          max_weight: "Heaviest weight",
+          max_reps: "Most reps",
+          estimated_1rm: "Estimated 1RM",
+          fastest_time: "Fastest time",
+        },
+      },
+      stats: {
+        title: "Statistics",
+        loadFailed: "Unable to load statistics",
+        week: "Weekly",
+        month: "Monthly",
+        sessions: "Sessions",
+        totalTime: "Total time",
+        workTime: "Work",
+        pauseTime: "Pause",
+        volume: "Volume (kg)",
+        currentStreak: "Current streak (days)",
+        longestStreak: "Longest streak (days)",
+        topExercises: "Most trained",
+        sessionsSuffix: "sessions",
+      },
     },
     training: {
       title: "Training",
       overrunTitle: "Still training?",
       overrunMessage: "You passed the target. Auto-pause in",
       overrunPostpone: "Postpone (+30s)",
+      classes: {
+        title: "Group class",
+        codeLabel: "Code",
+        participantsLabel: "joined",
+        joinButton: "Join class",
+        startButton: "Start class",
+        refreshButton: "Refresh",
+        endButton: "End class",
+        leaveButton: "Leave class",
+        waitingLabel: "Waiting for the coach",
+        trainingActive: "Finish your current training first.",
+        endedToast: "Class ended",
+        leftToast: "Left class",
+        startFailed: "Unable to start class",
+        loadFailed: "Unable to load class",
+        endFailed: "Unable to end class",
+        joinFailed: "Unable to join class",
+        leaveFailed: "Unable to leave class",
+      },
     },
     profile: {
       title: "Profile",
@@ -212,9 +325,63 @@ export const UI_TEXT = {
       exportLabel: "Export workout",
       exportButton: "Export",
       importButton: "Import",
+      dataLabel: "Your data",
+      dataHint: "Download everything stored about your account as a zip archive.",
+      dataExportButton: "Download my data",
+      deleteAccountLabel: "Delete account",
+      deleteAccountHint:
+        "Deleting your account removes your workouts, exercises and training history for good.",
+      deleteAccountConfirmLabel: "Type your user id to confirm",
+      deleteAccountButton: "Delete account",
       settingsTab: "Settings",
       passwordTab: "Password",
       transferTab: "Export/Import",
+      securityTab: "Security",
+      coachingTab: "Coaching",
+      coachesLabel: "Coaches",
+      athletesLabel: "Athletes",
+      noCoaches: "Nobody coaches you yet.",
+      noAthletes: "No athletes yet.",
+      pendingLink: "pending",
+      acceptCoach: "Accept",
+      removeLink: "Remove",
+      inviteAthleteLabel: "Invite athlete",
+      inviteAthleteButton: "Invite",
+      assignWorkoutLabel: "Assign workout",
+      assignWorkoutButton: "Assign",
+      organizationsTab: "Organizations",
+      organizationsLabel: "Your organizations",
+      organizationsHint:
+        "The active organization adds its shared templates and exercises to your library.",
+      noOrganizations: "You are not in any organization.",
+      activeOrganization: "active",
+      useOrganization: "Use",
+      leaveAllOrganizations: "Use personal library only",
+      leaveOrganization: "Leave",
+      deleteOrganization: "Delete",
+      createOrganizationLabel: "New organization",
+      createOrganizationButton: "Create",
+      orgMembersLabel: "Members",
+      addOrgMemberLabel: "Add member",
+      addOrgMemberButton: "Add",
+      twoFactorLabel: "Two-factor authentication",
+      twoFactorOff: "Protect your account with an authenticator app.",
+      twoFactorOn: "Two-factor authentication is on.",
+      twoFactorAdminRequired:
+        "Admin rights are only granted once two-factor authentication is on.",
+      enableTwoFactor: "Set up authenticator",
+      enrollHint:
+        "Add this key to your authenticator app, then enter the code it shows.",
+      secretLabel: "Setup key",
+      openAuthenticator: "Open in authenticator app",
+      confirmTwoFactor: "Enable",
+      recoveryCodesLabel: "Recovery codes",
+      recoveryCodesHint:
+        "Store these codes somewhere safe. Each one signs you in once if you lose your device.",
+      regenerateHint: "Enter a current code to replace your recovery codes.",
+      regenerateCodes: "New recovery codes",
+      disableHint: "Enter your password to turn off two-factor authentication.",
+      disableTwoFactor: "Turn off",
       displayNameLabel: "Display name",
       currentPasswordLabel: "Current password",
       newPasswordLabel: "New password",
@@ -263,10 +430,14 @@ export const UI_TEXT = {
     aiSummary: "AI-ready summary",
     loadingSteps: "Loading steps…",
     copySummary: "Copy summary",
+    loadMore: "Load more",
+    loadMoreFailed: "Unable to load more trainings",
   },
   auth: {
     enterPassword: "Enter a password",
     yourPassword: "Your password",
+    totpCodeLabel: "Authentication code",
+    totpCodePlaceholder: "123456 or recovery code",
   },
   accessibility: {
     navToggle: "Toggle navigation",